@@ -58,9 +58,12 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, authenticateMiddleware, api.PauseChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, authenticateMiddleware, api.DeleteChangefeed)
 	changefeedGroup.GET("/:changefeed_id/synced", coordinatorMiddleware, authenticateMiddleware, api.syncState)
+	changefeedGroup.GET("/:changefeed_id/dead_letters", coordinatorMiddleware, authenticateMiddleware, api.ListDeadLetters)
 
 	// internal APIs
 	changefeedGroup.POST("/:changefeed_id/move_table", authenticateMiddleware, api.MoveTable)
+	changefeedGroup.POST("/:changefeed_id/split_table", authenticateMiddleware, api.SplitTable)
+	changefeedGroup.POST("/:changefeed_id/merge_table", authenticateMiddleware, api.MergeTable)
 	changefeedGroup.GET("/:changefeed_id/get_dispatcher_count", api.getDispatcherCount)
 	changefeedGroup.GET("/:changefeed_id/tables", api.ListTables)
 
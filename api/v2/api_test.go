@@ -0,0 +1,72 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/server"
+	"github.com/stretchr/testify/require"
+)
+
+// mockServer implements server.Server interface for testing
+type mockServer struct {
+	server.Server
+}
+
+func (s *mockServer) IsCoordinator() bool {
+	return true
+}
+
+func (s *mockServer) GetEtcdClient() etcd.CDCEtcdClient {
+	return &mockEtcdClient{}
+}
+
+type mockEtcdClient struct {
+	etcd.CDCEtcdClient
+}
+
+func (c *mockEtcdClient) GetEtcdClient() etcd.Client {
+	return nil
+}
+
+func TestListDeadLettersRequireAuthentication(t *testing.T) {
+	originalConfig := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(originalConfig)
+	serverConfig := originalConfig.Clone()
+	serverConfig.Security.ClientUserRequired = true
+	serverConfig.Security.ClientAllowedUser = []string{"root"}
+	config.StoreGlobalServerConfig(serverConfig)
+
+	router := gin.New()
+	RegisterOpenAPIV2Routes(router, NewOpenAPIV2(&mockServer{}))
+
+	// the request without the user and password is rejected
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/changefeeds/test/dead_letters", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// the request with a user which is not allowed is rejected
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/api/v2/changefeeds/test/dead_letters", nil)
+	req.SetBasicAuth("test", "")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -45,6 +45,7 @@ func (h *OpenAPIV2) ListCaptures(c *gin.Context) {
 				IsCoordinator: c.ID == info.ID,
 				AdvertiseAddr: c.AdvertiseAddr,
 				ClusterID:     h.server.GetEtcdClient().GetClusterID(),
+				Labels:        c.Labels,
 			})
 	}
 	c.JSON(http.StatusOK, toListResponse(c, captures))
@@ -15,6 +15,8 @@ package v2
 
 import (
 	"context"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -26,13 +28,18 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/api/middleware"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
+	"github.com/pingcap/ticdc/maintainer"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/ticdc/pkg/spanz"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	putil "github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/ticdc/pkg/version"
 	"github.com/pingcap/tiflow/cdc/api"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -325,7 +332,7 @@ func CfInfoToAPIModel(
 		}
 	}
 
-	sinkURI, err := util.MaskSinkURI(info.SinkURI)
+	sinkURI, err := putil.MaskSinkURI(info.SinkURI)
 	if err != nil {
 		log.Error("failed to mask sink URI", zap.Error(err))
 	}
@@ -683,69 +690,136 @@ func verifyResumeChangefeedConfig(
 // This api is for inner test use, not public use. It may be removed in the future.
 // Usage:
 // curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/move_table?tableID={tableID}&targetNodeID={targetNodeID}
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/move_table?dispatcherID={dispatcherID}&targetNodeID={targetNodeID}
 // Note:
-// 1. tableID is the table id in the changefeed
-// 2. targetNodeID is the node id to move the table to
+// 1. tableID is the table id in the changefeed, only the table which is not split can be moved by tableID
+// 2. dispatcherID is the id of a single span of the table, you can find it by using the list_tables api
+// 3. targetNodeID is the node id to move the table to
+// 4. moving by dispatcherID returns once the move is submitted, the span is in scheduling state
+// in the list_tables api until the move is finished
 // You can find the node id by using the list_captures api
 func (h *OpenAPIV2) MoveTable(c *gin.Context) {
-	tableIdStr := c.Query("tableID")
-	tableId, err := strconv.ParseInt(tableIdStr, 10, 64)
-	if err != nil {
-		log.Error("failed to parse tableID", zap.Error(err), zap.String("tableID", tableIdStr))
-		_ = c.Error(err)
-		return
+	var (
+		tableId      int64
+		dispatcherID common.DispatcherID
+		err          error
+	)
+	dispatcherIDStr := c.Query("dispatcherID")
+	if dispatcherIDStr != "" {
+		dispatcherID, err = parseDispatcherID(dispatcherIDStr)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid dispatcherID: %s", dispatcherIDStr))
+			return
+		}
+	} else {
+		tableIdStr := c.Query("tableID")
+		tableId, err = strconv.ParseInt(tableIdStr, 10, 64)
+		if err != nil {
+			log.Error("failed to parse tableID", zap.Error(err), zap.String("tableID", tableIdStr))
+			_ = c.Error(err)
+			return
+		}
 	}
 
-	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), GetNamespaceValueWithDefault(c))
-	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
-		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
-			changefeedDisplayName.Name))
+	maintainer, ok := h.getMaintainer(c)
+	if !ok {
 		return
 	}
 
-	// get changefeedID first
-	cfInfo, err := getChangeFeed(c.Request.Host, changefeedDisplayName.Name)
+	targetNodeID := c.Query("targetNodeID")
+	if dispatcherIDStr != "" {
+		err = maintainer.MoveSpan(dispatcherID, node.ID(targetNodeID))
+	} else {
+		err = maintainer.MoveTable(tableId, node.ID(targetNodeID))
+	}
 	if err != nil {
+		log.Error("failed to move table", zap.Error(err), zap.Int64("tableID", tableId),
+			zap.String("dispatcherID", dispatcherIDStr), zap.String("targetNodeID", targetNodeID))
 		_ = c.Error(err)
 		return
 	}
+	c.JSON(getStatus(c), &EmptyResponse{})
+}
 
-	if cfInfo.MaintainerAddr == "" {
-		_ = c.Error(errors.New("Can't not find maintainer for changefeed: " + changefeedDisplayName.Name))
+// SplitTable handles split table in changefeed, the table is split into several spans,
+// each span is replicated by a dispatcher.
+// This api is for inner use, not public use. It may be changed in the future.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/split_table?tableID={tableID}&regionsPerSpan={regionsPerSpan}
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/split_table?tableID={tableID}&splitKey={key1}&splitKey={key2}
+// Note:
+// 1. regionsPerSpan is the max region count of each span after split
+// 2. splitKey is the hex encoded raw key to split the table at, such as the result of TIDB_ENCODE_RECORD_KEY,
+// the table is split by the split keys if any of them is given, and regionsPerSpan is ignored.
+// 3. it returns once the split is submitted, the spans are in scheduling state in the list_tables api
+// until the split is finished
+func (h *OpenAPIV2) SplitTable(c *gin.Context) {
+	tableIdStr := c.Query("tableID")
+	tableId, err := strconv.ParseInt(tableIdStr, 10, 64)
+	if err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid tableID: %s", tableIdStr))
 		return
 	}
 
-	selfInfo, err := h.server.SelfInfo()
-	if err != nil {
-		_ = c.Error(err)
-		return
+	splitKeyStrs := c.QueryArray("splitKey")
+	splitKeys := make([][]byte, 0, len(splitKeyStrs))
+	for _, keyStr := range splitKeyStrs {
+		key, err := hex.DecodeString(keyStr)
+		if err != nil {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid splitKey: %s", keyStr))
+			return
+		}
+		splitKeys = append(splitKeys, spanz.ToComparableKey(key))
 	}
 
-	if cfInfo.MaintainerAddr != selfInfo.AdvertiseAddr {
-		// Forward the request to the maintainer
-		middleware.ForwardToServer(c, selfInfo.ID, cfInfo.MaintainerAddr)
-		c.Abort()
+	var regionsPerSpan int
+	if len(splitKeys) == 0 {
+		regionsPerSpanStr := c.Query("regionsPerSpan")
+		regionsPerSpan, err = strconv.Atoi(regionsPerSpanStr)
+		if err != nil || regionsPerSpan <= 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid regionsPerSpan: %s", regionsPerSpanStr))
+			return
+		}
+	}
+
+	maintainer, ok := h.getMaintainer(c)
+	if !ok {
 		return
 	}
 
-	changefeedID := common.ChangeFeedID{
-		Id:          cfInfo.GID,
-		DisplayName: common.NewChangeFeedDisplayName(cfInfo.ID, cfInfo.Namespace),
+	err = maintainer.SplitTable(tableId, regionsPerSpan, splitKeys)
+	if err != nil {
+		log.Error("failed to split table", zap.Error(err), zap.Int64("tableID", tableId),
+			zap.Int("regionsPerSpan", regionsPerSpan), zap.Strings("splitKeys", splitKeyStrs))
+		_ = c.Error(err)
+		return
 	}
+	c.JSON(getStatus(c), &EmptyResponse{})
+}
 
-	maintainerManager := h.server.GetMaintainerManager()
-	maintainer, ok := maintainerManager.GetMaintainerForChangefeed(changefeedID)
+// MergeTable handles merge table in changefeed, all the spans of the table
+// are merged into one span.
+// This api is for inner use, not public use. It may be changed in the future.
+// Usage:
+// curl -X POST http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/merge_table?tableID={tableID}
+// Note: it returns once the merge is submitted, the spans are in scheduling state in the list_tables api
+// until the merge is finished
+func (h *OpenAPIV2) MergeTable(c *gin.Context) {
+	tableIdStr := c.Query("tableID")
+	tableId, err := strconv.ParseInt(tableIdStr, 10, 64)
+	if err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid tableID: %s", tableIdStr))
+		return
+	}
 
+	maintainer, ok := h.getMaintainer(c)
 	if !ok {
-		log.Error("maintainer not found for changefeed in this node", zap.String("GID", changefeedID.Id.String()), zap.String("Name", changefeedID.DisplayName.String()))
-		_ = c.Error(apperror.ErrMaintainerNotFounded)
 		return
 	}
 
-	targetNodeID := c.Query("targetNodeID")
-	err = maintainer.MoveTable(int64(tableId), node.ID(targetNodeID))
+	err = maintainer.MergeTable(tableId)
 	if err != nil {
-		log.Error("failed to move table", zap.Error(err), zap.Int64("tableID", tableId), zap.String("targetNodeID", targetNodeID))
+		log.Error("failed to merge table", zap.Error(err), zap.Int64("tableID", tableId))
 		_ = c.Error(err)
 		return
 	}
@@ -757,36 +831,137 @@ func (h *OpenAPIV2) MoveTable(c *gin.Context) {
 // curl -X GET http://127.0.0.1:8300/api/v2/changefeeds/changefeed-test1/tables
 // Note: This api is for inner test use, not public use. It may be changed or removed in the future.
 func (h *OpenAPIV2) ListTables(c *gin.Context) {
+	maintainer, ok := h.getMaintainer(c)
+	if !ok {
+		return
+	}
+
+	tables := maintainer.GetTables()
+
+	nodeTableInfoMap := make(map[string]*NodeTableInfo)
+
+	for _, table := range tables {
+		nodeID := table.GetNodeID().String()
+		nodeTableInfo, ok := nodeTableInfoMap[nodeID]
+		if !ok {
+			nodeTableInfo = newNodeTableInfo(nodeID)
+			nodeTableInfoMap[nodeID] = nodeTableInfo
+		}
+		nodeTableInfo.addTableID(table.Span.TableID)
+		nodeTableInfo.addSpan(table.ID, table.Span, table.GetStatus(), maintainer.IsScheduling(table.ID))
+	}
+
+	infos := make([]NodeTableInfo, 0, len(nodeTableInfoMap))
+	for _, nodeTableInfo := range nodeTableInfoMap {
+		infos = append(infos, *nodeTableInfo)
+	}
+
+	c.JSON(http.StatusOK, toListResponse(c, infos))
+}
+
+// defaultDeadLetterListLimit is the default number of entries returned by ListDeadLetters.
+const defaultDeadLetterListLimit = 100
+
+// ListDeadLetters lists the entries in the dead letter queue of a changefeed
+// @Summary List dead letters
+// @Description list the transactions which can't be applied to the downstream of a changefeed
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param namespace query string false "default"
+// @Param limit query int false "100"
+// @Success 200 {array} dlq.Entry
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/dead_letters [get]
+func (h *OpenAPIV2) ListDeadLetters(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), GetNamespaceValueWithDefault(c))
+	limit := defaultDeadLetterListLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid limit: %s", limitStr))
+			return
+		}
+	}
+
+	co, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	cfInfo, _, err := co.GetChangefeed(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var target string
+	if cfInfo.Config.Sink != nil && cfInfo.Config.Sink.MySQLConfig != nil {
+		target = util.GetOrZero(cfInfo.Config.Sink.MySQLConfig.DeadLetterQueue)
+	}
+	if target == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"dead letter queue is not enabled for changefeed %s", changefeedDisplayName.Name))
+		return
+	}
+
+	ctx := c.Request.Context()
+	var db *sql.DB
+	if target == dlq.DownstreamTarget {
+		sinkURI, err := url.Parse(cfInfo.SinkURI)
+		if err != nil {
+			_ = c.Error(errors.WrapError(errors.ErrSinkURIInvalid, err))
+			return
+		}
+		_, db, err = mysql.NewMysqlConfigAndDB(ctx, cfInfo.ChangefeedID, sinkURI, cfInfo.ToChangefeedConfig())
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		defer db.Close()
+	}
+	entries, err := dlq.List(ctx, cfInfo.ChangefeedID, target, db, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, toListResponse(c, entries))
+}
+
+// getMaintainer returns the maintainer of the changefeed in the request.
+// If the maintainer is not in this node, the request is forwarded to the node of the maintainer.
+// It returns false if the request has been handled, the caller should return directly.
+func (h *OpenAPIV2) getMaintainer(c *gin.Context) (*maintainer.Maintainer, bool) {
 	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), GetNamespaceValueWithDefault(c))
 	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
 		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
 			changefeedDisplayName.Name))
-		return
+		return nil, false
 	}
 
 	// get changefeedID first
 	cfInfo, err := getChangeFeed(c.Request.Host, changefeedDisplayName.Name)
 	if err != nil {
 		_ = c.Error(err)
-		return
+		return nil, false
 	}
 
 	if cfInfo.MaintainerAddr == "" {
 		_ = c.Error(errors.New("Can't not find maintainer for changefeed: " + changefeedDisplayName.Name))
-		return
+		return nil, false
 	}
 
 	selfInfo, err := h.server.SelfInfo()
 	if err != nil {
 		_ = c.Error(err)
-		return
+		return nil, false
 	}
 
 	if cfInfo.MaintainerAddr != selfInfo.AdvertiseAddr {
 		// Forward the request to the maintainer
 		middleware.ForwardToServer(c, selfInfo.ID, cfInfo.MaintainerAddr)
 		c.Abort()
-		return
+		return nil, false
 	}
 
 	changefeedID := common.ChangeFeedID{
@@ -799,29 +974,9 @@ func (h *OpenAPIV2) ListTables(c *gin.Context) {
 	if !ok {
 		log.Error("maintainer not found for changefeed in this node", zap.String("GID", changefeedID.Id.String()), zap.String("Name", changefeedID.DisplayName.String()))
 		_ = c.Error(apperror.ErrMaintainerNotFounded)
-		return
-	}
-
-	tables := maintainer.GetTables()
-
-	nodeTableInfoMap := make(map[string]*NodeTableInfo)
-
-	for _, table := range tables {
-		nodeID := table.GetNodeID().String()
-		nodeTableInfo, ok := nodeTableInfoMap[nodeID]
-		if !ok {
-			nodeTableInfo = newNodeTableInfo(nodeID)
-			nodeTableInfoMap[nodeID] = nodeTableInfo
-		}
-		nodeTableInfo.addTableID(table.Span.TableID)
-	}
-
-	infos := make([]NodeTableInfo, 0, len(nodeTableInfoMap))
-	for _, nodeTableInfo := range nodeTableInfoMap {
-		infos = append(infos, *nodeTableInfo)
+		return nil, false
 	}
-
-	c.JSON(http.StatusOK, toListResponse(c, infos))
+	return maintainer, true
 }
 
 // getDispatcherCount returns the count of dispatcher.
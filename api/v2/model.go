@@ -14,9 +14,14 @@
 package v2
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
@@ -192,9 +197,17 @@ type ReplicaConfig struct {
 	EnableSyncPoint       *bool  `json:"enable_sync_point,omitempty"`
 	EnableTableMonitor    *bool  `json:"enable_table_monitor,omitempty"`
 	BDRMode               *bool  `json:"bdr_mode,omitempty"`
+	InitialSnapshot       *bool  `json:"initial_snapshot,omitempty"`
+	// Priority is the priority class of the changefeed, it can be high, normal or batch.
+	Priority string `json:"priority,omitempty"`
 
 	SyncPointInterval  *JSONDuration `json:"sync_point_interval,omitempty" swaggertype:"string"`
 	SyncPointRetention *JSONDuration `json:"sync_point_retention,omitempty" swaggertype:"string"`
+	// ReplicationDelay keeps the downstream deliberately behind the upstream for the duration.
+	ReplicationDelay *JSONDuration `json:"replication_delay,omitempty" swaggertype:"string"`
+	// MaxRowsPerSecond and MaxBytesPerSecond limit the throughput written to the sink by the changefeed.
+	MaxRowsPerSecond  *uint64 `json:"max_rows_per_second,omitempty"`
+	MaxBytesPerSecond *uint64 `json:"max_bytes_per_second,omitempty"`
 
 	Filter                       *FilterConfig              `json:"filter"`
 	Mounter                      *MounterConfig             `json:"mounter"`
@@ -231,7 +244,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	if c.SyncPointRetention != nil {
 		res.SyncPointRetention = &c.SyncPointRetention.duration
 	}
+	if c.ReplicationDelay != nil {
+		res.ReplicationDelay = &c.ReplicationDelay.duration
+	}
+	res.MaxRowsPerSecond = c.MaxRowsPerSecond
+	res.MaxBytesPerSecond = c.MaxBytesPerSecond
 	res.BDRMode = c.BDRMode
+	res.InitialSnapshot = c.InitialSnapshot
+	res.Priority = config.ChangefeedPriority(c.Priority)
 
 	if c.Filter != nil {
 		var efs []*config.EventFilterRule
@@ -242,9 +262,10 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			}
 		}
 		res.Filter = &config.FilterConfig{
-			Rules:            c.Filter.Rules,
-			IgnoreTxnStartTs: c.Filter.IgnoreTxnStartTs,
-			EventFilters:     efs,
+			Rules:             c.Filter.Rules,
+			IgnoreTxnStartTs:  c.Filter.IgnoreTxnStartTs,
+			EventFilters:      efs,
+			ForwardDDLClasses: c.Filter.ForwardDDLClasses,
 		}
 	}
 	if c.Consistent != nil {
@@ -278,6 +299,14 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				TopicRule:      rule.TopicRule,
 			})
 		}
+		var routeRules []*config.RouteRule
+		for _, rule := range c.Sink.RouteRules {
+			routeRules = append(routeRules, &config.RouteRule{
+				Matcher:    rule.Matcher,
+				SchemaRule: rule.SchemaRule,
+				TableRule:  rule.TableRule,
+			})
+		}
 		var columnSelectors []*config.ColumnSelector
 		for _, selector := range c.Sink.ColumnSelectors {
 			columnSelectors = append(columnSelectors, &config.ColumnSelector{
@@ -285,6 +314,18 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransforms []*config.ColumnTransform
+		for _, t := range c.Sink.ColumnTransforms {
+			columnTransforms = append(columnTransforms, &config.ColumnTransform{
+				Matcher:    t.Matcher,
+				Columns:    t.Columns,
+				Type:       t.Type,
+				Salt:       t.Salt,
+				Length:     t.Length,
+				Value:      t.Value,
+				Expression: t.Expression,
+			})
+		}
 		var csvConfig *config.CSVConfig
 		if c.Sink.CSVConfig != nil {
 			csvConfig = &config.CSVConfig{
@@ -405,6 +446,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
 				OutputRawChangeEvent:         c.Sink.KafkaConfig.OutputRawChangeEvent,
+				EnableTransaction:            c.Sink.KafkaConfig.EnableTransaction,
 			}
 		}
 		var mysqlConfig *config.MySQLConfig
@@ -425,6 +467,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				EnableBatchDML:               c.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         c.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: c.Sink.MySQLConfig.EnableCachePreparedStatement,
+				DeadLetterQueue:              c.Sink.MySQLConfig.DeadLetterQueue,
 			}
 		}
 		var cloudStorageConfig *config.CloudStorageConfig
@@ -440,6 +483,20 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				OutputRawChangeEvent: c.Sink.CloudStorageConfig.OutputRawChangeEvent,
 			}
 		}
+		var webhookConfig *config.WebhookConfig
+		if c.Sink.WebhookConfig != nil {
+			webhookConfig = &config.WebhookConfig{
+				Headers:              c.Sink.WebhookConfig.Headers,
+				BasicAuthUser:        c.Sink.WebhookConfig.BasicAuthUser,
+				BasicAuthPassword:    c.Sink.WebhookConfig.BasicAuthPassword,
+				BearerToken:          c.Sink.WebhookConfig.BearerToken,
+				MaxBatchSize:         c.Sink.WebhookConfig.MaxBatchSize,
+				MaxRetries:           c.Sink.WebhookConfig.MaxRetries,
+				Timeout:              c.Sink.WebhookConfig.Timeout,
+				RetryBackoffBaseInMs: c.Sink.WebhookConfig.RetryBackoffBaseInMs,
+				RetryBackoffMaxInMs:  c.Sink.WebhookConfig.RetryBackoffMaxInMs,
+			}
+		}
 		var debeziumConfig *config.DebeziumConfig
 		if c.Sink.DebeziumConfig != nil {
 			debeziumConfig = &config.DebeziumConfig{
@@ -455,9 +512,11 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 
 		res.Sink = &config.SinkConfig{
 			DispatchRules:                    dispatchRules,
+			RouteRules:                       routeRules,
 			Protocol:                         c.Sink.Protocol,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransforms:                 columnTransforms,
 			SchemaRegistry:                   c.Sink.SchemaRegistry,
 			EncoderConcurrency:               c.Sink.EncoderConcurrency,
 			Terminator:                       c.Sink.Terminator,
@@ -472,6 +531,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			MySQLConfig:                      mysqlConfig,
 			PulsarConfig:                     pulsarConfig,
 			CloudStorageConfig:               cloudStorageConfig,
+			WebhookConfig:                    webhookConfig,
 			SafeMode:                         c.Sink.SafeMode,
 			OpenProtocol:                     openProtocolConfig,
 			Debezium:                         debeziumConfig,
@@ -514,6 +574,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			RegionThreshold:        c.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      c.Scheduler.WriteKeyThreshold,
 			SplitNumberPerNode:     c.Scheduler.SplitNumberPerNode,
+			NodeSelector:           c.Scheduler.NodeSelector,
+			SpreadByLabel:          c.Scheduler.SpreadByLabel,
 		}
 	}
 	if c.Integrity != nil {
@@ -547,6 +609,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		EnableSyncPoint:       cloned.EnableSyncPoint,
 		EnableTableMonitor:    cloned.EnableTableMonitor,
 		BDRMode:               cloned.BDRMode,
+		InitialSnapshot:       cloned.InitialSnapshot,
+		Priority:              string(cloned.Priority),
 	}
 
 	if cloned.SyncPointInterval != nil {
@@ -557,6 +621,12 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		res.SyncPointRetention = &JSONDuration{*cloned.SyncPointRetention}
 	}
 
+	if cloned.ReplicationDelay != nil {
+		res.ReplicationDelay = &JSONDuration{*cloned.ReplicationDelay}
+	}
+	res.MaxRowsPerSecond = cloned.MaxRowsPerSecond
+	res.MaxBytesPerSecond = cloned.MaxBytesPerSecond
+
 	if cloned.Filter != nil {
 		var efs []EventFilterRule
 		if len(c.Filter.EventFilters) != 0 {
@@ -567,9 +637,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 		}
 
 		res.Filter = &FilterConfig{
-			Rules:            cloned.Filter.Rules,
-			IgnoreTxnStartTs: cloned.Filter.IgnoreTxnStartTs,
-			EventFilters:     efs,
+			Rules:             cloned.Filter.Rules,
+			IgnoreTxnStartTs:  cloned.Filter.IgnoreTxnStartTs,
+			EventFilters:      efs,
+			ForwardDDLClasses: cloned.Filter.ForwardDDLClasses,
 		}
 	}
 	if cloned.Sink != nil {
@@ -583,6 +654,14 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				TopicRule:     rule.TopicRule,
 			})
 		}
+		var routeRules []*RouteRule
+		for _, rule := range cloned.Sink.RouteRules {
+			routeRules = append(routeRules, &RouteRule{
+				Matcher:    rule.Matcher,
+				SchemaRule: rule.SchemaRule,
+				TableRule:  rule.TableRule,
+			})
+		}
 		var columnSelectors []*ColumnSelector
 		for _, selector := range cloned.Sink.ColumnSelectors {
 			columnSelectors = append(columnSelectors, &ColumnSelector{
@@ -590,6 +669,18 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				Columns: selector.Columns,
 			})
 		}
+		var columnTransforms []*ColumnTransform
+		for _, t := range cloned.Sink.ColumnTransforms {
+			columnTransforms = append(columnTransforms, &ColumnTransform{
+				Matcher:    t.Matcher,
+				Columns:    t.Columns,
+				Type:       t.Type,
+				Salt:       t.Salt,
+				Length:     t.Length,
+				Value:      t.Value,
+				Expression: t.Expression,
+			})
+		}
 		var csvConfig *CSVConfig
 		if cloned.Sink.CSVConfig != nil {
 			csvConfig = &CSVConfig{
@@ -677,6 +768,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
 				OutputRawChangeEvent:         cloned.Sink.KafkaConfig.OutputRawChangeEvent,
+				EnableTransaction:            cloned.Sink.KafkaConfig.EnableTransaction,
 			}
 		}
 		var mysqlConfig *MySQLConfig
@@ -697,6 +789,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				EnableBatchDML:               cloned.Sink.MySQLConfig.EnableBatchDML,
 				EnableMultiStatement:         cloned.Sink.MySQLConfig.EnableMultiStatement,
 				EnableCachePreparedStatement: cloned.Sink.MySQLConfig.EnableCachePreparedStatement,
+				DeadLetterQueue:              cloned.Sink.MySQLConfig.DeadLetterQueue,
 			}
 		}
 		var pulsarConfig *PulsarConfig
@@ -744,6 +837,20 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				OutputRawChangeEvent: cloned.Sink.CloudStorageConfig.OutputRawChangeEvent,
 			}
 		}
+		var webhookConfig *WebhookConfig
+		if cloned.Sink.WebhookConfig != nil {
+			webhookConfig = &WebhookConfig{
+				Headers:              cloned.Sink.WebhookConfig.Headers,
+				BasicAuthUser:        cloned.Sink.WebhookConfig.BasicAuthUser,
+				BasicAuthPassword:    cloned.Sink.WebhookConfig.BasicAuthPassword,
+				BearerToken:          cloned.Sink.WebhookConfig.BearerToken,
+				MaxBatchSize:         cloned.Sink.WebhookConfig.MaxBatchSize,
+				MaxRetries:           cloned.Sink.WebhookConfig.MaxRetries,
+				Timeout:              cloned.Sink.WebhookConfig.Timeout,
+				RetryBackoffBaseInMs: cloned.Sink.WebhookConfig.RetryBackoffBaseInMs,
+				RetryBackoffMaxInMs:  cloned.Sink.WebhookConfig.RetryBackoffMaxInMs,
+			}
+		}
 		var debeziumConfig *DebeziumConfig
 		if cloned.Sink.Debezium != nil {
 			debeziumConfig = &DebeziumConfig{
@@ -760,8 +867,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			Protocol:                         cloned.Sink.Protocol,
 			SchemaRegistry:                   cloned.Sink.SchemaRegistry,
 			DispatchRules:                    dispatchRules,
+			RouteRules:                       routeRules,
 			CSVConfig:                        csvConfig,
 			ColumnSelectors:                  columnSelectors,
+			ColumnTransforms:                 columnTransforms,
 			EncoderConcurrency:               cloned.Sink.EncoderConcurrency,
 			Terminator:                       cloned.Sink.Terminator,
 			DateSeparator:                    cloned.Sink.DateSeparator,
@@ -775,6 +884,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			MySQLConfig:                      mysqlConfig,
 			PulsarConfig:                     pulsarConfig,
 			CloudStorageConfig:               cloudStorageConfig,
+			WebhookConfig:                    webhookConfig,
 			SafeMode:                         cloned.Sink.SafeMode,
 			DebeziumConfig:                   debeziumConfig,
 			OpenProtocolConfig:               openProtocolConfig,
@@ -838,6 +948,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			RegionThreshold:        cloned.Scheduler.RegionThreshold,
 			WriteKeyThreshold:      cloned.Scheduler.WriteKeyThreshold,
 			SplitNumberPerNode:     cloned.Scheduler.SplitNumberPerNode,
+			NodeSelector:           cloned.Scheduler.NodeSelector,
+			SpreadByLabel:          cloned.Scheduler.SpreadByLabel,
 		}
 	}
 
@@ -867,9 +979,10 @@ func GetDefaultReplicaConfig() *ReplicaConfig {
 // FilterConfig represents filter config for a changefeed
 // This is a duplicate of config.FilterConfig
 type FilterConfig struct {
-	Rules            []string          `json:"rules,omitempty"`
-	IgnoreTxnStartTs []uint64          `json:"ignore_txn_start_ts,omitempty"`
-	EventFilters     []EventFilterRule `json:"event_filters,omitempty"`
+	Rules             []string          `json:"rules,omitempty"`
+	IgnoreTxnStartTs  []uint64          `json:"ignore_txn_start_ts,omitempty"`
+	EventFilters      []EventFilterRule `json:"event_filters,omitempty"`
+	ForwardDDLClasses []string          `json:"forward_ddl_classes,omitempty"`
 }
 
 // MounterConfig represents mounter config for a changefeed
@@ -949,7 +1062,9 @@ type SinkConfig struct {
 	SchemaRegistry                   *string             `json:"schema_registry,omitempty"`
 	CSVConfig                        *CSVConfig          `json:"csv,omitempty"`
 	DispatchRules                    []*DispatchRule     `json:"dispatchers,omitempty"`
+	RouteRules                       []*RouteRule        `json:"routes,omitempty"`
 	ColumnSelectors                  []*ColumnSelector   `json:"column_selectors,omitempty"`
+	ColumnTransforms                 []*ColumnTransform  `json:"column_transforms,omitempty"`
 	TxnAtomicity                     *string             `json:"transaction_atomicity,omitempty"`
 	EncoderConcurrency               *int                `json:"encoder_concurrency,omitempty"`
 	Terminator                       *string             `json:"terminator,omitempty"`
@@ -965,6 +1080,7 @@ type SinkConfig struct {
 	PulsarConfig                     *PulsarConfig       `json:"pulsar_config,omitempty"`
 	MySQLConfig                      *MySQLConfig        `json:"mysql_config,omitempty"`
 	CloudStorageConfig               *CloudStorageConfig `json:"cloud_storage_config,omitempty"`
+	WebhookConfig                    *WebhookConfig      `json:"webhook_config,omitempty"`
 	AdvanceTimeoutInSec              *uint               `json:"advance_timeout,omitempty"`
 	SendBootstrapIntervalInSec       *int64              `json:"send_bootstrap_interval_in_sec,omitempty"`
 	SendBootstrapInMsgCount          *int32              `json:"send_bootstrap_in_msg_count,omitempty"`
@@ -1006,6 +1122,14 @@ type DispatchRule struct {
 	TopicRule     string   `json:"topic,omitempty"`
 }
 
+// RouteRule represents a route rule for a table.
+// This is a duplicate of config.RouteRule
+type RouteRule struct {
+	Matcher    []string `json:"matcher,omitempty"`
+	SchemaRule string   `json:"schema,omitempty"`
+	TableRule  string   `json:"table,omitempty"`
+}
+
 // ColumnSelector represents a column selector for a table.
 // This is a duplicate of config.ColumnSelector
 type ColumnSelector struct {
@@ -1013,6 +1137,18 @@ type ColumnSelector struct {
 	Columns []string `json:"columns,omitempty"`
 }
 
+// ColumnTransform represents a transform applied to the columns of a table.
+// This is a duplicate of config.ColumnTransform
+type ColumnTransform struct {
+	Matcher    []string `json:"matcher,omitempty"`
+	Columns    []string `json:"columns,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	Salt       string   `json:"salt,omitempty"`
+	Length     int      `json:"length,omitempty"`
+	Value      *string  `json:"value,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
 // ConsistentConfig represents replication consistency config for a changefeed
 // This is a duplicate of config.ConsistentConfig
 type ConsistentConfig struct {
@@ -1047,6 +1183,10 @@ type ChangefeedSchedulerConfig struct {
 	WriteKeyThreshold int `toml:"write_key_threshold" json:"write_key_threshold"`
 	// SplitNumberPerNode is the number of splits per node.
 	SplitNumberPerNode int `toml:"split_number_per_node" json:"split_number_per_node"`
+	// NodeSelector only places the changefeed on the nodes with all of the labels.
+	NodeSelector map[string]string `toml:"node_selector" json:"node_selector,omitempty"`
+	// SpreadByLabel spreads the tables across the distinct values of the node label.
+	SpreadByLabel string `toml:"spread_by_label" json:"spread_by_label,omitempty"`
 }
 
 // IntegrityConfig is the config for integrity check
@@ -1186,9 +1326,10 @@ type Capture struct {
 	ID string `json:"id"`
 	// IsCoordinator is true if the capture is the coordinator of the TiCDC cluster
 	// We make its json key as `is_owner` to keep the compatibility with old TiCDC.
-	IsCoordinator bool   `json:"is_owner"`
-	AdvertiseAddr string `json:"address"`
-	ClusterID     string `json:"cluster_id"`
+	IsCoordinator bool              `json:"is_owner"`
+	AdvertiseAddr string            `json:"address"`
+	ClusterID     string            `json:"cluster_id"`
+	Labels        map[string]string `json:"labels,omitempty"`
 }
 
 // CodecConfig represents a MQ codec configuration
@@ -1272,6 +1413,7 @@ type KafkaConfig struct {
 	LargeMessageHandle           *LargeMessageHandleConfig `json:"large_message_handle,omitempty"`
 	GlueSchemaRegistryConfig     *GlueSchemaRegistryConfig `json:"glue_schema_registry_config,omitempty"`
 	OutputRawChangeEvent         *bool                     `json:"output_raw_change_event,omitempty"`
+	EnableTransaction            *bool                     `json:"enable_transaction,omitempty"`
 }
 
 // MySQLConfig represents a MySQL sink configuration
@@ -1291,6 +1433,7 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `json:"enable_batch_dml,omitempty"`
 	EnableMultiStatement         *bool   `json:"enable_multi_statement,omitempty"`
 	EnableCachePreparedStatement *bool   `json:"enable_cache_prepared_statement,omitempty"`
+	DeadLetterQueue              *string `json:"dead_letter_queue,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -1305,6 +1448,19 @@ type CloudStorageConfig struct {
 	OutputRawChangeEvent *bool   `json:"output_raw_change_event,omitempty"`
 }
 
+// WebhookConfig represents a webhook sink configuration
+type WebhookConfig struct {
+	Headers              map[string]string `json:"headers,omitempty"`
+	BasicAuthUser        *string           `json:"basic_auth_user,omitempty"`
+	BasicAuthPassword    *string           `json:"basic_auth_password,omitempty"`
+	BearerToken          *string           `json:"bearer_token,omitempty"`
+	MaxBatchSize         *int              `json:"max_batch_size,omitempty"`
+	MaxRetries           *int              `json:"max_retries,omitempty"`
+	Timeout              *string           `json:"timeout,omitempty"`
+	RetryBackoffBaseInMs *int64            `json:"retry_backoff_base_in_ms,omitempty"`
+	RetryBackoffMaxInMs  *int64            `json:"retry_backoff_max_in_ms,omitempty"`
+}
+
 // ChangefeedStatus holds common information of a changefeed in cdc
 type ChangefeedStatus struct {
 	State        string        `json:"state,omitempty"`
@@ -1344,15 +1500,78 @@ type DispatcherCount struct {
 type NodeTableInfo struct {
 	NodeID   string  `json:"node_id"`
 	TableIDs []int64 `json:"table_ids"`
+	// Spans are the table spans replicated in the node,
+	// a table may have several spans if it's split.
+	Spans []TableSpanInfo `json:"spans"`
+}
+
+// TableSpanInfo is the information of a table span replicated by a dispatcher.
+type TableSpanInfo struct {
+	TableID      int64  `json:"table_id"`
+	DispatcherID string `json:"dispatcher_id"`
+	// StartKey and EndKey are hex encoded keys in comparable format.
+	StartKey string `json:"start_key"`
+	EndKey   string `json:"end_key"`
+	// SnapshotState is the state of the initial snapshot load of the span, loading or finished.
+	// It is empty if the span doesn't load an initial snapshot.
+	SnapshotState string `json:"snapshot_state,omitempty"`
+	// SnapshotRows is the number of the snapshot rows received by the dispatcher.
+	SnapshotRows uint64 `json:"snapshot_rows,omitempty"`
+	// Scheduling is true if the span is being scheduled, e.g. it's being moved, split or merged.
+	Scheduling bool `json:"scheduling,omitempty"`
 }
 
 func newNodeTableInfo(nodeID string) *NodeTableInfo {
 	return &NodeTableInfo{
 		NodeID:   nodeID,
 		TableIDs: []int64{},
+		Spans:    []TableSpanInfo{},
 	}
 }
 
 func (t *NodeTableInfo) addTableID(tableID int64) {
 	t.TableIDs = append(t.TableIDs, tableID)
 }
+
+func (t *NodeTableInfo) addSpan(
+	dispatcherID common.DispatcherID, span *heartbeatpb.TableSpan,
+	status *heartbeatpb.TableSpanStatus, scheduling bool,
+) {
+	info := TableSpanInfo{
+		TableID:      span.TableID,
+		DispatcherID: formatDispatcherID(dispatcherID),
+		StartKey:     hex.EncodeToString(span.StartKey),
+		EndKey:       hex.EncodeToString(span.EndKey),
+		Scheduling:   scheduling,
+	}
+	switch status.GetSnapshotState() {
+	case heartbeatpb.InitialSnapshotState_SnapshotLoading:
+		info.SnapshotState = "loading"
+	case heartbeatpb.InitialSnapshotState_SnapshotFinished:
+		info.SnapshotState = "finished"
+	}
+	info.SnapshotRows = status.GetSnapshotRows()
+	t.Spans = append(t.Spans, info)
+}
+
+// formatDispatcherID formats the dispatcher id as "{low}-{high}",
+// it can be parsed by parseDispatcherID.
+func formatDispatcherID(id common.DispatcherID) string {
+	return fmt.Sprintf("%d-%d", id.Low, id.High)
+}
+
+func parseDispatcherID(s string) (common.DispatcherID, error) {
+	low, high, found := strings.Cut(s, "-")
+	if !found {
+		return common.DispatcherID{}, errors.ErrAPIInvalidParam.GenWithStack("invalid dispatcher id: %s", s)
+	}
+	lowValue, err := strconv.ParseUint(low, 10, 64)
+	if err != nil {
+		return common.DispatcherID{}, errors.Trace(err)
+	}
+	highValue, err := strconv.ParseUint(high, 10, 64)
+	if err != nil {
+		return common.DispatcherID{}, errors.Trace(err)
+	}
+	return common.DispatcherID(common.NewGIDWithValue(lowValue, highValue)), nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	"github.com/pingcap/ticdc/cmd/cdc/factory"
+	"github.com/pingcap/ticdc/cmd/util"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/spf13/cobra"
+)
+
+// mergeTableChangefeedOptions defines common flags for the `cli changefeed merge-table` command.
+type mergeTableChangefeedOptions struct {
+	apiClientV2 apiv2client.APIV2Interface
+
+	changefeedID string
+	namespace    string
+	tableID      int64
+}
+
+// newMergeTableChangefeedOptions creates new options for the `cli changefeed merge-table` command.
+func newMergeTableChangefeedOptions() *mergeTableChangefeedOptions {
+	return &mergeTableChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *mergeTableChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().Int64VarP(&o.tableID, "table-id", "t", 0, "the id of table to merge")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("table-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *mergeTableChangefeedOptions) complete(f factory.Factory) error {
+	clientV2, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClientV2 = clientV2
+	return nil
+}
+
+// run the `cli changefeed merge-table` command.
+// return success or error message.
+func (o *mergeTableChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	err := o.apiClientV2.Changefeeds().MergeTable(ctx, o.namespace, o.changefeedID, o.tableID)
+	var errStr string
+	if err != nil {
+		errStr = err.Error()
+	}
+	response := &response{
+		Success: err == nil,
+		Error:   errStr,
+	}
+	return util.JSONPrint(cmd, response)
+}
+
+// newCmdMergeTable creates the `cli changefeed merge-table` command.
+func newCmdMergeTable(f factory.Factory) *cobra.Command {
+	o := newMergeTableChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "merge-table",
+		Short: "merge all the spans of a table in a changefeed into one span",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -29,6 +29,7 @@ type moveTableChangefeedOptions struct {
 	changefeedID string
 	namespace    string
 	tableId      int64
+	dispatcherID string
 	targetNodeID string
 }
 
@@ -43,10 +44,12 @@ func (o *moveTableChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().Int64VarP(&o.tableId, "table-id", "t", 0, "the id of table to move")
+	cmd.PersistentFlags().StringVar(&o.dispatcherID, "dispatcher-id", "", "the id of the dispatcher to move, it's used to move a single span of a split table")
 	cmd.PersistentFlags().StringVarP(&o.targetNodeID, "target-node-id", "d", "", "the dest for the table to move")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
-	_ = cmd.MarkPersistentFlagRequired("table-id")
 	_ = cmd.MarkPersistentFlagRequired("target-node-id")
+	cmd.MarkFlagsOneRequired("table-id", "dispatcher-id")
+	cmd.MarkFlagsMutuallyExclusive("table-id", "dispatcher-id")
 }
 
 // complete adapts from the command line args to the data and client required.
@@ -69,7 +72,12 @@ type response struct {
 func (o *moveTableChangefeedOptions) run(cmd *cobra.Command) error {
 	ctx := context.Background()
 
-	err := o.apiClientV2.Changefeeds().MoveTable(ctx, o.namespace, o.changefeedID, o.tableId, o.targetNodeID)
+	var err error
+	if o.dispatcherID != "" {
+		err = o.apiClientV2.Changefeeds().MoveSplitTable(ctx, o.namespace, o.changefeedID, o.dispatcherID, o.targetNodeID)
+	} else {
+		err = o.apiClientV2.Changefeeds().MoveTable(ctx, o.namespace, o.changefeedID, o.tableId, o.targetNodeID)
+	}
 	var errStr string
 	if err != nil {
 		errStr = err.Error()
@@ -0,0 +1,100 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	"github.com/pingcap/ticdc/cmd/cdc/factory"
+	"github.com/pingcap/ticdc/cmd/util"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/spf13/cobra"
+)
+
+// splitTableChangefeedOptions defines common flags for the `cli changefeed split-table` command.
+type splitTableChangefeedOptions struct {
+	apiClientV2 apiv2client.APIV2Interface
+
+	changefeedID   string
+	namespace      string
+	tableID        int64
+	regionsPerSpan int
+	splitKeys      []string
+}
+
+// newSplitTableChangefeedOptions creates new options for the `cli changefeed split-table` command.
+func newSplitTableChangefeedOptions() *splitTableChangefeedOptions {
+	return &splitTableChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *splitTableChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().Int64VarP(&o.tableID, "table-id", "t", 0, "the id of table to split")
+	cmd.PersistentFlags().IntVar(&o.regionsPerSpan, "regions-per-span", 0, "the max number of regions of each span after split")
+	cmd.PersistentFlags().StringSliceVar(&o.splitKeys, "split-keys", nil,
+		"the hex encoded raw keys to split the table at, such as the result of TIDB_ENCODE_RECORD_KEY")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("table-id")
+	cmd.MarkFlagsOneRequired("regions-per-span", "split-keys")
+	cmd.MarkFlagsMutuallyExclusive("regions-per-span", "split-keys")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *splitTableChangefeedOptions) complete(f factory.Factory) error {
+	clientV2, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiClientV2 = clientV2
+	return nil
+}
+
+// run the `cli changefeed split-table` command.
+// return success or error message.
+func (o *splitTableChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	err := o.apiClientV2.Changefeeds().SplitTable(ctx, o.namespace, o.changefeedID, o.tableID, o.regionsPerSpan, o.splitKeys)
+	var errStr string
+	if err != nil {
+		errStr = err.Error()
+	}
+	response := &response{
+		Success: err == nil,
+		Error:   errStr,
+	}
+	return util.JSONPrint(cmd, response)
+}
+
+// newCmdSplitTable creates the `cli changefeed split-table` command.
+func newCmdSplitTable(f factory.Factory) *cobra.Command {
+	o := newSplitTableChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "split-table",
+		Short: "split a table in a changefeed into several spans",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cmd/util"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/logger"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// options defines the flags shared by the `cdc consumer` commands.
+type options struct {
+	downstreamURI string
+	configFile    string
+	timezone      string
+	logFile       string
+	logLevel      string
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// the flags shared by the `cdc consumer` commands to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.downstreamURI, "downstream-uri", "", "the uri of the MySQL compatible downstream, such as mysql://root@127.0.0.1:3306/")
+	cmd.PersistentFlags().StringVar(&o.configFile, "config", "", "the config file of the changefeed which produces the data")
+	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "System", "the time zone used to decode the data")
+	cmd.PersistentFlags().StringVar(&o.logFile, "log-file", "", "the log file path, the log is written to stdout if it's empty")
+	cmd.PersistentFlags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+	_ = cmd.MarkPersistentFlagRequired("downstream-uri")
+}
+
+// initialize initializes the logger and returns a context which is canceled when
+// the process receives a signal to shutdown.
+func (o *options) initialize(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	err := logger.InitLogger(&logger.Config{File: o.logFile, Level: o.logLevel})
+	if err != nil {
+		cmd.Printf("init logger error %v\n", errors.Trace(err))
+		os.Exit(1)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	// A notify that complete immediately, it skips the second signal essentially.
+	doneNotify := func() <-chan struct{} {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	util.InitSignalHandling(doneNotify, cancel)
+	return ctx, cancel
+}
+
+// openDownstream opens a connection to the downstream, the uri is parsed in
+// the same way as the MySQL sink uri, and the parsed config is returned as well.
+func (o *options) openDownstream(ctx context.Context) (*mysql.MysqlConfig, *sql.DB, error) {
+	uri, err := url.Parse(o.downstreamURI)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err := mysql.NewMySQLConfig(common.NewChangeFeedIDWithName("consumer"), uri, &config.ChangefeedConfig{
+		TimeZone:   o.timezone,
+		SinkConfig: &config.SinkConfig{},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	dsn, err := mysql.GenerateDSN(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := mysql.CreateMysqlDBConn(dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("downstream connected", zap.String("uri", uri.Redacted()))
+	return cfg, db, nil
+}
+
+// NewCmdConsumer creates the `consumer` command.
+func NewCmdConsumer() *cobra.Command {
+	o := &options{}
+
+	cmds := &cobra.Command{
+		Use:   "consumer",
+		Short: "Replicate the data written by a changefeed from the sink to a MySQL compatible database",
+		Args:  cobra.NoArgs,
+	}
+	o.addFlags(cmds)
+
+	cmds.AddCommand(newCmdKafka(o))
+	cmds.AddCommand(newCmdStorage(o))
+
+	return cmds
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cmd/util"
+	"github.com/pingcap/ticdc/pkg/consumer"
+	tzutil "github.com/pingcap/ticdc/pkg/util"
+	tiflowConfig "github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/filter"
+	"github.com/spf13/cobra"
+)
+
+// kafkaOptions defines flags for the `cdc consumer kafka` command.
+type kafkaOptions struct {
+	*options
+
+	upstreamURI       string
+	consumerID        string
+	schemaRegistryURI string
+	upstreamTiDBDSN   string
+	ca, cert, key     string
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to the kafka consumer to it.
+func (o *kafkaOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.upstreamURI, "upstream-uri", "", "the kafka uri, such as kafka://127.0.0.1:9092/topic?protocol=canal-json")
+	cmd.Flags().StringVar(&o.consumerID, "consumer-id", "", "the id to store the progress in the downstream, the topic is used if it's empty")
+	cmd.Flags().StringVar(&o.schemaRegistryURI, "schema-registry-uri", "", "the schema registry uri, it's required by the avro protocol")
+	cmd.Flags().StringVar(&o.upstreamTiDBDSN, "upstream-tidb-dsn", "", "the dsn of the upstream TiDB, it's used to fetch the large messages")
+	cmd.Flags().StringVar(&o.ca, "ca", "", "CA certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.cert, "cert", "", "Certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.key, "key", "", "Private key path for Kafka SSL connection")
+	_ = cmd.MarkFlagRequired("upstream-uri")
+}
+
+// run the `cdc consumer kafka` command.
+func (o *kafkaOptions) run(cmd *cobra.Command) error {
+	ctx, cancel := o.initialize(cmd)
+	defer cancel()
+
+	upstreamURI, err := url.Parse(o.upstreamURI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if strings.ToLower(upstreamURI.Scheme) != "kafka" {
+		return errors.Errorf("the scheme of upstream-uri must be kafka, but got %s", upstreamURI.Scheme)
+	}
+	tz, err := tzutil.GetTimezone(o.timezone)
+	if err != nil {
+		return err
+	}
+	replicaConfig := tiflowConfig.GetDefaultReplicaConfig()
+	if len(o.configFile) > 0 {
+		if err = util.StrictDecodeFile(o.configFile, "TiCDC changefeed", replicaConfig); err != nil {
+			return err
+		}
+		if _, err = filter.VerifyTableRules(replicaConfig.Filter); err != nil {
+			return err
+		}
+	}
+	cfg, err := consumer.NewKafkaConfig(upstreamURI, replicaConfig, tz)
+	if err != nil {
+		return err
+	}
+	if o.consumerID != "" {
+		cfg.ConsumerID = o.consumerID
+	}
+	cfg.SchemaRegistryURI = o.schemaRegistryURI
+	cfg.Reader.CA, cfg.Reader.Cert, cfg.Reader.Key = o.ca, o.cert, o.key
+
+	_, downstream, err := o.openDownstream(ctx)
+	if err != nil {
+		return err
+	}
+	defer downstream.Close()
+	var upstreamTiDB *sql.DB
+	if o.upstreamTiDBDSN != "" {
+		upstreamTiDB, err = sql.Open("mysql", o.upstreamTiDBDSN)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer upstreamTiDB.Close()
+	}
+	reader, err := consumer.NewKafkaReader(&cfg.Reader)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	err = consumer.NewKafkaConsumer(cfg, reader, downstream, upstreamTiDB).Run(ctx)
+	if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+// newCmdKafka creates the `consumer kafka` command.
+func newCmdKafka(o *options) *cobra.Command {
+	ko := &kafkaOptions{options: o}
+
+	command := &cobra.Command{
+		Use:   "kafka",
+		Short: "Replicate the messages of a kafka topic to a MySQL compatible database",
+		Long: "Replicate the messages of a kafka topic to a MySQL compatible database. The offsets and watermarks " +
+			"of the partitions are stored in the downstream together with the applied rows, so the consumer " +
+			"resumes where it left off after restarted.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(ko.run(cmd))
+		},
+	}
+
+	ko.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cmd/util"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/pkg/consumer"
+	tzutil "github.com/pingcap/ticdc/pkg/util"
+	tiflowConfig "github.com/pingcap/tiflow/pkg/config"
+	psink "github.com/pingcap/tiflow/pkg/sink"
+	"github.com/spf13/cobra"
+)
+
+// storageOptions defines flags for the `cdc consumer storage` command.
+type storageOptions struct {
+	*options
+
+	upstreamURI       string
+	consumerID        string
+	workerCount       int
+	scanInterval      time.Duration
+	discoveryInterval time.Duration
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to the storage consumer to it.
+func (o *storageOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.upstreamURI, "upstream-uri", "", "the storage uri, such as s3://bucket/prefix?protocol=csv")
+	cmd.Flags().StringVar(&o.consumerID, "consumer-id", "", "the id to store the progress in the downstream, the storage uri is used if it's empty")
+	cmd.Flags().IntVar(&o.workerCount, "worker-count", 0, "the number of tables applied in parallel, a default value is used if it's 0")
+	cmd.Flags().DurationVar(&o.scanInterval, "scan-interval", 0, "the interval to scan the storage for new files, a default value is used if it's 0")
+	cmd.Flags().DurationVar(&o.discoveryInterval, "discovery-interval", 0, "the interval to walk the storage for new tables and directories, a default value is used if it's 0")
+	_ = cmd.MarkFlagRequired("upstream-uri")
+}
+
+// run the `cdc consumer storage` command.
+func (o *storageOptions) run(cmd *cobra.Command) error {
+	ctx, cancel := o.initialize(cmd)
+	defer cancel()
+
+	upstreamURI, err := url.Parse(o.upstreamURI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !psink.IsStorageScheme(upstreamURI.Scheme) {
+		return errors.Errorf("the scheme of upstream-uri must be a storage scheme, but got %s", upstreamURI.Scheme)
+	}
+	tz, err := tzutil.GetTimezone(o.timezone)
+	if err != nil {
+		return err
+	}
+	replicaConfig := tiflowConfig.GetDefaultReplicaConfig()
+	if len(o.configFile) > 0 {
+		if err = util.StrictDecodeFile(o.configFile, "TiCDC changefeed", replicaConfig); err != nil {
+			return err
+		}
+	}
+	cfg, err := consumer.NewStorageConfig(upstreamURI, replicaConfig, tz)
+	if err != nil {
+		return err
+	}
+	if o.consumerID != "" {
+		cfg.ConsumerID = o.consumerID
+	}
+	if o.workerCount > 0 {
+		cfg.WorkerCount = o.workerCount
+	}
+	if o.scanInterval > 0 {
+		cfg.ScanInterval = o.scanInterval
+	}
+	if o.discoveryInterval > 0 {
+		cfg.DiscoveryInterval = o.discoveryInterval
+	}
+
+	storage, err := helper.GetExternalStorageFromURI(ctx, o.upstreamURI)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+	mysqlCfg, downstream, err := o.openDownstream(ctx)
+	if err != nil {
+		return err
+	}
+	defer downstream.Close()
+
+	err = consumer.NewStorageConsumer(cfg, storage, mysqlCfg, downstream).Run(ctx)
+	if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+// newCmdStorage creates the `consumer storage` command.
+func newCmdStorage(o *options) *cobra.Command {
+	so := &storageOptions{options: o}
+
+	command := &cobra.Command{
+		Use:   "storage",
+		Short: "Replicate the files written by the cloud storage sink to a MySQL compatible database",
+		Long: "Replicate the files written by the cloud storage sink to a MySQL compatible database. The applied " +
+			"files of each table version are stored in the downstream, so the consumer resumes where it left " +
+			"off after restarted, and the DDLs are executed in the order of the table versions.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(so.run(cmd))
+		},
+	}
+
+	so.addFlags(command)
+
+	return command
+}
@@ -20,7 +20,9 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cmd/cdc/cli"
+	"github.com/pingcap/ticdc/cmd/cdc/consumer"
 	"github.com/pingcap/ticdc/cmd/cdc/server"
+	"github.com/pingcap/ticdc/cmd/cdc/verify"
 	"github.com/pingcap/ticdc/cmd/cdc/version"
 	"github.com/pingcap/ticdc/cmd/util"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -46,6 +48,8 @@ func addNewArchCommandTo(cmd *cobra.Command) {
 	cmd.AddCommand(server.NewCmdServer())
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
+	cmd.AddCommand(verify.NewCmdVerify())
+	cmd.AddCommand(consumer.NewCmdConsumer())
 }
 
 func isNewArchEnabledByConfig(serverConfigFilePath string) bool {
@@ -82,8 +86,9 @@ func parseConfigFlagFromOSArgs() string {
 		}
 	}
 
-	// If the command is `cdc cli changefeed`, means it's not a server config file.
-	if slices.Contains(os.Args, "cli") && slices.Contains(os.Args, "changefeed") {
+	// If the command is `cdc cli changefeed`, `cdc verify` or `cdc consumer`, means it's not a server config file.
+	if (slices.Contains(os.Args, "cli") && slices.Contains(os.Args, "changefeed")) ||
+		slices.Contains(os.Args, "verify") || slices.Contains(os.Args, "consumer") {
 		serverConfigFilePath = ""
 	}
 
@@ -66,6 +66,7 @@ func (o *options) addFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.serverConfig.LogLevel, "log-level", o.serverConfig.LogLevel, "log level (etc: debug|info|warn|error)")
 
 	cmd.Flags().StringVar(&o.serverConfig.DataDir, "data-dir", o.serverConfig.DataDir, "the path to the directory used to store TiCDC-generated data")
+	cmd.Flags().StringToStringVar(&o.serverConfig.Labels, "labels", o.serverConfig.Labels, "Set the labels of the server, e.g. zone=az1,role=heavy")
 
 	cmd.Flags().StringSliceVar(&o.pdEndpoints, "pd", []string{"http://127.0.0.1:2379"}, "Set the PD endpoints to use. Use ',' to separate multiple PDs")
 	cmd.Flags().StringVar(&o.serverConfigFilePath, "config", "", "Path of the configuration file")
@@ -156,6 +157,8 @@ func (o *options) complete(command *cobra.Command) error {
 			cfg.Security.CertAllowedCN = o.serverConfig.Security.CertAllowedCN
 		case "cluster-id":
 			cfg.ClusterID = o.serverConfig.ClusterID
+		case "labels":
+			cfg.Labels = o.serverConfig.Labels
 		case "pd", "config":
 			// do nothing
 		default:
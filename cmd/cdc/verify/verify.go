@@ -0,0 +1,152 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cmd/util"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/ticdc/pkg/verify"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `cdc verify` command.
+type options struct {
+	upstreamURI  string
+	sinkURI      string
+	clusterID    string
+	namespace    string
+	changefeedID string
+	primaryTs    uint64
+	chunkSize    int
+	configFile   string
+}
+
+// newOptions creates new options for the `cdc verify` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.upstreamURI, "upstream-uri", "", "the uri of the upstream TiDB, such as mysql://root@127.0.0.1:4000/")
+	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "", "the sink uri of the changefeed, the downstream must be TiDB")
+	cmd.PersistentFlags().StringVar(&o.clusterID, "cluster-id", "default", "the id of the TiCDC cluster")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().Uint64Var(&o.primaryTs, "primary-ts", 0, "the primary ts of the sync point to verify, 0 means the latest sync point")
+	cmd.PersistentFlags().IntVar(&o.chunkSize, "chunk-size", verify.DefaultChunkSize, "the max number of rows in a chunk")
+	cmd.PersistentFlags().StringVar(&o.configFile, "config", "", "the config file of the changefeed, the filter in it is used to pick the tables")
+	_ = cmd.MarkPersistentFlagRequired("upstream-uri")
+	_ = cmd.MarkPersistentFlagRequired("sink-uri")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+}
+
+// run the `cdc verify` command.
+func (o *options) run(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	cfg := config.GetDefaultReplicaConfig()
+	if len(o.configFile) > 0 {
+		if err := util.StrictDecodeFile(o.configFile, "TiCDC changefeed", cfg); err != nil {
+			return err
+		}
+		if _, err := filter.VerifyTableRules(cfg.Filter); err != nil {
+			return err
+		}
+	}
+
+	upstream, err := openDB(ctx, o.upstreamURI)
+	if err != nil {
+		return err
+	}
+	defer upstream.Close()
+	downstream, err := openDB(ctx, o.sinkURI)
+	if err != nil {
+		return err
+	}
+	defer downstream.Close()
+
+	verifier, err := verify.NewVerifier(upstream, downstream, &verify.Config{
+		ClusterID:     o.clusterID,
+		ChangefeedID:  common.NewChangeFeedDisplayName(o.changefeedID, o.namespace).String(),
+		PrimaryTs:     o.primaryTs,
+		ChunkSize:     o.chunkSize,
+		Filter:        cfg.Filter,
+		CaseSensitive: cfg.CaseSensitive,
+	})
+	if err != nil {
+		return err
+	}
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	if err := util.JSONPrint(cmd, report); err != nil {
+		return err
+	}
+	if !report.Consistent() {
+		return errors.Errorf("found %d mismatches between upstream and downstream", len(report.Mismatches))
+	}
+	return nil
+}
+
+// openDB opens a connection to the MySQL compatible database by the uri,
+// the uri is parsed in the same way as the MySQL sink uri.
+func openDB(ctx context.Context, uri string) (*sql.DB, error) {
+	dbURI, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := mysql.NewMySQLConfig(common.NewChangeFeedIDWithName("verify"), dbURI, &config.ChangefeedConfig{
+		TimeZone:   dbURI.Query().Get("time-zone"),
+		SinkConfig: &config.SinkConfig{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	dsn, err := mysql.GenBasicDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return mysql.CreateMysqlDBConn(dsn.FormatDSN())
+}
+
+// NewCmdVerify creates the `verify` command.
+func NewCmdVerify() *cobra.Command {
+	o := newOptions()
+
+	command := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the data consistency between upstream and downstream at a sync point",
+		Long: "Verify reads each replicated table in the upstream at the primary ts and in the downstream " +
+			"at the secondary ts of a sync point, and compares the checksums of them chunk by chunk.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
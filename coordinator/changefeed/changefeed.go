@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/atomic"
@@ -134,6 +135,15 @@ func (c *Changefeed) ShouldRun() bool {
 	return c.backoff.ShouldRun()
 }
 
+// GetPriority returns the priority class of the changefeed.
+func (c *Changefeed) GetPriority() config.ChangefeedPriority {
+	info := c.GetInfo()
+	if info == nil || info.Config == nil {
+		return config.ChangefeedPriorityNormal
+	}
+	return info.Config.Priority
+}
+
 // UpdateStatus updates the changefeed status
 // It returns true if the status is changed
 // It returns false if the status is not changed
@@ -151,8 +161,9 @@ func (c *Changefeed) UpdateStatus(newStatus *heartbeatpb.MaintainerStatus) (bool
 		}
 
 		info := c.GetInfo()
-		// the changefeed reaches the targetTs
-		if info.TargetTs != 0 && newStatus.CheckpointTs >= info.TargetTs {
+		// the changefeed reaches the targetTs, only report the finished state once
+		if info.TargetTs != 0 && newStatus.CheckpointTs >= info.TargetTs &&
+			info.State != model.StateFinished {
 			return true, model.StateFinished, nil
 		}
 
@@ -168,7 +179,8 @@ func (c *Changefeed) ForceUpdateStatus(newStatus *heartbeatpb.MaintainerStatus)
 }
 
 func (c *Changefeed) NeedCheckpointTsMessage() bool {
-	return c.sinkType == common.KafkaSinkType || c.sinkType == common.CloudStorageSinkType
+	return c.sinkType == common.KafkaSinkType || c.sinkType == common.CloudStorageSinkType ||
+		c.sinkType == common.WebhookSinkType
 }
 
 func (c *Changefeed) SetIsNew(isNew bool) {
@@ -271,5 +283,8 @@ func getSinkType(scheme string) common.SinkType {
 	if sink.IsStorageScheme(scheme) {
 		return common.CloudStorageSinkType
 	}
+	if webhook.IsWebhookScheme(scheme) {
+		return common.WebhookSinkType
+	}
 	return common.BlackHoleSinkType
 }
@@ -16,6 +16,7 @@ package changefeed
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 
 	"github.com/pingcap/log"
@@ -185,6 +186,19 @@ func (db *ChangefeedDB) MarkMaintainerReplicating(task *Changefeed) {
 	task.StartFinished()
 }
 
+// GetAbsentByGroup returns at most batch absent changefeeds of the group, the
+// changefeeds with higher priority come first, so they are scheduled earlier.
+func (db *ChangefeedDB) GetAbsentByGroup(id replica.GroupID, batch int) []*Changefeed {
+	absent := db.ReplicationDB.GetAbsentByGroup(id, db.GetAbsentSize())
+	sort.SliceStable(absent, func(i, j int) bool {
+		return absent[i].GetPriority().Level() < absent[j].GetPriority().Level()
+	})
+	if len(absent) > batch {
+		absent = absent[:batch]
+	}
+	return absent
+}
+
 // GetWaitingSchedulingChangefeeds returns the absent maintainers and the working state of each node
 func (db *ChangefeedDB) GetWaitingSchedulingChangefeeds(absent []*Changefeed, maxSize int) ([]*Changefeed, map[node.ID]int) {
 	absent = db.GetAbsent()
@@ -282,6 +296,37 @@ func (db *ChangefeedDB) CalculateGCSafepoint() uint64 {
 	return minCpts
 }
 
+// UpstreamGCSafepoint is the minimum checkpointTs of the changefeeds that replicating the same upstream.
+type UpstreamGCSafepoint struct {
+	// Namespace is the namespace of one of the changefeeds, it is used to load the upstream info.
+	Namespace       string
+	MinCheckpointTs uint64
+}
+
+// CalculateGCSafepointByUpstream calculates the minimum checkpointTs of the changefeeds
+// for each upstream TiDB cluster, the key of the returned map is the upstream ID.
+func (db *ChangefeedDB) CalculateGCSafepointByUpstream() map[uint64]UpstreamGCSafepoint {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	safepoints := make(map[uint64]UpstreamGCSafepoint)
+	for _, cf := range db.changefeeds {
+		info := cf.GetInfo()
+		if info == nil || !info.NeedBlockGC() {
+			continue
+		}
+		checkpointTs := cf.GetLastSavedCheckPointTs()
+		safepoint, ok := safepoints[info.UpstreamID]
+		if !ok || safepoint.MinCheckpointTs > checkpointTs {
+			safepoints[info.UpstreamID] = UpstreamGCSafepoint{
+				Namespace:       cf.ID.Namespace(),
+				MinCheckpointTs: checkpointTs,
+			}
+		}
+	}
+	return safepoints
+}
+
 // ReplaceStoppedChangefeed updates the stopped changefeed
 func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 	db.lock.Lock()
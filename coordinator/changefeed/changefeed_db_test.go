@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
@@ -38,6 +39,27 @@ func TestAddAbsentChangefeed(t *testing.T) {
 	require.Contains(t, db.changefeeds, cf.ID)
 }
 
+func TestGetAbsentByGroupOrderByPriority(t *testing.T) {
+	db := NewChangefeedDB(1216)
+	newChangefeed := func(name string, priority config.ChangefeedPriority) *Changefeed {
+		cf := &Changefeed{
+			ID:   common.NewChangeFeedIDWithName(name),
+			info: atomic.NewPointer(&config.ChangeFeedInfo{Config: &config.ReplicaConfig{Priority: priority}}),
+		}
+		cf.backoff = NewBackoff(cf.ID, 0, 0)
+		return cf
+	}
+	batch := newChangefeed("batch", config.ChangefeedPriorityBatch)
+	normal := newChangefeed("normal", "")
+	high := newChangefeed("high", config.ChangefeedPriorityHigh)
+	db.AddAbsentChangefeed(batch, normal, high)
+
+	absent := db.GetAbsentByGroup(replica.DefaultGroupID, 10)
+	require.Equal(t, []*Changefeed{high, normal, batch}, absent)
+	absent = db.GetAbsentByGroup(replica.DefaultGroupID, 1)
+	require.Equal(t, []*Changefeed{high}, absent)
+}
+
 func TestAddStoppedChangefeed(t *testing.T) {
 	db := NewChangefeedDB(1216)
 	cf := &Changefeed{ID: common.NewChangeFeedIDWithName("test")}
@@ -330,3 +352,31 @@ func TestCalculateGCSafepoint(t *testing.T) {
 	db.AddStoppedChangefeed(cf5)
 	require.Equal(t, uint64(7), db.CalculateGCSafepoint())
 }
+
+func TestCalculateGCSafepointByUpstream(t *testing.T) {
+	db := NewChangefeedDB(1216)
+	require.Empty(t, db.CalculateGCSafepointByUpstream())
+
+	addChangefeed := func(upstreamID uint64, state model.FeedState, checkpointTs uint64) {
+		cfID := common.NewChangeFeedIDWithName("test")
+		cf := NewChangefeed(cfID,
+			&config.ChangeFeedInfo{
+				ChangefeedID: cfID,
+				UpstreamID:   upstreamID,
+				Config:       config.GetDefaultReplicaConfig(),
+				State:        state,
+			}, checkpointTs, true)
+		db.AddStoppedChangefeed(cf)
+	}
+	addChangefeed(1, model.StateNormal, 11)
+	addChangefeed(1, model.StateNormal, 10)
+	addChangefeed(2, model.StateNormal, 20)
+	// finished changefeed does not block gc
+	addChangefeed(2, model.StateFinished, 5)
+
+	safepoints := db.CalculateGCSafepointByUpstream()
+	require.Len(t, safepoints, 2)
+	require.Equal(t, uint64(10), safepoints[1].MinCheckpointTs)
+	require.Equal(t, uint64(20), safepoints[2].MinCheckpointTs)
+	require.Equal(t, common.DefaultNamespace, safepoints[2].Namespace)
+}
@@ -91,6 +91,33 @@ func TestChangefeed_UpdateStatus(t *testing.T) {
 	require.Equal(t, newStatus, cf.GetStatus())
 }
 
+func TestChangefeed_UpdateStatusReachTargetTs(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		SinkURI:  "kafka://127.0.0.1:9092",
+		TargetTs: 300,
+		State:    model.StateNormal,
+		Config:   config.GetDefaultReplicaConfig(),
+	}
+	cf := NewChangefeed(cfID, info, 100, true)
+
+	updated, state, _ := cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 200})
+	require.False(t, updated)
+	require.Equal(t, model.StateNormal, state)
+
+	updated, state, _ = cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 300})
+	require.True(t, updated)
+	require.Equal(t, model.StateFinished, state)
+
+	// The finished state is not reported again once it is saved.
+	finishedInfo, err := info.Clone()
+	require.NoError(t, err)
+	finishedInfo.State = model.StateFinished
+	cf.SetInfo(finishedInfo)
+	updated, _, _ = cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 300})
+	require.False(t, updated)
+}
+
 func TestChangefeed_IsMQSink(t *testing.T) {
 	cfID := common.NewChangeFeedIDWithName("test")
 	info := &config.ChangeFeedInfo{
@@ -117,6 +117,7 @@ func NewController(
 				changefeedDB,
 				nodeManager,
 				oc.NewAddMaintainerOperator,
+				getChangefeedPlacement,
 			),
 			scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(
 				selfNode.ID.String(),
@@ -126,6 +127,7 @@ func NewController(
 				nodeManager,
 				balanceInterval,
 				oc.NewMoveMaintainerOperator,
+				getChangefeedPlacement,
 			),
 		}),
 		eventCh:             eventCh,
@@ -715,8 +717,8 @@ func (c *Controller) moveChangefeedToSchedulingQueue(
 	c.changefeedDB.MoveToSchedulingQueue(id, resetBackoff, overwriteCheckpointTs)
 }
 
-func (c *Controller) calculateGCSafepoint() uint64 {
-	return c.changefeedDB.CalculateGCSafepoint()
+func (c *Controller) calculateGCSafepoint() map[uint64]changefeed.UpstreamGCSafepoint {
+	return c.changefeedDB.CalculateGCSafepointByUpstream()
 }
 
 func shouldRunChangefeed(state model.FeedState) bool {
@@ -726,3 +728,12 @@ func shouldRunChangefeed(state model.FeedState) bool {
 	}
 	return true
 }
+
+// getChangefeedPlacement returns the placement constraints of the changefeed maintainer.
+func getChangefeedPlacement(cf *changefeed.Changefeed) *scheduler.Placement {
+	info := cf.GetInfo()
+	if info == nil || info.Config == nil {
+		return nil
+	}
+	return scheduler.NewPlacement(info.Config.Scheduler)
+}
@@ -16,6 +16,7 @@ package coordinator
 import (
 	"context"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/pingcap/failpoint"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/server"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/chann"
 	"github.com/pingcap/ticdc/utils/threadpool"
@@ -86,6 +88,12 @@ type coordinator struct {
 	pdClient  pd.Client
 	pdClock   pdutil.Clock
 
+	// upstreams holds the upstreams other than the default one, and each of
+	// them has its own gc manager to maintain the gc safepoint.
+	upstreams          *upstream.Manager
+	upstreamGCMu       sync.Mutex
+	upstreamGCManagers map[uint64]*upstreamGCManager
+
 	// eventCh is used to receive the event from message center, basically these messages
 	// are from maintainer.
 	eventCh *chann.DrainableChann[*Event]
@@ -99,6 +107,7 @@ type coordinator struct {
 func New(node *node.Info,
 	pdClient pd.Client,
 	pdClock pdutil.Clock,
+	upstreams *upstream.Manager,
 	backend changefeed.Backend,
 	gcServiceID string,
 	version int64,
@@ -115,6 +124,8 @@ func New(node *node.Info,
 		eventCh:            chann.NewAutoDrainChann[*Event](),
 		pdClient:           pdClient,
 		pdClock:            pdClock,
+		upstreams:          upstreams,
+		upstreamGCManagers: make(map[uint64]*upstreamGCManager),
 		mc:                 mc,
 		changefeedChangeCh: make(chan []*ChangefeedChange, 1024),
 		backend:            backend,
@@ -286,8 +297,14 @@ func (c *coordinator) handleStateChange(
 }
 
 // checkStaleCheckpointTs checks if the checkpointTs is stale, if it is, it will send a state change event to the stateChangedCh
-func (c *coordinator) checkStaleCheckpointTs(ctx context.Context, id common.ChangeFeedID, reportedCheckpointTs uint64) {
-	err := c.gcManager.CheckStaleCheckpointTs(ctx, id, reportedCheckpointTs)
+func (c *coordinator) checkStaleCheckpointTs(ctx context.Context, info *config.ChangeFeedInfo, reportedCheckpointTs uint64) {
+	id := info.ChangefeedID
+	// the changefeed is in warning state if the gc manager of its upstream is not available,
+	// since whether the checkpointTs is stale is unknown.
+	gcManager, err := c.getGCManager(ctx, info.UpstreamID, id.Namespace())
+	if err == nil {
+		err = gcManager.CheckStaleCheckpointTs(ctx, id, reportedCheckpointTs)
+	}
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	if err != nil {
@@ -329,7 +346,7 @@ func (c *coordinator) saveCheckpointTs(ctx context.Context, changes []*Changefee
 		if upCf.GetLastSavedCheckPointTs() < reportedCheckpointTs {
 			statusMap[upCf.ID] = reportedCheckpointTs
 			cfsMap[upCf.ID] = upCf
-			c.checkStaleCheckpointTs(ctx, upCf.ID, reportedCheckpointTs)
+			c.checkStaleCheckpointTs(ctx, upCf.GetInfo(), reportedCheckpointTs)
 		}
 	}
 	if len(statusMap) == 0 {
@@ -408,7 +425,15 @@ func (c *coordinator) sendMessages(msgs []*messaging.TargetMessage) {
 func (c *coordinator) updateGCSafepoint(
 	ctx context.Context,
 ) error {
-	minCheckpointTs := c.controller.calculateGCSafepoint()
+	safepoints := c.controller.calculateGCSafepoint()
+	// the changefeeds of the default upstream may use 0 or the cluster ID as the upstream ID
+	var minCheckpointTs uint64 = math.MaxUint64
+	for upstreamID, safepoint := range safepoints {
+		if c.isDefaultUpstream(ctx, upstreamID) {
+			minCheckpointTs = min(minCheckpointTs, safepoint.MinCheckpointTs)
+			delete(safepoints, upstreamID)
+		}
+	}
 	// check if the upstream has a changefeed, if not we should update the gc safepoint
 	if minCheckpointTs == math.MaxUint64 {
 		ts := c.pdClock.CurrentTime()
@@ -419,7 +444,76 @@ func (c *coordinator) updateGCSafepoint(
 	// bound for the GC safepoint.
 	gcSafepointUpperBound := minCheckpointTs - 1
 	err := c.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, false)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The gc safepoint of the other upstreams is maintained in best effort,
+	// an unavailable upstream should not block the default one.
+	for upstreamID, safepoint := range safepoints {
+		gcManager, err := c.getGCManager(ctx, upstreamID, safepoint.Namespace)
+		if err == nil {
+			err = gcManager.TryUpdateGCSafePoint(ctx, safepoint.MinCheckpointTs-1, false)
+		}
+		if err != nil {
+			log.Warn("failed to update gc safepoint of the upstream",
+				zap.Uint64("upstreamID", upstreamID), zap.Error(err))
+		}
+	}
+	// Same as the default upstream, the gc safepoint of the upstreams which have
+	// no changefeeds any more is updated by the current time.
+	c.upstreamGCMu.Lock()
+	idleUpstreams := make(map[uint64]*upstreamGCManager)
+	for upstreamID, gcManager := range c.upstreamGCManagers {
+		if _, ok := safepoints[upstreamID]; !ok {
+			idleUpstreams[upstreamID] = gcManager
+		}
+	}
+	c.upstreamGCMu.Unlock()
+	for upstreamID, gcManager := range idleUpstreams {
+		ts := oracle.GoTimeToTS(gcManager.pdClock.CurrentTime())
+		if err := gcManager.TryUpdateGCSafePoint(ctx, ts-1, false); err != nil {
+			log.Warn("failed to update gc safepoint of the upstream",
+				zap.Uint64("upstreamID", upstreamID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// upstreamGCManager is the gc manager of an upstream other than the default one.
+type upstreamGCManager struct {
+	gc.Manager
+	pdClock pdutil.Clock
+}
+
+func (c *coordinator) isDefaultUpstream(ctx context.Context, upstreamID uint64) bool {
+	return upstreamID == 0 || upstreamID == c.pdClient.GetClusterID(ctx)
+}
+
+// getGCManager returns the gc manager of the upstream, the gc manager of the
+// other upstreams is created when it is used for the first time.
+func (c *coordinator) getGCManager(ctx context.Context, upstreamID uint64, namespace string) (gc.Manager, error) {
+	if c.isDefaultUpstream(ctx, upstreamID) {
+		return c.gcManager, nil
+	}
+	c.upstreamGCMu.Lock()
+	defer c.upstreamGCMu.Unlock()
+	if gcManager, ok := c.upstreamGCManagers[upstreamID]; ok {
+		return gcManager.Manager, nil
+	}
+	if c.upstreams == nil {
+		return nil, errors.ErrUpstreamNotFound.GenWithStackByArgs(upstreamID)
+	}
+	up, err := c.upstreams.GetOrLoad(ctx, upstreamID, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !up.IsNormal() {
+		return nil, errors.ErrUpstreamNotFound.GenWithStackByArgs(upstreamID)
+	}
+	gcManager := gc.NewManager(c.gcServiceID, up.PDClient, up.PDClock)
+	c.upstreamGCManagers[upstreamID] = &upstreamGCManager{Manager: gcManager, pdClock: up.PDClock}
+	return gcManager, nil
 }
 
 // GetEnsureGCServiceID return the prefix for the gc service id when changefeed is creating
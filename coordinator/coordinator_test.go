@@ -33,11 +33,13 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
@@ -278,7 +280,7 @@ func TestCoordinatorScheduling(t *testing.T) {
 		}
 	}
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, "default", 100, 10000, time.Minute)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), nil, backend, "default", 100, 10000, time.Minute)
 	co := cr.(*coordinator)
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -331,7 +333,7 @@ func TestScaleNode(t *testing.T) {
 	}
 	backend.EXPECT().GetAllChangefeeds(gomock.Any()).Return(cfs, nil).AnyTimes()
 
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*1)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), nil, backend, serviceID, 100, 10000, time.Millisecond*1)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -463,7 +465,7 @@ func TestBootstrapWithUnStoppedChangefeed(t *testing.T) {
 	}, nil).AnyTimes()
 	backend.EXPECT().DeleteChangefeed(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	backend.EXPECT().SetChangefeedProgress(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), backend, serviceID, 100, 10000, time.Millisecond*10)
+	cr := New(info, &mockPdClient{}, pdutil.NewClock4Test(), nil, backend, serviceID, 100, 10000, time.Millisecond*10)
 
 	// run coordinator
 	go func() { cr.Run(ctx) }()
@@ -531,3 +533,59 @@ func newMockEtcdClient(ownerID string) *mockEtcdClient {
 func (m *mockEtcdClient) GetOwnerID(ctx context.Context) (model.CaptureID, error) {
 	return model.CaptureID(m.ownerID), nil
 }
+
+func (m *mockEtcdClient) GetNodeInfo(ctx context.Context, id model.CaptureID) (*node.Info, error) {
+	return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(id)
+}
+
+func TestUpdateGCSafepointOfUpstreams(t *testing.T) {
+	ctx := context.Background()
+	safepoints := make(map[uint64]uint64)
+	newPDClient := func(clusterID uint64) *gc.MockPDClient {
+		return &gc.MockPDClient{
+			ClusterID: clusterID,
+			UpdateServiceGCSafePointFunc: func(_ context.Context, _ string, _ int64, safePoint uint64) (uint64, error) {
+				safepoints[clusterID] = safePoint
+				return safePoint, nil
+			},
+		}
+	}
+	pdClock := pdutil.NewClock4Test()
+	defaultPDClient := newPDClient(1)
+	changefeedDB := changefeed.NewChangefeedDB(1216)
+	co := &coordinator{
+		controller:         &Controller{changefeedDB: changefeedDB},
+		gcManager:          gc.NewManager("test", defaultPDClient, pdClock),
+		pdClient:           defaultPDClient,
+		pdClock:            pdClock,
+		upstreamGCManagers: make(map[uint64]*upstreamGCManager),
+		changefeedChangeCh: make(chan []*ChangefeedChange, 1),
+	}
+	// the upstream 2 has no changefeeds any more.
+	co.upstreamGCManagers[2] = &upstreamGCManager{
+		Manager: gc.NewManager("test", newPDClient(2), pdClock),
+		pdClock: pdClock,
+	}
+
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		Config:       config.GetDefaultReplicaConfig(),
+		State:        model.StateNormal,
+		SinkURI:      "mysql://127.0.0.1:3306",
+		UpstreamID:   1,
+	}
+	changefeedDB.AddAbsentChangefeed(changefeed.NewChangefeed(cfID, info, 100, true))
+	require.NoError(t, co.updateGCSafepoint(ctx))
+	require.Equal(t, uint64(99), safepoints[1])
+	// the gc safepoint of the idle upstream is updated by the current time.
+	require.Greater(t, safepoints[2], uint64(99))
+
+	// the changefeed is in warning state if the gc manager of its upstream is not available.
+	info.UpstreamID = 3
+	co.checkStaleCheckpointTs(ctx, info, 200)
+	changes := <-co.changefeedChangeCh
+	require.Len(t, changes, 1)
+	require.Equal(t, cfID, changes[0].changefeedID)
+	require.Equal(t, model.StateWarning, changes[0].state)
+}
@@ -15,6 +15,7 @@ package dispatcher
 
 import (
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -26,7 +27,10 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	"github.com/pingcap/ticdc/pkg/common/columntransform"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/ticdc/pkg/spanz"
 	"go.uber.org/zap"
@@ -39,6 +43,12 @@ type EventDispatcher interface {
 	GetChangefeedID() common.ChangeFeedID
 	GetTableSpan() *heartbeatpb.TableSpan
 	GetFilterConfig() *eventpb.FilterConfig
+	GetColumnProjection() *eventpb.ColumnProjection
+	GetPriority() config.ChangefeedPriority
+	NeedInitialSnapshot() bool
+	GetTargetTs() uint64
+	GetReplicationDelay() time.Duration
+	GetUpstreamID() uint64
 	EnableSyncPoint() bool
 	GetSyncPointInterval() time.Duration
 	GetStartTsIsSyncpoint() bool
@@ -88,6 +98,8 @@ type Dispatcher struct {
 	// startTs is the timestamp that the dispatcher need to receive and flush events.
 	startTs            uint64
 	startTsIsSyncpoint bool
+	// sharedConfig is the config of the changefeed shared by all the dispatchers in this node.
+	sharedConfig *SharedConfig
 	// The ts from pd when the dispatcher is created.
 	// when downstream is mysql-class, for dml event we need to compare the commitTs with this ts
 	// to determine whether the insert event should use `Replace` or just `Insert`
@@ -99,6 +111,22 @@ type Dispatcher struct {
 	componentStatus *ComponentStateWithMutex
 	// the config of filter
 	filterConfig *eventpb.FilterConfig
+	// projection is built from the column projection of the shared config,
+	// it's used to assemble the rows of the dml events with the same columns.
+	projection *columnselector.Projection
+	// throttleMu protects throttledEvents and throttleClosed.
+	throttleMu sync.Mutex
+	// throttledEvents is the number of the dml events held by the throughput limiter,
+	// they are in the table progress but not written to the sink.
+	throttledEvents int
+	// throttleClosed is true if the dispatcher is closed, the throttled events are
+	// never written to the sink after that.
+	throttleClosed bool
+	// initialSnapshot is true if the dispatcher receives the rows of the table at startTs
+	// before the incremental events.
+	initialSnapshot bool
+	// snapshotRows is the number of the snapshot rows received by the dispatcher.
+	snapshotRows atomic.Uint64
 
 	// tableInfo is the latest table info of the dispatcher's corresponding table.
 	tableInfo *common.TableInfo
@@ -146,6 +174,36 @@ type Dispatcher struct {
 	errCh chan error
 }
 
+// SharedConfig is the config of a changefeed shared by all its dispatchers in a node,
+// it's built once by the event dispatcher manager.
+type SharedConfig struct {
+	// StartTs is the startTs of the changefeed when the event dispatcher manager is created.
+	StartTs uint64
+	// ColumnProjection is sent to the event service, so only the columns needed by the sink are decoded.
+	// It's nil if all the columns are needed.
+	ColumnProjection *eventpb.ColumnProjection
+	// ColumnTransformer transforms the values of the columns before they are written to the sink.
+	// It's nil if there is no column transform.
+	ColumnTransformer *columntransform.Transformer
+	// ThroughputLimiter limits the rows and bytes per second written to the sink by the changefeed
+	// in this node. It's nil if there is no throughput limit.
+	ThroughputLimiter *ThroughputLimiter
+	// Priority is the priority class of the changefeed.
+	Priority config.ChangefeedPriority
+	// InitialSnapshot is true if the tables replicated from the StartTs load the rows at the StartTs
+	// before the incremental events.
+	InitialSnapshot bool
+	// TargetTs is the target ts of the changefeed, it is the final resolvedTs of the dispatchers,
+	// and the events after it are never written to the sink. 0 means no target ts.
+	TargetTs uint64
+	// ReplicationDelay is the duration the events are delayed by the event service
+	// before they are sent to the dispatchers.
+	ReplicationDelay time.Duration
+	// UpstreamID is the ID of the TiDB cluster the changefeed replicates from,
+	// the event service uses it to pick the log service of the upstream.
+	UpstreamID uint64
+}
+
 func NewDispatcher(
 	changefeedID common.ChangeFeedID,
 	id common.DispatcherID,
@@ -158,6 +216,7 @@ func NewDispatcher(
 	syncPointConfig *syncpoint.SyncPointConfig,
 	startTsIsSyncpoint bool,
 	filterConfig *eventpb.FilterConfig,
+	sharedConfig *SharedConfig,
 	currentPdTs uint64,
 	errCh chan error,
 ) *Dispatcher {
@@ -173,6 +232,7 @@ func NewDispatcher(
 		componentStatus:       newComponentStateWithMutex(heartbeatpb.ComponentState_Working),
 		resolvedTs:            startTs,
 		filterConfig:          filterConfig,
+		sharedConfig:          sharedConfig,
 		isRemoving:            atomic.Bool{},
 		blockEventStatus:      BlockEventStatus{blockPendingEvent: nil},
 		tableProgress:         NewTableProgress(),
@@ -183,6 +243,16 @@ func NewDispatcher(
 		errCh:                 errCh,
 	}
 
+	projection, err := columnselector.NewProjection(sharedConfig.ColumnProjection)
+	if err != nil {
+		log.Panic("create column projection failed", zap.Error(err), zap.Any("columnProjection", sharedConfig.ColumnProjection))
+	}
+	dispatcher.projection = projection
+	// Only the tables replicated from the start of the changefeed load the initial snapshot,
+	// the tables created later are replicated from their creation.
+	dispatcher.initialSnapshot = sharedConfig.InitialSnapshot &&
+		!tableSpan.Equal(heartbeatpb.DDLSpan) && startTs == sharedConfig.StartTs
+
 	dispatcher.addToStatusDynamicStream()
 
 	return dispatcher
@@ -300,6 +370,16 @@ func (d *Dispatcher) HandleDispatcherStatus(dispatcherStatus *heartbeatpb.Dispat
 func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallback func()) (block bool) {
 	// Only return false when all events are resolvedTs Event.
 	block = false
+	// The dml events throttled by the throughput limiter, they are written to the sink after throttleDelay.
+	var (
+		throttledDMLs []*commonEvent.DMLEvent
+		throttleDelay time.Duration
+	)
+	defer func() {
+		if len(throttledDMLs) > 0 {
+			d.addThrottledDMLEventsToSink(throttledDMLs, throttleDelay)
+		}
+	}()
 	// Dispatcher is ready, handle the events
 	for _, dispatcherEvent := range dispatcherEvents {
 		log.Debug("dispatcher receive all event",
@@ -323,9 +403,24 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			continue
 		}
 
+		// The changefeed stops at the target ts, so the events after it are ignored.
+		if targetTs := d.sharedConfig.TargetTs; targetTs != 0 && event.GetCommitTs() > targetTs && event.GetType() != commonEvent.TypeResolvedEvent {
+			log.Warn("Received an event after the target ts, should ignore it",
+				zap.Uint64("targetTs", targetTs),
+				zap.Uint64("eventCommitTs", event.GetCommitTs()),
+				zap.Uint64("seq", event.GetSeq()),
+				zap.Int("eventType", event.GetType()),
+				zap.Stringer("dispatcher", d.id))
+			continue
+		}
+
 		switch event.GetType() {
 		case commonEvent.TypeResolvedEvent:
-			atomic.StoreUint64(&d.resolvedTs, event.(commonEvent.ResolvedEvent).ResolvedTs)
+			resolvedTs := event.(commonEvent.ResolvedEvent).ResolvedTs
+			if targetTs := d.sharedConfig.TargetTs; targetTs != 0 && resolvedTs > targetTs {
+				resolvedTs = targetTs
+			}
+			atomic.StoreUint64(&d.resolvedTs, resolvedTs)
 		case commonEvent.TypeDMLEvent:
 			dml := event.(*commonEvent.DMLEvent)
 			if dml.Len() == 0 {
@@ -333,7 +428,23 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			}
 			block = true
 			dml.ReplicatingTs = d.creationPDTs
-			dml.AssembleRows(d.tableInfo)
+			dml.TableSpan = d.tableSpan
+			// Only the rows of the initial snapshot are at startTs.
+			if d.initialSnapshot && dml.CommitTs == d.startTs {
+				d.snapshotRows.Add(uint64(dml.Len()))
+			}
+			dml.AssembleRows(d.projection.ProjectTableInfo(d.tableInfo))
+			if err := d.sharedConfig.ColumnTransformer.Transform(dml); err != nil {
+				select {
+				case d.errCh <- err:
+				default:
+					log.Error("error channel is full, discard error",
+						zap.Stringer("changefeedID", d.changefeedID),
+						zap.Stringer("dispatcherID", d.id),
+						zap.Error(err))
+				}
+				return
+			}
 			dml.AddPostFlushFunc(func() {
 				// Considering dml event in sink may be written to downstream not in order,
 				// thus, we use tableProgress.Empty() to ensure these events are flushed to downstream completely
@@ -342,6 +453,15 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 					wakeCallback()
 				}
 			})
+			// Once a dml event is throttled, the following ones in the batch are throttled too to keep the order.
+			delay := d.sharedConfig.ThroughputLimiter.reserve(int64(dml.Len()), dml.GetSize())
+			if delay > 0 || len(throttledDMLs) > 0 {
+				// Add the event to the table progress now, so the checkpoint ts can't pass it while it's waiting.
+				d.tableProgress.Add(dml)
+				throttledDMLs = append(throttledDMLs, dml)
+				throttleDelay = max(throttleDelay, delay)
+				continue
+			}
 			err := d.AddDMLEventToSink(dml)
 			if err != nil {
 				select {
@@ -426,6 +546,42 @@ func (d *Dispatcher) AddDMLEventToSink(event *commonEvent.DMLEvent) error {
 	return d.sink.AddDMLEvent(event)
 }
 
+// addThrottledDMLEventsToSink writes the dml events throttled by the throughput limiter to the sink after the delay.
+// The dynamic stream path of the dispatcher keeps blocked until these events are flushed,
+// so the back pressure is passed to the event collector instead of buffering events in the dispatcher.
+// The events are dropped if the dispatcher is removed or closed before the delay, they are
+// kept in the table progress, so the checkpoint ts reported when closing doesn't pass them.
+func (d *Dispatcher) addThrottledDMLEventsToSink(events []*commonEvent.DMLEvent, delay time.Duration) {
+	d.throttleMu.Lock()
+	d.throttledEvents += len(events)
+	d.throttleMu.Unlock()
+	time.AfterFunc(delay, func() {
+		d.throttleMu.Lock()
+		defer d.throttleMu.Unlock()
+		if d.throttleClosed || d.isRemoving.Load() {
+			log.Info("dispatcher is removed, drop the throttled dml events",
+				zap.Stringer("changefeedID", d.changefeedID),
+				zap.Stringer("dispatcherID", d.id),
+				zap.Int("eventCount", len(events)))
+			return
+		}
+		d.throttledEvents -= len(events)
+		for _, event := range events {
+			if err := d.sink.AddDMLEvent(event); err != nil {
+				select {
+				case d.errCh <- err:
+				default:
+					log.Error("error channel is full, discard error",
+						zap.Stringer("changefeedID", d.changefeedID),
+						zap.Stringer("dispatcherID", d.id),
+						zap.Error(err))
+				}
+				return
+			}
+		}
+	})
+}
+
 func (d *Dispatcher) AddBlockEventToSink(event commonEvent.BlockEvent) error {
 	d.tableProgress.Add(event)
 	return d.sink.WriteBlockEvent(event)
@@ -649,6 +805,14 @@ func (d *Dispatcher) GetFilterConfig() *eventpb.FilterConfig {
 	return d.filterConfig
 }
 
+func (d *Dispatcher) GetColumnProjection() *eventpb.ColumnProjection {
+	return d.sharedConfig.ColumnProjection
+}
+
+func (d *Dispatcher) GetPriority() config.ChangefeedPriority {
+	return d.sharedConfig.Priority
+}
+
 func (d *Dispatcher) GetSyncPointInterval() time.Duration {
 	if d.syncPointConfig != nil {
 		return d.syncPointConfig.SyncPointInterval
@@ -656,6 +820,35 @@ func (d *Dispatcher) GetSyncPointInterval() time.Duration {
 	return time.Duration(0)
 }
 
+func (d *Dispatcher) NeedInitialSnapshot() bool {
+	return d.initialSnapshot
+}
+
+func (d *Dispatcher) GetTargetTs() uint64 {
+	return d.sharedConfig.TargetTs
+}
+
+func (d *Dispatcher) GetReplicationDelay() time.Duration {
+	return d.sharedConfig.ReplicationDelay
+}
+
+func (d *Dispatcher) GetUpstreamID() uint64 {
+	return d.sharedConfig.UpstreamID
+}
+
+// GetSnapshotProgress returns the state of the initial snapshot and the number of the snapshot rows received.
+// The snapshot is finished after all its rows are flushed to the downstream,
+// that is, the checkpointTs of the dispatcher exceeds the startTs.
+func (d *Dispatcher) GetSnapshotProgress() (heartbeatpb.InitialSnapshotState, uint64) {
+	if !d.initialSnapshot {
+		return heartbeatpb.InitialSnapshotState_SnapshotNone, 0
+	}
+	if d.GetCheckpointTs() > d.startTs {
+		return heartbeatpb.InitialSnapshotState_SnapshotFinished, d.snapshotRows.Load()
+	}
+	return heartbeatpb.InitialSnapshotState_SnapshotLoading, d.snapshotRows.Load()
+}
+
 func (d *Dispatcher) GetStartTsIsSyncpoint() bool {
 	return d.startTsIsSyncpoint
 }
@@ -696,9 +889,14 @@ func (d *Dispatcher) addToStatusDynamicStream() {
 }
 
 func (d *Dispatcher) TryClose() (w heartbeatpb.Watermark, ok bool) {
+	d.throttleMu.Lock()
+	defer d.throttleMu.Unlock()
 	// If sink is normal(not meet error), we need to wait all the events in sink to flushed downstream successfully.
 	// If sink is not normal, we can close the dispatcher immediately.
-	if !d.sink.IsNormal() || d.tableProgress.Empty() {
+	// The events held by the throughput limiter are not waited, they are dropped after
+	// the dispatcher is closed, and the checkpoint ts doesn't pass them.
+	if !d.sink.IsNormal() || d.tableProgress.Len() == d.throttledEvents {
+		d.throttleClosed = true
 		w.CheckpointTs = d.GetCheckpointTs()
 		w.ResolvedTs = d.GetResolvedTs()
 
@@ -15,6 +15,8 @@ package dispatcher
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,12 +34,15 @@ import (
 // TODO: Merge this file into dispatcher_test.go after refactoring the dispatcher test.
 
 type mockSink struct {
+	mu       sync.Mutex
 	dmls     []*commonEvent.DMLEvent
 	isNormal bool
 	sinkType common.SinkType
 }
 
 func (s *mockSink) AddDMLEvent(event *commonEvent.DMLEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.dmls = append(s.dmls, event)
 	return nil
 }
@@ -71,7 +76,15 @@ func (s *mockSink) IsNormal() bool {
 	return s.isNormal
 }
 
+func (s *mockSink) dmlCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.dmls)
+}
+
 func (s *mockSink) flushDMLs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for _, dml := range s.dmls {
 		dml.PostFlush()
 	}
@@ -107,6 +120,12 @@ func getUncompleteTableSpan() *heartbeatpb.TableSpan {
 }
 
 func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dispatcher {
+	return newDispatcherWithSharedConfigForTest(sink, tableSpan, &SharedConfig{})
+}
+
+func newDispatcherWithSharedConfigForTest(
+	sink sink.Sink, tableSpan *heartbeatpb.TableSpan, sharedConfig *SharedConfig,
+) *Dispatcher {
 	return NewDispatcher(
 		common.NewChangefeedID(),
 		common.NewDispatcherID(),
@@ -121,7 +140,8 @@ func newDispatcherForTest(sink sink.Sink, tableSpan *heartbeatpb.TableSpan) *Dis
 			SyncPointRetention: time.Duration(10 * time.Minute),
 		}, // syncPointConfig
 		false,
-		nil,          // filterConfig
+		nil, // filterConfig
+		sharedConfig,
 		common.Ts(0), // pdTs
 		make(chan error, 1),
 	)
@@ -133,6 +153,160 @@ func callback() {
 	count++
 }
 
+func TestDispatcherInitialSnapshotBySharedConfig(t *testing.T) {
+	sink := newMockSink(common.MysqlSinkType)
+	sharedConfig := &SharedConfig{InitialSnapshot: true}
+	// The table replicated from the startTs of the changefeed loads the initial snapshot.
+	require.True(t, newDispatcherWithSharedConfigForTest(sink, getCompleteTableSpan(), sharedConfig).NeedInitialSnapshot())
+	// The table trigger event dispatcher never loads the initial snapshot.
+	require.False(t, newDispatcherWithSharedConfigForTest(sink, heartbeatpb.DDLSpan, sharedConfig).NeedInitialSnapshot())
+	// The table created after the start of the changefeed doesn't load the initial snapshot.
+	sharedConfig.StartTs = 5
+	require.False(t, newDispatcherWithSharedConfigForTest(sink, getCompleteTableSpan(), sharedConfig).NeedInitialSnapshot())
+	require.False(t, newDispatcherForTest(sink, getCompleteTableSpan()).NeedInitialSnapshot())
+}
+
+func TestDispatcherInitialSnapshotProgress(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	state, rows := dispatcher.GetSnapshotProgress()
+	require.Equal(t, heartbeatpb.InitialSnapshotState_SnapshotNone, state)
+	require.Equal(t, uint64(0), rows)
+
+	// The snapshot rows are at the startTs of the dispatcher.
+	startTs := uint64(5)
+	dispatcher.initialSnapshot = true
+	dispatcher.startTs = startTs
+	dispatcher.resolvedTs = startTs
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values(1, 1)", "insert into t values(2, 2)")
+	require.NotNil(t, dmlEvent)
+	dmlEvent.CommitTs = startTs
+	dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+
+	nodeID := node.NewID()
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, dmlEvent)}, callback)
+	state, rows = dispatcher.GetSnapshotProgress()
+	require.Equal(t, heartbeatpb.InitialSnapshotState_SnapshotLoading, state)
+	require.Equal(t, uint64(2), rows)
+
+	// The snapshot is finished after the rows are flushed and the resolvedTs exceeds the startTs.
+	sink.flushDMLs()
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: startTs + 1})}, callback)
+	state, rows = dispatcher.GetSnapshotProgress()
+	require.Equal(t, heartbeatpb.InitialSnapshotState_SnapshotFinished, state)
+	require.Equal(t, uint64(2), rows)
+}
+
+func TestDispatcherStopAtTargetTs(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.sharedConfig.TargetTs = 10
+	nodeID := node.NewID()
+
+	// The dml event after the target ts is not written to the sink.
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values(1, 1)")
+	require.NotNil(t, dmlEvent)
+	dmlEvent.CommitTs = 11
+	dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, dmlEvent)}, callback)
+	require.False(t, block)
+	require.Equal(t, 0, len(sink.dmls))
+
+	// The target ts is the final resolvedTs of the dispatcher.
+	dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, commonEvent.ResolvedEvent{ResolvedTs: 20})}, callback)
+	require.Equal(t, uint64(10), dispatcher.GetResolvedTs())
+	require.Equal(t, uint64(10), dispatcher.GetCheckpointTs())
+}
+
+func TestDispatcherThroughputLimit(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.sharedConfig.ThroughputLimiter = NewThroughputLimiter(dispatcher.changefeedID, 1, 0)
+	nodeID := node.NewID()
+
+	events := make([]DispatcherEvent, 0, 3)
+	for i := 1; i <= 3; i++ {
+		dmlEvent := helper.DML2Event("test", "t", fmt.Sprintf("insert into t values(%d, %d)", i, i))
+		require.NotNil(t, dmlEvent)
+		dmlEvent.CommitTs = uint64(10 + i)
+		dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+		events = append(events, NewDispatcherEvent(&nodeID, dmlEvent))
+	}
+	// Only the first event is in the burst, the others are throttled.
+	block := dispatcher.HandleEvents(events, callback)
+	require.True(t, block)
+	require.Equal(t, 1, sink.dmlCount())
+	// The throttled events still block the checkpoint ts.
+	sink.flushDMLs()
+	require.Equal(t, uint64(11), dispatcher.GetCheckpointTs())
+
+	require.Eventually(t, func() bool {
+		return sink.dmlCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	sink.flushDMLs()
+	require.True(t, dispatcher.tableProgress.Empty())
+}
+
+func TestDispatcherCloseWithThrottledEvents(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlJob := helper.DDL2Job("create table t(id int primary key, v int)")
+	require.NotNil(t, ddlJob)
+
+	sink := newMockSink(common.MysqlSinkType)
+	dispatcher := newDispatcherForTest(sink, getCompleteTableSpan())
+	dispatcher.sharedConfig.ThroughputLimiter = NewThroughputLimiter(dispatcher.changefeedID, 1, 0)
+	nodeID := node.NewID()
+
+	events := make([]DispatcherEvent, 0, 3)
+	for i := 1; i <= 3; i++ {
+		dmlEvent := helper.DML2Event("test", "t", fmt.Sprintf("insert into t values(%d, %d)", i, i))
+		require.NotNil(t, dmlEvent)
+		dmlEvent.CommitTs = uint64(10 + i)
+		dispatcher.SetInitialTableInfo(dmlEvent.TableInfo)
+		events = append(events, NewDispatcherEvent(&nodeID, dmlEvent))
+	}
+	dispatcher.HandleEvents(events, callback)
+	require.Equal(t, 1, sink.dmlCount())
+
+	// The event written to the sink must be flushed before closing.
+	_, ok := dispatcher.TryClose()
+	require.False(t, ok)
+	sink.flushDMLs()
+
+	// The throttled events are not waited, and the checkpoint ts doesn't pass them.
+	watermark, ok := dispatcher.TryClose()
+	require.True(t, ok)
+	require.Equal(t, uint64(11), watermark.CheckpointTs)
+
+	// The throttled events are never written to the sink after the dispatcher is closed.
+	time.Sleep(3 * time.Second)
+	require.Equal(t, 0, sink.dmlCount())
+}
+
 // test different events can be correctly handled by the dispatcher
 func TestDispatcherHandleEvents(t *testing.T) {
 	count = 0
@@ -94,6 +94,13 @@ func (p *TableProgress) Empty() bool {
 	return p.list.Len() == 0
 }
 
+// Len returns the number of the events in the TableProgress.
+func (p *TableProgress) Len() int {
+	p.rwMutex.RLock()
+	defer p.rwMutex.RUnlock()
+	return p.list.Len()
+}
+
 // Pass updates the maxCommitTs with the given event's commit timestamp.
 func (p *TableProgress) Pass(event commonEvent.BlockEvent) {
 	p.rwMutex.Lock()
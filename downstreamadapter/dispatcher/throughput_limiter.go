@@ -0,0 +1,107 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"math"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// ThroughputLimiter limits the rows and bytes per second written to the sink by a changefeed in a node.
+// It's shared by all the dispatchers of the changefeed in the node. The maintainer splits the limits of
+// the changefeed across the nodes, and the limiter is updated to the share of this node by SetLimits.
+// A nil ThroughputLimiter means no limit.
+type ThroughputLimiter struct {
+	rows  *rate.Limiter
+	bytes *rate.Limiter
+
+	throttledDuration prometheus.Counter
+}
+
+// NewThroughputLimiter creates a ThroughputLimiter, 0 means no limit for the rows or bytes.
+// It returns nil if neither of the limits is set.
+func NewThroughputLimiter(changefeedID common.ChangeFeedID, maxRowsPerSecond, maxBytesPerSecond uint64) *ThroughputLimiter {
+	if maxRowsPerSecond == 0 && maxBytesPerSecond == 0 {
+		return nil
+	}
+	return &ThroughputLimiter{
+		rows:  newLimiter(maxRowsPerSecond),
+		bytes: newLimiter(maxBytesPerSecond),
+		throttledDuration: metrics.DispatcherThrottledDuration.WithLabelValues(
+			changefeedID.Namespace(), changefeedID.Name()),
+	}
+}
+
+func newLimiter(limit uint64) *rate.Limiter {
+	if limit == 0 {
+		return nil
+	}
+	burst := int(min(limit, math.MaxInt32))
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// SetLimits updates the limits to the share of the changefeed limits assigned to this node.
+// A limit which is not set when the limiter is created stays unlimited, and 0 keeps the current limit.
+func (l *ThroughputLimiter) SetLimits(maxRowsPerSecond, maxBytesPerSecond uint64) {
+	if l == nil {
+		return
+	}
+	setLimit(l.rows, maxRowsPerSecond)
+	setLimit(l.bytes, maxBytesPerSecond)
+}
+
+func setLimit(limiter *rate.Limiter, limit uint64) {
+	if limiter == nil || limit == 0 || limiter.Limit() == rate.Limit(limit) {
+		return
+	}
+	now := time.Now()
+	limiter.SetLimitAt(now, rate.Limit(limit))
+	limiter.SetBurstAt(now, int(min(limit, math.MaxInt32)))
+}
+
+// reserve takes the rows and bytes of a transaction from the limiter,
+// and returns how long the caller must wait before writing the transaction to the sink.
+func (l *ThroughputLimiter) reserve(rows, bytes int64) time.Duration {
+	if l == nil {
+		return 0
+	}
+	now := time.Now()
+	delay := max(reserveN(l.rows, now, rows), reserveN(l.bytes, now, bytes))
+	if delay > 0 {
+		l.throttledDuration.Add(delay.Seconds())
+	}
+	return delay
+}
+
+// reserveN reserves n tokens from the limiter at now.
+// A transaction can't be split, so a transaction larger than the burst is reserved
+// in several parts, and the delay of the last part is the delay of the transaction.
+func reserveN(limiter *rate.Limiter, now time.Time, n int64) time.Duration {
+	if limiter == nil {
+		return 0
+	}
+	var delay time.Duration
+	burst := int64(limiter.Burst())
+	for n > 0 {
+		part := min(n, burst)
+		delay = limiter.ReserveN(now, int(part)).DelayFrom(now)
+		n -= part
+	}
+	return delay
+}
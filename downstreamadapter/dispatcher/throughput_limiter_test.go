@@ -0,0 +1,65 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThroughputLimiter(t *testing.T) {
+	var limiter *ThroughputLimiter
+	require.Equal(t, time.Duration(0), limiter.reserve(100, 100))
+
+	changefeedID := common.NewChangefeedID()
+	require.Nil(t, NewThroughputLimiter(changefeedID, 0, 0))
+
+	// The burst is the limit per second, so the first second is free.
+	limiter = NewThroughputLimiter(changefeedID, 0, 100)
+	require.Equal(t, time.Duration(0), limiter.reserve(1000, 100))
+	// A transaction larger than the burst waits for all its bytes.
+	delay := limiter.reserve(1, 300)
+	require.Greater(t, delay, 2900*time.Millisecond)
+	require.LessOrEqual(t, delay, 3*time.Second)
+
+	// The delay is the longer one of the rows and bytes.
+	limiter = NewThroughputLimiter(changefeedID, 10, 1000)
+	require.Equal(t, time.Duration(0), limiter.reserve(10, 10))
+	delay = limiter.reserve(10, 10)
+	require.Greater(t, delay, 900*time.Millisecond)
+	require.LessOrEqual(t, delay, time.Second)
+}
+
+func TestThroughputLimiterSetLimits(t *testing.T) {
+	var limiter *ThroughputLimiter
+	limiter.SetLimits(10, 10)
+
+	changefeedID := common.NewChangefeedID()
+	limiter = NewThroughputLimiter(changefeedID, 100, 0)
+	// The bytes is not limited, it stays unlimited.
+	limiter.SetLimits(50, 10)
+	require.Equal(t, 50, limiter.rows.Burst())
+	require.Nil(t, limiter.bytes)
+	require.Equal(t, time.Duration(0), limiter.reserve(50, 1000))
+	delay := limiter.reserve(50, 1000)
+	require.Greater(t, delay, 900*time.Millisecond)
+	require.LessOrEqual(t, delay, time.Second)
+
+	// 0 keeps the current limit.
+	limiter.SetLimits(0, 0)
+	require.Equal(t, 50, limiter.rows.Burst())
+}
@@ -28,8 +28,11 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	"github.com/pingcap/ticdc/pkg/common/columntransform"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
@@ -70,6 +73,11 @@ type EventDispatcherManager struct {
 
 	config       *config.ChangefeedConfig
 	filterConfig *eventpb.FilterConfig
+	// sharedConfig is the config of the changefeed shared by all the dispatchers,
+	// it's built once when the event dispatcher manager is created.
+	// The throughput limiter in it is updated to the share of this node by the
+	// ThroughputQuota sent from the maintainer.
+	sharedConfig *dispatcher.SharedConfig
 	// only not nil when enable sync point
 	// TODO: changefeed update config
 	syncPointConfig *syncpoint.SyncPointConfig
@@ -134,8 +142,17 @@ func NewEventDispatcherManager(
 ) (*EventDispatcherManager, uint64, error) {
 	failpoint.Inject("NewEventDispatcherManagerDelay", nil)
 
-	ctx, cancel := context.WithCancel(context.Background())
 	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
+	if cfConfig.UpstreamID != 0 {
+		logServices := appcontext.GetService[*upstreamlog.Manager](appcontext.UpstreamLogManager)
+		logService, ok := logServices.Get(cfConfig.UpstreamID)
+		if !ok {
+			return nil, 0, errors.ErrUpstreamNotFound.GenWithStackByArgs(cfConfig.UpstreamID)
+		}
+		pdClock = logService.PDClock
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 
 	filterCfg := &eventpb.FilterConfig{
 		CaseSensitive:  cfConfig.CaseSensitive,
@@ -182,10 +199,35 @@ func NewEventDispatcherManager(
 	}
 
 	var err error
-	manager.sink, err = sink.NewSink(ctx, manager.config, manager.changefeedID)
+	manager.sink, err = sink.NewSink(ctx, manager.config, manager.changefeedID, manager.pdClock)
 	if err != nil {
 		return nil, 0, errors.Trace(err)
 	}
+	manager.sharedConfig = &dispatcher.SharedConfig{
+		StartTs:          cfConfig.StartTS,
+		Priority:         cfConfig.Priority,
+		InitialSnapshot:  cfConfig.InitialSnapshot,
+		TargetTs:         cfConfig.TargetTS,
+		ReplicationDelay: cfConfig.ReplicationDelay,
+		UpstreamID:       cfConfig.UpstreamID,
+	}
+	// The column selectors and delete-only-output-handle-key-columns are only available when the sink is MQ or webhook,
+	// push them down to the event service to avoid decoding and transferring the unused columns.
+	if sinkType := manager.sink.SinkType(); sinkType == common.KafkaSinkType || sinkType == common.PulsarSinkType ||
+		sinkType == common.WebhookSinkType {
+		manager.sharedConfig.ColumnProjection = columnselector.ToColumnProjectionPB(cfConfig.SinkConfig)
+		if _, err = columnselector.NewProjection(manager.sharedConfig.ColumnProjection); err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+	}
+
+	manager.sharedConfig.ColumnTransformer, err = columntransform.NewTransformer(cfConfig.SinkConfig, cfConfig.TimeZone)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+
+	manager.sharedConfig.ThroughputLimiter = dispatcher.NewThroughputLimiter(
+		changefeedID, cfConfig.MaxRowsPerSecond, cfConfig.MaxBytesPerSecond)
 
 	// Register Event Dispatcher Manager in HeartBeatCollector,
 	// which is responsible for communication with the maintainer.
@@ -286,6 +328,7 @@ func (e *EventDispatcherManager) cleanMetrics() {
 	metrics.EventDispatcherManagerResolvedTsGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerCheckpointTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerResolvedTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	metrics.DispatcherThrottledDuration.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 }
 
 func (e *EventDispatcherManager) close(removeChangefeed bool) {
@@ -328,6 +371,7 @@ func (e *EventDispatcherManager) close(removeChangefeed bool) {
 	metrics.EventDispatcherManagerResolvedTsGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerCheckpointTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerResolvedTsLagGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	metrics.DispatcherThrottledDuration.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 
 	e.closed.Store(true)
 	log.Info("event dispatcher manager closed",
@@ -499,6 +543,7 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo, re
 			e.syncPointConfig,
 			startTsIsSyncpointList[idx],
 			e.filterConfig,
+			e.sharedConfig,
 			pdTsList[idx],
 			e.errCh)
 
@@ -730,11 +775,14 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 
 		message.Watermark.UpdateMin(heartBeatInfo.Watermark)
 		if needCompleteStatus {
+			snapshotState, snapshotRows := dispatcherItem.GetSnapshotProgress()
 			message.Statuses = append(message.Statuses, &heartbeatpb.TableSpanStatus{
 				ID:                 id.ToPB(),
 				ComponentStatus:    heartBeatInfo.ComponentStatus,
 				CheckpointTs:       heartBeatInfo.Watermark.CheckpointTs,
 				EventSizePerSecond: dispatcherItem.GetEventSizePerSecond(),
+				SnapshotState:      snapshotState,
+				SnapshotRows:       snapshotRows,
 			})
 		}
 	})
@@ -828,6 +876,12 @@ func (e *EventDispatcherManager) GetTableTriggerEventDispatcher() *dispatcher.Di
 	return e.tableTriggerEventDispatcher
 }
 
+// SetThroughputQuota updates the throughput limiter to the share of the changefeed limits
+// assigned to this node by the maintainer.
+func (e *EventDispatcherManager) SetThroughputQuota(maxRowsPerSecond, maxBytesPerSecond uint64) {
+	e.sharedConfig.ThroughputLimiter.SetLimits(maxRowsPerSecond, maxBytesPerSecond)
+}
+
 func (e *EventDispatcherManager) SetHeartbeatRequestQueue(heartbeatRequestQueue *HeartbeatRequestQueue) {
 	e.heartbeatRequestQueue = heartbeatRequestQueue
 }
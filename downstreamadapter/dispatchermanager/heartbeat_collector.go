@@ -40,6 +40,7 @@ Receiving messages include:
  1. HeartBeatResponse: the ack and actions for block events(Need a better name)
  2. SchedulerDispatcherRequest: ask for create or remove a dispatcher
  3. CheckpointTsMessage: the latest checkpoint ts of the changefeed, it only for the MQ-class Sink
+ 4. ThroughputQuota: the share of the changefeed throughput limits assigned to this node
 
 HeartBeatCollector is an server level component.
 */
@@ -53,6 +54,7 @@ type HeartBeatCollector struct {
 	heartBeatResponseDynamicStream          dynstream.DynamicStream[int, common.GID, HeartBeatResponse, *EventDispatcherManager, *HeartBeatResponseHandler]
 	schedulerDispatcherRequestDynamicStream dynstream.DynamicStream[int, common.GID, SchedulerDispatcherRequest, *EventDispatcherManager, *SchedulerDispatcherRequestHandler]
 	checkpointTsMessageDynamicStream        dynstream.DynamicStream[int, common.GID, CheckpointTsMessage, *EventDispatcherManager, *CheckpointTsMessageHandler]
+	throughputQuotaDynamicStream            dynstream.DynamicStream[int, common.GID, ThroughputQuota, *EventDispatcherManager, *ThroughputQuotaHandler]
 
 	mc messaging.MessageCenter
 
@@ -70,6 +72,7 @@ func NewHeartBeatCollector(serverId node.ID) *HeartBeatCollector {
 		heartBeatResponseDynamicStream:          newHeartBeatResponseDynamicStream(dStatusDS),
 		schedulerDispatcherRequestDynamicStream: newSchedulerDispatcherRequestDynamicStream(),
 		checkpointTsMessageDynamicStream:        newCheckpointTsMessageDynamicStream(),
+		throughputQuotaDynamicStream:            newThroughputQuotaDynamicStream(),
 		mc:                                      appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter),
 	}
 	heartBeatCollector.mc.RegisterHandler(messaging.HeartbeatCollectorTopic, heartBeatCollector.RecvMessages)
@@ -112,6 +115,10 @@ func (c *HeartBeatCollector) RegisterEventDispatcherManager(m *EventDispatcherMa
 	if err != nil {
 		return errors.Trace(err)
 	}
+	err = c.throughputQuotaDynamicStream.AddPath(m.changefeedID.Id, m)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -129,6 +136,10 @@ func (c *HeartBeatCollector) RemoveEventDispatcherManager(m *EventDispatcherMana
 	if err != nil {
 		return errors.Trace(err)
 	}
+	err = c.throughputQuotaDynamicStream.RemovePath(m.changefeedID.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -205,6 +216,11 @@ func (c *HeartBeatCollector) RecvMessages(_ context.Context, msg *messaging.Targ
 		c.checkpointTsMessageDynamicStream.Push(
 			common.NewChangefeedIDFromPB(checkpointTsMessage.ChangefeedID).Id,
 			NewCheckpointTsMessage(checkpointTsMessage))
+	case messaging.TypeThroughputQuota:
+		throughputQuota := msg.Message[0].(*heartbeatpb.ThroughputQuota)
+		c.throughputQuotaDynamicStream.Push(
+			common.NewChangefeedGIDFromPB(throughputQuota.ChangefeedID),
+			NewThroughputQuota(throughputQuota))
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
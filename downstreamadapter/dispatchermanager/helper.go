@@ -106,9 +106,10 @@ func (d *DispatcherMap) ForEach(fn func(id common.DispatcherID, dispatcher *disp
 
 func toFilterConfigPB(filter *config.FilterConfig) *eventpb.InnerFilterConfig {
 	filterConfig := &eventpb.InnerFilterConfig{
-		Rules:            filter.Rules,
-		IgnoreTxnStartTs: filter.IgnoreTxnStartTs,
-		EventFilters:     make([]*eventpb.EventFilterRule, 0),
+		Rules:             filter.Rules,
+		IgnoreTxnStartTs:  filter.IgnoreTxnStartTs,
+		EventFilters:      make([]*eventpb.EventFilterRule, 0),
+		ForwardDdlClasses: filter.ForwardDDLClasses,
 	}
 
 	for _, eventFilterRule := range filter.EventFilters {
@@ -445,3 +446,48 @@ func (h *CheckpointTsMessageHandler) GetType(event CheckpointTsMessage) dynstrea
 	return dynstream.DefaultEventType
 }
 func (h *CheckpointTsMessageHandler) OnDrop(event CheckpointTsMessage) {}
+
+// throughputQuotaDynamicStream is responsible for push throughputQuota to the corresponding event dispatcher manager.
+func newThroughputQuotaDynamicStream() dynstream.DynamicStream[int, common.GID, ThroughputQuota, *EventDispatcherManager, *ThroughputQuotaHandler] {
+	ds := dynstream.NewParallelDynamicStream(
+		func(id common.GID) uint64 { return id.FastHash() },
+		&ThroughputQuotaHandler{})
+	ds.Start()
+	return ds
+}
+
+type ThroughputQuota struct {
+	*heartbeatpb.ThroughputQuota
+}
+
+func NewThroughputQuota(msg *heartbeatpb.ThroughputQuota) ThroughputQuota {
+	return ThroughputQuota{msg}
+}
+
+type ThroughputQuotaHandler struct{}
+
+func (h *ThroughputQuotaHandler) Path(throughputQuota ThroughputQuota) common.GID {
+	return common.NewChangefeedGIDFromPB(throughputQuota.ChangefeedID)
+}
+
+func (h *ThroughputQuotaHandler) Handle(eventDispatcherManager *EventDispatcherManager, messages ...ThroughputQuota) bool {
+	// only the latest quota takes effect
+	quota := messages[len(messages)-1]
+	eventDispatcherManager.SetThroughputQuota(quota.MaxRowsPerSecond, quota.MaxBytesPerSecond)
+	return false
+}
+
+func (h *ThroughputQuotaHandler) GetSize(event ThroughputQuota) int   { return 0 }
+func (h *ThroughputQuotaHandler) IsPaused(event ThroughputQuota) bool { return false }
+func (h *ThroughputQuotaHandler) GetArea(path common.GID, dest *EventDispatcherManager) int {
+	return 0
+}
+
+func (h *ThroughputQuotaHandler) GetTimestamp(event ThroughputQuota) dynstream.Timestamp {
+	return 0
+}
+
+func (h *ThroughputQuotaHandler) GetType(event ThroughputQuota) dynstream.EventType {
+	return dynstream.DefaultEventType
+}
+func (h *ThroughputQuotaHandler) OnDrop(event ThroughputQuota) {}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
 	"github.com/pingcap/ticdc/downstreamadapter/dispatchermanager"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
@@ -85,6 +86,26 @@ func (m *DispatcherOrchestrator) handleBootstrapRequest(
 	var err error
 	var startTs uint64
 	if !exists {
+		if cfConfig.UpstreamID != 0 {
+			logServices := appcontext.GetService[*upstreamlog.Manager](appcontext.UpstreamLogManager)
+			if _, ok := logServices.Prepare(cfConfig.UpstreamID, cfId.Namespace()); !ok {
+				// The log service failed, report the error to the maintainer to restart the changefeed,
+				// the log service is recreated after a backoff by a later bootstrap request.
+				if err := logServices.Err(cfConfig.UpstreamID); err != nil {
+					log.Warn("log service of the upstream failed, can not create dispatcher manager",
+						zap.String("changefeedID", cfId.Name()),
+						zap.Uint64("upstreamID", cfConfig.UpstreamID),
+						zap.Error(err))
+					return m.handleDispatcherError(from, req.ChangefeedID, err)
+				}
+				// The maintainer resends the bootstrap request until it gets the response,
+				// so just wait for the log service of the upstream to be ready.
+				log.Info("log service of the upstream is not ready, create dispatcher manager later",
+					zap.String("changefeedID", cfId.Name()),
+					zap.Uint64("upstreamID", cfConfig.UpstreamID))
+				return nil
+			}
+		}
 		manager, startTs, err = dispatchermanager.
 			NewEventDispatcherManager(
 				cfId,
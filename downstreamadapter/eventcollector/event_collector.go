@@ -15,6 +15,7 @@ package eventcollector
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -199,6 +201,8 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 	c.changefeedIDMap.Store(target.GetChangefeedID().ID(), target.GetChangefeedID())
 	metrics.EventCollectorRegisteredDispatcherCount.Inc()
 
+	// The changefeeds with higher priority get a larger share of the memory.
+	memoryQuota = target.GetPriority().ScaleMemoryQuota(memoryQuota)
 	areaSetting := dynstream.NewAreaSettingsWithMaxPendingSize(memoryQuota, dynstream.MemoryControlAlgorithmV2)
 	err := c.ds.AddPath(target.GetId(), stat, areaSetting)
 	if err != nil {
@@ -212,6 +216,10 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 		ActionType: eventpb.ActionType_ACTION_TYPE_REGISTER,
 	})
 
+	// The log coordinator only tracks the event stores of the default upstream.
+	if !c.isDefaultUpstream(target.GetUpstreamID()) {
+		return
+	}
 	c.logCoordinatorRequestChan.In() <- &logservicepb.ReusableEventServiceRequest{
 		ID:      target.GetId().ToPB(),
 		Span:    target.GetTableSpan(),
@@ -219,6 +227,13 @@ func (c *EventCollector) AddDispatcher(target dispatcher.EventDispatcher, memory
 	}
 }
 
+func (c *EventCollector) isDefaultUpstream(upstreamID uint64) bool {
+	if upstreamID == 0 {
+		return true
+	}
+	return appcontext.GetService[*upstreamlog.Manager](appcontext.UpstreamLogManager).IsDefault(upstreamID)
+}
+
 func (c *EventCollector) RemoveDispatcher(target *dispatcher.Dispatcher) {
 	log.Info("remove dispatcher", zap.Stringer("dispatcher", target.GetId()))
 	defer func() {
@@ -337,6 +352,8 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 			TableSpan: req.Dispatcher.GetTableSpan(),
 			StartTs:   req.StartTs,
 			OnlyReuse: req.OnlyUse,
+			// UpstreamId is used by the event service to route all kinds of requests.
+			UpstreamId: req.Dispatcher.GetUpstreamID(),
 		},
 	}
 
@@ -344,6 +361,11 @@ func (c *EventCollector) mustSendDispatcherRequest(target node.ID, topic string,
 	if req.ActionType == eventpb.ActionType_ACTION_TYPE_REGISTER ||
 		req.ActionType == eventpb.ActionType_ACTION_TYPE_RESET {
 		message.RegisterDispatcherRequest.FilterConfig = req.Dispatcher.GetFilterConfig()
+		message.RegisterDispatcherRequest.ColumnProjection = req.Dispatcher.GetColumnProjection()
+		message.RegisterDispatcherRequest.Priority = string(req.Dispatcher.GetPriority())
+		message.RegisterDispatcherRequest.InitialSnapshot = req.Dispatcher.NeedInitialSnapshot()
+		message.RegisterDispatcherRequest.TargetTs = req.Dispatcher.GetTargetTs()
+		message.RegisterDispatcherRequest.ReplicationDelay = uint64(req.Dispatcher.GetReplicationDelay().Milliseconds())
 		message.RegisterDispatcherRequest.EnableSyncPoint = req.Dispatcher.EnableSyncPoint()
 		message.RegisterDispatcherRequest.SyncPointInterval = uint64(req.Dispatcher.GetSyncPointInterval().Seconds())
 		message.RegisterDispatcherRequest.SyncPointTs = syncpoint.CalculateStartSyncPointTs(req.StartTs, req.Dispatcher.GetSyncPointInterval(), req.Dispatcher.GetStartTsIsSyncpoint())
@@ -453,6 +475,12 @@ func (c *EventCollector) updateMetrics(ctx context.Context) {
 			dsMetrics := c.ds.GetMetrics()
 			metricsDSInputChanLen.Set(float64(dsMetrics.EventChanSize))
 			metricsDSPendingQueueLen.Set(float64(dsMetrics.PendingQueueLen))
+			metrics.DynamicStreamArrangeStreamNum.WithLabelValues("event-collector", "move_path").Set(float64(dsMetrics.MovePath))
+			for _, streamMetric := range dsMetrics.Streams {
+				stream := strconv.Itoa(streamMetric.ID)
+				metrics.DynamicStreamStreamPendingQueueLen.WithLabelValues("event-collector", stream).Set(float64(streamMetric.PendingQueueLen))
+				metrics.DynamicStreamStreamPendingSize.WithLabelValues("event-collector", stream).Set(float64(streamMetric.PendingSize))
+			}
 			for _, areaMetric := range dsMetrics.MemoryControl.AreaMemoryMetrics {
 				cfID, ok := c.changefeedIDMap.Load(areaMetric.Area())
 				if !ok {
@@ -26,6 +26,9 @@ func NewEventDynamicStream(collector *EventCollector) dynstream.DynamicStream[co
 	option := dynstream.NewOption()
 	option.BatchCount = 4196
 	option.UseBuffer = true
+	// Balance the dispatchers between the streams, so a few hot dispatchers
+	// hashed to the same stream don't make it the bottleneck.
+	option.SchedulerInterval = dynstream.DefaultSchedulerInterval
 	// Enable memory control for dispatcher events dynamic stream.
 	option.EnableMemoryControl = true
 	if option.EnableMemoryControl {
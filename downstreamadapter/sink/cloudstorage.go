@@ -29,6 +29,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	putil "github.com/pingcap/ticdc/pkg/util"
@@ -81,7 +82,8 @@ func verifyCloudStorageSink(ctx context.Context, changefeedID common.ChangeFeedI
 }
 
 func newCloudStorageSink(
-	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+	ctx context.Context, changefeedID common.ChangeFeedID, pdClock pdutil.Clock,
+	sinkURI *url.URL, sinkConfig *config.SinkConfig,
 	cleanupJobs []func(), /* only for test */
 ) (*CloudStorageSink, error) {
 	// create cloud storage config and then apply the params of sinkURI to it.
@@ -116,7 +118,7 @@ func newCloudStorageSink(
 		statistics:           metrics.NewStatistics(changefeedID, "CloudStorageSink"),
 	}
 
-	s.dmlWorker, err = worker.NewCloudStorageDMLWorker(changefeedID, storage, cfg, encoderConfig, ext, s.statistics)
+	s.dmlWorker, err = worker.NewCloudStorageDMLWorker(changefeedID, storage, cfg, encoderConfig, ext, pdClock, s.statistics)
 	if err != nil {
 		return nil, err
 	}
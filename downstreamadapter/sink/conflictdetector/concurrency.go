@@ -0,0 +1,165 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflictdetector
+
+import (
+	"math/bits"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	// highConflictRatio is the conflict ratio above which the txns are
+	// mostly executed sequentially, so more workers can't help.
+	highConflictRatio = 0.5
+	// latencyTolerance is the ratio of latency increase that is tolerated
+	// when scaling up the workers.
+	latencyTolerance = 1.5
+	// latencyDegradation is the ratio of latency increase that makes the
+	// workers scale down, it usually means the downstream is overloaded.
+	latencyDegradation = 2.0
+
+	// slotsPerCache is the number of slots for each active cache.
+	slotsPerCache = 1024
+	minSlotCount  = 1024
+	maxSlotCount  = 1024 * 1024
+)
+
+// concurrencyStats is the statistics of the txns in the conflict detector,
+// it's reset each time the concurrency is adjusted.
+type concurrencyStats struct {
+	added      atomic.Int64
+	conflicted atomic.Int64
+	flushed    atomic.Int64
+	latency    atomic.Int64
+}
+
+func (s *concurrencyStats) observeAdd(conflicted bool) {
+	s.added.Inc()
+	if conflicted {
+		s.conflicted.Inc()
+	}
+}
+
+func (s *concurrencyStats) observeFlush(latency time.Duration) {
+	s.flushed.Inc()
+	s.latency.Add(int64(latency))
+}
+
+// ConcurrencySnapshot is the statistics of the conflict detector in a period.
+type ConcurrencySnapshot struct {
+	// Added is the number of txns added to the conflict detector.
+	Added int64
+	// ConflictRatio is the ratio of txns that conflict with unfinished txns.
+	ConflictRatio float64
+	// QueueDepth is the average number of txns waiting in the active caches.
+	QueueDepth float64
+	// AvgLatency is the average duration from a txn being added to being flushed.
+	AvgLatency time.Duration
+}
+
+func (d *ConflictDetector) takeSnapshot() ConcurrencySnapshot {
+	added := d.stats.added.Swap(0)
+	conflicted := d.stats.conflicted.Swap(0)
+	flushed := d.stats.flushed.Swap(0)
+	latency := d.stats.latency.Swap(0)
+
+	snapshot := ConcurrencySnapshot{
+		Added:      added,
+		QueueDepth: d.queueDepth(),
+	}
+	if added > 0 {
+		snapshot.ConflictRatio = float64(conflicted) / float64(added)
+	}
+	if flushed > 0 {
+		snapshot.AvgLatency = time.Duration(latency / flushed)
+	}
+	return snapshot
+}
+
+// ConcurrencyController adjusts the active cache count and the slot count of a
+// ConflictDetector based on the observed conflict ratio, queue depth and latency.
+// Each active cache is consumed by a worker, so the active cache count is the
+// number of workers that are actually writing to the downstream.
+type ConcurrencyController struct {
+	detector *ConflictDetector
+	minCount int
+	maxCount int
+
+	lastLatency time.Duration
+}
+
+// NewConcurrencyController creates a new ConcurrencyController, the active
+// cache count is limited to [minCount, maxCount].
+func NewConcurrencyController(detector *ConflictDetector, minCount, maxCount int) *ConcurrencyController {
+	maxCount = min(maxCount, len(detector.resolvedTxnCaches))
+	minCount = max(1, min(minCount, maxCount))
+	return &ConcurrencyController{
+		detector: detector,
+		minCount: minCount,
+		maxCount: maxCount,
+	}
+}
+
+// Adjust collects the statistics since the last call and adjusts the concurrency.
+// It returns the active cache count and the slot count after adjusting.
+func (c *ConcurrencyController) Adjust() (int, uint64) {
+	snapshot := c.detector.takeSnapshot()
+	count := c.nextActiveCount(c.detector.ActiveCacheCount(), snapshot)
+	c.detector.SetActiveCacheCount(count)
+	if snapshot.AvgLatency > 0 {
+		c.lastLatency = snapshot.AvgLatency
+	}
+
+	// The slots can only be resized when there is no unfinished txn,
+	// so just try it and keep the current slots if it fails.
+	c.detector.TryResizeSlots(slotCountFor(count))
+	return c.detector.ActiveCacheCount(), c.detector.SlotCount()
+}
+
+func (c *ConcurrencyController) nextActiveCount(current int, snapshot ConcurrencySnapshot) int {
+	if snapshot.Added == 0 {
+		return current
+	}
+	latencyIncreased := func(ratio float64) bool {
+		return c.lastLatency > 0 && float64(snapshot.AvgLatency) > float64(c.lastLatency)*ratio
+	}
+
+	next := current
+	switch {
+	case snapshot.ConflictRatio >= highConflictRatio:
+		// Hot rows, most txns wait for the conflicting ones, so the
+		// parallelism can't be used.
+		next = current / 2
+	case latencyIncreased(latencyDegradation):
+		// The downstream is overloaded.
+		next = current - max(1, current/4)
+	case snapshot.QueueDepth >= float64(c.detector.cacheSize)/4 && !latencyIncreased(latencyTolerance):
+		// The workers can't keep up with the incoming txns and the
+		// downstream still has capacity.
+		next = current + max(1, current/2)
+	}
+	return max(c.minCount, min(next, c.maxCount))
+}
+
+// slotCountFor returns the slot count for the active cache count, it's a power of 2.
+func slotCountFor(activeCount int) uint64 {
+	count := uint64(activeCount) * slotsPerCache
+	count = max(minSlotCount, min(count, maxSlotCount))
+	if count&(count-1) != 0 {
+		count = 1 << bits.Len64(count)
+	}
+	return count
+}
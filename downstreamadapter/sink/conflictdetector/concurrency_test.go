@@ -0,0 +1,118 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflictdetector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConflictDetector(count, activeCount int) *ConflictDetector {
+	return NewConflictDetector(1024, TxnCacheOption{
+		Count:         count,
+		ActiveCount:   activeCount,
+		Size:          64,
+		BlockStrategy: BlockStrategyWaitEmpty,
+	}, nil)
+}
+
+func TestConcurrencyControllerNextActiveCount(t *testing.T) {
+	t.Parallel()
+
+	detector := newTestConflictDetector(32, 8)
+	defer detector.Close()
+	require.Equal(t, 8, detector.ActiveCacheCount())
+	controller := NewConcurrencyController(detector, 1, 32)
+
+	// no txn, keep the current count
+	require.Equal(t, 8, controller.nextActiveCount(8, ConcurrencySnapshot{}))
+	// hot rows, scale down
+	require.Equal(t, 4, controller.nextActiveCount(8, ConcurrencySnapshot{
+		Added: 100, ConflictRatio: 0.9, QueueDepth: 64,
+	}))
+	require.Equal(t, 1, controller.nextActiveCount(1, ConcurrencySnapshot{
+		Added: 100, ConflictRatio: 0.9,
+	}))
+	// backlog without conflicts, scale up
+	require.Equal(t, 12, controller.nextActiveCount(8, ConcurrencySnapshot{
+		Added: 100, QueueDepth: 32, AvgLatency: time.Millisecond,
+	}))
+	require.Equal(t, 32, controller.nextActiveCount(30, ConcurrencySnapshot{
+		Added: 100, QueueDepth: 32, AvgLatency: time.Millisecond,
+	}))
+	// the latency increased after scaling up, keep the current count
+	controller.lastLatency = time.Millisecond
+	require.Equal(t, 8, controller.nextActiveCount(8, ConcurrencySnapshot{
+		Added: 100, QueueDepth: 32, AvgLatency: 1600 * time.Microsecond,
+	}))
+	// the downstream is overloaded, scale down
+	require.Equal(t, 6, controller.nextActiveCount(8, ConcurrencySnapshot{
+		Added: 100, QueueDepth: 32, AvgLatency: 3 * time.Millisecond,
+	}))
+}
+
+func TestConcurrencyControllerAdjust(t *testing.T) {
+	t.Parallel()
+
+	detector := newTestConflictDetector(32, 8)
+	defer detector.Close()
+	controller := NewConcurrencyController(detector, 1, 32)
+
+	// 3 of 4 txns conflict with others.
+	detector.stats.observeAdd(false)
+	for i := 0; i < 3; i++ {
+		detector.stats.observeAdd(true)
+	}
+	detector.stats.observeFlush(time.Millisecond)
+	detector.stats.observeFlush(3 * time.Millisecond)
+	snapshot := detector.takeSnapshot()
+	require.Equal(t, int64(4), snapshot.Added)
+	require.Equal(t, 0.75, snapshot.ConflictRatio)
+	require.Equal(t, 2*time.Millisecond, snapshot.AvgLatency)
+	require.Zero(t, detector.takeSnapshot().Added)
+
+	for i := 0; i < 4; i++ {
+		detector.stats.observeAdd(true)
+	}
+	workers, slots := controller.Adjust()
+	require.Equal(t, 4, workers)
+	require.Equal(t, uint64(4096), slots)
+	require.Equal(t, uint64(4096), detector.SlotCount())
+}
+
+func TestTryResizeSlots(t *testing.T) {
+	t.Parallel()
+
+	detector := newTestConflictDetector(4, 0)
+	defer detector.Close()
+	require.Equal(t, 4, detector.ActiveCacheCount())
+
+	node := detector.slots.AllocNode([]uint64{1, 2})
+	node.RandCacheID = func() int64 { return 0 }
+	node.TrySendToTxnCache = func(int64) bool { return true }
+	detector.slots.Add(node)
+	// the slots can't be resized with unfinished txns
+	require.False(t, detector.TryResizeSlots(2048))
+	require.Equal(t, uint64(1024), detector.SlotCount())
+
+	detector.slots.Remove(node)
+	require.True(t, detector.TryResizeSlots(2048))
+	require.Equal(t, uint64(2048), detector.SlotCount())
+
+	require.Equal(t, uint64(1024), slotCountFor(1))
+	require.Equal(t, uint64(4096), slotCountFor(3))
+	require.Equal(t, uint64(1024*1024), slotCountFor(4096))
+}
@@ -15,9 +15,11 @@ package conflictdetector
 
 import (
 	"sync"
+	"time"
 
 	"github.com/pingcap/log"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/utils/chann"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -31,15 +33,26 @@ import (
 type ConflictDetector struct {
 	// resolvedTxnCaches are used to cache resolved transactions.
 	resolvedTxnCaches []txnCache
+	cacheSize         int
+	// activeCacheCount is the number of caches that transactions without
+	// dependencies are dispatched to, the rest caches are kept idle.
+	activeCacheCount atomic.Int64
 
 	// slots are used to find all unfinished transactions
 	// conflicting with an incoming transactions.
-	slots    *Slots
-	numSlots uint64
+	// slotsMu protects slots from being replaced when resizing.
+	slotsMu sync.RWMutex
+	slots   *Slots
 
 	// nextCacheID is used to dispatch transactions round-robin.
 	nextCacheID atomic.Int64
 
+	// stats is used to adjust the concurrency adaptively.
+	stats concurrencyStats
+
+	// router is used to detect conflicts of the rows routed to the same table.
+	router *mysql.TableRouter
+
 	closeCh chan struct{}
 
 	notifiedNodes *chann.DrainableChann[func()]
@@ -48,18 +61,24 @@ type ConflictDetector struct {
 
 // NewConflictDetector creates a new ConflictDetector.
 func NewConflictDetector(
-	numSlots uint64, opt TxnCacheOption,
+	numSlots uint64, opt TxnCacheOption, router *mysql.TableRouter,
 ) *ConflictDetector {
 	ret := &ConflictDetector{
 		resolvedTxnCaches: make([]txnCache, opt.Count),
+		cacheSize:         opt.Size,
 		slots:             NewSlots(numSlots),
-		numSlots:          numSlots,
 		closeCh:           make(chan struct{}),
 		notifiedNodes:     chann.NewAutoDrainChann[func()](),
+		router:            router,
 	}
 	for i := 0; i < opt.Count; i++ {
 		ret.resolvedTxnCaches[i] = newTxnCache(opt)
 	}
+	activeCount := opt.ActiveCount
+	if activeCount <= 0 || activeCount > opt.Count {
+		activeCount = opt.Count
+	}
+	ret.activeCacheCount.Store(int64(activeCount))
 
 	ret.wg.Add(1)
 	go func() {
@@ -91,27 +110,83 @@ func (d *ConflictDetector) runBackgroundTasks() {
 // NOTE: if multiple threads access this concurrently,
 // ConflictKeys must be sorted by the slot index.
 func (d *ConflictDetector) Add(event *commonEvent.DMLEvent) error {
-	hashes, err := ConflictKeys(event)
+	hashes, err := ConflictKeys(event, d.router)
 	if err != nil {
 		return err
 	}
-	node := d.slots.AllocNode(hashes)
+	// Hold the read lock until the node is added, so the slots can't be
+	// replaced between allocating and adding the node.
+	d.slotsMu.RLock()
+	defer d.slotsMu.RUnlock()
 
+	slots := d.slots
+	node := slots.AllocNode(hashes)
+
+	start := time.Now()
 	event.AddPostFlushFunc(func() {
-		d.slots.Remove(node)
+		slots.Remove(node)
+		d.stats.observeFlush(time.Since(start))
 	})
 
 	node.TrySendToTxnCache = func(cacheID int64) bool {
 		// Try sending this txn to related cache as soon as all dependencies are resolved.
 		return d.sendToCache(event, cacheID)
 	}
-	node.RandCacheID = func() int64 { return d.nextCacheID.Add(1) % int64(len(d.resolvedTxnCaches)) }
+	node.RandCacheID = func() int64 { return d.nextCacheID.Add(1) % d.activeCacheCount.Load() }
 	node.OnNotified = func(callback func()) { d.notifiedNodes.In() <- callback }
-	d.slots.Add(node)
+	slots.Add(node)
+	d.stats.observeAdd(node.totalDependencies > 0)
 
 	return nil
 }
 
+// ActiveCacheCount returns the number of caches that transactions
+// without dependencies are dispatched to.
+func (d *ConflictDetector) ActiveCacheCount() int {
+	return int(d.activeCacheCount.Load())
+}
+
+// SetActiveCacheCount sets the number of caches that transactions
+// without dependencies are dispatched to. The count is limited to
+// [1, the number of caches].
+func (d *ConflictDetector) SetActiveCacheCount(count int) {
+	count = max(1, min(count, len(d.resolvedTxnCaches)))
+	d.activeCacheCount.Store(int64(count))
+}
+
+// SlotCount returns the number of slots currently used.
+func (d *ConflictDetector) SlotCount() uint64 {
+	d.slotsMu.RLock()
+	defer d.slotsMu.RUnlock()
+	return d.slots.numSlots
+}
+
+// TryResizeSlots replaces the slots with new ones which have numSlots slots.
+// It only succeeds when there is no unfinished transaction in the slots,
+// otherwise the incoming transactions can't find the ones they conflict with.
+func (d *ConflictDetector) TryResizeSlots(numSlots uint64) bool {
+	d.slotsMu.Lock()
+	defer d.slotsMu.Unlock()
+	if d.slots.numSlots == numSlots {
+		return true
+	}
+	if !d.slots.isEmpty() {
+		return false
+	}
+	d.slots = NewSlots(numSlots)
+	return true
+}
+
+// queueDepth returns the average number of transactions cached in the active caches.
+func (d *ConflictDetector) queueDepth() float64 {
+	activeCount := d.activeCacheCount.Load()
+	total := 0
+	for i := int64(0); i < activeCount; i++ {
+		total += d.resolvedTxnCaches[i].len()
+	}
+	return float64(total) / float64(activeCount)
+}
+
 // Close closes the ConflictDetector.
 func (d *ConflictDetector) Close() {
 	close(d.closeCh)
@@ -22,27 +22,29 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/errors"
-	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	tmysql "github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/cdc/model"
 	"go.uber.org/zap"
 )
 
 // ConflictKeys implements causality.txnEvent interface.
-func ConflictKeys(event *commonEvent.DMLEvent) ([]uint64, error) {
+func ConflictKeys(event *commonEvent.DMLEvent, router *mysql.TableRouter) ([]uint64, error) {
 	if event.Len() == 0 {
 		return nil, nil
 	}
 
 	hashRes := make(map[uint64]struct{}, event.Len())
 	hasher := fnv.New32a()
+	tableKey := genTableKey(event, router)
 
 	for {
 		row, ok := event.GetNextRow()
 		if !ok {
 			break
 		}
-		keys, err := genRowKeys(row, event.TableInfo, event.DispatcherID)
+		keys, err := genRowKeys(row, event.TableInfo, tableKey)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -64,12 +66,25 @@ func ConflictKeys(event *commonEvent.DMLEvent) ([]uint64, error) {
 	return keys, nil
 }
 
-func genRowKeys(row commonEvent.RowChange, tableInfo *common.TableInfo, dispatcherID common.DispatcherID) ([][]byte, error) {
+// genTableKey returns the key of the table which the event is written to. The rows
+// of different dispatchers are written to the same table if they are routed to it,
+// so the key of a routed table is generated from the downstream table name.
+func genTableKey(event *commonEvent.DMLEvent, router *mysql.TableRouter) uint64 {
+	routed := router.RouteTableInfo(event.TableInfo)
+	if routed == event.TableInfo {
+		return event.DispatcherID.GetLow()
+	}
+	hasher := fnv.New64a()
+	hasher.Write([]byte(routed.TableName.QuoteString()))
+	return hasher.Sum64()
+}
+
+func genRowKeys(row commonEvent.RowChange, tableInfo *common.TableInfo, tableKey uint64) ([][]byte, error) {
 	var keys [][]byte
 
 	if !row.Row.IsEmpty() {
 		for iIdx, idxCol := range tableInfo.GetIndexColumnsOffset() {
-			key, err := genKeyList(&row.Row, iIdx, idxCol, dispatcherID, tableInfo)
+			key, err := genKeyList(&row.Row, iIdx, idxCol, tableKey, tableInfo)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -81,7 +96,7 @@ func genRowKeys(row commonEvent.RowChange, tableInfo *common.TableInfo, dispatch
 	}
 	if !row.PreRow.IsEmpty() {
 		for iIdx, idxCol := range tableInfo.GetIndexColumnsOffset() {
-			key, err := genKeyList(&row.PreRow, iIdx, idxCol, dispatcherID, tableInfo)
+			key, err := genKeyList(&row.PreRow, iIdx, idxCol, tableKey, tableInfo)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -91,19 +106,21 @@ func genRowKeys(row commonEvent.RowChange, tableInfo *common.TableInfo, dispatch
 			keys = append(keys, key)
 		}
 	}
-	if len(keys) == 0 {
-		// use dispatcherID as key if no key generated (no PK/UK),
-		// no concurrence for rows in the same dispatcher.
-		log.Debug("Use dispatcherID as the key", zap.Any("dispatcherID", dispatcherID))
-		tableKey := make([]byte, 8)
-		binary.BigEndian.PutUint64(tableKey, uint64(dispatcherID.GetLow()))
-		keys = [][]byte{tableKey}
+	if len(keys) == 0 || !tableInfo.HasHandleKey() {
+		// use table key as key if no key generated (no PK/UK),
+		// or the table doesn't have a PK or NOT NULL UK, because the rows
+		// whose unique key is NULL can't be distinguished by the unique keys.
+		// no concurrence for rows in the same table.
+		log.Debug("Use table key as the key", zap.Uint64("tableKey", tableKey))
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, tableKey)
+		keys = append(keys, key)
 	}
 	return keys, nil
 }
 
 func genKeyList(
-	row *chunk.Row, iIdx int, colIdx []int, dispatcherID common.DispatcherID, tableInfo *common.TableInfo,
+	row *chunk.Row, iIdx int, colIdx []int, tableKey uint64, tableInfo *common.TableInfo,
 ) ([]byte, error) {
 	var key []byte
 	columnInfos := tableInfo.GetColumns()
@@ -133,17 +150,17 @@ func genKeyList(
 	if len(key) == 0 {
 		return nil, nil
 	}
-	tableKey := make([]byte, 16)
-	binary.BigEndian.PutUint64(tableKey[:8], uint64(iIdx))
-	binary.BigEndian.PutUint64(tableKey[8:], uint64(dispatcherID.GetLow()))
-	key = append(key, tableKey...)
+	idxKey := make([]byte, 16)
+	binary.BigEndian.PutUint64(idxKey[:8], uint64(iIdx))
+	binary.BigEndian.PutUint64(idxKey[8:], tableKey)
+	key = append(key, idxKey...)
 	return key, nil
 }
 
 func columnNeeds2LowerCase(mysqlType byte, collation string) bool {
 	switch mysqlType {
-	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+	case tmysql.TypeVarchar, tmysql.TypeString, tmysql.TypeVarString, tmysql.TypeTinyBlob,
+		tmysql.TypeMediumBlob, tmysql.TypeBlob, tmysql.TypeLongBlob:
 		return collationNeeds2LowerCase(collation)
 	}
 	return false
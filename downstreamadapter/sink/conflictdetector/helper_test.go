@@ -0,0 +1,68 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conflictdetector
+
+import (
+	"encoding/binary"
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenRowKeys(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	tableKey := uint64(100)
+	tableKeyBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(tableKeyBytes, tableKey)
+
+	genKeys := func(tableName string, dml string) [][]byte {
+		event := helper.DML2Event("test", tableName, dml)
+		row, ok := event.GetNextRow()
+		require.True(t, ok)
+		keys, err := genRowKeys(row, event.TableInfo, tableKey)
+		require.NoError(t, err)
+
+		// the keys of the row deleted are the same as the ones of the row inserted.
+		deleteRow := commonEvent.RowChange{PreRow: row.Row, RowType: commonEvent.RowTypeDelete}
+		deleteKeys, err := genRowKeys(deleteRow, event.TableInfo, tableKey)
+		require.NoError(t, err)
+		require.Equal(t, keys, deleteKeys)
+		return keys
+	}
+
+	// the rows are identified by the not null unique key, the table key is not used.
+	helper.DDL2Job("create table t1 (id int not null, name varchar(32), unique key uk_id(id))")
+	keys := genKeys("t1", "insert into t1 values (1, 'a')")
+	require.Len(t, keys, 1)
+	require.NotEqual(t, tableKeyBytes, keys[0])
+	require.NotEqual(t, keys, genKeys("t1", "insert into t1 values (2, 'a')"))
+
+	// the rows whose unique key is NULL can't be distinguished by the unique key,
+	// so the table key is always added.
+	helper.DDL2Job("create table t2 (id int, name varchar(32), unique key uk_id(id))")
+	keys = genKeys("t2", "insert into t2 values (1, 'a')")
+	require.Len(t, keys, 2)
+	require.Equal(t, tableKeyBytes, keys[1])
+	keys = genKeys("t2", "insert into t2 values (NULL, 'a')")
+	require.Equal(t, [][]byte{tableKeyBytes}, keys)
+
+	// no unique key, only the table key is used.
+	helper.DDL2Job("create table t3 (id int, name varchar(32))")
+	keys = genKeys("t3", "insert into t3 values (1, 'a')")
+	require.Equal(t, [][]byte{tableKeyBytes}, keys)
+}
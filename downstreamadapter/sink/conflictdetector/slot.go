@@ -17,6 +17,8 @@ import (
 	"math"
 	"sort"
 	"sync"
+
+	"go.uber.org/atomic"
 )
 
 type slot struct {
@@ -30,6 +32,9 @@ type slot struct {
 type Slots struct {
 	slots    []slot
 	numSlots uint64
+
+	// nodeCount is the number of nodes added but not removed.
+	nodeCount atomic.Int64
 }
 
 // NewSlots creates a new Slots.
@@ -59,6 +64,7 @@ func (s *Slots) AllocNode(hashes []uint64) *Node {
 
 // Add adds an elem to the slots and calls DependOn for elem.
 func (s *Slots) Add(elem *Node) {
+	s.nodeCount.Inc()
 	hashes := elem.sortedDedupKeysHash
 	dependencyNodes := make(map[int64]*Node, len(hashes))
 
@@ -115,6 +121,12 @@ func (s *Slots) Remove(elem *Node) {
 		}
 		s.slots[slotIdx].mu.Unlock()
 	}
+	s.nodeCount.Dec()
+}
+
+// isEmpty returns true if all the added nodes are removed.
+func (s *Slots) isEmpty() bool {
+	return s.nodeCount.Load() == 0
 }
 
 func getSlot(hash, numSlots uint64) uint64 {
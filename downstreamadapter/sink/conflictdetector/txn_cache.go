@@ -26,7 +26,6 @@ const (
 	BlockStrategyWaitAvailable BlockStrategy = "waitAvailable"
 	// BlockStrategyWaitEmpty means the cache will block until all cached txns are consumed.
 	BlockStrategyWaitEmpty = "waitEmpty"
-	// TODO: maybe we can implement a strategy that can automatically adapt to different scenarios
 )
 
 // BlockStrategy is the strategy to handle the situation when the cache is full.
@@ -36,6 +35,9 @@ type BlockStrategy string
 type TxnCacheOption struct {
 	// Count controls the number of caches, txns in different caches could be executed concurrently.
 	Count int
+	// ActiveCount controls the number of caches that txns without dependencies are dispatched
+	// to at the beginning, it can be adjusted at runtime. 0 means all the caches are active.
+	ActiveCount int
 	// Size controls the max number of txns a cache can hold.
 	Size int
 	// BlockStrategy controls the strategy when the cache is full.
@@ -48,6 +50,8 @@ type txnCache interface {
 	add(txn *commonEvent.DMLEvent) bool
 	// out returns a channel to receive events which are ready to be executed.
 	out() <-chan *commonEvent.DMLEvent
+	// len returns the number of events in the cache.
+	len() int
 }
 
 func newTxnCache(opt TxnCacheOption) txnCache {
@@ -90,6 +94,11 @@ func (w *boundedTxnCache) out() <-chan *commonEvent.DMLEvent {
 	return w.ch
 }
 
+//nolint:unused
+func (w *boundedTxnCache) len() int {
+	return len(w.ch)
+}
+
 // boundedTxnCacheWithBlock is a special boundedWorker. Once the cache
 // is full, it will block until all cached txns are consumed.
 type boundedTxnCacheWithBlock struct {
@@ -119,3 +128,8 @@ func (w *boundedTxnCacheWithBlock) add(txn *commonEvent.DMLEvent) bool {
 func (w *boundedTxnCacheWithBlock) out() <-chan *commonEvent.DMLEvent {
 	return w.ch
 }
+
+//nolint:unused
+func (w *boundedTxnCacheWithBlock) len() int {
+	return len(w.ch)
+}
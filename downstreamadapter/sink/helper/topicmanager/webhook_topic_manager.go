@@ -0,0 +1,39 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topicmanager
+
+import "context"
+
+// webhookTopicManager is the topic manager for webhook sink.
+// All the messages are posted to the same endpoint in order,
+// so every topic is treated as a topic with only one partition.
+type webhookTopicManager struct{}
+
+// NewWebhookTopicManager creates a new topic manager for webhook sink.
+func NewWebhookTopicManager() TopicManager {
+	return &webhookTopicManager{}
+}
+
+// GetPartitionNum always returns 1.
+func (m *webhookTopicManager) GetPartitionNum(_ context.Context, _ string) (int32, error) {
+	return 1, nil
+}
+
+// CreateTopicAndWaitUntilVisible does nothing, the topic of webhook sink always exists.
+func (m *webhookTopicManager) CreateTopicAndWaitUntilVisible(_ context.Context, _ string) (int32, error) {
+	return 1, nil
+}
+
+// Close does nothing.
+func (m *webhookTopicManager) Close() {}
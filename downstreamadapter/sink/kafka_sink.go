@@ -84,11 +84,10 @@ func newKafkaSink(
 	}()
 
 	statistics := metrics.NewStatistics(changefeedID, "KafkaSink")
-	asyncProducer, err := kafkaComponent.Factory.AsyncProducer(ctx)
+	dmlProducer, ddlProducer, err := newKafkaProducers(ctx, changefeedID, kafkaComponent)
 	if err != nil {
-		return nil, errors.WrapError(errors.ErrKafkaNewProducer, err)
+		return nil, errors.Trace(err)
 	}
-	dmlProducer := producer.NewKafkaDMLProducer(changefeedID, asyncProducer)
 	dmlWorker := worker.NewMQDMLWorker(
 		changefeedID,
 		protocol,
@@ -99,11 +98,6 @@ func newKafkaSink(
 		kafkaComponent.TopicManager,
 		statistics)
 
-	syncProducer, err := kafkaComponent.Factory.SyncProducer()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	ddlProducer := producer.NewKafkaDDLProducer(ctx, changefeedID, syncProducer)
 	ddlWorker := worker.NewMQDDLWorker(
 		changefeedID,
 		protocol,
@@ -126,6 +120,34 @@ func newKafkaSink(
 	return sink, nil
 }
 
+// newKafkaProducers creates the DML and DDL producers of the kafka sink,
+// the transactional producers are used if the kafka transaction is enabled.
+func newKafkaProducers(
+	ctx context.Context, changefeedID common.ChangeFeedID, kafkaComponent worker.KafkaComponent,
+) (producer.DMLProducer, producer.DDLProducer, error) {
+	if kafkaComponent.EnableTransaction {
+		// The transactional producers are created on demand for each table span,
+		// see NewTransactionalID for how the transactional id is generated.
+		newTxnProducer := func(transactionalID string) (kafka.TransactionalProducer, error) {
+			return kafkaComponent.Factory.TransactionalProducer(ctx, transactionalID)
+		}
+		return producer.NewKafkaTxnDMLProducer(changefeedID, newTxnProducer),
+			producer.NewKafkaTxnDDLProducer(changefeedID, newTxnProducer), nil
+	}
+
+	asyncProducer, err := kafkaComponent.Factory.AsyncProducer(ctx)
+	if err != nil {
+		return nil, nil, errors.WrapError(errors.ErrKafkaNewProducer, err)
+	}
+	syncProducer, err := kafkaComponent.Factory.SyncProducer()
+	if err != nil {
+		asyncProducer.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return producer.NewKafkaDMLProducer(changefeedID, asyncProducer),
+		producer.NewKafkaDDLProducer(ctx, changefeedID, syncProducer), nil
+}
+
 func (s *KafkaSink) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error {
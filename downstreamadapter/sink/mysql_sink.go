@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"net/url"
 	"sync/atomic"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/errors"
@@ -28,6 +29,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -37,8 +39,13 @@ import (
 )
 
 const (
-	// DefaultConflictDetectorSlots indicates the default slot count of conflict detector. TODO:check this
+	// DefaultConflictDetectorSlots indicates the default slot count of conflict detector.
+	// It's adjusted at runtime if adaptive concurrency is enabled.
 	DefaultConflictDetectorSlots uint64 = 16 * 1024
+
+	// adjustConcurrencyInterval is the interval to adjust the concurrency
+	// of the sink when adaptive concurrency is enabled.
+	adjustConcurrencyInterval = 5 * time.Second
 )
 
 // MysqlSink is responsible for writing data to mysql downstream.
@@ -49,11 +56,17 @@ type MysqlSink struct {
 	ddlWorker   *worker.MysqlDDLWorker
 	dmlWorker   []*worker.MysqlDMLWorker
 	workerCount int
+	// maxWorkerCount is the number of dml workers created, only workerCount of them
+	// are active at the beginning, the others are activated by the concurrency controller.
+	maxWorkerCount int
 
 	db         *sql.DB
 	statistics *metrics.Statistics
+	// deadLetterQueue is nil if the dead letter queue is disabled.
+	deadLetterQueue *dlq.Queue
 
-	conflictDetector *conflictdetector.ConflictDetector
+	conflictDetector      *conflictdetector.ConflictDetector
+	concurrencyController *conflictdetector.ConcurrencyController
 
 	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
 }
@@ -66,11 +79,17 @@ func verifyMySQLSink(
 	config *config.ChangefeedConfig,
 ) error {
 	testID := common.NewChangefeedID4Test("test", "mysql_create_sink_test")
-	_, db, err := mysql.NewMysqlConfigAndDB(ctx, testID, uri, config)
+	cfg, db, err := mysql.NewMysqlConfigAndDB(ctx, testID, uri, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	// make sure the dead letter queue target is accessible.
+	queue, err := dlq.New(ctx, testID, cfg.DeadLetterQueueTarget, db)
 	if err != nil {
 		return err
 	}
-	_ = db.Close()
+	queue.Close()
 	return nil
 }
 
@@ -84,6 +103,11 @@ func newMySQLSink(
 	if err != nil {
 		return nil, err
 	}
+	cfg.DeadLetterQueue, err = dlq.New(ctx, changefeedID, cfg.DeadLetterQueueTarget, db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
 	return newMysqlSinkWithDBAndConfig(ctx, changefeedID, cfg.WorkerCount, cfg, db), nil
 }
 
@@ -95,21 +119,32 @@ func newMysqlSinkWithDBAndConfig(
 	db *sql.DB,
 ) *MysqlSink {
 	stat := metrics.NewStatistics(changefeedID, "TxnSink")
+	maxWorkerCount := workerCount
+	if cfg.EnableAdaptiveConcurrency {
+		maxWorkerCount = max(workerCount, cfg.GetMaxWorkerCount())
+	}
 	mysqlSink := &MysqlSink{
-		changefeedID: changefeedID,
-		db:           db,
-		dmlWorker:    make([]*worker.MysqlDMLWorker, workerCount),
-		workerCount:  workerCount,
-		statistics:   stat,
+		changefeedID:    changefeedID,
+		db:              db,
+		deadLetterQueue: cfg.DeadLetterQueue,
+		dmlWorker:       make([]*worker.MysqlDMLWorker, maxWorkerCount),
+		workerCount:     workerCount,
+		maxWorkerCount:  maxWorkerCount,
+		statistics:      stat,
 		conflictDetector: conflictdetector.NewConflictDetector(DefaultConflictDetectorSlots, conflictdetector.TxnCacheOption{
-			Count:         workerCount,
+			Count:         maxWorkerCount,
+			ActiveCount:   workerCount,
 			Size:          1024,
 			BlockStrategy: causality.BlockStrategyWaitEmpty,
-		}),
+		}, cfg.Router),
 		isNormal: 1,
 	}
+	if cfg.EnableAdaptiveConcurrency {
+		mysqlSink.concurrencyController = conflictdetector.NewConcurrencyController(
+			mysqlSink.conflictDetector, 1, maxWorkerCount)
+	}
 	formatVectorType := mysql.ShouldFormatVectorType(db, cfg)
-	for i := 0; i < workerCount; i++ {
+	for i := 0; i < maxWorkerCount; i++ {
 		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, changefeedID, stat, formatVectorType, mysqlSink.conflictDetector.GetOutChByCacheID(int64(i)))
 	}
 	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, changefeedID, stat, formatVectorType)
@@ -118,17 +153,60 @@ func newMysqlSinkWithDBAndConfig(
 
 func (s *MysqlSink) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
-	for i := 0; i < s.workerCount; i++ {
+	for i := 0; i < s.maxWorkerCount; i++ {
 		i := i // capture loop variable
 		g.Go(func() error {
 			return s.dmlWorker[i].Run(ctx)
 		})
 	}
+	g.Go(func() error {
+		return s.runConcurrencyController(ctx)
+	})
 	err := g.Wait()
 	atomic.StoreUint32(&s.isNormal, 0)
 	return errors.Trace(err)
 }
 
+// runConcurrencyController adjusts the active workers and the conflict detector slots
+// periodically if adaptive concurrency is enabled, and exposes them as metrics.
+func (s *MysqlSink) runConcurrencyController(ctx context.Context) error {
+	namespace, changefeed := s.changefeedID.Namespace(), s.changefeedID.Name()
+	activeWorkerCount := metrics.ActiveWorkerCount.WithLabelValues(namespace, changefeed)
+	slotCount := metrics.ConflictDetectorSlotCount.WithLabelValues(namespace, changefeed)
+	defer func() {
+		metrics.ActiveWorkerCount.DeleteLabelValues(namespace, changefeed)
+		metrics.ConflictDetectorSlotCount.DeleteLabelValues(namespace, changefeed)
+	}()
+	lastWorkers, lastSlots := s.conflictDetector.ActiveCacheCount(), s.conflictDetector.SlotCount()
+	activeWorkerCount.Set(float64(lastWorkers))
+	slotCount.Set(float64(lastSlots))
+	if s.concurrencyController == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(adjustConcurrencyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			workers, slots := s.concurrencyController.Adjust()
+			if workers != lastWorkers || slots != lastSlots {
+				log.Info("mysql sink concurrency adjusted",
+					zap.String("namespace", namespace),
+					zap.String("changefeed", changefeed),
+					zap.Int("activeWorkers", workers), zap.Int("lastActiveWorkers", lastWorkers),
+					zap.Uint64("slots", slots), zap.Uint64("lastSlots", lastSlots))
+				lastWorkers, lastSlots = workers, slots
+			}
+			activeWorkerCount.Set(float64(workers))
+			slotCount.Set(float64(slots))
+		}
+	}
+}
+
 func (s *MysqlSink) IsNormal() bool {
 	value := atomic.LoadUint32(&s.isNormal) == 1
 	return value
@@ -198,11 +276,12 @@ func (s *MysqlSink) Close(removeChangefeed bool) {
 				zap.Any("changefeed", s.changefeedID.String()), zap.Error(err))
 		}
 	}
-	for i := 0; i < s.workerCount; i++ {
+	for i := 0; i < s.maxWorkerCount; i++ {
 		s.dmlWorker[i].Close()
 	}
 
 	s.ddlWorker.Close()
+	s.deadLetterQueue.Close()
 
 	if err := s.db.Close(); err != nil {
 		log.Warn("close mysql sink db meet error",
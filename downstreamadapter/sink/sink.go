@@ -21,7 +21,9 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
 	"github.com/pingcap/tiflow/pkg/sink"
 )
 
@@ -39,7 +41,10 @@ type Sink interface {
 	Run(ctx context.Context) error
 }
 
-func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID) (Sink, error) {
+// NewSink creates the sink of the changefeed, pdClock is the clock of the upstream of the changefeed.
+func NewSink(
+	ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID, pdClock pdutil.Clock,
+) (Sink, error) {
 	sinkURI, err := url.Parse(config.SinkURI)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
@@ -53,9 +58,11 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 	case sink.PulsarScheme, sink.PulsarSSLScheme, sink.PulsarHTTPScheme, sink.PulsarHTTPSScheme:
 		return newPulsarSink(ctx, changefeedID, sinkURI, config.SinkConfig)
 	case sink.S3Scheme, sink.FileScheme, sink.GCSScheme, sink.GSScheme, sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
-		return newCloudStorageSink(ctx, changefeedID, sinkURI, config.SinkConfig, nil)
+		return newCloudStorageSink(ctx, changefeedID, pdClock, sinkURI, config.SinkConfig, nil)
 	case sink.BlackHoleScheme:
 		return newBlackHoleSink()
+	case webhook.Scheme, webhook.SSLScheme:
+		return newWebhookSink(ctx, changefeedID, sinkURI, config.SinkConfig)
 	}
 	return nil, cerror.ErrSinkURIInvalid.GenWithStackByArgs(sinkURI)
 }
@@ -77,6 +84,8 @@ func VerifySink(ctx context.Context, config *config.ChangefeedConfig, changefeed
 		return verifyCloudStorageSink(ctx, changefeedID, sinkURI, config.SinkConfig)
 	case sink.BlackHoleScheme:
 		return nil
+	case webhook.Scheme, webhook.SSLScheme:
+		return verifyWebhookSink(ctx, changefeedID, sinkURI, config.SinkConfig)
 	}
 	return cerror.ErrSinkURIInvalid.GenWithStackByArgs(sinkURI)
 }
@@ -0,0 +1,180 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// WebhookSink posts the encoded events to an HTTP endpoint.
+// It reuses the MQ workers to encode the events, and treats
+// the endpoint as a topic with only one partition, so the
+// messages are posted in order.
+type WebhookSink struct {
+	changefeedID common.ChangeFeedID
+
+	dmlWorker *worker.MQDMLWorker
+	ddlWorker *worker.MQDDLWorker
+
+	client       *webhook.Client
+	topicManager topicmanager.TopicManager
+	statistics   *metrics.Statistics
+
+	// isNormal means the sink does not meet error.
+	// if sink is normal, isNormal is 1, otherwise is 0
+	isNormal uint32
+	ctx      context.Context
+}
+
+func (s *WebhookSink) SinkType() common.SinkType {
+	return common.WebhookSinkType
+}
+
+func verifyWebhookSink(ctx context.Context, changefeedID common.ChangeFeedID, uri *url.URL, sinkConfig *config.SinkConfig) error {
+	components, _, err := worker.GetWebhookSinkComponent(ctx, changefeedID, uri, sinkConfig)
+	if components.Client != nil {
+		components.Client.Close()
+	}
+	return err
+}
+
+func newWebhookSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *config.SinkConfig,
+) (*WebhookSink, error) {
+	webhookComponent, protocol, err := worker.GetWebhookSinkComponent(ctx, changefeedID, sinkURI, sinkConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statistics := metrics.NewStatistics(changefeedID, "WebhookSink")
+
+	dmlProducer := producer.NewWebhookDMLProducer(changefeedID, webhookComponent.Client, webhookComponent.Config.MaxBatchSize)
+	dmlWorker := worker.NewMQDMLWorker(
+		changefeedID,
+		protocol,
+		dmlProducer,
+		webhookComponent.EncoderGroup,
+		webhookComponent.ColumnSelector,
+		webhookComponent.EventRouter,
+		webhookComponent.TopicManager,
+		statistics,
+	)
+
+	ddlProducer := producer.NewWebhookDDLProducer(changefeedID, webhookComponent.Client)
+	ddlWorker := worker.NewMQDDLWorker(
+		changefeedID,
+		protocol,
+		ddlProducer,
+		webhookComponent.Encoder,
+		webhookComponent.EventRouter,
+		webhookComponent.TopicManager,
+		statistics,
+	)
+
+	sink := &WebhookSink{
+		changefeedID: changefeedID,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		client:       webhookComponent.Client,
+		topicManager: webhookComponent.TopicManager,
+		statistics:   statistics,
+		isNormal:     1,
+		ctx:          ctx,
+	}
+	return sink, nil
+}
+
+func (s *WebhookSink) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.dmlWorker.Run(ctx)
+	})
+	g.Go(func() error {
+		return s.ddlWorker.Run(ctx)
+	})
+	err := g.Wait()
+	atomic.StoreUint32(&s.isNormal, 0)
+	return errors.Trace(err)
+}
+
+func (s *WebhookSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+func (s *WebhookSink) AddDMLEvent(event *commonEvent.DMLEvent) error {
+	s.dmlWorker.AddDMLEvent(event)
+	return nil
+}
+
+func (s *WebhookSink) PassBlockEvent(event commonEvent.BlockEvent) {
+	event.PostFlush()
+}
+
+func (s *WebhookSink) WriteBlockEvent(event commonEvent.BlockEvent) error {
+	switch v := event.(type) {
+	case *commonEvent.DDLEvent:
+		if v.TiDBOnly {
+			// run callback directly and return
+			v.PostFlush()
+			return nil
+		}
+		err := s.ddlWorker.WriteBlockEvent(s.ctx, v)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	case *commonEvent.SyncPointEvent:
+		log.Error("WebhookSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event", event))
+	default:
+		log.Error("WebhookSink doesn't support this type of block event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("eventType", event.GetType()))
+	}
+	return nil
+}
+
+func (s *WebhookSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.AddCheckpoint(ts)
+}
+
+func (s *WebhookSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
+}
+
+func (s *WebhookSink) Close(_ bool) {
+	s.ddlWorker.Close()
+	s.dmlWorker.Close()
+	s.client.Close()
+	s.topicManager.Close()
+	s.statistics.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestWebhookSinkBasicFunctionality(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		lines    []string
+		authList []string
+		requests atomic.Int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request to verify the request is retried.
+		if requests.Inc() == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		authList = append(authList, r.Header.Get("Authorization"))
+		lines = append(lines, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")...)
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse(server.URL + "/events?protocol=canal-json&enable-tidb-extension=true" +
+		"&bearer-token=token&retry-backoff-base-in-ms=1&retry-backoff-max-in-ms=10")
+	require.NoError(t, err)
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.Protocol = aws.String("canal-json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	sink, err := newWebhookSink(ctx, changefeedID, sinkURI, sinkConfig)
+	require.NoError(t, err)
+	sink.SetTableSchemaStore(util.NewTableSchemaStore(nil, common.WebhookSinkType))
+	go sink.Run(ctx)
+	defer sink.Close(false)
+
+	count := atomic.NewInt64(0)
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		NeedAddedTables: []commonEvent.Table{{TableID: 1, SchemaID: 1}},
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+	require.NoError(t, sink.WriteBlockEvent(ddlEvent))
+	require.Equal(t, int64(1), count.Load())
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count.Add(1) },
+	}
+	dmlEvent.CommitTs = 2
+	require.NoError(t, sink.AddDMLEvent(dmlEvent))
+	require.Eventually(t, func() bool {
+		return count.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	sink.AddCheckpointTs(3)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(lines) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, int64(4), requests.Load())
+	require.Contains(t, lines[0], `"isDdl":true`)
+	require.Contains(t, lines[1], `"type":"INSERT"`)
+	require.Contains(t, lines[1], `"id":"1"`)
+	require.Contains(t, lines[2], `"type":"INSERT"`)
+	require.Contains(t, lines[2], `"id":"2"`)
+	require.Contains(t, lines[3], `"type":"TIDB_WATERMARK"`)
+	for _, auth := range authList {
+		require.Equal(t, "Bearer token", auth)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/util"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/stretchr/testify/require"
+)
+
+func cloudStorageDDLWorkerForTest(t *testing.T, dir string) *CloudStorageDDLWorker {
+	ctx := context.Background()
+	changefeedID := common.NewChangefeedID4Test("test", "test")
+	uri := fmt.Sprintf("file:///%s?protocol=csv", dir)
+	sinkURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DateSeparator = util.AddressOf(config.DateSeparatorNone.String())
+	cfg := cloudstorage.NewConfig()
+	err = cfg.Apply(ctx, sinkURI, replicaConfig.Sink)
+	require.NoError(t, err)
+	storage, err := helper.GetExternalStorageFromURI(ctx, uri)
+	require.NoError(t, err)
+
+	statistics := metrics.NewStatistics(changefeedID, "CloudStorageSink")
+	return NewCloudStorageDDLWorker(changefeedID, sinkURI, cfg, nil, storage, statistics)
+}
+
+func TestCloudStorageWriteRecoverSchemaEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	count := 0
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionRecoverSchema),
+		Query:      "FLASHBACK DATABASE `test`",
+		SchemaName: "test",
+		FinishedTs: 100,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		NeedAddedTables: []commonEvent.Table{{TableID: job.TableID, SchemaID: job.SchemaID}},
+		MultipleTableInfos: []*common.TableInfo{
+			common.WrapTableInfo(job.SchemaID, job.SchemaName, job.BinlogInfo.TableInfo),
+		},
+		NonTiDBQueries: []string{
+			"CREATE DATABASE IF NOT EXISTS `test`",
+			"CREATE TABLE `t` (`id` int PRIMARY KEY, `name` varchar(32))",
+		},
+		PostTxnFlushed: []func(){
+			func() { count++ },
+		},
+	}
+
+	dir := t.TempDir()
+	ddlWorker := cloudStorageDDLWorkerForTest(t, dir)
+	err := ddlWorker.WriteBlockEvent(ddlEvent)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	// the schema and the table are created instead of flashback
+	schemaFiles, err := filepath.Glob(filepath.Join(dir, "test", "meta", "schema_100_*.json"))
+	require.NoError(t, err)
+	require.Len(t, schemaFiles, 1)
+	content, err := os.ReadFile(schemaFiles[0])
+	require.NoError(t, err)
+	require.Contains(t, string(content), "CREATE DATABASE IF NOT EXISTS `test`")
+
+	tableFiles, err := filepath.Glob(filepath.Join(dir, "test", "t", "meta", "schema_100_*.json"))
+	require.NoError(t, err)
+	require.Len(t, tableFiles, 1)
+	content, err = os.ReadFile(tableFiles[0])
+	require.NoError(t, err)
+	require.Contains(t, string(content), "CREATE TABLE `t` (`id` int PRIMARY KEY, `name` varchar(32))")
+	require.NotContains(t, string(content), "FLASHBACK")
+}
@@ -23,6 +23,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
 	"github.com/pingcap/ticdc/pkg/sink/codec"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
@@ -68,6 +69,7 @@ func NewCloudStorageDMLWorker(
 	config *cloudstorage.Config,
 	encoderConfig *common.Config,
 	extension string,
+	pdClock pdutil.Clock,
 	statistics *metrics.Statistics,
 ) (*CloudStorageDMLWorker, error) {
 	w := &CloudStorageDMLWorker{
@@ -93,7 +95,7 @@ func NewCloudStorageDMLWorker(
 	for i := 0; i < w.config.WorkerCount; i++ {
 		inputCh := chann.NewAutoDrainChann[writer.EventFragment]()
 		w.writers[i] = writer.NewWriter(i, w.changefeedID, storage, config, extension,
-			inputCh, w.statistics)
+			pdClock, inputCh, w.statistics)
 		workerChannels[i] = inputCh
 	}
 	// create defragmenter.
@@ -31,6 +31,7 @@ import (
 	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
 	"github.com/pingcap/ticdc/pkg/sink/pulsar"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
 	"github.com/pingcap/tidb/br/pkg/utils"
 	"github.com/pingcap/tiflow/pkg/sink"
 )
@@ -43,6 +44,8 @@ type KafkaComponent struct {
 	TopicManager   topicmanager.TopicManager
 	AdminClient    kafka.ClusterAdminClient
 	Factory        kafka.Factory
+	// EnableTransaction indicates whether to send the messages in kafka transactions.
+	EnableTransaction bool
 }
 
 func getKafkaSinkComponentWithFactory(ctx context.Context,
@@ -62,6 +65,7 @@ func getKafkaSinkComponentWithFactory(ctx context.Context,
 		return kafkaComponent, protocol, errors.WrapError(errors.ErrKafkaInvalidConfig, err)
 	}
 
+	kafkaComponent.EnableTransaction = options.EnableTransaction
 	kafkaComponent.Factory, err = factoryCreator(ctx, options, changefeedID)
 	if err != nil {
 		return kafkaComponent, protocol, errors.WrapError(errors.ErrKafkaNewProducer, err)
@@ -234,3 +238,62 @@ func GetPulsarSinkComponentForTest(
 ) (PulsarComponent, config.Protocol, error) {
 	return getPulsarSinkComponentWithFactory(ctx, changefeedID, sinkURI, sinkConfig, pulsar.NewMockCreatorFactory)
 }
+
+type WebhookComponent struct {
+	Config         *webhook.Config
+	Client         *webhook.Client
+	EncoderGroup   codec.EncoderGroup
+	Encoder        common.EventEncoder
+	ColumnSelector *columnselector.ColumnSelectors
+	EventRouter    *eventrouter.EventRouter
+	TopicManager   topicmanager.TopicManager
+}
+
+func GetWebhookSinkComponent(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	sinkURI *url.URL,
+	sinkConfig *config.SinkConfig,
+) (WebhookComponent, config.Protocol, error) {
+	webhookComponent := WebhookComponent{}
+	protocol, err := helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return webhookComponent, config.ProtocolUnknown, errors.Trace(err)
+	}
+
+	webhookComponent.Config = webhook.NewConfig()
+	if err = webhookComponent.Config.Apply(sinkURI, sinkConfig); err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+
+	// The topic makes no sense for webhook sink, all the messages are
+	// posted to the endpoint, use the host as the default topic.
+	webhookComponent.TopicManager = topicmanager.NewWebhookTopicManager()
+	scheme := sink.GetScheme(sinkURI)
+	webhookComponent.EventRouter, err = eventrouter.NewEventRouter(sinkConfig, protocol, sinkURI.Host, scheme)
+	if err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+
+	webhookComponent.ColumnSelector, err = columnselector.NewColumnSelectors(sinkConfig)
+	if err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, config.DefaultMaxMessageBytes)
+	if err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+
+	webhookComponent.EncoderGroup, err = codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
+	if err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+
+	webhookComponent.Encoder, err = codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return webhookComponent, protocol, errors.Trace(err)
+	}
+	webhookComponent.Client = webhook.NewClient(changefeedID, webhookComponent.Config, protocol, encoderConfig.EncodingFormat)
+	return webhookComponent, protocol, nil
+}
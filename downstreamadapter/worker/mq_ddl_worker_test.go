@@ -116,6 +116,47 @@ func TestWriteDDLEvents(t *testing.T) {
 	require.Equal(t, count, 2)
 }
 
+func TestWriteRecoverSchemaEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(mm.ActionRecoverSchema),
+		Query:      "FLASHBACK DATABASE `test`",
+		SchemaName: "test",
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		NeedAddedTables: []commonEvent.Table{{TableID: job.TableID, SchemaID: job.SchemaID}},
+		MultipleTableInfos: []*common.TableInfo{
+			common.WrapTableInfo(job.SchemaID, job.SchemaName, job.BinlogInfo.TableInfo),
+		},
+		NonTiDBQueries: []string{
+			"CREATE DATABASE IF NOT EXISTS `test`",
+			"CREATE TABLE `t` (`id` int PRIMARY KEY, `name` varchar(32))",
+		},
+	}
+
+	ddlWorker := kafkaDDLWorkerForTest(t)
+	err := ddlWorker.WriteBlockEvent(context.Background(), ddlEvent)
+	require.NoError(t, err)
+
+	// the schema and the tables are created instead of flashback
+	messages := ddlWorker.producer.(*producer.KafkaMockProducer).GetAllEvents()
+	require.Len(t, messages, 2)
+	require.Contains(t, string(messages[0].Value), "CREATE DATABASE IF NOT EXISTS `test`")
+	require.Contains(t, string(messages[1].Value), "CREATE TABLE `t` (`id` int PRIMARY KEY, `name` varchar(32))")
+	for _, message := range messages {
+		require.NotContains(t, string(message.Value), "FLASHBACK")
+	}
+}
+
 func TestWriteCheckpointTs(t *testing.T) {
 	ddlWorker := kafkaDDLWorkerForTest(t)
 	ctx, cancel := context.WithCancel(context.Background())
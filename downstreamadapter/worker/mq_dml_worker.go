@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
 	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -165,6 +166,7 @@ func (w *MQDMLWorker) calculateKeyPartitions(ctx context.Context) error {
 					},
 					RowEvent: commonEvent.RowEvent{
 						TableInfo:      event.TableInfo,
+						TableSpan:      event.TableSpan,
 						CommitTs:       event.CommitTs,
 						Event:          row,
 						Callback:       rowCallback,
@@ -250,7 +252,7 @@ func (w *MQDMLWorker) batchEncodeRun(ctx context.Context) error {
 		// Group messages by its TopicPartitionKey before adding them to the encoder group.
 		groupedMsgs := w.group(msgs)
 		for key, msg := range groupedMsgs {
-			if err = w.encoderGroup.AddEvents(ctx, key, msg...); err != nil {
+			if err = w.encoderGroup.AddEvents(ctx, key.key, msg...); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -303,14 +305,23 @@ func (w *MQDMLWorker) batch(ctx context.Context, buffer []*commonEvent.MQRowEven
 	}
 }
 
+// groupKey is the key to group messages, the span is only set for the transactional
+// producer, which sends the messages of different table spans in different transactions.
+type groupKey struct {
+	key  model.TopicPartitionKey
+	span *heartbeatpb.TableSpan
+}
+
 // group groups messages by its key.
-func (w *MQDMLWorker) group(msgs []*commonEvent.MQRowEvent) map[model.TopicPartitionKey][]*commonEvent.RowEvent {
-	groupedMsgs := make(map[model.TopicPartitionKey][]*commonEvent.RowEvent)
+func (w *MQDMLWorker) group(msgs []*commonEvent.MQRowEvent) map[groupKey][]*commonEvent.RowEvent {
+	_, isTxn := w.producer.(producer.TxnDMLProducer)
+	groupedMsgs := make(map[groupKey][]*commonEvent.RowEvent)
 	for _, msg := range msgs {
-		if _, ok := groupedMsgs[msg.Key]; !ok {
-			groupedMsgs[msg.Key] = make([]*commonEvent.RowEvent, 0)
+		key := groupKey{key: msg.Key}
+		if isTxn {
+			key.span = msg.RowEvent.TableSpan
 		}
-		groupedMsgs[msg.Key] = append(groupedMsgs[msg.Key], &msg.RowEvent)
+		groupedMsgs[key] = append(groupedMsgs[key], &msg.RowEvent)
 	}
 	return groupedMsgs
 }
@@ -319,6 +330,12 @@ func (w *MQDMLWorker) sendMessages(ctx context.Context) error {
 	metricSendMessageDuration := metrics.WorkerSendMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	defer metrics.WorkerSendMessageDuration.DeleteLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 
+	// The messages are sent in kafka transactions if the producer is transactional,
+	// the transaction is committed when there are no more encoded messages ready
+	// to send, or too many messages are sent in it.
+	txnProducer, _ := w.producer.(producer.TxnDMLProducer)
+	sentInTxn := 0
+
 	var err error
 	outCh := w.encoderGroup.Output()
 	for {
@@ -339,6 +356,9 @@ func (w *MQDMLWorker) sendMessages(ctx context.Context) error {
 				start := time.Now()
 				if err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
 					message.SetPartitionKey(future.Key.PartitionKey)
+					if txnProducer != nil {
+						message.TableSpan = future.TableSpan()
+					}
 					if err = w.producer.AsyncSendMessage(
 						ctx,
 						future.Key.Topic,
@@ -352,6 +372,16 @@ func (w *MQDMLWorker) sendMessages(ctx context.Context) error {
 				}
 				metricSendMessageDuration.Observe(time.Since(start).Seconds())
 			}
+			if txnProducer == nil {
+				continue
+			}
+			sentInTxn += len(future.Messages)
+			if len(outCh) == 0 || sentInTxn >= batchSize {
+				if err = txnProducer.Commit(ctx); err != nil {
+					return errors.Trace(err)
+				}
+				sentInTxn = 0
+			}
 		}
 	}
 }
@@ -0,0 +1,320 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"go.uber.org/zap"
+)
+
+var (
+	_ TxnDMLProducer = (*KafkaTxnDMLProducer)(nil)
+	_ DDLProducer    = (*kafkaTxnDDLProducer)(nil)
+)
+
+// txnProducerIdleTimeout is the duration after which the transactional producer
+// of a table span is closed if no message of the span is sent, it happens after
+// the dispatcher of the span is removed or moved to another capture.
+const txnProducerIdleTimeout = 10 * time.Minute
+
+// NewTxnProducerFunc creates a transactional producer with the given transactional id.
+type NewTxnProducerFunc func(transactionalID string) (kafka.TransactionalProducer, error)
+
+// KafkaTxnDMLProducer sends the DML messages to kafka in transactions.
+// The messages are sent in a transaction until Commit is called, and the callbacks
+// of the messages are called only after the transaction is committed, so the
+// checkpoint never goes beyond the uncommitted messages.
+// The messages of each table span are sent by a separate transactional producer,
+// whose transactional id is derived from the changefeed and the span, so when the
+// dispatcher of the span moves to another capture, the producer created there
+// fences the old one and aborts its ongoing transaction.
+// AsyncSendMessage and Commit should be called by the same goroutine.
+type KafkaTxnDMLProducer struct {
+	id             commonType.ChangeFeedID
+	newTxnProducer NewTxnProducerFunc
+
+	// spanProducers are the transactional producers of the table spans.
+	spanProducers *commonType.SpanHashMap[*spanTxnProducer]
+	// inTxnProducers are the producers which have an ongoing transaction.
+	inTxnProducers []*spanTxnProducer
+	lastCleanTime  time.Time
+
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// spanTxnProducer is the transactional producer of a table span.
+type spanTxnProducer struct {
+	txnProducer kafka.TransactionalProducer
+	// inTxn indicates whether there is an ongoing transaction.
+	inTxn bool
+	// callbacks are the callbacks of the messages in the ongoing transaction.
+	callbacks []func()
+	// lastSendTime is the time when the last message is sent.
+	lastSendTime time.Time
+}
+
+// NewKafkaTxnDMLProducer creates a new kafka transactional producer for DML messages.
+func NewKafkaTxnDMLProducer(
+	changefeedID commonType.ChangeFeedID,
+	newTxnProducer NewTxnProducerFunc,
+) *KafkaTxnDMLProducer {
+	return &KafkaTxnDMLProducer{
+		id:             changefeedID,
+		newTxnProducer: newTxnProducer,
+		spanProducers:  commonType.NewSpanHashMap[*spanTxnProducer](),
+		lastCleanTime:  time.Now(),
+	}
+}
+
+// Run blocks until the context is done, the messages are acknowledged when committing.
+func (k *KafkaTxnDMLProducer) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// AsyncSendMessage sends the message in the ongoing transaction of its table span,
+// a new transaction is started if there is no ongoing one.
+func (k *KafkaTxnDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	k.closedMu.RLock()
+	defer k.closedMu.RUnlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+
+	p, err := k.getSpanProducer(message.TableSpan)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !p.inTxn {
+		if err = p.txnProducer.BeginTxn(); err != nil {
+			return errors.Trace(err)
+		}
+		p.inTxn = true
+		k.inTxnProducers = append(k.inTxnProducers, p)
+	}
+	p.lastSendTime = time.Now()
+	if err = p.txnProducer.AsyncSend(ctx, topic, partition, message); err != nil {
+		return errors.Trace(err)
+	}
+	p.callbacks = append(p.callbacks, message.Callback)
+	return nil
+}
+
+func (k *KafkaTxnDMLProducer) getSpanProducer(span *heartbeatpb.TableSpan) (*spanTxnProducer, error) {
+	if span == nil {
+		return nil, cerror.ErrKafkaTransaction.GenWithStack(
+			"the table span of the message is not set")
+	}
+	if p, ok := k.spanProducers.Get(*span); ok {
+		return p, nil
+	}
+	transactionalID := kafka.NewTransactionalID(k.id, span)
+	txnProducer, err := k.newTxnProducer(transactionalID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	p := &spanTxnProducer{txnProducer: txnProducer}
+	k.spanProducers.ReplaceOrInsert(*span, p)
+	return p, nil
+}
+
+// Commit commits the ongoing transactions and calls the callbacks of the messages in them.
+func (k *KafkaTxnDMLProducer) Commit(ctx context.Context) error {
+	k.closedMu.RLock()
+	defer k.closedMu.RUnlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+
+	producers := k.inTxnProducers
+	k.inTxnProducers = nil
+	var err error
+	for _, p := range producers {
+		p.inTxn = false
+		callbacks := p.callbacks
+		p.callbacks = nil
+		// The remaining transactions are aborted if one of them fails to commit.
+		if err == nil {
+			err = p.txnProducer.CommitTxn(ctx)
+		}
+		if err != nil {
+			// The messages of an aborted transaction are invisible to the `read_committed`
+			// consumers, they are sent again after the changefeed restarts.
+			if abortErr := p.txnProducer.AbortTxn(); abortErr != nil {
+				log.Warn("abort kafka transaction failed",
+					zap.String("namespace", k.id.Namespace()),
+					zap.String("changefeed", k.id.Name()),
+					zap.Error(abortErr))
+			}
+			continue
+		}
+		for _, callback := range callbacks {
+			if callback != nil {
+				callback()
+			}
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	k.closeIdleProducers()
+	return nil
+}
+
+// closeIdleProducers closes the producers of the table spans which are
+// not written for a while, it's called when there is no ongoing transaction.
+func (k *KafkaTxnDMLProducer) closeIdleProducers() {
+	now := time.Now()
+	if now.Sub(k.lastCleanTime) < txnProducerIdleTimeout {
+		return
+	}
+	k.lastCleanTime = now
+	var idleSpans []heartbeatpb.TableSpan
+	k.spanProducers.Range(func(span heartbeatpb.TableSpan, p *spanTxnProducer) bool {
+		if now.Sub(p.lastSendTime) >= txnProducerIdleTimeout {
+			idleSpans = append(idleSpans, span)
+		}
+		return true
+	})
+	for _, span := range idleSpans {
+		k.spanProducers.GetV(span).txnProducer.Close()
+		k.spanProducers.Delete(span)
+	}
+}
+
+func (k *KafkaTxnDMLProducer) Close() {
+	k.closedMu.Lock()
+	defer k.closedMu.Unlock()
+	if k.closed {
+		log.Warn("Kafka transactional DML producer already closed",
+			zap.String("namespace", k.id.Namespace()),
+			zap.String("changefeed", k.id.Name()))
+		return
+	}
+	k.spanProducers.Range(func(_ heartbeatpb.TableSpan, p *spanTxnProducer) bool {
+		p.txnProducer.Close()
+		return true
+	})
+	k.closed = true
+}
+
+// kafkaTxnDDLProducer sends each DDL and checkpoint message to kafka in a transaction.
+// The transactional producer is created when the first message is sent, since the
+// kafka sink is created in every capture but only the one which hosts the table
+// trigger event dispatcher sends DDL messages, and creating the producer with the
+// same transactional id in other captures fences the working one.
+type kafkaTxnDDLProducer struct {
+	id             commonType.ChangeFeedID
+	newTxnProducer NewTxnProducerFunc
+	txnProducer    kafka.TransactionalProducer
+
+	// mu makes sure that only one transaction is ongoing, the DDL messages and
+	// the checkpoint messages are sent concurrently.
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewKafkaTxnDDLProducer creates a new kafka transactional producer for DDL and checkpoint messages.
+func NewKafkaTxnDDLProducer(
+	changefeedID commonType.ChangeFeedID,
+	newTxnProducer NewTxnProducerFunc,
+) DDLProducer {
+	return &kafkaTxnDDLProducer{
+		id:             changefeedID,
+		newTxnProducer: newTxnProducer,
+	}
+}
+
+func (k *kafkaTxnDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	totalPartitionsNum int32, message *common.Message,
+) error {
+	return k.sendInTxn(ctx, func() error {
+		for i := int32(0); i < totalPartitionsNum; i++ {
+			if err := k.txnProducer.AsyncSend(ctx, topic, i, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (k *kafkaTxnDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	partitionNum int32, message *common.Message,
+) error {
+	return k.sendInTxn(ctx, func() error {
+		return k.txnProducer.AsyncSend(ctx, topic, partitionNum, message)
+	})
+}
+
+func (k *kafkaTxnDDLProducer) sendInTxn(ctx context.Context, send func() error) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if k.txnProducer == nil {
+		txnProducer, err := k.newTxnProducer(kafka.NewTransactionalID(k.id, heartbeatpb.DDLSpan))
+		if err != nil {
+			return cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+		}
+		k.txnProducer = txnProducer
+	}
+
+	if err := k.txnProducer.BeginTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+	}
+	err := send()
+	if err == nil {
+		err = k.txnProducer.CommitTxn(ctx)
+	}
+	if err != nil {
+		if abortErr := k.txnProducer.AbortTxn(); abortErr != nil {
+			log.Warn("abort kafka transaction failed",
+				zap.String("namespace", k.id.Namespace()),
+				zap.String("changefeed", k.id.Name()),
+				zap.Error(abortErr))
+		}
+		return cerror.WrapError(cerror.ErrKafkaSendMessage, err)
+	}
+	return nil
+}
+
+func (k *kafkaTxnDDLProducer) Close() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		log.Warn("Kafka transactional DDL producer already closed",
+			zap.String("namespace", k.id.Namespace()),
+			zap.String("changefeed", k.id.Name()))
+		return
+	}
+	k.closed = true
+	if k.txnProducer != nil {
+		k.txnProducer.Close()
+	}
+}
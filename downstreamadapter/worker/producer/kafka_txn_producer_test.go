@@ -0,0 +1,260 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// mockTxnProducers creates the mock transactional producers of a capture.
+type mockTxnProducers struct {
+	t          *testing.T
+	ctx        context.Context
+	changefeed commonType.ChangeFeedID
+	factory    kafka.Factory
+	producers  map[string]*kafka.MockSaramaTransactionalProducer
+	// ids are the transactional ids of the producers used by the kafka producer.
+	ids []string
+}
+
+func newMockTxnProducers(
+	ctx context.Context, t *testing.T, changefeed commonType.ChangeFeedID,
+) *mockTxnProducers {
+	options := getOptions()
+	options.Version = "2.0.0"
+	options.EnableTransaction = true
+
+	factory, err := kafka.NewMockFactory(ctx, options, changefeed)
+	require.NoError(t, err)
+	factory.(*kafka.MockFactory).ErrorReporter = t
+	return &mockTxnProducers{
+		t:          t,
+		ctx:        ctx,
+		changefeed: changefeed,
+		factory:    factory,
+		producers:  make(map[string]*kafka.MockSaramaTransactionalProducer),
+	}
+}
+
+// get returns the mock producer of the span, it's created in advance to set the expectations.
+func (m *mockTxnProducers) get(span *heartbeatpb.TableSpan) *kafka.MockSaramaTransactionalProducer {
+	transactionalID := kafka.NewTransactionalID(m.changefeed, span)
+	if p, ok := m.producers[transactionalID]; ok {
+		return p
+	}
+	txnProducer, err := m.factory.TransactionalProducer(m.ctx, transactionalID)
+	require.NoError(m.t, err)
+	m.producers[transactionalID] = txnProducer.(*kafka.MockSaramaTransactionalProducer)
+	return m.producers[transactionalID]
+}
+
+func (m *mockTxnProducers) newTxnProducer(transactionalID string) (kafka.TransactionalProducer, error) {
+	m.ids = append(m.ids, transactionalID)
+	p, ok := m.producers[transactionalID]
+	if !ok {
+		return nil, errors.ErrKafkaNewProducer.GenWithStackByArgs()
+	}
+	return p, nil
+}
+
+func TestTxnDMLProducerCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed := commonType.NewChangefeedID4Test("test", "test")
+	producers := newMockTxnProducers(ctx, t, changefeed)
+	span1 := heartbeatpb.TableSpan{TableID: 1}
+	span2 := heartbeatpb.TableSpan{TableID: 2}
+	txnProducer1, txnProducer2 := producers.get(&span1), producers.get(&span2)
+	producer := NewKafkaTxnDMLProducer(changefeed, producers.newTxnProducer)
+
+	// Commit without any message is a no-op.
+	require.NoError(t, producer.Commit(ctx))
+	require.Empty(t, producers.ids)
+
+	count := atomic.NewInt64(0)
+	for i := 0; i < 4; i++ {
+		span, txnProducer := &span1, txnProducer1
+		if i%2 == 1 {
+			span, txnProducer = &span2, txnProducer2
+		}
+		txnProducer.AsyncProducer.ExpectInputAndSucceed()
+		err := producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(i%2), &common.Message{
+			Key:       []byte("test-key"),
+			Value:     []byte("test-value"),
+			TableSpan: span,
+			Callback: func() {
+				count.Add(1)
+			},
+		})
+		require.NoError(t, err)
+	}
+	// The messages of each span are sent in the transaction of its own producer.
+	require.Equal(t, []string{
+		kafka.NewTransactionalID(changefeed, &span1),
+		kafka.NewTransactionalID(changefeed, &span2),
+	}, producers.ids)
+	// The callbacks are not called until the transactions are committed.
+	require.Equal(t, sarama.ProducerTxnFlagInTransaction, txnProducer1.AsyncProducer.TxnStatus())
+	require.Equal(t, sarama.ProducerTxnFlagInTransaction, txnProducer2.AsyncProducer.TxnStatus())
+	require.Equal(t, int64(0), count.Load())
+
+	require.NoError(t, producer.Commit(ctx))
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer1.AsyncProducer.TxnStatus())
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer2.AsyncProducer.TxnStatus())
+	require.Equal(t, int64(4), count.Load())
+
+	// The message without a table span can't be sent in a transaction.
+	err := producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+		Key: []byte("no-span"),
+	})
+	require.ErrorIs(t, err, errors.ErrKafkaTransaction)
+
+	producer.Close()
+	err = producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+		Key:       []byte("closed"),
+		TableSpan: &span1,
+	})
+	require.ErrorIs(t, err, errors.ErrKafkaProducerClosed)
+	require.ErrorIs(t, producer.Commit(ctx), errors.ErrKafkaProducerClosed)
+}
+
+func TestTxnDMLProducerSendFailed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed := commonType.NewChangefeedID4Test("test", "test")
+	producers := newMockTxnProducers(ctx, t, changefeed)
+	span := heartbeatpb.TableSpan{TableID: 1}
+	txnProducer := producers.get(&span)
+	producer := NewKafkaTxnDMLProducer(changefeed, producers.newTxnProducer)
+	defer producer.Close()
+
+	count := atomic.NewInt64(0)
+	txnProducer.AsyncProducer.ExpectInputAndSucceed()
+	txnProducer.AsyncProducer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	for i := 0; i < 2; i++ {
+		err := producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+			Key:       []byte("test-key"),
+			Value:     []byte("test-value"),
+			TableSpan: &span,
+			Callback: func() {
+				count.Add(1)
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	// The transaction is aborted and no callback is called.
+	err := producer.Commit(ctx)
+	require.ErrorIs(t, err, errors.ErrKafkaAsyncSendMessage)
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer.AsyncProducer.TxnStatus())
+	require.Equal(t, int64(0), count.Load())
+}
+
+func TestTxnDMLProducerDispatcherMoved(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed := commonType.NewChangefeedID4Test("test", "test")
+	span := heartbeatpb.TableSpan{
+		TableID:  100,
+		StartKey: []byte("t\x80\x00\x00\x00\x00\x00\x00\x64_r\x01"),
+		EndKey:   []byte("t\x80\x00\x00\x00\x00\x00\x00\x64_r\x02"),
+	}
+	send := func(producers *mockTxnProducers) {
+		producer := NewKafkaTxnDMLProducer(changefeed, producers.newTxnProducer)
+		defer producer.Close()
+		producers.get(&span).AsyncProducer.ExpectInputAndSucceed()
+		err := producer.AsyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+			Key:       []byte("test-key"),
+			Value:     []byte("test-value"),
+			TableSpan: &span,
+		})
+		require.NoError(t, err)
+		require.NoError(t, producer.Commit(ctx))
+	}
+
+	// The dispatcher of the span sends messages in the first capture,
+	// and then moves to the second capture.
+	before := newMockTxnProducers(ctx, t, changefeed)
+	send(before)
+	after := newMockTxnProducers(ctx, t, changefeed)
+	send(after)
+
+	// The transactional id is the same, so the producer in the second capture
+	// fences the one in the first capture.
+	require.Len(t, before.ids, 1)
+	require.Equal(t, before.ids, after.ids)
+
+	// The spans of the same table have different transactional ids.
+	otherSpan := span
+	otherSpan.StartKey = span.EndKey
+	otherSpan.EndKey = []byte("t\x80\x00\x00\x00\x00\x00\x00\x64_r\x03")
+	require.NotEqual(t, kafka.NewTransactionalID(changefeed, &span), kafka.NewTransactionalID(changefeed, &otherSpan))
+}
+
+func TestTxnDDLProducerSendMessage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changefeed := commonType.NewChangefeedID4Test("test", "test")
+	producers := newMockTxnProducers(ctx, t, changefeed)
+	txnProducer := producers.get(heartbeatpb.DDLSpan)
+	producer := NewKafkaTxnDDLProducer(changefeed, producers.newTxnProducer)
+	// The producer is created when the first message is sent.
+	require.Empty(t, producers.ids)
+
+	txnProducer.AsyncProducer.ExpectInputAndSucceed()
+	err := producer.SyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+		Key:   []byte("test-key"),
+		Value: []byte("test-value"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer.AsyncProducer.TxnStatus())
+
+	for i := 0; i < kafka.DefaultMockPartitionNum; i++ {
+		txnProducer.AsyncProducer.ExpectInputAndSucceed()
+	}
+	err = producer.SyncBroadcastMessage(ctx, kafka.DefaultMockTopicName, kafka.DefaultMockPartitionNum, &common.Message{
+		Key:   []byte("test-key"),
+		Value: []byte("test-value"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer.AsyncProducer.TxnStatus())
+
+	txnProducer.AsyncProducer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	err = producer.SyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+		Key:   []byte("test-key"),
+		Value: []byte("test-value"),
+	})
+	require.ErrorIs(t, err, errors.ErrKafkaSendMessage)
+	require.Equal(t, sarama.ProducerTxnFlagReady, txnProducer.AsyncProducer.TxnStatus())
+
+	producer.Close()
+	err = producer.SyncSendMessage(ctx, kafka.DefaultMockTopicName, int32(0), &common.Message{
+		Key: []byte("closed"),
+	})
+	require.ErrorIs(t, err, errors.ErrKafkaProducerClosed)
+}
@@ -43,3 +43,11 @@ type DMLProducer interface {
 	// Close closes the producer and client(s).
 	Close()
 }
+
+// TxnDMLProducer is the DMLProducer which sends the messages in transactions,
+// the callbacks of the messages are called after the transaction is committed.
+type TxnDMLProducer interface {
+	DMLProducer
+	// Commit commits the messages sent since the last commit.
+	Commit(ctx context.Context) error
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/webhook"
+	"go.uber.org/zap"
+)
+
+var (
+	_ DMLProducer = (*webhookDMLProducer)(nil)
+	_ DDLProducer = (*webhookDDLProducer)(nil)
+)
+
+// webhookDMLProducer posts the DML messages to the webhook endpoint.
+// The messages are posted in order, each request contains at most
+// max-batch-size messages, and the callbacks of the messages are
+// called after the request succeeds.
+type webhookDMLProducer struct {
+	id           commonType.ChangeFeedID
+	client       *webhook.Client
+	maxBatchSize int
+	msgCh        chan *common.Message
+
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// NewWebhookDMLProducer creates a new webhook producer for DML messages.
+func NewWebhookDMLProducer(
+	changefeedID commonType.ChangeFeedID,
+	client *webhook.Client,
+	maxBatchSize int,
+) DMLProducer {
+	return &webhookDMLProducer{
+		id:           changefeedID,
+		client:       client,
+		maxBatchSize: maxBatchSize,
+		msgCh:        make(chan *common.Message, maxBatchSize),
+	}
+}
+
+func (p *webhookDMLProducer) AsyncSendMessage(
+	ctx context.Context, _ string, _ int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+	if p.closed {
+		return cerror.ErrWebhookProducerClosed.GenWithStackByArgs()
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case p.msgCh <- message:
+	}
+	return nil
+}
+
+func (p *webhookDMLProducer) Run(ctx context.Context) error {
+	batch := make([]*common.Message, 0, p.maxBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case message := <-p.msgCh:
+			batch = append(batch, message)
+		}
+		// Collect the messages which are already sent to the producer,
+		// the messages are batched by the worker before encoding, so
+		// there is no need to wait for more messages here.
+	collect:
+		for len(batch) < p.maxBatchSize {
+			select {
+			case message := <-p.msgCh:
+				batch = append(batch, message)
+			default:
+				break collect
+			}
+		}
+
+		if err := p.client.Send(ctx, batch...); err != nil {
+			return errors.Trace(err)
+		}
+		for _, message := range batch {
+			if message.Callback != nil {
+				message.Callback()
+			}
+		}
+		clear(batch)
+		batch = batch[:0]
+	}
+}
+
+func (p *webhookDMLProducer) Close() {
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	if p.closed {
+		log.Warn("Webhook DML producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+}
+
+// webhookDDLProducer posts the DDL and checkpoint messages to the webhook endpoint.
+type webhookDDLProducer struct {
+	id     commonType.ChangeFeedID
+	client *webhook.Client
+
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// NewWebhookDDLProducer creates a new webhook producer for DDL and checkpoint messages.
+func NewWebhookDDLProducer(
+	changefeedID commonType.ChangeFeedID,
+	client *webhook.Client,
+) DDLProducer {
+	return &webhookDDLProducer{
+		id:     changefeedID,
+		client: client,
+	}
+}
+
+// SyncBroadcastMessage posts the message only once, since the topics
+// of webhook sink only have one partition.
+func (p *webhookDDLProducer) SyncBroadcastMessage(
+	ctx context.Context, _ string, _ int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, "", 0, message)
+}
+
+func (p *webhookDDLProducer) SyncSendMessage(
+	ctx context.Context, _ string, _ int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+	if p.closed {
+		return cerror.ErrWebhookProducerClosed.GenWithStackByArgs()
+	}
+	return errors.Trace(p.client.Send(ctx, message))
+}
+
+func (p *webhookDDLProducer) Close() {
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	if p.closed {
+		log.Warn("Webhook DDL producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+}
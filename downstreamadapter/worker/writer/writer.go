@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/log"
 	commonType "github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
 	"github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/utils/chann"
@@ -61,6 +62,7 @@ func NewWriter(
 	storage storage.ExternalStorage,
 	config *cloudstorage.Config,
 	extension string,
+	pdClock pdutil.Clock,
 	inputCh *chann.DrainableChann[EventFragment],
 	statistics *metrics.Statistics,
 ) *Writer {
@@ -72,7 +74,7 @@ func NewWriter(
 		inputCh:           inputCh,
 		toBeFlushedCh:     make(chan batchedTask, 64),
 		statistics:        statistics,
-		filePathGenerator: cloudstorage.NewFilePathGenerator(changefeedID, config, storage, extension),
+		filePathGenerator: cloudstorage.NewFilePathGenerator(changefeedID, config, storage, extension, pdClock),
 		metricWriteBytes: mcloudstorage.CloudStorageWriteBytesGauge.
 			WithLabelValues(changefeedID.Namespace(), changefeedID.ID().String()),
 		metricFileCount: mcloudstorage.CloudStorageFileCountGauge.
@@ -185,9 +185,10 @@ func (m *EventFilterRule) GetIgnoreDeleteValueExpr() string {
 }
 
 type InnerFilterConfig struct {
-	Rules            []string           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
-	IgnoreTxnStartTs []uint64           `protobuf:"varint,2,rep,packed,name=ignore_txn_start_ts,json=ignoreTxnStartTs,proto3" json:"ignore_txn_start_ts,omitempty"`
-	EventFilters     []*EventFilterRule `protobuf:"bytes,3,rep,name=EventFilters,proto3" json:"EventFilters,omitempty"`
+	Rules             []string           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	IgnoreTxnStartTs  []uint64           `protobuf:"varint,2,rep,packed,name=ignore_txn_start_ts,json=ignoreTxnStartTs,proto3" json:"ignore_txn_start_ts,omitempty"`
+	EventFilters      []*EventFilterRule `protobuf:"bytes,3,rep,name=EventFilters,proto3" json:"EventFilters,omitempty"`
+	ForwardDdlClasses []string           `protobuf:"bytes,4,rep,name=forward_ddl_classes,json=forwardDdlClasses,proto3" json:"forward_ddl_classes,omitempty"`
 }
 
 func (m *InnerFilterConfig) Reset()         { *m = InnerFilterConfig{} }
@@ -244,6 +245,13 @@ func (m *InnerFilterConfig) GetEventFilters() []*EventFilterRule {
 	return nil
 }
 
+func (m *InnerFilterConfig) GetForwardDdlClasses() []string {
+	if m != nil {
+		return m.ForwardDdlClasses
+	}
+	return nil
+}
+
 type FilterConfig struct {
 	CaseSensitive  bool               `protobuf:"varint,1,opt,name=caseSensitive,proto3" json:"caseSensitive,omitempty"`
 	ForceReplicate bool               `protobuf:"varint,2,opt,name=forceReplicate,proto3" json:"forceReplicate,omitempty"`
@@ -304,6 +312,120 @@ func (m *FilterConfig) GetFilterConfig() *InnerFilterConfig {
 	return nil
 }
 
+type ColumnSelector struct {
+	Matcher []string `protobuf:"bytes,1,rep,name=matcher,proto3" json:"matcher,omitempty"`
+	Columns []string `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (m *ColumnSelector) Reset()         { *m = ColumnSelector{} }
+func (m *ColumnSelector) String() string { return proto.CompactTextString(m) }
+func (*ColumnSelector) ProtoMessage()    {}
+func (*ColumnSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{3}
+}
+func (m *ColumnSelector) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ColumnSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ColumnSelector.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ColumnSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnSelector.Merge(m, src)
+}
+func (m *ColumnSelector) XXX_Size() int {
+	return m.Size()
+}
+func (m *ColumnSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnSelector proto.InternalMessageInfo
+
+func (m *ColumnSelector) GetMatcher() []string {
+	if m != nil {
+		return m.Matcher
+	}
+	return nil
+}
+
+func (m *ColumnSelector) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+// ColumnProjection decides which columns of a row are decoded by the event service.
+type ColumnProjection struct {
+	CaseSensitive   bool              `protobuf:"varint,1,opt,name=caseSensitive,proto3" json:"caseSensitive,omitempty"`
+	ColumnSelectors []*ColumnSelector `protobuf:"bytes,2,rep,name=columnSelectors,proto3" json:"columnSelectors,omitempty"`
+	// deleteOnlyHandleKeyColumns means only the handle key columns of the delete event are needed.
+	DeleteOnlyHandleKeyColumns bool `protobuf:"varint,3,opt,name=deleteOnlyHandleKeyColumns,proto3" json:"deleteOnlyHandleKeyColumns,omitempty"`
+}
+
+func (m *ColumnProjection) Reset()         { *m = ColumnProjection{} }
+func (m *ColumnProjection) String() string { return proto.CompactTextString(m) }
+func (*ColumnProjection) ProtoMessage()    {}
+func (*ColumnProjection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{4}
+}
+func (m *ColumnProjection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ColumnProjection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ColumnProjection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ColumnProjection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnProjection.Merge(m, src)
+}
+func (m *ColumnProjection) XXX_Size() int {
+	return m.Size()
+}
+func (m *ColumnProjection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnProjection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnProjection proto.InternalMessageInfo
+
+func (m *ColumnProjection) GetCaseSensitive() bool {
+	if m != nil {
+		return m.CaseSensitive
+	}
+	return false
+}
+
+func (m *ColumnProjection) GetColumnSelectors() []*ColumnSelector {
+	if m != nil {
+		return m.ColumnSelectors
+	}
+	return nil
+}
+
+func (m *ColumnProjection) GetDeleteOnlyHandleKeyColumns() bool {
+	if m != nil {
+		return m.DeleteOnlyHandleKeyColumns
+	}
+	return false
+}
+
 type ResolvedTs struct {
 }
 
@@ -311,7 +433,7 @@ func (m *ResolvedTs) Reset()         { *m = ResolvedTs{} }
 func (m *ResolvedTs) String() string { return proto.CompactTextString(m) }
 func (*ResolvedTs) ProtoMessage()    {}
 func (*ResolvedTs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{3}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{5}
 }
 func (m *ResolvedTs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -353,7 +475,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{4}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{6}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -420,7 +542,7 @@ func (m *TxnEvent) Reset()         { *m = TxnEvent{} }
 func (m *TxnEvent) String() string { return proto.CompactTextString(m) }
 func (*TxnEvent) ProtoMessage()    {}
 func (*TxnEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{5}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{7}
 }
 func (m *TxnEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -477,7 +599,7 @@ func (m *TableInfo) Reset()         { *m = TableInfo{} }
 func (m *TableInfo) String() string { return proto.CompactTextString(m) }
 func (*TableInfo) ProtoMessage()    {}
 func (*TableInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{6}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{8}
 }
 func (m *TableInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -518,7 +640,7 @@ func (m *EventFeed) Reset()         { *m = EventFeed{} }
 func (m *EventFeed) String() string { return proto.CompactTextString(m) }
 func (*EventFeed) ProtoMessage()    {}
 func (*EventFeed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{7}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{9}
 }
 func (m *EventFeed) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -594,13 +716,29 @@ type RegisterDispatcherRequest struct {
 	SyncPointTs       uint64                    `protobuf:"varint,9,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,10,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
 	OnlyReuse         bool                      `protobuf:"varint,11,opt,name=only_reuse,json=onlyReuse,proto3" json:"only_reuse,omitempty"`
+	// priority is the priority class of the changefeed, e.g. high, normal or batch.
+	Priority string `protobuf:"bytes,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	// column_projection is nil if all the columns are needed by the dispatcher.
+	ColumnProjection *ColumnProjection `protobuf:"bytes,13,opt,name=column_projection,json=columnProjection,proto3" json:"column_projection,omitempty"`
+	// initial_snapshot is true if the dispatcher needs a snapshot of the table at start_ts
+	// before the incremental events.
+	InitialSnapshot bool `protobuf:"varint,14,opt,name=initial_snapshot,json=initialSnapshot,proto3" json:"initial_snapshot,omitempty"`
+	// target_ts is the target ts of the changefeed, no event after it is sent to the dispatcher.
+	// 0 means the changefeed has no target ts.
+	TargetTs uint64 `protobuf:"varint,15,opt,name=target_ts,json=targetTs,proto3" json:"target_ts,omitempty"`
+	// replication_delay is the milliseconds the events are delayed before they are sent to the dispatcher,
+	// 0 means the events are sent as soon as possible.
+	ReplicationDelay uint64 `protobuf:"varint,16,opt,name=replication_delay,json=replicationDelay,proto3" json:"replication_delay,omitempty"`
+	// upstream_id is the ID of the TiDB cluster the dispatcher replicates from,
+	// 0 means the default upstream of the server.
+	UpstreamId uint64 `protobuf:"varint,17,opt,name=upstream_id,json=upstreamId,proto3" json:"upstream_id,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
 func (m *RegisterDispatcherRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterDispatcherRequest) ProtoMessage()    {}
 func (*RegisterDispatcherRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d7fb2554dfcf7f7d, []int{8}
+	return fileDescriptor_d7fb2554dfcf7f7d, []int{10}
 }
 func (m *RegisterDispatcherRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -706,12 +844,56 @@ func (m *RegisterDispatcherRequest) GetOnlyReuse() bool {
 	return false
 }
 
+func (m *RegisterDispatcherRequest) GetPriority() string {
+	if m != nil {
+		return m.Priority
+	}
+	return ""
+}
+
+func (m *RegisterDispatcherRequest) GetColumnProjection() *ColumnProjection {
+	if m != nil {
+		return m.ColumnProjection
+	}
+	return nil
+}
+
+func (m *RegisterDispatcherRequest) GetInitialSnapshot() bool {
+	if m != nil {
+		return m.InitialSnapshot
+	}
+	return false
+}
+
+func (m *RegisterDispatcherRequest) GetTargetTs() uint64 {
+	if m != nil {
+		return m.TargetTs
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetReplicationDelay() uint64 {
+	if m != nil {
+		return m.ReplicationDelay
+	}
+	return 0
+}
+
+func (m *RegisterDispatcherRequest) GetUpstreamId() uint64 {
+	if m != nil {
+		return m.UpstreamId
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
 	proto.RegisterType((*EventFilterRule)(nil), "eventpb.EventFilterRule")
 	proto.RegisterType((*InnerFilterConfig)(nil), "eventpb.InnerFilterConfig")
 	proto.RegisterType((*FilterConfig)(nil), "eventpb.FilterConfig")
+	proto.RegisterType((*ColumnSelector)(nil), "eventpb.ColumnSelector")
+	proto.RegisterType((*ColumnProjection)(nil), "eventpb.ColumnProjection")
 	proto.RegisterType((*ResolvedTs)(nil), "eventpb.ResolvedTs")
 	proto.RegisterType((*Event)(nil), "eventpb.Event")
 	proto.RegisterType((*TxnEvent)(nil), "eventpb.TxnEvent")
@@ -723,71 +905,85 @@ func init() {
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 1021 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
-	0x17, 0xae, 0x93, 0x34, 0x1f, 0x27, 0xe9, 0xae, 0x3b, 0xdd, 0xee, 0xeb, 0xb6, 0xbb, 0x79, 0xb3,
-	0x11, 0x5a, 0x85, 0x4a, 0xa4, 0x50, 0x40, 0x48, 0x2b, 0x54, 0xa9, 0xb4, 0xee, 0xe2, 0x8b, 0x6d,
-	0xab, 0x89, 0xbb, 0x12, 0xdc, 0x58, 0xae, 0x7d, 0x92, 0x1a, 0xdc, 0xb1, 0xeb, 0x99, 0x64, 0x9b,
-	0x7f, 0xc1, 0x15, 0x37, 0xf0, 0x83, 0xb8, 0xdc, 0x4b, 0xee, 0x40, 0xad, 0x04, 0x7f, 0x03, 0x79,
-	0xc6, 0x71, 0x9c, 0x06, 0x90, 0xb8, 0xca, 0xcc, 0x79, 0x9e, 0x33, 0xe7, 0x39, 0x5f, 0x0e, 0x6c,
-	0xe0, 0x04, 0x99, 0x88, 0x2f, 0xf7, 0xe4, 0x6f, 0x3f, 0x4e, 0x22, 0x11, 0x91, 0x5a, 0x66, 0xdc,
-	0xde, 0xb9, 0x42, 0x37, 0x11, 0x97, 0xe8, 0xa6, 0x8c, 0xfc, 0xac, 0x58, 0xdd, 0xdf, 0x4a, 0xf0,
-	0xd8, 0x4c, 0x89, 0x27, 0x41, 0x28, 0x30, 0xa1, 0xe3, 0x10, 0x89, 0x01, 0xb5, 0x6b, 0x57, 0x78,
-	0x57, 0x98, 0x18, 0x5a, 0xa7, 0xdc, 0x6b, 0xd0, 0xd9, 0x95, 0xbc, 0x80, 0x56, 0x30, 0x62, 0x51,
-	0x82, 0x8e, 0x7c, 0xdc, 0x28, 0x49, 0xb8, 0xa9, 0x6c, 0xf2, 0x19, 0xf2, 0x1c, 0x20, 0xa3, 0xf0,
-	0x9b, 0xd0, 0x28, 0x4b, 0x42, 0x43, 0x59, 0x06, 0x37, 0x21, 0xf9, 0x02, 0x8c, 0x0c, 0x0e, 0x18,
-	0xc7, 0x44, 0x38, 0x13, 0x37, 0x1c, 0xa3, 0x83, 0xb7, 0x71, 0x62, 0x54, 0x3a, 0x5a, 0xaf, 0x41,
-	0x37, 0x15, 0x6e, 0x49, 0xf8, 0x6d, 0x8a, 0x9a, 0xb7, 0x71, 0x42, 0x0e, 0xe0, 0x59, 0xe6, 0x38,
-	0x8e, 0x7d, 0x57, 0xa0, 0xc3, 0xf0, 0x5d, 0xd1, 0x79, 0x55, 0x3a, 0x67, 0x8f, 0x5f, 0x48, 0xca,
-	0x29, 0xbe, 0xfb, 0x17, 0xff, 0x28, 0xf4, 0x8b, 0xfe, 0xd5, 0x65, 0xff, 0xb3, 0xd0, 0x9f, 0xfb,
-	0xcf, 0x85, 0xfb, 0x18, 0xa2, 0xc0, 0xa2, 0x6f, 0xad, 0x28, 0xfc, 0x58, 0xc2, 0xb9, 0x63, 0xf7,
-	0x47, 0x0d, 0xd6, 0x2d, 0xc6, 0x30, 0x51, 0x15, 0x3e, 0x8a, 0xd8, 0x30, 0x18, 0x91, 0x27, 0xb0,
-	0x9a, 0x8c, 0x43, 0xe4, 0x59, 0x85, 0xd5, 0x85, 0x7c, 0x04, 0x1b, 0x59, 0x10, 0x71, 0xcb, 0x1c,
-	0x2e, 0xdc, 0x44, 0x38, 0x82, 0xcb, 0x32, 0x57, 0xa8, 0xae, 0x20, 0xfb, 0x96, 0x0d, 0x52, 0xc0,
-	0xe6, 0xe4, 0x4b, 0x68, 0x15, 0x7a, 0xc7, 0x65, 0xb5, 0x9b, 0xfb, 0x46, 0x3f, 0xeb, 0x7c, 0xff,
-	0x41, 0x63, 0xe9, 0x02, 0xbb, 0xfb, 0xb3, 0x06, 0xad, 0x05, 0x4d, 0x1f, 0xc0, 0x9a, 0xe7, 0x72,
-	0x1c, 0x20, 0xe3, 0x81, 0x08, 0x26, 0x68, 0x68, 0x1d, 0xad, 0x57, 0xa7, 0x8b, 0x46, 0xf2, 0x12,
-	0x1e, 0x0d, 0xa3, 0xc4, 0x43, 0x8a, 0x71, 0x18, 0x78, 0xae, 0x40, 0xa3, 0x24, 0x69, 0x0f, 0xac,
-	0xe4, 0x00, 0x5a, 0xc3, 0xc2, 0xeb, 0x46, 0xb9, 0xa3, 0xf5, 0x9a, 0xfb, 0xdb, 0xb9, 0xb8, 0xa5,
-	0x9a, 0xd0, 0x05, 0x7e, 0xb7, 0x05, 0x40, 0x91, 0x47, 0xe1, 0x04, 0x7d, 0x9b, 0x77, 0xc7, 0xb0,
-	0xaa, 0xe6, 0x4b, 0x87, 0xf2, 0xf7, 0x38, 0x95, 0xd2, 0x5a, 0x34, 0x3d, 0xa6, 0xa5, 0x94, 0xbd,
-	0x90, 0x3a, 0x5a, 0x54, 0x5d, 0xc8, 0x36, 0xd4, 0x67, 0xfd, 0x93, 0xa1, 0x5b, 0x34, 0xbf, 0x93,
-	0x1e, 0xd4, 0xa2, 0xd8, 0x11, 0xd3, 0x18, 0xe5, 0xcc, 0x3d, 0xda, 0x7f, 0x9c, 0xab, 0x3a, 0x8b,
-	0xed, 0x69, 0x8c, 0xb4, 0x1a, 0xc9, 0xdf, 0xee, 0x77, 0x50, 0xb7, 0x6f, 0x99, 0x8a, 0xfc, 0x12,
-	0xaa, 0x92, 0xa5, 0x7a, 0xd6, 0xdc, 0x7f, 0xb4, 0x58, 0x67, 0x9a, 0xa1, 0x64, 0x07, 0x1a, 0x5e,
-	0x74, 0x7d, 0x1d, 0x64, 0xad, 0xd3, 0x7a, 0x15, 0x5a, 0x57, 0x06, 0x9b, 0x93, 0x2d, 0xa8, 0xe7,
-	0x6d, 0x2d, 0x4b, 0xac, 0xc6, 0x55, 0x37, 0xbb, 0x4d, 0x68, 0xd8, 0xee, 0x65, 0x88, 0x16, 0x1b,
-	0x46, 0xdd, 0x3f, 0x35, 0x68, 0xa8, 0x6e, 0x21, 0xfa, 0xe4, 0x63, 0x80, 0x74, 0x20, 0x16, 0xc2,
-	0xaf, 0xe7, 0xe1, 0x67, 0x0a, 0x69, 0x43, 0x64, 0x27, 0x4e, 0xfe, 0x0f, 0xcd, 0x24, 0xab, 0xde,
-	0x5c, 0x06, 0x24, 0x79, 0x41, 0xc9, 0x01, 0xac, 0xf9, 0x01, 0x8f, 0xd5, 0x62, 0x3b, 0x81, 0x9f,
-	0xf5, 0x67, 0xab, 0x5f, 0xf8, 0x5a, 0xf4, 0x8f, 0x73, 0x86, 0x75, 0x4c, 0x5b, 0x73, 0xbe, 0xe5,
-	0xcb, 0x01, 0x76, 0x45, 0x10, 0xc9, 0x0a, 0x96, 0xa8, 0xba, 0x90, 0x4f, 0x00, 0x44, 0x9a, 0x83,
-	0x13, 0xb0, 0x61, 0x24, 0x77, 0xb2, 0xb9, 0x4f, 0xe6, 0x42, 0x67, 0xe9, 0xd1, 0x86, 0xc8, 0x33,
-	0xfd, 0xa9, 0x02, 0x5b, 0x14, 0x47, 0x01, 0x17, 0x98, 0xcc, 0xe3, 0x51, 0xbc, 0x19, 0x23, 0x17,
-	0xa9, 0x4c, 0xef, 0xca, 0x65, 0x23, 0x1c, 0x22, 0xfa, 0xa9, 0x4c, 0xed, 0x6f, 0x64, 0x1e, 0xe5,
-	0x8c, 0x54, 0xe6, 0x9c, 0x6f, 0xf9, 0xcb, 0x69, 0x96, 0xfe, 0x5b, 0x9a, 0x9f, 0xcf, 0x12, 0xe2,
-	0xb1, 0xcb, 0xb2, 0x1a, 0x3d, 0x5d, 0x70, 0x96, 0x49, 0x0d, 0x62, 0x97, 0x65, 0x49, 0xa5, 0xc7,
-	0x85, 0x36, 0x57, 0x16, 0xda, 0x9c, 0x8e, 0x07, 0xc7, 0x64, 0xa2, 0xd4, 0xa8, 0xaf, 0x56, 0x5d,
-	0x19, 0x2c, 0x9f, 0x7c, 0x06, 0x4d, 0xd7, 0x13, 0x41, 0xc4, 0xd4, 0x74, 0x56, 0xe5, 0x74, 0x6e,
-	0xe4, 0x05, 0x3c, 0x94, 0x98, 0x9c, 0x50, 0x70, 0xf3, 0x33, 0x79, 0x05, 0x6b, 0x6a, 0x75, 0x1c,
-	0x4f, 0xed, 0x5a, 0x4d, 0xea, 0xdc, 0xcc, 0xfd, 0xfe, 0x79, 0xcd, 0xc8, 0x2e, 0xac, 0x23, 0x53,
-	0x19, 0x4e, 0x99, 0xe7, 0xc4, 0x51, 0xc0, 0x84, 0x51, 0x97, 0x1b, 0xfd, 0x58, 0x01, 0x83, 0x29,
-	0xf3, 0xce, 0x53, 0x33, 0xe9, 0xc2, 0xda, 0x9c, 0x94, 0xa6, 0xd6, 0x90, 0xa9, 0x35, 0xf9, 0x8c,
-	0x61, 0x73, 0xd2, 0x87, 0x8d, 0x02, 0x27, 0x60, 0x02, 0x93, 0x89, 0x1b, 0x1a, 0x20, 0x99, 0xeb,
-	0x39, 0xd3, 0xca, 0x80, 0xf4, 0xff, 0x22, 0x62, 0xe1, 0xd4, 0x49, 0x70, 0xcc, 0xd1, 0x68, 0xca,
-	0xc0, 0x8d, 0xd4, 0x42, 0x53, 0xc3, 0xee, 0x87, 0x50, 0x55, 0x2b, 0x49, 0xd6, 0xa0, 0xa1, 0x4e,
-	0xe7, 0x63, 0xa1, 0xaf, 0x10, 0x1d, 0x5a, 0xea, 0xaa, 0xbe, 0xb7, 0xba, 0xb6, 0xfb, 0x87, 0x06,
-	0x30, 0x2f, 0x10, 0xd9, 0x81, 0xff, 0x1d, 0x1e, 0xd9, 0xd6, 0xd9, 0xa9, 0x63, 0x7f, 0x73, 0x6e,
-	0x3a, 0x17, 0xa7, 0x83, 0x73, 0xf3, 0xc8, 0x3a, 0xb1, 0xcc, 0x63, 0x7d, 0x85, 0x18, 0xf0, 0xa4,
-	0x08, 0x52, 0xf3, 0xb5, 0x35, 0xb0, 0x4d, 0xaa, 0x6b, 0xe4, 0x29, 0x90, 0x45, 0xe4, 0xcd, 0xd9,
-	0x5b, 0x53, 0x2f, 0x91, 0x4d, 0x58, 0x2f, 0xda, 0xcf, 0x0f, 0x2f, 0x06, 0xa6, 0x5e, 0x5e, 0xa6,
-	0x0f, 0x2e, 0xde, 0x98, 0x7a, 0xe5, 0x21, 0x9d, 0x9a, 0x03, 0xd3, 0xd6, 0x57, 0x49, 0x07, 0x9e,
-	0x2d, 0xbd, 0xe2, 0x1c, 0x7d, 0x7d, 0x78, 0xfa, 0xda, 0x3c, 0x31, 0xcd, 0x63, 0xbd, 0x4a, 0x5e,
-	0xc0, 0xf3, 0xe5, 0x07, 0x8b, 0x94, 0xda, 0x57, 0xaf, 0x7e, 0xb9, 0x6b, 0x6b, 0xef, 0xef, 0xda,
-	0xda, 0xef, 0x77, 0x6d, 0xed, 0x87, 0xfb, 0xf6, 0xca, 0xfb, 0xfb, 0xf6, 0xca, 0xaf, 0xf7, 0xed,
-	0x95, 0x6f, 0x3b, 0xa3, 0x40, 0x5c, 0x8d, 0x2f, 0xfb, 0x5e, 0x74, 0xbd, 0x17, 0x07, 0x6c, 0xe4,
-	0xb9, 0xf1, 0x9e, 0x08, 0x3c, 0xdf, 0xdb, 0xcb, 0x26, 0xe1, 0xb2, 0x2a, 0xff, 0xf6, 0x3f, 0xfd,
-	0x2b, 0x00, 0x00, 0xff, 0xff, 0x40, 0x60, 0xc1, 0x74, 0x33, 0x08, 0x00, 0x00,
+	// 1241 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0x2d, 0x5b, 0x3f, 0x23, 0xd9, 0xa6, 0xd6, 0xf9, 0x61, 0x9c, 0xc4, 0x75, 0x84, 0x22,
+	0x70, 0x52, 0x54, 0x6e, 0xdd, 0x16, 0x05, 0x82, 0xc2, 0x80, 0x2b, 0xd1, 0x09, 0x51, 0xc4, 0x36,
+	0x56, 0x74, 0x80, 0xf6, 0x42, 0xd0, 0xe4, 0x48, 0x66, 0x4a, 0x2f, 0x99, 0xdd, 0x95, 0x63, 0xbd,
+	0x45, 0x2f, 0xbd, 0xf5, 0x4d, 0x7a, 0x2f, 0x7a, 0xcc, 0xb1, 0xb7, 0x16, 0x09, 0xd0, 0xbe, 0x46,
+	0xc1, 0x5d, 0x8a, 0x12, 0xed, 0x36, 0x68, 0x4f, 0xda, 0x9d, 0xef, 0x9b, 0xdd, 0x99, 0xd9, 0x6f,
+	0x86, 0x82, 0x75, 0xbc, 0x40, 0x26, 0xd3, 0xd3, 0x1d, 0xf5, 0xdb, 0x4d, 0x79, 0x22, 0x13, 0x52,
+	0xcb, 0x8d, 0x1b, 0x77, 0xcf, 0xd0, 0xe7, 0xf2, 0x14, 0xfd, 0x8c, 0x51, 0xac, 0x35, 0xab, 0xf3,
+	0xfb, 0x22, 0xac, 0xd9, 0x19, 0xf1, 0x20, 0x8a, 0x25, 0x72, 0x3a, 0x8e, 0x91, 0x58, 0x50, 0x3b,
+	0xf7, 0x65, 0x70, 0x86, 0xdc, 0x32, 0xb6, 0x2a, 0xdb, 0x0d, 0x3a, 0xdd, 0x92, 0x07, 0xd0, 0x8a,
+	0x46, 0x2c, 0xe1, 0xe8, 0xa9, 0xc3, 0xad, 0x45, 0x05, 0x37, 0xb5, 0x4d, 0x1d, 0x43, 0xee, 0x03,
+	0xe4, 0x14, 0xf1, 0x2a, 0xb6, 0x2a, 0x8a, 0xd0, 0xd0, 0x96, 0xc1, 0xab, 0x98, 0x7c, 0x09, 0x56,
+	0x0e, 0x47, 0x4c, 0x20, 0x97, 0xde, 0x85, 0x1f, 0x8f, 0xd1, 0xc3, 0xcb, 0x94, 0x5b, 0x4b, 0x5b,
+	0xc6, 0x76, 0x83, 0xde, 0xd4, 0xb8, 0xa3, 0xe0, 0x17, 0x19, 0x6a, 0x5f, 0xa6, 0x9c, 0xec, 0xc1,
+	0xbd, 0xdc, 0x71, 0x9c, 0x86, 0xbe, 0x44, 0x8f, 0xe1, 0xeb, 0x79, 0xe7, 0x65, 0xe5, 0x9c, 0x1f,
+	0x7e, 0xa2, 0x28, 0x87, 0xf8, 0xfa, 0x3d, 0xfe, 0x49, 0x1c, 0xce, 0xfb, 0x57, 0xaf, 0xfb, 0x1f,
+	0xc5, 0xe1, 0xcc, 0x7f, 0x16, 0x78, 0x88, 0x31, 0x4a, 0x9c, 0xf7, 0xad, 0xcd, 0x07, 0xde, 0x57,
+	0x70, 0xe1, 0xd8, 0xf9, 0xc5, 0x80, 0xb6, 0xc3, 0x18, 0x72, 0x5d, 0xe1, 0x5e, 0xc2, 0x86, 0xd1,
+	0x88, 0xdc, 0x80, 0x65, 0x3e, 0x8e, 0x51, 0xe4, 0x15, 0xd6, 0x1b, 0xf2, 0x31, 0xac, 0xe7, 0x97,
+	0xc8, 0x4b, 0xe6, 0x09, 0xe9, 0x73, 0xe9, 0x49, 0xa1, 0xca, 0xbc, 0x44, 0x4d, 0x0d, 0xb9, 0x97,
+	0x6c, 0x90, 0x01, 0xae, 0x20, 0x5f, 0x41, 0x6b, 0xee, 0xed, 0x84, 0xaa, 0x76, 0x73, 0xd7, 0xea,
+	0xe6, 0x2f, 0xdf, 0xbd, 0xf2, 0xb0, 0xb4, 0xc4, 0x26, 0x5d, 0x58, 0x1f, 0x26, 0xfc, 0xb5, 0xcf,
+	0x43, 0x2f, 0x0c, 0x63, 0x2f, 0x88, 0x7d, 0x21, 0x50, 0x58, 0x4b, 0x2a, 0xa0, 0x76, 0x0e, 0xf5,
+	0xc3, 0xb8, 0xa7, 0x81, 0xce, 0x4f, 0x06, 0xb4, 0x4a, 0x39, 0x7c, 0x08, 0x2b, 0x81, 0x2f, 0x70,
+	0x80, 0x4c, 0x44, 0x32, 0xba, 0x40, 0xcb, 0xd8, 0x32, 0xb6, 0xeb, 0xb4, 0x6c, 0x24, 0x0f, 0x61,
+	0x75, 0x98, 0xf0, 0x00, 0x29, 0xa6, 0x71, 0x14, 0xf8, 0x12, 0xad, 0x45, 0x45, 0xbb, 0x62, 0x25,
+	0x7b, 0xd0, 0x1a, 0xce, 0x9d, 0x6e, 0x55, 0xb6, 0x8c, 0xed, 0xe6, 0xee, 0x46, 0x91, 0xcc, 0xb5,
+	0x1a, 0xd2, 0x12, 0xbf, 0xd3, 0x87, 0xd5, 0x5e, 0x12, 0x8f, 0xcf, 0xd9, 0x00, 0x63, 0x0c, 0x64,
+	0xc2, 0xdf, 0xa3, 0x63, 0x0b, 0x6a, 0x81, 0xe2, 0x8a, 0x5c, 0xc2, 0xd3, 0x6d, 0xe7, 0x67, 0x03,
+	0x4c, 0x7d, 0xcc, 0x31, 0x4f, 0x5e, 0x62, 0x20, 0xa3, 0x84, 0xfd, 0xc7, 0x44, 0xf7, 0x61, 0x2d,
+	0x28, 0x05, 0xa0, 0x0f, 0x6f, 0xee, 0xde, 0x2e, 0x72, 0x28, 0x07, 0x48, 0xaf, 0xf2, 0xc9, 0x1e,
+	0x6c, 0x68, 0x75, 0x1d, 0xb1, 0x78, 0xf2, 0xcc, 0x67, 0x61, 0x8c, 0xdf, 0xe0, 0xa4, 0x97, 0x87,
+	0x5a, 0x51, 0xb7, 0xbe, 0x87, 0xd1, 0x69, 0x01, 0x50, 0x14, 0x49, 0x7c, 0x81, 0xa1, 0x2b, 0x3a,
+	0x63, 0x58, 0xd6, 0x3d, 0x69, 0x42, 0xe5, 0x7b, 0x9c, 0xa8, 0xa8, 0x5b, 0x34, 0x5b, 0x66, 0xf2,
+	0x53, 0xfa, 0x55, 0x6f, 0xd1, 0xa2, 0x7a, 0x43, 0x36, 0xa0, 0x3e, 0xd5, 0xbc, 0xba, 0xac, 0x45,
+	0x8b, 0x3d, 0xd9, 0x86, 0x5a, 0x92, 0x7a, 0x72, 0x92, 0xa2, 0xea, 0xd3, 0xd5, 0xdd, 0xb5, 0x22,
+	0xab, 0xa3, 0xd4, 0x9d, 0xa4, 0x48, 0xab, 0x89, 0xfa, 0xed, 0xbc, 0x84, 0xba, 0x7b, 0xc9, 0xf4,
+	0xcd, 0x0f, 0xa1, 0xaa, 0x58, 0x5a, 0xe7, 0xcd, 0xdd, 0xd5, 0xb2, 0x36, 0x69, 0x8e, 0x92, 0xbb,
+	0xd0, 0x08, 0x92, 0xf3, 0xf3, 0x28, 0x97, 0xbb, 0xb1, 0xbd, 0x44, 0xeb, 0xda, 0xe0, 0x0a, 0x72,
+	0x07, 0xea, 0x45, 0x2b, 0x54, 0x14, 0x56, 0x13, 0xba, 0x03, 0x3a, 0x4d, 0x68, 0xb8, 0xfe, 0x69,
+	0x8c, 0x0e, 0x1b, 0x26, 0x9d, 0xbf, 0x0c, 0x68, 0x68, 0x85, 0x23, 0x86, 0xe4, 0x13, 0x80, 0xac,
+	0x89, 0x4a, 0xd7, 0xb7, 0x8b, 0xeb, 0xa7, 0x11, 0xd2, 0x86, 0xcc, 0x57, 0x82, 0x7c, 0x00, 0x4d,
+	0x9e, 0x57, 0x6f, 0x16, 0x06, 0xf0, 0xa2, 0xa0, 0x64, 0x0f, 0x56, 0xc2, 0x48, 0xa4, 0x5a, 0x44,
+	0x5e, 0x14, 0xe6, 0x1a, 0xbd, 0xd3, 0x9d, 0x9b, 0xb0, 0xdd, 0x7e, 0xc1, 0x70, 0xfa, 0xb4, 0x35,
+	0xe3, 0x3b, 0xa1, 0x6a, 0x7a, 0x5f, 0x46, 0x89, 0xaa, 0xe0, 0x22, 0xd5, 0x1b, 0xf2, 0x29, 0x80,
+	0xcc, 0x72, 0xf0, 0x22, 0x36, 0x4c, 0xd4, 0x1c, 0x6b, 0xee, 0x92, 0x59, 0xa0, 0xd3, 0xf4, 0x68,
+	0x43, 0x16, 0x99, 0xfe, 0x58, 0x85, 0x3b, 0x14, 0x47, 0x91, 0x90, 0xc8, 0x67, 0xf7, 0x51, 0x7c,
+	0x35, 0x46, 0x21, 0xb3, 0x30, 0x83, 0x33, 0x9f, 0x8d, 0x70, 0x88, 0x18, 0x66, 0x61, 0x1a, 0xff,
+	0x10, 0x66, 0xaf, 0x60, 0x64, 0x61, 0xce, 0xf8, 0x4e, 0x78, 0x3d, 0xcd, 0xc5, 0xff, 0x97, 0xe6,
+	0x17, 0xd3, 0x84, 0x44, 0xea, 0xb3, 0xbc, 0x46, 0xb7, 0x4a, 0xce, 0x2a, 0xa9, 0x41, 0xea, 0xb3,
+	0x3c, 0xa9, 0x6c, 0x59, 0x7a, 0xe6, 0xa5, 0xd2, 0x33, 0x67, 0xf2, 0x10, 0xc8, 0x2f, 0x74, 0x34,
+	0x7a, 0xd2, 0xd7, 0xb5, 0xc1, 0x09, 0xc9, 0xe7, 0xd0, 0xf4, 0x55, 0x9f, 0x6a, 0x75, 0x56, 0x95,
+	0x3a, 0xd7, 0x8b, 0x02, 0xee, 0x2b, 0x4c, 0x29, 0x14, 0xfc, 0x62, 0x4d, 0x9e, 0xc0, 0x8a, 0x1e,
+	0x1f, 0x5e, 0xa0, 0xe7, 0x4d, 0x4d, 0xc5, 0x79, 0xb3, 0xf0, 0xfb, 0xf7, 0x51, 0x43, 0x1e, 0x43,
+	0x1b, 0x99, 0xce, 0x70, 0xc2, 0x02, 0x2f, 0x4d, 0x22, 0x26, 0xad, 0xba, 0xea, 0xce, 0x35, 0x0d,
+	0x0c, 0x26, 0x2c, 0x38, 0xce, 0xcc, 0xa4, 0x03, 0x2b, 0x33, 0x52, 0x96, 0x5a, 0x43, 0xa5, 0xd6,
+	0x14, 0x53, 0x86, 0xab, 0x26, 0xf1, 0x1c, 0x27, 0x62, 0x12, 0xf9, 0x85, 0x1f, 0x5b, 0xa0, 0x98,
+	0xed, 0x82, 0xe9, 0xe4, 0x40, 0xf6, 0x8d, 0x4d, 0x58, 0x3c, 0xf1, 0x38, 0x8e, 0x05, 0x5a, 0x4d,
+	0x75, 0x71, 0x23, 0xb3, 0xd0, 0xcc, 0x90, 0xb5, 0x71, 0xca, 0xa3, 0x84, 0x47, 0x72, 0x62, 0xb5,
+	0x74, 0xb1, 0xa6, 0x7b, 0x72, 0x00, 0x6d, 0x3d, 0x74, 0xbc, 0xb4, 0x98, 0x6f, 0xd6, 0x4a, 0xfe,
+	0xbe, 0xe5, 0x31, 0x35, 0x1b, 0x80, 0xd4, 0x0c, 0xae, 0x58, 0xc8, 0x23, 0x30, 0x23, 0x16, 0xc9,
+	0xc8, 0x8f, 0x3d, 0xc1, 0xfc, 0x54, 0x9c, 0x25, 0xd2, 0x5a, 0xd5, 0x15, 0xc8, 0xed, 0x83, 0xdc,
+	0x9c, 0x3d, 0x9e, 0xf4, 0xf9, 0x08, 0x55, 0xf6, 0x6b, 0xba, 0xb7, 0xb5, 0xc1, 0x15, 0xe4, 0x23,
+	0x68, 0xf3, 0xfc, 0x13, 0x90, 0xbd, 0x60, 0x88, 0xb1, 0x3f, 0xb1, 0x4c, 0x45, 0x32, 0xe7, 0x80,
+	0x7e, 0x66, 0xcf, 0x1a, 0x74, 0x9c, 0x0a, 0xc9, 0xd1, 0x3f, 0xcf, 0x84, 0xd0, 0xd6, 0x0d, 0x3a,
+	0x35, 0x39, 0xe1, 0xe3, 0x47, 0x50, 0xd5, 0xc3, 0x88, 0xac, 0x40, 0x43, 0xaf, 0x8e, 0xc7, 0xd2,
+	0x5c, 0x20, 0x26, 0xb4, 0xf4, 0x56, 0x7f, 0x9d, 0x4d, 0xe3, 0xf1, 0x9f, 0x06, 0xc0, 0x4c, 0x1a,
+	0xe4, 0x2e, 0xdc, 0xde, 0xef, 0xb9, 0xce, 0xd1, 0xa1, 0xe7, 0x7e, 0x7b, 0x6c, 0x7b, 0x27, 0x87,
+	0x83, 0x63, 0xbb, 0xe7, 0x1c, 0x38, 0x76, 0xdf, 0x5c, 0x20, 0x16, 0xdc, 0x98, 0x07, 0xa9, 0xfd,
+	0xd4, 0x19, 0xb8, 0x36, 0x35, 0x0d, 0x72, 0x0b, 0x48, 0x19, 0x79, 0x7e, 0xf4, 0xc2, 0x36, 0x17,
+	0xc9, 0x4d, 0x68, 0xcf, 0xdb, 0x8f, 0xf7, 0x4f, 0x06, 0xb6, 0x59, 0xb9, 0x4e, 0x1f, 0x9c, 0x3c,
+	0xb7, 0xcd, 0xa5, 0xab, 0x74, 0x6a, 0x0f, 0x6c, 0xd7, 0x5c, 0x26, 0x5b, 0x70, 0xef, 0xda, 0x29,
+	0x5e, 0xef, 0xd9, 0xfe, 0xe1, 0x53, 0xfb, 0xc0, 0xb6, 0xfb, 0x66, 0x95, 0x3c, 0x80, 0xfb, 0xd7,
+	0x0f, 0x9c, 0xa7, 0xd4, 0xbe, 0x7e, 0xf2, 0xeb, 0xdb, 0x4d, 0xe3, 0xcd, 0xdb, 0x4d, 0xe3, 0x8f,
+	0xb7, 0x9b, 0xc6, 0x0f, 0xef, 0x36, 0x17, 0xde, 0xbc, 0xdb, 0x5c, 0xf8, 0xed, 0xdd, 0xe6, 0xc2,
+	0x77, 0x5b, 0xa3, 0x48, 0x9e, 0x8d, 0x4f, 0xbb, 0x41, 0x72, 0xbe, 0x93, 0x46, 0x6c, 0x14, 0xf8,
+	0xe9, 0x8e, 0x8c, 0x82, 0x30, 0xd8, 0xc9, 0x85, 0x70, 0x5a, 0x55, 0x7f, 0x12, 0x3f, 0xfb, 0x7b,
+	0x00, 0xc7, 0xd5, 0x01, 0x6f, 0x61, 0x0a, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -888,6 +1084,15 @@ func (m *InnerFilterConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ForwardDdlClasses) > 0 {
+		for iNdEx := len(m.ForwardDdlClasses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ForwardDdlClasses[iNdEx])
+			copy(dAtA[i:], m.ForwardDdlClasses[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.ForwardDdlClasses[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.EventFilters) > 0 {
 		for iNdEx := len(m.EventFilters) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -987,6 +1192,104 @@ func (m *FilterConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ColumnSelector) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColumnSelector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ColumnSelector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Columns) > 0 {
+		for iNdEx := len(m.Columns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Columns[iNdEx])
+			copy(dAtA[i:], m.Columns[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.Columns[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Matcher) > 0 {
+		for iNdEx := len(m.Matcher) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Matcher[iNdEx])
+			copy(dAtA[i:], m.Matcher[iNdEx])
+			i = encodeVarintEvent(dAtA, i, uint64(len(m.Matcher[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ColumnProjection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColumnProjection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ColumnProjection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DeleteOnlyHandleKeyColumns {
+		i--
+		if m.DeleteOnlyHandleKeyColumns {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ColumnSelectors) > 0 {
+		for iNdEx := len(m.ColumnSelectors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ColumnSelectors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEvent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.CaseSensitive {
+		i--
+		if m.CaseSensitive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ResolvedTs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1221,6 +1524,54 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.UpstreamId != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.UpstreamId))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.ReplicationDelay != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.ReplicationDelay))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.TargetTs != 0 {
+		i = encodeVarintEvent(dAtA, i, uint64(m.TargetTs))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.InitialSnapshot {
+		i--
+		if m.InitialSnapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.ColumnProjection != nil {
+		{
+			size, err := m.ColumnProjection.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Priority) > 0 {
+		i -= len(m.Priority)
+		copy(dAtA[i:], m.Priority)
+		i = encodeVarintEvent(dAtA, i, uint64(len(m.Priority)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.OnlyReuse {
 		i--
 		if m.OnlyReuse {
@@ -1398,6 +1749,12 @@ func (m *InnerFilterConfig) Size() (n int) {
 			n += 1 + l + sovEvent(uint64(l))
 		}
 	}
+	if len(m.ForwardDdlClasses) > 0 {
+		for _, s := range m.ForwardDdlClasses {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1420,31 +1777,73 @@ func (m *FilterConfig) Size() (n int) {
 	return n
 }
 
-func (m *ResolvedTs) Size() (n int) {
+func (m *ColumnSelector) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if len(m.Matcher) > 0 {
+		for _, s := range m.Matcher {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
+	if len(m.Columns) > 0 {
+		for _, s := range m.Columns {
+			l = len(s)
+			n += 1 + l + sovEvent(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *Event) Size() (n int) {
+func (m *ColumnProjection) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovEvent(uint64(l))
+	if m.CaseSensitive {
+		n += 2
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovEvent(uint64(l))
+	if len(m.ColumnSelectors) > 0 {
+		for _, e := range m.ColumnSelectors {
+			l = e.Size()
+			n += 1 + l + sovEvent(uint64(l))
+		}
 	}
-	l = len(m.OldValue)
-	if l > 0 {
+	if m.DeleteOnlyHandleKeyColumns {
+		n += 2
+	}
+	return n
+}
+
+func (m *ResolvedTs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	l = len(m.OldValue)
+	if l > 0 {
 		n += 1 + l + sovEvent(uint64(l))
 	}
 	if m.OpType != 0 {
@@ -1556,6 +1955,26 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.OnlyReuse {
 		n += 2
 	}
+	l = len(m.Priority)
+	if l > 0 {
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.ColumnProjection != nil {
+		l = m.ColumnProjection.Size()
+		n += 1 + l + sovEvent(uint64(l))
+	}
+	if m.InitialSnapshot {
+		n += 2
+	}
+	if m.TargetTs != 0 {
+		n += 1 + sovEvent(uint64(m.TargetTs))
+	}
+	if m.ReplicationDelay != 0 {
+		n += 2 + sovEvent(uint64(m.ReplicationDelay))
+	}
+	if m.UpstreamId != 0 {
+		n += 2 + sovEvent(uint64(m.UpstreamId))
+	}
 	return n
 }
 
@@ -2010,6 +2429,38 @@ func (m *InnerFilterConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardDdlClasses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ForwardDdlClasses = append(m.ForwardDdlClasses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -2157,6 +2608,244 @@ func (m *FilterConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ColumnSelector) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnSelector: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnSelector: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matcher", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matcher = append(m.Matcher, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Columns = append(m.Columns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ColumnProjection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnProjection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnProjection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaseSensitive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CaseSensitive = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnSelectors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnSelectors = append(m.ColumnSelectors, &ColumnSelector{})
+			if err := m.ColumnSelectors[len(m.ColumnSelectors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteOnlyHandleKeyColumns", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeleteOnlyHandleKeyColumns = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ResolvedTs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3057,6 +3746,151 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.OnlyReuse = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Priority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnProjection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ColumnProjection == nil {
+				m.ColumnProjection = &ColumnProjection{}
+			}
+			if err := m.ColumnProjection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialSnapshot", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.InitialSnapshot = bool(v != 0)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetTs", wireType)
+			}
+			m.TargetTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationDelay", wireType)
+			}
+			m.ReplicationDelay = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationDelay |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpstreamId", wireType)
+			}
+			m.UpstreamId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpstreamId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
@@ -160,6 +160,34 @@ func (ComponentState) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_6d584080fdadb670, []int{4}
 }
 
+type InitialSnapshotState int32
+
+const (
+	InitialSnapshotState_SnapshotNone     InitialSnapshotState = 0
+	InitialSnapshotState_SnapshotLoading  InitialSnapshotState = 1
+	InitialSnapshotState_SnapshotFinished InitialSnapshotState = 2
+)
+
+var InitialSnapshotState_name = map[int32]string{
+	0: "SnapshotNone",
+	1: "SnapshotLoading",
+	2: "SnapshotFinished",
+}
+
+var InitialSnapshotState_value = map[string]int32{
+	"SnapshotNone":     0,
+	"SnapshotLoading":  1,
+	"SnapshotFinished": 2,
+}
+
+func (x InitialSnapshotState) String() string {
+	return proto.EnumName(InitialSnapshotState_name, int32(x))
+}
+
+func (InitialSnapshotState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{5}
+}
+
 type TableSpan struct {
 	TableID  int64  `protobuf:"varint,1,opt,name=TableID,proto3" json:"TableID,omitempty"`
 	StartKey []byte `protobuf:"bytes,2,opt,name=StartKey,proto3" json:"StartKey,omitempty"`
@@ -2088,6 +2116,10 @@ type TableSpanStatus struct {
 	ComponentStatus    ComponentState `protobuf:"varint,2,opt,name=component_status,json=componentStatus,proto3,enum=heartbeatpb.ComponentState" json:"component_status,omitempty"`
 	CheckpointTs       uint64         `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	EventSizePerSecond float32        `protobuf:"fixed32,4,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	// snapshot_state is the state of the initial snapshot load of the span.
+	SnapshotState InitialSnapshotState `protobuf:"varint,5,opt,name=snapshot_state,json=snapshotState,proto3,enum=heartbeatpb.InitialSnapshotState" json:"snapshot_state,omitempty"`
+	// snapshot_rows is the number of the snapshot rows the span has received.
+	SnapshotRows uint64 `protobuf:"varint,6,opt,name=snapshot_rows,json=snapshotRows,proto3" json:"snapshot_rows,omitempty"`
 }
 
 func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
@@ -2151,6 +2183,20 @@ func (m *TableSpanStatus) GetEventSizePerSecond() float32 {
 	return 0
 }
 
+func (m *TableSpanStatus) GetSnapshotState() InitialSnapshotState {
+	if m != nil {
+		return m.SnapshotState
+	}
+	return InitialSnapshotState_SnapshotNone
+}
+
+func (m *TableSpanStatus) GetSnapshotRows() uint64 {
+	if m != nil {
+		return m.SnapshotRows
+	}
+	return 0
+}
+
 type BlockStatusRequest struct {
 	ChangefeedID  *ChangefeedID           `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	BlockStatuses []*TableSpanBlockStatus `protobuf:"bytes,2,rep,name=blockStatuses,proto3" json:"blockStatuses,omitempty"`
@@ -2391,12 +2437,73 @@ func (m *ChangefeedID) GetNamespace() string {
 	return ""
 }
 
+type ThroughputQuota struct {
+	ChangefeedID      *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	MaxRowsPerSecond  uint64        `protobuf:"varint,2,opt,name=max_rows_per_second,json=maxRowsPerSecond,proto3" json:"max_rows_per_second,omitempty"`
+	MaxBytesPerSecond uint64        `protobuf:"varint,3,opt,name=max_bytes_per_second,json=maxBytesPerSecond,proto3" json:"max_bytes_per_second,omitempty"`
+}
+
+func (m *ThroughputQuota) Reset()         { *m = ThroughputQuota{} }
+func (m *ThroughputQuota) String() string { return proto.CompactTextString(m) }
+func (*ThroughputQuota) ProtoMessage()    {}
+func (*ThroughputQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{36}
+}
+func (m *ThroughputQuota) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThroughputQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThroughputQuota.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThroughputQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThroughputQuota.Merge(m, src)
+}
+func (m *ThroughputQuota) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThroughputQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThroughputQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThroughputQuota proto.InternalMessageInfo
+
+func (m *ThroughputQuota) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *ThroughputQuota) GetMaxRowsPerSecond() uint64 {
+	if m != nil {
+		return m.MaxRowsPerSecond
+	}
+	return 0
+}
+
+func (m *ThroughputQuota) GetMaxBytesPerSecond() uint64 {
+	if m != nil {
+		return m.MaxBytesPerSecond
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("heartbeatpb.Action", Action_name, Action_value)
 	proto.RegisterEnum("heartbeatpb.ScheduleAction", ScheduleAction_name, ScheduleAction_value)
 	proto.RegisterEnum("heartbeatpb.BlockStage", BlockStage_name, BlockStage_value)
 	proto.RegisterEnum("heartbeatpb.InfluenceType", InfluenceType_name, InfluenceType_value)
 	proto.RegisterEnum("heartbeatpb.ComponentState", ComponentState_name, ComponentState_value)
+	proto.RegisterEnum("heartbeatpb.InitialSnapshotState", InitialSnapshotState_name, InitialSnapshotState_value)
 	proto.RegisterType((*TableSpan)(nil), "heartbeatpb.TableSpan")
 	proto.RegisterType((*HeartBeatRequest)(nil), "heartbeatpb.HeartBeatRequest")
 	proto.RegisterType((*Watermark)(nil), "heartbeatpb.Watermark")
@@ -2433,127 +2540,137 @@ func init() {
 	proto.RegisterType((*RunningError)(nil), "heartbeatpb.RunningError")
 	proto.RegisterType((*DispatcherID)(nil), "heartbeatpb.DispatcherID")
 	proto.RegisterType((*ChangefeedID)(nil), "heartbeatpb.ChangefeedID")
+	proto.RegisterType((*ThroughputQuota)(nil), "heartbeatpb.ThroughputQuota")
 }
 
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1838 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x19, 0x4b, 0x6f, 0x23, 0x49,
-	0x39, 0xdd, 0x6d, 0x3b, 0xf6, 0xe7, 0x24, 0xd3, 0x53, 0xb3, 0x33, 0xe3, 0xc9, 0xc3, 0x9b, 0x2d,
-	0x40, 0x0a, 0x59, 0x48, 0x34, 0xd9, 0x1d, 0x2d, 0x20, 0x96, 0x25, 0xb1, 0xc3, 0xae, 0x15, 0x8d,
-	0x37, 0x2a, 0x07, 0x0d, 0xcb, 0xc5, 0x6a, 0x77, 0x57, 0x9c, 0x56, 0xec, 0xee, 0x9e, 0xae, 0x76,
-	0x92, 0x59, 0x89, 0x13, 0x57, 0x0e, 0x1c, 0x39, 0xac, 0x84, 0xf6, 0x08, 0x7f, 0x04, 0x8e, 0x73,
-	0x02, 0x0e, 0x1c, 0xd0, 0x8c, 0xf8, 0x03, 0x5c, 0xb8, 0xa2, 0xaa, 0xee, 0xea, 0x97, 0xdb, 0x49,
-	0x46, 0xb1, 0x38, 0xb9, 0xea, 0xab, 0xef, 0x55, 0xdf, 0xbb, 0xda, 0xb0, 0x76, 0x46, 0x0d, 0x3f,
-	0x18, 0x50, 0x23, 0xf0, 0x06, 0xbb, 0xf1, 0x7a, 0xc7, 0xf3, 0xdd, 0xc0, 0x45, 0xf5, 0xd4, 0x21,
-	0xfe, 0x0a, 0x6a, 0x27, 0xc6, 0x60, 0x44, 0x7b, 0x9e, 0xe1, 0xa0, 0x06, 0x2c, 0x8a, 0x4d, 0xa7,
-	0xdd, 0x50, 0x36, 0x95, 0x2d, 0x8d, 0xc8, 0x2d, 0x5a, 0x85, 0x6a, 0x2f, 0x30, 0xfc, 0xe0, 0x88,
-	0xbe, 0x6a, 0xa8, 0x9b, 0xca, 0xd6, 0x12, 0x89, 0xf7, 0xe8, 0x11, 0x54, 0x0e, 0x1d, 0x8b, 0x9f,
-	0x68, 0xe2, 0x24, 0xda, 0xe1, 0x3f, 0xa8, 0xa0, 0x7f, 0xc1, 0x45, 0x1d, 0x50, 0x23, 0x20, 0xf4,
-	0xe5, 0x84, 0xb2, 0x00, 0x7d, 0x0a, 0x4b, 0xe6, 0x99, 0xe1, 0x0c, 0xe9, 0x29, 0xa5, 0x56, 0x24,
-	0xa7, 0xbe, 0xf7, 0x64, 0x27, 0xa5, 0xd3, 0x4e, 0x2b, 0x85, 0x40, 0x32, 0xe8, 0xe8, 0x63, 0xa8,
-	0x5d, 0x1a, 0x01, 0xf5, 0xc7, 0x86, 0x7f, 0x2e, 0x14, 0xa9, 0xef, 0x3d, 0xca, 0xd0, 0xbe, 0x90,
-	0xa7, 0x24, 0x41, 0x44, 0x3f, 0x82, 0x2a, 0x0b, 0x8c, 0x60, 0xc2, 0x28, 0x6b, 0x68, 0x9b, 0xda,
-	0x56, 0x7d, 0x6f, 0x3d, 0x43, 0x14, 0x5b, 0xa0, 0x27, 0xb0, 0x48, 0x8c, 0x8d, 0xb6, 0xe0, 0x9e,
-	0xe9, 0x8e, 0x3d, 0x3a, 0xa2, 0x01, 0x0d, 0x0f, 0x1b, 0xa5, 0x4d, 0x65, 0xab, 0x4a, 0xf2, 0x60,
-	0xf4, 0x21, 0x68, 0xd4, 0xf7, 0x1b, 0xe5, 0x82, 0xfb, 0x90, 0x89, 0xe3, 0xd8, 0xce, 0xf0, 0xd0,
-	0xf7, 0x5d, 0x9f, 0x70, 0x2c, 0x6c, 0x40, 0x2d, 0x56, 0x14, 0x61, 0x6e, 0x12, 0x6a, 0x9e, 0x7b,
-	0xae, 0xed, 0x04, 0x27, 0x4c, 0x98, 0xa4, 0x44, 0x32, 0x30, 0xd4, 0x04, 0xf0, 0x29, 0x73, 0x47,
-	0x17, 0xd4, 0x3a, 0x61, 0xe2, 0xe2, 0x25, 0x92, 0x82, 0x20, 0x1d, 0x34, 0x46, 0x5f, 0x0a, 0x07,
-	0x94, 0x08, 0x5f, 0xe2, 0xdf, 0x80, 0xde, 0xb6, 0x99, 0x67, 0x04, 0xe6, 0x19, 0xf5, 0xf7, 0xcd,
-	0xc0, 0x76, 0x1d, 0xf4, 0x21, 0x54, 0x0c, 0xb1, 0x12, 0x32, 0x56, 0xf6, 0x1e, 0x64, 0xd4, 0x0c,
-	0x91, 0x48, 0x84, 0xc2, 0x5d, 0xde, 0x72, 0xc7, 0x63, 0x3b, 0x88, 0x05, 0xc6, 0x7b, 0xb4, 0x09,
-	0xf5, 0x0e, 0xeb, 0xbd, 0x72, 0xcc, 0x63, 0xae, 0x9f, 0x10, 0x5b, 0x25, 0x69, 0x10, 0x6e, 0x81,
-	0xb6, 0xdf, 0x3a, 0xca, 0x30, 0x51, 0xae, 0x67, 0xa2, 0x4e, 0x33, 0xf9, 0xad, 0x0a, 0x0f, 0x3b,
-	0xce, 0xe9, 0x68, 0x42, 0x1d, 0x93, 0x5a, 0xc9, 0x75, 0x18, 0xfa, 0x39, 0x2c, 0xc7, 0x07, 0x27,
-	0xaf, 0x3c, 0x1a, 0x5d, 0x68, 0x35, 0x73, 0xa1, 0x0c, 0x06, 0xc9, 0x12, 0xa0, 0xcf, 0x60, 0x39,
-	0x61, 0xd8, 0x69, 0xf3, 0x3b, 0x6a, 0x53, 0x9e, 0x4b, 0x63, 0x90, 0x2c, 0xbe, 0x48, 0x09, 0xf3,
-	0x8c, 0x8e, 0x8d, 0x4e, 0x5b, 0x18, 0x40, 0x23, 0xf1, 0x1e, 0x1d, 0xc1, 0x03, 0x7a, 0x65, 0x8e,
-	0x26, 0x16, 0x4d, 0xd1, 0x58, 0x22, 0x74, 0xae, 0x15, 0x51, 0x44, 0x85, 0xff, 0xa2, 0xa4, 0x5d,
-	0x19, 0x85, 0xdb, 0xaf, 0xe0, 0xa1, 0x5d, 0x64, 0x99, 0x28, 0xa1, 0x70, 0xb1, 0x21, 0xd2, 0x98,
-	0xa4, 0x98, 0x01, 0x7a, 0x16, 0x07, 0x49, 0x98, 0x5f, 0x1b, 0x33, 0xd4, 0xcd, 0x85, 0x0b, 0x06,
-	0xcd, 0x30, 0xcf, 0x85, 0x25, 0xea, 0x7b, 0x7a, 0x36, 0xb0, 0x5a, 0x47, 0x84, 0x1f, 0xe2, 0x6f,
-	0x15, 0xb8, 0x9f, 0xaa, 0x08, 0xcc, 0x73, 0x1d, 0x46, 0xef, 0x5a, 0x12, 0x9e, 0x03, 0xb2, 0x72,
-	0xd6, 0xa1, 0xd2, 0x9b, 0xb3, 0x74, 0x8f, 0xf2, 0xbc, 0x80, 0x10, 0x5f, 0xc1, 0x83, 0x56, 0x2a,
-	0xf3, 0x9e, 0x53, 0xc6, 0x8c, 0xe1, 0x9d, 0x95, 0xcc, 0xe7, 0xb8, 0x3a, 0x9d, 0xe3, 0xf8, 0xef,
-	0x19, 0x3f, 0xb7, 0x5c, 0xe7, 0xd4, 0x1e, 0xa2, 0x6d, 0x28, 0x31, 0xcf, 0x70, 0x22, 0x79, 0x8f,
-	0x8a, 0xcb, 0x16, 0x11, 0x38, 0xbc, 0x7c, 0x33, 0x5e, 0x94, 0x63, 0xfe, 0x72, 0xcb, 0xb5, 0xb7,
-	0x52, 0x71, 0x16, 0x79, 0xe9, 0x9a, 0x40, 0xcc, 0xa0, 0xf3, 0x50, 0x67, 0x32, 0xd4, 0x4b, 0x61,
-	0xa8, 0xcb, 0x3d, 0xc2, 0xb0, 0x6c, 0x4e, 0x7c, 0x9f, 0x3a, 0x41, 0xdf, 0xb3, 0xfa, 0x01, 0x13,
-	0x15, 0xb0, 0x44, 0xea, 0x11, 0xf0, 0xd8, 0x3a, 0x61, 0xf8, 0x6f, 0x0a, 0x3c, 0xe1, 0xb9, 0x61,
-	0x4d, 0x46, 0xa9, 0xd0, 0x9e, 0x53, 0x4b, 0x78, 0x06, 0x15, 0x53, 0xd8, 0xea, 0x86, 0x78, 0x0d,
-	0x0d, 0x4a, 0x22, 0x64, 0xd4, 0x82, 0x15, 0x16, 0xa9, 0x14, 0x46, 0xb2, 0x30, 0xca, 0xca, 0xde,
-	0x5a, 0x86, 0xbc, 0x97, 0x41, 0x21, 0x39, 0x12, 0x7c, 0x0c, 0x0f, 0x9e, 0x1b, 0xb6, 0x13, 0x18,
-	0xb6, 0x43, 0xfd, 0x2f, 0x24, 0x1d, 0xfa, 0x71, 0xaa, 0xdf, 0x28, 0x05, 0x81, 0x98, 0xd0, 0xe4,
-	0x1b, 0x0e, 0xfe, 0x46, 0x05, 0x3d, 0x7f, 0x7c, 0x57, 0x0b, 0x6d, 0x00, 0xf0, 0x55, 0x9f, 0x0b,
-	0xa1, 0xc2, 0x4a, 0x35, 0x52, 0xe3, 0x10, 0xce, 0x9e, 0xa2, 0xa7, 0x50, 0x0e, 0x4f, 0x8a, 0x0c,
-	0xd0, 0x72, 0xc7, 0x9e, 0xeb, 0x50, 0x27, 0x10, 0xb8, 0x24, 0xc4, 0x44, 0xdf, 0x81, 0xe5, 0x24,
-	0x74, 0xb9, 0xd3, 0x4b, 0x05, 0x3d, 0x2b, 0xee, 0x88, 0xda, 0xcd, 0x1d, 0x11, 0x7d, 0x0f, 0x56,
-	0x06, 0xae, 0x1b, 0xb0, 0xc0, 0x37, 0xbc, 0xbe, 0xe5, 0x3a, 0xb4, 0x51, 0x11, 0xfd, 0x60, 0x39,
-	0x86, 0xb6, 0x5d, 0x87, 0xe2, 0x4f, 0x60, 0xad, 0xe5, 0xba, 0xbe, 0x65, 0x3b, 0x46, 0xe0, 0xfa,
-	0x07, 0xf2, 0x4c, 0x86, 0x52, 0x03, 0x16, 0x2f, 0xa8, 0xcf, 0x64, 0x87, 0xd3, 0x88, 0xdc, 0xe2,
-	0xaf, 0x60, 0xbd, 0x98, 0x30, 0x2a, 0x42, 0x77, 0x70, 0xd9, 0x9f, 0x15, 0x78, 0x6f, 0xdf, 0xb2,
-	0x12, 0x0c, 0xa9, 0xcd, 0xf7, 0x41, 0xb5, 0xad, 0x9b, 0x9d, 0xa5, 0xda, 0x16, 0x9f, 0xa1, 0x52,
-	0x41, 0xbc, 0x14, 0x47, 0xe9, 0x94, 0xa1, 0xb5, 0x02, 0x43, 0x6f, 0xc3, 0x7d, 0x9b, 0xf5, 0x1d,
-	0x7a, 0xd9, 0x4f, 0xdc, 0x2e, 0xc7, 0x14, 0x9b, 0x75, 0xe9, 0x65, 0x22, 0x0e, 0x5f, 0xc1, 0x63,
-	0x42, 0xc7, 0xee, 0x05, 0xbd, 0x93, 0xba, 0x0d, 0x58, 0x34, 0x0d, 0x66, 0x1a, 0x16, 0x8d, 0xda,
-	0xb6, 0xdc, 0xf2, 0x13, 0x5f, 0xf0, 0xb7, 0xa2, 0xa9, 0x40, 0x6e, 0xf1, 0x1f, 0x55, 0x58, 0x4d,
-	0x84, 0x4e, 0xb9, 0xee, 0x8e, 0x31, 0x3e, 0xcb, 0x80, 0x4f, 0x84, 0x5f, 0xfd, 0x94, 0xed, 0xe2,
-	0xa2, 0x68, 0xc2, 0x07, 0x01, 0xaf, 0xa0, 0xfd, 0xc0, 0xb7, 0x87, 0x43, 0xea, 0xf7, 0xe9, 0x05,
-	0xaf, 0x62, 0x49, 0xe5, 0xeb, 0xdb, 0xb7, 0x68, 0xd9, 0x1b, 0x82, 0xc7, 0x49, 0xc8, 0xe2, 0x90,
-	0x73, 0x48, 0x37, 0xef, 0x62, 0xdf, 0x94, 0x8b, 0x7d, 0xf3, 0x6f, 0x05, 0xd6, 0x0a, 0x2d, 0x34,
-	0x9f, 0x46, 0xf9, 0x0c, 0xca, 0xbc, 0x4d, 0xc8, 0xde, 0xf8, 0x7e, 0x86, 0x2e, 0x96, 0x96, 0x34,
-	0x95, 0x10, 0x5b, 0xa6, 0xb1, 0x76, 0x9b, 0xc1, 0xf6, 0x56, 0x85, 0x01, 0xff, 0x57, 0x81, 0x66,
-	0x72, 0xcf, 0x63, 0x97, 0x05, 0xf3, 0x8e, 0x86, 0x5b, 0xb9, 0x56, 0xbd, 0xa3, 0x6b, 0x9f, 0xc2,
-	0x62, 0xd8, 0x05, 0xe5, 0xa3, 0xe2, 0xf1, 0x54, 0xeb, 0x18, 0x1b, 0x1d, 0xe7, 0xd4, 0x25, 0x12,
-	0x0f, 0xff, 0x47, 0x81, 0xf7, 0x67, 0xde, 0x7c, 0x3e, 0x5e, 0xfe, 0xbf, 0x5c, 0xfd, 0x5d, 0x62,
-	0x02, 0x5f, 0x01, 0x24, 0xb6, 0xc8, 0x8c, 0xcd, 0x4a, 0x6e, 0x6c, 0x6e, 0x4a, 0xcc, 0xae, 0x31,
-	0x96, 0x8d, 0x2a, 0x05, 0x41, 0x3b, 0x50, 0x11, 0xe1, 0x29, 0x0d, 0x5e, 0x30, 0x0e, 0x09, 0x7b,
-	0x47, 0x58, 0xb8, 0x15, 0x3d, 0x6e, 0x85, 0xe0, 0xd9, 0x8f, 0xdb, 0xf5, 0x08, 0x2d, 0x25, 0x35,
-	0x01, 0xe0, 0x3f, 0xa9, 0x80, 0xa6, 0xb3, 0x83, 0x57, 0xcb, 0x19, 0xce, 0xc9, 0x18, 0x52, 0x8d,
-	0x1e, 0xcf, 0xf2, 0xca, 0x6a, 0xee, 0xca, 0x72, 0xbe, 0xd3, 0x6e, 0x31, 0xdf, 0xfd, 0x02, 0x74,
-	0x53, 0xb6, 0xe3, 0x3e, 0x4b, 0x5e, 0xa3, 0x37, 0xf4, 0xec, 0x7b, 0x66, 0x7a, 0x3f, 0x61, 0xd3,
-	0x49, 0x5a, 0x2e, 0x68, 0x2a, 0x1f, 0x41, 0x7d, 0x30, 0x72, 0xcd, 0xf3, 0x68, 0x6a, 0xa8, 0x08,
-	0xfd, 0x50, 0x36, 0xc2, 0x05, 0x7b, 0x10, 0x68, 0x62, 0x8d, 0x5f, 0xc2, 0xa3, 0x24, 0xbc, 0x5b,
-	0x23, 0x97, 0xd1, 0x39, 0x25, 0x74, 0xaa, 0xad, 0xa8, 0xd9, 0xb6, 0xe2, 0xc3, 0xe3, 0x29, 0x91,
-	0xf3, 0xc9, 0x24, 0x3e, 0x4e, 0x4f, 0x4c, 0x93, 0x32, 0x26, 0x65, 0x46, 0x5b, 0xfc, 0x3b, 0x05,
-	0xf4, 0xe4, 0x4d, 0x15, 0x06, 0xdb, 0x1c, 0x9e, 0xa4, 0xab, 0x50, 0x8d, 0x42, 0x32, 0xac, 0xd1,
-	0x1a, 0x89, 0xf7, 0xd7, 0xbd, 0x36, 0xf1, 0xa7, 0x50, 0x16, 0x78, 0x37, 0x7c, 0xbf, 0x99, 0x11,
-	0x82, 0xd8, 0x81, 0x15, 0xb9, 0x0e, 0xad, 0x71, 0x0d, 0x9f, 0x4d, 0xa8, 0x7f, 0x39, 0xb2, 0x72,
-	0xac, 0xd2, 0x20, 0x8e, 0xd1, 0xa5, 0x97, 0x39, 0x5d, 0xd3, 0x20, 0xfc, 0xad, 0x06, 0xe5, 0x70,
-	0xf2, 0x5c, 0x87, 0x5a, 0x87, 0x1d, 0xf0, 0xf0, 0xa1, 0xe1, 0xe0, 0x51, 0x25, 0x09, 0x80, 0x6b,
-	0x21, 0x96, 0xc9, 0x73, 0x26, 0xda, 0xa2, 0xcf, 0xa0, 0x1e, 0x2e, 0x65, 0x31, 0x98, 0x9e, 0xfb,
-	0xf3, 0xee, 0x21, 0x69, 0x0a, 0x74, 0x04, 0xf7, 0xbb, 0x94, 0x5a, 0x6d, 0xdf, 0xf5, 0x3c, 0x89,
-	0x11, 0xb5, 0xfa, 0x1b, 0xd8, 0x4c, 0xd3, 0xa1, 0x9f, 0xc2, 0x3d, 0x0e, 0xdc, 0xb7, 0xac, 0x98,
-	0x55, 0x38, 0xf3, 0xa2, 0xe9, 0x6c, 0x26, 0x79, 0x54, 0xfe, 0x0e, 0xf9, 0xa5, 0x67, 0x19, 0x01,
-	0x8d, 0x4c, 0xc8, 0x1a, 0x15, 0x41, 0xbc, 0x56, 0xd4, 0x4c, 0x22, 0x07, 0x91, 0x1c, 0x49, 0xfe,
-	0x53, 0xca, 0xe2, 0xd4, 0xa7, 0x14, 0xf4, 0x43, 0x31, 0xe4, 0x0f, 0x69, 0xa3, 0x2a, 0xa2, 0x32,
-	0xdb, 0xaa, 0x0e, 0xa2, 0x0c, 0x1e, 0x86, 0x03, 0xfe, 0x90, 0xe2, 0x73, 0x78, 0x2f, 0xae, 0x3e,
-	0xf2, 0x94, 0x97, 0x8e, 0x77, 0xa8, 0x7a, 0x5b, 0xf2, 0x59, 0xa1, 0xce, 0x2c, 0x1d, 0x21, 0x02,
-	0xfe, 0xa7, 0x02, 0xf7, 0x72, 0x9f, 0xe0, 0xde, 0x45, 0x50, 0x51, 0x59, 0x54, 0xe7, 0x51, 0x16,
-	0x8b, 0x66, 0xed, 0xa7, 0xf0, 0x30, 0x6c, 0xa8, 0xcc, 0xfe, 0x9a, 0xf6, 0x3d, 0xea, 0xf7, 0x19,
-	0x35, 0x5d, 0x27, 0x1c, 0x14, 0x55, 0x82, 0xc4, 0x61, 0xcf, 0xfe, 0x9a, 0x1e, 0x53, 0xbf, 0x27,
-	0x4e, 0xf0, 0x37, 0x0a, 0xa0, 0x94, 0x0d, 0xe7, 0x54, 0x11, 0x3f, 0x87, 0xe5, 0x41, 0xc2, 0x34,
-	0xfe, 0xe2, 0xf1, 0x41, 0x71, 0x07, 0x49, 0xcb, 0xcf, 0xd2, 0x61, 0x0b, 0x96, 0xd2, 0x3d, 0x1b,
-	0x21, 0x28, 0x05, 0xf6, 0x38, 0x2c, 0x5f, 0x35, 0x22, 0xd6, 0x1c, 0xe6, 0xb8, 0x96, 0x6c, 0x8e,
-	0x62, 0xcd, 0x61, 0x26, 0x87, 0x69, 0x21, 0x8c, 0xaf, 0x79, 0xca, 0x8e, 0xc3, 0x0f, 0x26, 0xc2,
-	0x1e, 0x35, 0x22, 0xb7, 0xf8, 0x63, 0x58, 0x4a, 0x3b, 0x8e, 0x53, 0x9f, 0xd9, 0xc3, 0xb3, 0xe8,
-	0xa3, 0xa0, 0x58, 0x23, 0x1d, 0xb4, 0x91, 0x7b, 0x19, 0x25, 0x3b, 0x5f, 0xe2, 0x53, 0x58, 0x4a,
-	0x9b, 0xe0, 0x76, 0x54, 0x42, 0x5b, 0xde, 0xca, 0x23, 0xcd, 0xf8, 0x9a, 0x97, 0x1a, 0xfe, 0xcb,
-	0x3c, 0xc3, 0x94, 0xba, 0x25, 0x80, 0xed, 0x0d, 0xa8, 0x44, 0x9f, 0x48, 0x6b, 0x50, 0x7e, 0xe1,
-	0xdb, 0x01, 0xd5, 0x17, 0x50, 0x15, 0x4a, 0xc7, 0x06, 0x63, 0xba, 0xb2, 0xbd, 0x15, 0x56, 0xc8,
-	0xe4, 0xe1, 0x8f, 0x00, 0x2a, 0x2d, 0x9f, 0x1a, 0x02, 0x0f, 0xa0, 0x12, 0x3e, 0xa9, 0x74, 0x65,
-	0xfb, 0x27, 0x00, 0x49, 0x32, 0x71, 0x0e, 0xdd, 0x2f, 0xbb, 0x87, 0xfa, 0x02, 0xaa, 0xc3, 0xe2,
-	0x8b, 0xfd, 0xce, 0x49, 0xa7, 0xfb, 0xb9, 0xae, 0x88, 0x0d, 0x09, 0x37, 0x2a, 0xc7, 0x69, 0x73,
-	0x1c, 0x6d, 0xfb, 0x07, 0xb9, 0x06, 0x82, 0x16, 0x41, 0xdb, 0x1f, 0x8d, 0xf4, 0x05, 0x54, 0x01,
-	0xb5, 0x7d, 0xa0, 0x2b, 0x5c, 0x52, 0xd7, 0xf5, 0xc7, 0xc6, 0x48, 0x57, 0xb7, 0x3f, 0x81, 0x95,
-	0x6c, 0x40, 0x0b, 0xb6, 0xae, 0x7f, 0x6e, 0x3b, 0xc3, 0x50, 0x60, 0x2f, 0x10, 0x55, 0x2a, 0x14,
-	0x18, 0x6a, 0x68, 0xe9, 0xea, 0xc1, 0xcf, 0xfe, 0xfa, 0xa6, 0xa9, 0xbc, 0x7e, 0xd3, 0x54, 0xfe,
-	0xf5, 0xa6, 0xa9, 0xfc, 0xfe, 0x6d, 0x73, 0xe1, 0xf5, 0xdb, 0xe6, 0xc2, 0x3f, 0xde, 0x36, 0x17,
-	0x7e, 0xfd, 0xdd, 0xa1, 0x1d, 0x9c, 0x4d, 0x06, 0x3b, 0xa6, 0x3b, 0xde, 0xf5, 0x6c, 0x67, 0x68,
-	0x1a, 0xde, 0x6e, 0x60, 0x9b, 0x96, 0xb9, 0x9b, 0x8a, 0xa9, 0x41, 0x45, 0xfc, 0x8b, 0xf0, 0xd1,
-	0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x1f, 0x8f, 0xc0, 0xf4, 0x64, 0x18, 0x00, 0x00,
+	// 1982 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x39, 0xcd, 0x6f, 0x1c, 0x49,
+	0xf5, 0xee, 0xee, 0xf1, 0xd8, 0xf3, 0xc6, 0x1f, 0x9d, 0xb2, 0x93, 0x4c, 0xe2, 0xc4, 0xeb, 0xd4,
+	0xef, 0x87, 0x64, 0xbc, 0xac, 0xad, 0x78, 0x37, 0x5a, 0x40, 0x2c, 0x8b, 0x3d, 0xce, 0x6e, 0x2c,
+	0x13, 0xaf, 0xa9, 0x31, 0x0a, 0xcb, 0x65, 0x54, 0xee, 0x2e, 0xcf, 0xb4, 0x3c, 0xd3, 0xd5, 0xe9,
+	0xea, 0x89, 0x9d, 0x95, 0x38, 0x71, 0xe5, 0xc0, 0x91, 0xc3, 0x4a, 0x68, 0x8f, 0x70, 0xe2, 0xbf,
+	0x00, 0x89, 0xcb, 0x9e, 0x80, 0x23, 0x4a, 0xc4, 0x3f, 0xc0, 0x85, 0x2b, 0xaa, 0xea, 0xae, 0xfe,
+	0x9a, 0xf6, 0x47, 0x64, 0x8b, 0xd3, 0xd4, 0x7b, 0xf5, 0xbe, 0xea, 0xbd, 0x57, 0xef, 0xbd, 0xea,
+	0x81, 0xa5, 0x3e, 0xa3, 0x61, 0x74, 0xc4, 0x68, 0x14, 0x1c, 0x6d, 0xa4, 0xeb, 0xf5, 0x20, 0xe4,
+	0x11, 0x47, 0xcd, 0xdc, 0x26, 0xfe, 0x12, 0x1a, 0x87, 0xf4, 0x68, 0xc0, 0x3a, 0x01, 0xf5, 0x51,
+	0x0b, 0xa6, 0x14, 0xb0, 0xbb, 0xd3, 0x32, 0x56, 0x8c, 0x55, 0x8b, 0x68, 0x10, 0xdd, 0x87, 0xe9,
+	0x4e, 0x44, 0xc3, 0x68, 0x8f, 0xbd, 0x6e, 0x99, 0x2b, 0xc6, 0xea, 0x0c, 0x49, 0x61, 0x74, 0x07,
+	0xea, 0x4f, 0x7d, 0x57, 0xee, 0x58, 0x6a, 0x27, 0x81, 0xf0, 0xef, 0x4c, 0xb0, 0x9f, 0x49, 0x55,
+	0xdb, 0x8c, 0x46, 0x84, 0xbd, 0x1c, 0x31, 0x11, 0xa1, 0x4f, 0x60, 0xc6, 0xe9, 0x53, 0xbf, 0xc7,
+	0x8e, 0x19, 0x73, 0x13, 0x3d, 0xcd, 0xcd, 0x7b, 0xeb, 0x39, 0x9b, 0xd6, 0xdb, 0x39, 0x02, 0x52,
+	0x20, 0x47, 0x1f, 0x41, 0xe3, 0x94, 0x46, 0x2c, 0x1c, 0xd2, 0xf0, 0x44, 0x19, 0xd2, 0xdc, 0xbc,
+	0x53, 0xe0, 0x7d, 0xa1, 0x77, 0x49, 0x46, 0x88, 0xbe, 0x0f, 0xd3, 0x22, 0xa2, 0xd1, 0x48, 0x30,
+	0xd1, 0xb2, 0x56, 0xac, 0xd5, 0xe6, 0xe6, 0x83, 0x02, 0x53, 0xea, 0x81, 0x8e, 0xa2, 0x22, 0x29,
+	0x35, 0x5a, 0x85, 0x79, 0x87, 0x0f, 0x03, 0x36, 0x60, 0x11, 0x8b, 0x37, 0x5b, 0xb5, 0x15, 0x63,
+	0x75, 0x9a, 0x94, 0xd1, 0xe8, 0x7d, 0xb0, 0x58, 0x18, 0xb6, 0x26, 0x2b, 0xce, 0x43, 0x46, 0xbe,
+	0xef, 0xf9, 0xbd, 0xa7, 0x61, 0xc8, 0x43, 0x22, 0xa9, 0x30, 0x85, 0x46, 0x6a, 0x28, 0xc2, 0xd2,
+	0x25, 0xcc, 0x39, 0x09, 0xb8, 0xe7, 0x47, 0x87, 0x42, 0xb9, 0xa4, 0x46, 0x0a, 0x38, 0xb4, 0x0c,
+	0x10, 0x32, 0xc1, 0x07, 0xaf, 0x98, 0x7b, 0x28, 0xd4, 0xc1, 0x6b, 0x24, 0x87, 0x41, 0x36, 0x58,
+	0x82, 0xbd, 0x54, 0x01, 0xa8, 0x11, 0xb9, 0xc4, 0xbf, 0x02, 0x7b, 0xc7, 0x13, 0x01, 0x8d, 0x9c,
+	0x3e, 0x0b, 0xb7, 0x9c, 0xc8, 0xe3, 0x3e, 0x7a, 0x1f, 0xea, 0x54, 0xad, 0x94, 0x8e, 0xb9, 0xcd,
+	0x85, 0x82, 0x99, 0x31, 0x11, 0x49, 0x48, 0x64, 0xc8, 0xdb, 0x7c, 0x38, 0xf4, 0xa2, 0x54, 0x61,
+	0x0a, 0xa3, 0x15, 0x68, 0xee, 0x8a, 0xce, 0x6b, 0xdf, 0x39, 0x90, 0xf6, 0x29, 0xb5, 0xd3, 0x24,
+	0x8f, 0xc2, 0x6d, 0xb0, 0xb6, 0xda, 0x7b, 0x05, 0x21, 0xc6, 0xc5, 0x42, 0xcc, 0x71, 0x21, 0xbf,
+	0x36, 0xe1, 0xf6, 0xae, 0x7f, 0x3c, 0x18, 0x31, 0xdf, 0x61, 0x6e, 0x76, 0x1c, 0x81, 0x7e, 0x02,
+	0xb3, 0xe9, 0xc6, 0xe1, 0xeb, 0x80, 0x25, 0x07, 0xba, 0x5f, 0x38, 0x50, 0x81, 0x82, 0x14, 0x19,
+	0xd0, 0xa7, 0x30, 0x9b, 0x09, 0xdc, 0xdd, 0x91, 0x67, 0xb4, 0xc6, 0x22, 0x97, 0xa7, 0x20, 0x45,
+	0x7a, 0x75, 0x25, 0x9c, 0x3e, 0x1b, 0xd2, 0xdd, 0x1d, 0xe5, 0x00, 0x8b, 0xa4, 0x30, 0xda, 0x83,
+	0x05, 0x76, 0xe6, 0x0c, 0x46, 0x2e, 0xcb, 0xf1, 0xb8, 0x2a, 0x75, 0x2e, 0x54, 0x51, 0xc5, 0x85,
+	0xff, 0x6c, 0xe4, 0x43, 0x99, 0xa4, 0xdb, 0x2f, 0xe0, 0xb6, 0x57, 0xe5, 0x99, 0xe4, 0x42, 0xe1,
+	0x6a, 0x47, 0xe4, 0x29, 0x49, 0xb5, 0x00, 0xf4, 0x24, 0x4d, 0x92, 0xf8, 0x7e, 0x3d, 0x3c, 0xc7,
+	0xdc, 0x52, 0xba, 0x60, 0xb0, 0xa8, 0x73, 0xa2, 0x3c, 0xd1, 0xdc, 0xb4, 0x8b, 0x89, 0xd5, 0xde,
+	0x23, 0x72, 0x13, 0x7f, 0x63, 0xc0, 0xad, 0x5c, 0x45, 0x10, 0x01, 0xf7, 0x05, 0xbb, 0x6e, 0x49,
+	0x78, 0x0e, 0xc8, 0x2d, 0x79, 0x87, 0xe9, 0x68, 0x9e, 0x67, 0x7b, 0x72, 0xcf, 0x2b, 0x18, 0xf1,
+	0x19, 0x2c, 0xb4, 0x73, 0x37, 0xef, 0x39, 0x13, 0x82, 0xf6, 0xae, 0x6d, 0x64, 0xf9, 0x8e, 0x9b,
+	0xe3, 0x77, 0x1c, 0xff, 0xbd, 0x10, 0xe7, 0x36, 0xf7, 0x8f, 0xbd, 0x1e, 0x5a, 0x83, 0x9a, 0x08,
+	0xa8, 0xdf, 0x32, 0x2a, 0x6a, 0x5d, 0x5a, 0xb6, 0x48, 0x4d, 0x24, 0xe5, 0x5b, 0xc8, 0xa2, 0x9c,
+	0xca, 0xd7, 0xa0, 0xb4, 0xde, 0xcd, 0xe5, 0x59, 0xcb, 0xaa, 0xb0, 0xbe, 0x90, 0x88, 0x05, 0x72,
+	0x99, 0xea, 0x42, 0xa7, 0x7a, 0x2d, 0x4e, 0x75, 0x0d, 0x23, 0x0c, 0xb3, 0xce, 0x28, 0x0c, 0x99,
+	0x1f, 0x75, 0x03, 0xb7, 0x1b, 0x09, 0x55, 0x01, 0x6b, 0xa4, 0x99, 0x20, 0x0f, 0xdc, 0x43, 0x81,
+	0xff, 0x66, 0xc0, 0x3d, 0x79, 0x37, 0xdc, 0xd1, 0x20, 0x97, 0xda, 0x37, 0xd4, 0x12, 0x9e, 0x40,
+	0xdd, 0x51, 0xbe, 0xba, 0x24, 0x5f, 0x63, 0x87, 0x92, 0x84, 0x18, 0xb5, 0x61, 0x4e, 0x24, 0x26,
+	0xc5, 0x99, 0xac, 0x9c, 0x32, 0xb7, 0xb9, 0x54, 0x60, 0xef, 0x14, 0x48, 0x48, 0x89, 0x05, 0x1f,
+	0xc0, 0xc2, 0x73, 0xea, 0xf9, 0x11, 0xf5, 0x7c, 0x16, 0x3e, 0xd3, 0x7c, 0xe8, 0x07, 0xb9, 0x7e,
+	0x63, 0x54, 0x24, 0x62, 0xc6, 0x53, 0x6e, 0x38, 0xf8, 0x6b, 0x13, 0xec, 0xf2, 0xf6, 0x75, 0x3d,
+	0xf4, 0x10, 0x40, 0xae, 0xba, 0x52, 0x09, 0x53, 0x5e, 0x6a, 0x90, 0x86, 0xc4, 0x48, 0xf1, 0x0c,
+	0x3d, 0x86, 0xc9, 0x78, 0xa7, 0xca, 0x01, 0x6d, 0x3e, 0x0c, 0xb8, 0xcf, 0xfc, 0x48, 0xd1, 0x92,
+	0x98, 0x12, 0xfd, 0x1f, 0xcc, 0x66, 0xa9, 0x2b, 0x83, 0x5e, 0xab, 0xe8, 0x59, 0x69, 0x47, 0xb4,
+	0x2e, 0xef, 0x88, 0xe8, 0x3b, 0x30, 0x77, 0xc4, 0x79, 0x24, 0xa2, 0x90, 0x06, 0x5d, 0x97, 0xfb,
+	0xac, 0x55, 0x57, 0xfd, 0x60, 0x36, 0xc5, 0xee, 0x70, 0x9f, 0xe1, 0x8f, 0x61, 0xa9, 0xcd, 0x79,
+	0xe8, 0x7a, 0x3e, 0x8d, 0x78, 0xb8, 0xad, 0xf7, 0x74, 0x2a, 0xb5, 0x60, 0xea, 0x15, 0x0b, 0x85,
+	0xee, 0x70, 0x16, 0xd1, 0x20, 0xfe, 0x12, 0x1e, 0x54, 0x33, 0x26, 0x45, 0xe8, 0x1a, 0x21, 0xfb,
+	0xa3, 0x01, 0x8b, 0x5b, 0xae, 0x9b, 0x51, 0x68, 0x6b, 0xbe, 0x0b, 0xa6, 0xe7, 0x5e, 0x1e, 0x2c,
+	0xd3, 0x73, 0xe5, 0x0c, 0x95, 0x4b, 0xe2, 0x99, 0x34, 0x4b, 0xc7, 0x1c, 0x6d, 0x55, 0x38, 0x7a,
+	0x0d, 0x6e, 0x79, 0xa2, 0xeb, 0xb3, 0xd3, 0x6e, 0x16, 0x76, 0x3d, 0xa6, 0x78, 0x62, 0x9f, 0x9d,
+	0x66, 0xea, 0xf0, 0x19, 0xdc, 0x25, 0x6c, 0xc8, 0x5f, 0xb1, 0x6b, 0x99, 0xdb, 0x82, 0x29, 0x87,
+	0x0a, 0x87, 0xba, 0x2c, 0x69, 0xdb, 0x1a, 0x94, 0x3b, 0xa1, 0x92, 0xef, 0x26, 0x53, 0x81, 0x06,
+	0xf1, 0xef, 0x4d, 0xb8, 0x9f, 0x29, 0x1d, 0x0b, 0xdd, 0x35, 0x73, 0xfc, 0x3c, 0x07, 0xde, 0x53,
+	0x71, 0x0d, 0x73, 0xbe, 0x4b, 0x8b, 0xa2, 0x03, 0x8f, 0x22, 0x59, 0x41, 0xbb, 0x51, 0xe8, 0xf5,
+	0x7a, 0x2c, 0xec, 0xb2, 0x57, 0xb2, 0x8a, 0x65, 0x95, 0xaf, 0xeb, 0x5d, 0xa1, 0x65, 0x3f, 0x54,
+	0x32, 0x0e, 0x63, 0x11, 0x4f, 0xa5, 0x84, 0xdc, 0xb6, 0x5b, 0x1d, 0x9b, 0xc9, 0xea, 0xd8, 0xfc,
+	0xcb, 0x80, 0xa5, 0x4a, 0x0f, 0xdd, 0x4c, 0xa3, 0x7c, 0x02, 0x93, 0xb2, 0x4d, 0xe8, 0xde, 0xf8,
+	0x5e, 0x81, 0x2f, 0xd5, 0x96, 0x35, 0x95, 0x98, 0x5a, 0x5f, 0x63, 0xeb, 0x2a, 0x83, 0xed, 0x95,
+	0x0a, 0x03, 0xfe, 0x8f, 0x01, 0xcb, 0xd9, 0x39, 0x0f, 0xb8, 0x88, 0x6e, 0x3a, 0x1b, 0xae, 0x14,
+	0x5a, 0xf3, 0x9a, 0xa1, 0x7d, 0x0c, 0x53, 0x71, 0x17, 0xd4, 0x8f, 0x8a, 0xbb, 0x63, 0xad, 0x63,
+	0x48, 0x77, 0xfd, 0x63, 0x4e, 0x34, 0x1d, 0xfe, 0xb7, 0x01, 0xef, 0x9d, 0x7b, 0xf2, 0x9b, 0x89,
+	0xf2, 0xff, 0xe4, 0xe8, 0xef, 0x92, 0x13, 0xf8, 0x0c, 0x20, 0xf3, 0x45, 0x61, 0x6c, 0x36, 0x4a,
+	0x63, 0xf3, 0xb2, 0xa6, 0xdc, 0xa7, 0x43, 0xdd, 0xa8, 0x72, 0x18, 0xb4, 0x0e, 0x75, 0x95, 0x9e,
+	0xda, 0xe1, 0x15, 0xe3, 0x90, 0xf2, 0x77, 0x42, 0x85, 0xdb, 0xd0, 0x48, 0x91, 0x17, 0x3c, 0x6e,
+	0x1f, 0x24, 0x64, 0x39, 0xad, 0x19, 0x02, 0xff, 0xc1, 0x04, 0x34, 0x7e, 0x3b, 0x64, 0xb5, 0x3c,
+	0x27, 0x38, 0x05, 0x47, 0x9a, 0xc9, 0xe3, 0x59, 0x1f, 0xd9, 0x2c, 0x1d, 0x59, 0xcf, 0x77, 0xd6,
+	0x15, 0xe6, 0xbb, 0xcf, 0xc0, 0x76, 0x74, 0x3b, 0xee, 0x8a, 0xec, 0x35, 0x7a, 0x49, 0xcf, 0x9e,
+	0x77, 0xf2, 0xf0, 0x48, 0x8c, 0x5f, 0xd2, 0xc9, 0x8a, 0xa6, 0xf2, 0x21, 0x34, 0x8f, 0x06, 0xdc,
+	0x39, 0x49, 0xa6, 0x86, 0xba, 0xb2, 0x0f, 0x15, 0x33, 0x5c, 0x89, 0x07, 0x45, 0xa6, 0xd6, 0xf8,
+	0x25, 0xdc, 0xc9, 0xd2, 0xbb, 0x3d, 0xe0, 0x82, 0xdd, 0xd0, 0x85, 0xce, 0xb5, 0x15, 0xb3, 0xd8,
+	0x56, 0x42, 0xb8, 0x3b, 0xa6, 0xf2, 0x66, 0x6e, 0x92, 0x1c, 0xa7, 0x47, 0x8e, 0xc3, 0x84, 0xd0,
+	0x3a, 0x13, 0x10, 0xff, 0xc6, 0x00, 0x3b, 0x7b, 0x53, 0xc5, 0xc9, 0x76, 0x03, 0x4f, 0xd2, 0xfb,
+	0x30, 0x9d, 0xa4, 0x64, 0x5c, 0xa3, 0x2d, 0x92, 0xc2, 0x17, 0xbd, 0x36, 0xf1, 0x27, 0x30, 0xa9,
+	0xe8, 0x2e, 0xf9, 0x7e, 0x73, 0x4e, 0x0a, 0x62, 0x1f, 0xe6, 0xf4, 0x3a, 0xf6, 0xc6, 0x05, 0x72,
+	0x56, 0xa0, 0xf9, 0xc5, 0xc0, 0x2d, 0x89, 0xca, 0xa3, 0x24, 0xc5, 0x3e, 0x3b, 0x2d, 0xd9, 0x9a,
+	0x47, 0xe1, 0x6f, 0x2c, 0x98, 0x8c, 0x27, 0xcf, 0x07, 0xd0, 0xd8, 0x15, 0xdb, 0x32, 0x7d, 0x58,
+	0x3c, 0x78, 0x4c, 0x93, 0x0c, 0x21, 0xad, 0x50, 0xcb, 0xec, 0x39, 0x93, 0x80, 0xe8, 0x53, 0x68,
+	0xc6, 0x4b, 0x5d, 0x0c, 0xc6, 0xe7, 0xfe, 0x72, 0x78, 0x48, 0x9e, 0x03, 0xed, 0xc1, 0xad, 0x7d,
+	0xc6, 0xdc, 0x9d, 0x90, 0x07, 0x81, 0xa6, 0x68, 0xd5, 0xae, 0x22, 0x66, 0x9c, 0x0f, 0xfd, 0x08,
+	0xe6, 0x25, 0x72, 0xcb, 0x75, 0x53, 0x51, 0xf1, 0xcc, 0x8b, 0xc6, 0x6f, 0x33, 0x29, 0x93, 0xca,
+	0x77, 0xc8, 0xcf, 0x03, 0x97, 0x46, 0x2c, 0x71, 0xa1, 0x68, 0xd5, 0x15, 0xf3, 0x52, 0x55, 0x33,
+	0x49, 0x02, 0x44, 0x4a, 0x2c, 0xe5, 0x4f, 0x29, 0x53, 0x63, 0x9f, 0x52, 0xd0, 0x07, 0x6a, 0xc8,
+	0xef, 0xb1, 0xd6, 0xb4, 0xca, 0xca, 0x62, 0xab, 0xda, 0x4e, 0x6e, 0x70, 0x2f, 0x1e, 0xf0, 0x7b,
+	0x0c, 0x9f, 0xc0, 0x62, 0x5a, 0x7d, 0xf4, 0xae, 0x2c, 0x1d, 0xef, 0x50, 0xf5, 0x56, 0xf5, 0xb3,
+	0xc2, 0x3c, 0xb7, 0x74, 0xc4, 0x04, 0xf8, 0xaf, 0x26, 0xcc, 0x97, 0x3e, 0xc1, 0xbd, 0x8b, 0xa2,
+	0xaa, 0xb2, 0x68, 0xde, 0x44, 0x59, 0xac, 0x9a, 0xb5, 0x1f, 0xc3, 0xed, 0xb8, 0xa1, 0x0a, 0xef,
+	0x2b, 0xd6, 0x0d, 0x58, 0xd8, 0x15, 0xcc, 0xe1, 0x7e, 0x3c, 0x28, 0x9a, 0x04, 0xa9, 0xcd, 0x8e,
+	0xf7, 0x15, 0x3b, 0x60, 0x61, 0x47, 0xed, 0xa0, 0x67, 0x30, 0x27, 0x7c, 0x1a, 0x88, 0x3e, 0x8f,
+	0x92, 0x62, 0x3a, 0xa9, 0xac, 0x7b, 0x54, 0xca, 0x34, 0x2f, 0xf2, 0xe8, 0xa0, 0x93, 0x50, 0xc6,
+	0x36, 0xce, 0x8a, 0x3c, 0x28, 0x2d, 0x4c, 0x25, 0x85, 0xfc, 0x54, 0xa8, 0xaa, 0x5c, 0x23, 0x33,
+	0x1a, 0x49, 0xf8, 0xa9, 0xc0, 0x5f, 0x1b, 0x80, 0x72, 0x21, 0xbb, 0xa1, 0x02, 0xfc, 0x39, 0xcc,
+	0x1e, 0x65, 0x42, 0xd3, 0x0f, 0x2c, 0x8f, 0xaa, 0x1b, 0x56, 0x5e, 0x7f, 0x91, 0x0f, 0xbb, 0x30,
+	0x93, 0x1f, 0x11, 0x10, 0x82, 0x5a, 0xe4, 0x0d, 0xe3, 0x6a, 0xd9, 0x20, 0x6a, 0x2d, 0x71, 0x3e,
+	0x77, 0x75, 0x2f, 0x56, 0x6b, 0x89, 0x73, 0x24, 0xce, 0x8a, 0x71, 0x72, 0x2d, 0x2b, 0xc4, 0x30,
+	0xfe, 0x3e, 0xa3, 0xdc, 0xdf, 0x20, 0x1a, 0xc4, 0x1f, 0xc1, 0x4c, 0x3e, 0x4f, 0x24, 0x77, 0xdf,
+	0xeb, 0xf5, 0x93, 0x6f, 0x90, 0x6a, 0x2d, 0xbf, 0x99, 0x0e, 0xf8, 0x69, 0x52, 0x5b, 0xe4, 0x12,
+	0x1f, 0xc3, 0x4c, 0xde, 0x05, 0x57, 0xe3, 0x52, 0xd6, 0xd2, 0x61, 0x6a, 0x99, 0x5c, 0xcb, 0xca,
+	0x26, 0x7f, 0x45, 0x40, 0x1d, 0x6d, 0x5b, 0x86, 0xc0, 0x7f, 0x32, 0x60, 0xfe, 0xb0, 0x1f, 0xf2,
+	0x51, 0xaf, 0x1f, 0x8c, 0xa2, 0x9f, 0x8d, 0x78, 0x44, 0xaf, 0x1b, 0x9f, 0x0f, 0x60, 0x61, 0x48,
+	0xcf, 0x54, 0x56, 0xe4, 0xb3, 0x32, 0x36, 0xd3, 0x1e, 0xd2, 0x33, 0x99, 0x1b, 0x59, 0x4e, 0x6e,
+	0xc0, 0xa2, 0x24, 0x3f, 0x7a, 0x1d, 0xb1, 0x02, 0x7d, 0x9c, 0xf2, 0xb7, 0x86, 0xf4, 0x6c, 0x5b,
+	0x6e, 0xa5, 0x0c, 0x6b, 0x0f, 0xa1, 0x9e, 0x7c, 0x44, 0x6e, 0xc0, 0xe4, 0x8b, 0xd0, 0x8b, 0x98,
+	0x3d, 0x81, 0xa6, 0xa1, 0x76, 0x40, 0x85, 0xb0, 0x8d, 0xb5, 0xd5, 0xb8, 0x87, 0x64, 0x9f, 0x46,
+	0x10, 0x40, 0xbd, 0x1d, 0x32, 0xaa, 0xe8, 0x00, 0xea, 0xf1, 0xa3, 0xd3, 0x36, 0xd6, 0x7e, 0x08,
+	0x90, 0x95, 0x1b, 0x29, 0x61, 0xff, 0x8b, 0xfd, 0xa7, 0xf6, 0x04, 0x6a, 0xc2, 0xd4, 0x8b, 0xad,
+	0xdd, 0xc3, 0xdd, 0xfd, 0xcf, 0x6d, 0x43, 0x01, 0x24, 0x06, 0x4c, 0x49, 0xb3, 0x23, 0x69, 0xac,
+	0xb5, 0xef, 0x95, 0x5a, 0x2c, 0x9a, 0x02, 0x6b, 0x6b, 0x30, 0xb0, 0x27, 0x50, 0x1d, 0xcc, 0x9d,
+	0x6d, 0xdb, 0x90, 0x9a, 0xf6, 0x79, 0x38, 0xa4, 0x03, 0xdb, 0x5c, 0xfb, 0x18, 0xe6, 0x8a, 0x57,
+	0x5e, 0x89, 0xe5, 0xe1, 0x89, 0xe7, 0xf7, 0x62, 0x85, 0x9d, 0x48, 0xd5, 0xf1, 0x58, 0x61, 0x6c,
+	0xa1, 0x6b, 0x9b, 0x6b, 0x1d, 0x58, 0xac, 0xba, 0x8d, 0xc8, 0x86, 0x19, 0x8d, 0xd8, 0xe7, 0xbe,
+	0x3c, 0xd8, 0x02, 0xcc, 0x6b, 0xcc, 0x4f, 0x39, 0x75, 0xa5, 0x60, 0x03, 0x2d, 0x82, 0xad, 0x91,
+	0x9f, 0x79, 0xbe, 0x27, 0xfa, 0x52, 0xe8, 0xf6, 0x8f, 0xff, 0xf2, 0x66, 0xd9, 0xf8, 0xf6, 0xcd,
+	0xb2, 0xf1, 0xcf, 0x37, 0xcb, 0xc6, 0x6f, 0xdf, 0x2e, 0x4f, 0x7c, 0xfb, 0x76, 0x79, 0xe2, 0x1f,
+	0x6f, 0x97, 0x27, 0x7e, 0xf9, 0xff, 0x3d, 0x2f, 0xea, 0x8f, 0x8e, 0xd6, 0x1d, 0x3e, 0xdc, 0x08,
+	0x3c, 0xbf, 0xe7, 0xd0, 0x60, 0x23, 0xf2, 0x1c, 0xd7, 0xd9, 0xc8, 0xc5, 0xfe, 0xa8, 0xae, 0xfe,
+	0xbc, 0xf9, 0xf0, 0xbf, 0x03, 0x00, 0xb3, 0x89, 0x42, 0x60, 0xdb, 0x19, 0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -4209,6 +4326,16 @@ func (m *TableSpanStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SnapshotRows != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SnapshotRows))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.SnapshotState != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.SnapshotState))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.EventSizePerSecond != 0 {
 		i -= 4
 		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
@@ -4420,6 +4547,51 @@ func (m *ChangefeedID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ThroughputQuota) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThroughputQuota) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThroughputQuota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytesPerSecond != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaxBytesPerSecond))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxRowsPerSecond != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.MaxRowsPerSecond))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintHeartbeat(dAtA []byte, offset int, v uint64) int {
 	offset -= sovHeartbeat(v)
 	base := offset
@@ -5104,6 +5276,12 @@ func (m *TableSpanStatus) Size() (n int) {
 	if m.EventSizePerSecond != 0 {
 		n += 5
 	}
+	if m.SnapshotState != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SnapshotState))
+	}
+	if m.SnapshotRows != 0 {
+		n += 1 + sovHeartbeat(uint64(m.SnapshotRows))
+	}
 	return n
 }
 
@@ -5189,6 +5367,25 @@ func (m *ChangefeedID) Size() (n int) {
 	return n
 }
 
+func (m *ThroughputQuota) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.MaxRowsPerSecond != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaxRowsPerSecond))
+	}
+	if m.MaxBytesPerSecond != 0 {
+		n += 1 + sovHeartbeat(uint64(m.MaxBytesPerSecond))
+	}
+	return n
+}
+
 func sovHeartbeat(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -9651,6 +9848,44 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
 			iNdEx += 4
 			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotState", wireType)
+			}
+			m.SnapshotState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotState |= InitialSnapshotState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotRows", wireType)
+			}
+			m.SnapshotRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -10210,6 +10445,130 @@ func (m *ChangefeedID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ThroughputQuota) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThroughputQuota: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThroughputQuota: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRowsPerSecond", wireType)
+			}
+			m.MaxRowsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRowsPerSecond |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytesPerSecond", wireType)
+			}
+			m.MaxBytesPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytesPerSecond |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipHeartbeat(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -153,6 +153,9 @@ type eventStore struct {
 	gcManager *gcManager
 
 	messageCenter messaging.MessageCenter
+	// reportState is true if the store uploads its subscriptions to the log coordinator,
+	// only the store of the default upstream does it.
+	reportState bool
 
 	coordinatorInfo struct {
 		sync.RWMutex
@@ -184,6 +187,33 @@ func New(
 	subClient *logpuller.SubscriptionClient,
 	pdClock pdutil.Clock,
 ) EventStore {
+	store := newEventStore(root, subClient, pdClock)
+	store.reportState = true
+
+	// recv and handle messages
+	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
+	store.messageCenter = messageCenter
+	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
+
+	return store
+}
+
+// NewForUpstream creates the event store of an upstream other than the default one.
+// The log coordinator only tracks the event stores of the default upstream,
+// so the store doesn't report its subscriptions.
+func NewForUpstream(
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) EventStore {
+	return newEventStore(root, subClient, pdClock)
+}
+
+func newEventStore(
+	root string,
+	subClient *logpuller.SubscriptionClient,
+	pdClock pdutil.Clock,
+) *eventStore {
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
 	// FIXME: avoid remove
@@ -211,12 +241,6 @@ func New(
 	store.dispatcherMeta.dispatcherStats = make(map[common.DispatcherID]*dispatcherStat)
 	store.dispatcherMeta.subscriptionStats = make(map[logpuller.SubscriptionID]*subscriptionStat)
 	store.dispatcherMeta.tableToDispatchers = make(map[int64]map[common.DispatcherID]bool)
-
-	// recv and handle messages
-	messageCenter := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
-	store.messageCenter = messageCenter
-	messageCenter.RegisterHandler(messaging.EventStoreTopic, store.handleMessage)
-
 	return store
 }
 
@@ -302,9 +326,11 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.updateMetrics(ctx)
 	})
 
-	eg.Go(func() error {
-		return e.uploadStatePeriodically(ctx)
-	})
+	if e.reportState {
+		eg.Go(func() error {
+			return e.uploadStatePeriodically(ctx)
+		})
+	}
 
 	return eg.Wait()
 }
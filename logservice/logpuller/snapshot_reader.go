@@ -0,0 +1,93 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tidbkv "github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/codec"
+)
+
+// SnapshotReader reads the rows of a table span from a consistent snapshot of the upstream.
+type SnapshotReader interface {
+	// Scan returns at most limit rows of the span in the snapshot at ts, starting from startKey.
+	// A nil startKey means the scan starts from the beginning of the span.
+	// It also returns the key to continue the scan with, which is nil if all the rows are returned.
+	// The rows are returned as put entries whose StartTs and CRTs are both ts.
+	Scan(ctx context.Context, span *heartbeatpb.TableSpan, ts uint64, startKey []byte, limit int) ([]*common.RawKVEntry, []byte, error)
+}
+
+type kvSnapshotReader struct {
+	kvStorage tidbkv.Storage
+}
+
+// NewSnapshotReader creates a SnapshotReader which reads the snapshot from the kv storage.
+func NewSnapshotReader(kvStorage tidbkv.Storage) SnapshotReader {
+	return &kvSnapshotReader{kvStorage: kvStorage}
+}
+
+func (r *kvSnapshotReader) Scan(
+	ctx context.Context, span *heartbeatpb.TableSpan, ts uint64, startKey []byte, limit int,
+) ([]*common.RawKVEntry, []byte, error) {
+	// The keys of the span are in comparable format, the keys in the storage are not.
+	_, spanStartKey, err := codec.DecodeBytes(span.StartKey, nil)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrScanSnapshot, err, common.FormatTableSpan(span), ts)
+	}
+	_, spanEndKey, err := codec.DecodeBytes(span.EndKey, nil)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrScanSnapshot, err, common.FormatTableSpan(span), ts)
+	}
+	if startKey == nil {
+		startKey = spanStartKey
+	}
+
+	snapshot := r.kvStorage.GetSnapshot(tidbkv.NewVersion(ts))
+	// The snapshot is read only once, don't pollute the block cache of TiKV.
+	snapshot.SetOption(tidbkv.NotFillCache, true)
+	iter, err := snapshot.Iter(startKey, spanEndKey)
+	if err != nil {
+		return nil, nil, cerror.WrapError(cerror.ErrScanSnapshot, err, common.FormatTableSpan(span), ts)
+	}
+	defer iter.Close()
+
+	rows := make([]*common.RawKVEntry, 0, limit)
+	for iter.Valid() {
+		if len(rows) >= limit {
+			return rows, iter.Key().Clone(), nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		key := iter.Key().Clone()
+		value := append([]byte(nil), iter.Value()...)
+		rows = append(rows, &common.RawKVEntry{
+			OpType:   common.OpTypePut,
+			StartTs:  ts,
+			CRTs:     ts,
+			KeyLen:   uint32(len(key)),
+			ValueLen: uint32(len(value)),
+			Key:      key,
+			Value:    value,
+		})
+		if err := iter.Next(); err != nil {
+			return nil, nil, cerror.WrapError(cerror.ErrScanSnapshot, err, common.FormatTableSpan(span), ts)
+		}
+	}
+	return rows, nil, nil
+}
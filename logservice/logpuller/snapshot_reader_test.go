@@ -0,0 +1,71 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpuller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/spanz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotReaderScan(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	job := helper.DDL2Job("create table test.t(id int primary key, name varchar(32))")
+	helper.Tk().MustExec("insert into test.t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e')")
+	version, err := helper.Storage().CurrentVersion("")
+	require.NoError(t, err)
+	snapshotTs := version.Ver
+	// The rows written after the snapshot ts must not be read.
+	helper.Tk().MustExec("insert into test.t values (6, 'f')")
+	helper.Tk().MustExec("delete from test.t where id = 1")
+
+	tableSpan := spanz.TableIDToComparableSpan(job.TableID)
+	span := &heartbeatpb.TableSpan{
+		TableID:  tableSpan.TableID,
+		StartKey: tableSpan.StartKey,
+		EndKey:   tableSpan.EndKey,
+	}
+
+	reader := NewSnapshotReader(helper.Storage())
+	var (
+		startKey []byte
+		total    int
+		batches  int
+	)
+	for {
+		rows, nextKey, err := reader.Scan(context.Background(), span, snapshotTs, startKey, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(rows), 2)
+		for _, row := range rows {
+			require.Equal(t, snapshotTs, row.CRTs)
+			require.Equal(t, snapshotTs, row.StartTs)
+			require.NotNil(t, row.Value)
+			require.Nil(t, row.OldValue)
+		}
+		total += len(rows)
+		batches++
+		if nextKey == nil {
+			break
+		}
+		startKey = nextKey
+	}
+	require.Equal(t, 5, total)
+	require.Equal(t, 3, batches)
+}
@@ -15,6 +15,7 @@ package logpuller
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -234,6 +235,9 @@ func NewSubscriptionClient(
 	option.BatchCount = 1024
 	option.UseBuffer = false
 	option.EnableMemoryControl = true
+	// Balance the subscriptions between the streams, so a few hot subscriptions
+	// hashed to the same stream don't make it the bottleneck.
+	option.SchedulerInterval = dynstream.DefaultSchedulerInterval
 	ds := dynstream.NewParallelDynamicStream(
 		func(subID SubscriptionID) uint64 { return uint64(subID) },
 		&regionEventHandler{subClient: subClient},
@@ -276,6 +280,12 @@ func (s *SubscriptionClient) updateMetrics(ctx context.Context) error {
 			dsMetrics := s.ds.GetMetrics()
 			metricSubscriptionClientDSChannelSize.Set(float64(dsMetrics.EventChanSize))
 			metricSubscriptionClientDSPendingQueueLen.Set(float64(dsMetrics.PendingQueueLen))
+			metrics.DynamicStreamArrangeStreamNum.WithLabelValues("log-puller", "move_path").Set(float64(dsMetrics.MovePath))
+			for _, streamMetric := range dsMetrics.Streams {
+				stream := strconv.Itoa(streamMetric.ID)
+				metrics.DynamicStreamStreamPendingQueueLen.WithLabelValues("log-puller", stream).Set(float64(streamMetric.PendingQueueLen))
+				metrics.DynamicStreamStreamPendingSize.WithLabelValues("log-puller", stream).Set(float64(streamMetric.PendingSize))
+			}
 			if len(dsMetrics.MemoryControl.AreaMemoryMetrics) > 1 {
 				log.Panic("subscription client should have only one area")
 			}
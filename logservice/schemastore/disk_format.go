@@ -230,8 +230,9 @@ func loadTablesInKVSnap(
 		// TODO: add a unit test for this case
 		databaseInfo.Tables[tableInfo.ID] = true
 		tablesInKVSnap[tableInfo.ID] = &BasicTableInfo{
-			SchemaID: table_info_entry.SchemaID,
-			Name:     tableInfo.Name.O,
+			SchemaID:   table_info_entry.SchemaID,
+			Name:       tableInfo.Name.O,
+			IsSequence: tableInfo.IsSequence(),
 		}
 		if tableInfo.Partition != nil {
 			partitionInfo := make(BasicPartitionInfo)
@@ -290,8 +291,9 @@ func loadFullTablesInKVSnap(
 		tableInfosInKVSnap[tableInfo.ID] = &tableInfo
 		databaseInfo.Tables[tableInfo.ID] = true
 		tablesInKVSnap[tableInfo.ID] = &BasicTableInfo{
-			SchemaID: table_info_entry.SchemaID,
-			Name:     tableInfo.Name.O,
+			SchemaID:   table_info_entry.SchemaID,
+			Name:       tableInfo.Name.O,
+			IsSequence: tableInfo.IsSequence(),
 		}
 		if tableInfo.Partition != nil {
 			partitionInfo := make(BasicPartitionInfo)
@@ -487,8 +489,17 @@ func writeSchemaInfoToBatch(batch *pebble.Batch, ts uint64, info *model.DBInfo)
 	batch.Set(schemaKey, schemaValue, pebble.NoSync)
 }
 
-func writeTableInfoToBatch(batch *pebble.Batch, ts uint64, dbInfo *model.DBInfo, tableInfoValue []byte) (int64, string) {
-	tbNameInfo := model.TableNameInfo{}
+// tableNameAndSequenceInfo is used to decode the id, name and sequence info of a table
+// without unmarshalling the whole table info.
+type tableNameAndSequenceInfo struct {
+	model.TableNameInfo
+	Sequence *model.SequenceInfo `json:"sequence"`
+}
+
+func writeTableInfoToBatch(
+	batch *pebble.Batch, ts uint64, dbInfo *model.DBInfo, tableInfoValue []byte,
+) (int64, string, bool) {
+	tbNameInfo := tableNameAndSequenceInfo{}
 	if err := json.Unmarshal(tableInfoValue, &tbNameInfo); err != nil {
 		log.Fatal("unmarshal table info failed", zap.Error(err))
 	}
@@ -506,7 +517,7 @@ func writeTableInfoToBatch(batch *pebble.Batch, ts uint64, dbInfo *model.DBInfo,
 		log.Fatal("marshal table info entry failed", zap.Error(err))
 	}
 	batch.Set(tableKey, tableInfoEntryValue, pebble.NoSync)
-	return tbNameInfo.ID, tbNameInfo.Name.O
+	return tbNameInfo.ID, tbNameInfo.Name.O, tbNameInfo.Sequence != nil
 }
 
 func writeSchemaSnapshotAndMeta(
@@ -548,11 +559,12 @@ func writeSchemaSnapshotAndMeta(
 			if !isTableRawKey(rawTable.Field) {
 				continue
 			}
-			tableID, tableName := writeTableInfoToBatch(batch, snapTs, dbInfo, rawTable.Value)
+			tableID, tableName, isSequence := writeTableInfoToBatch(batch, snapTs, dbInfo, rawTable.Value)
 			if needTableInfo {
 				tablesInKVSnap[tableID] = &BasicTableInfo{
-					SchemaID: dbInfo.ID,
-					Name:     tableName,
+					SchemaID:   dbInfo.ID,
+					Name:       tableName,
+					IsSequence: isSequence,
 				}
 				tables[tableID] = true
 			}
@@ -705,6 +717,10 @@ func loadAllPhysicalTablesAtTs(
 		if !ok {
 			log.Panic("table info not found", zap.Int64("tableID", tableID))
 		}
+		// sequences are synced by the table trigger dispatcher, they don't need dispatchers.
+		if fullTableInfo.IsSequence() {
+			continue
+		}
 		if tableFilter != nil && tableFilter.ShouldIgnoreTable(schemaName, tableInfo.Name, fullTableInfo) {
 			continue
 		}
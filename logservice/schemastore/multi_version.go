@@ -204,7 +204,7 @@ func (v *versionedTableInfoStore) doApplyDDL(event *PersistedDDLEvent) {
 	}
 	tableInfo, deleted := handler.extractTableInfoFunc(event, v.tableID)
 	if tableInfo != nil {
-		if ddlType == model.ActionRecoverTable {
+		if ddlType == model.ActionRecoverTable || ddlType == model.ActionRecoverSchema {
 			v.deleteVersion = math.MaxUint64
 		} else {
 			assertNonDeleted(v)
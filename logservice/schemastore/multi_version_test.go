@@ -14,6 +14,7 @@
 package schemastore
 
 import (
+	"math"
 	"testing"
 
 	"github.com/pingcap/ticdc/pkg/common"
@@ -151,6 +152,31 @@ func TestBuildVersionedTableInfoStore(t *testing.T) {
 				},
 			},
 		},
+		// test recover schema
+		{
+			testName: "recover schema",
+			tableID:  300,
+			ddlEvents: func() []*PersistedDDLEvent {
+				return []*PersistedDDLEvent{
+					buildCreateTableEventForTest(10, 300, "test", "normal_table", 1010),   // create table 300
+					buildDropTableEventForTest(10, 300, "test", "normal_table", 1020),     // drop table 300
+					buildRecoverSchemaEventForTest(10, 300, "test", "normal_table", 1030), // recover schema 10 with table 300
+				}
+			}(),
+			queryCases: []QueryTableInfoTestCase{
+				{
+					snapTs:     1010,
+					schemaName: "test",
+					tableName:  "normal_table",
+				},
+				{
+					snapTs:     1030,
+					schemaName: "test",
+					tableName:  "normal_table",
+				},
+			},
+			deleteVersion: math.MaxUint64,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
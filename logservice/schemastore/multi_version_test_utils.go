@@ -60,6 +60,21 @@ func buildRecoverTableEventForTest(schemaID, tableID int64, schemaName, tableNam
 	}
 }
 
+func buildRecoverSchemaEventForTest(schemaID, tableID int64, schemaName, tableName string, finishedTs uint64) *PersistedDDLEvent {
+	return &PersistedDDLEvent{
+		Type:       byte(model.ActionRecoverSchema),
+		SchemaID:   schemaID,
+		SchemaName: schemaName,
+		MultipleTableInfos: []*model.TableInfo{
+			{
+				ID:   tableID,
+				Name: pmodel.NewCIStr(tableName),
+			},
+		},
+		FinishedTs: finishedTs,
+	}
+}
+
 func buildCreatePartitionTableEventForTest(schemaID, tableID int64, schemaName, tableName string, partitionIDs []int64, finishedTs uint64) *PersistedDDLEvent {
 	partitionDefinitions := make([]model.PartitionDefinition, 0, len(partitionIDs))
 	for _, partitionID := range partitionIDs {
@@ -25,12 +25,14 @@ import (
 	"github.com/cockroachdb/pebble/bloom"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
@@ -640,6 +642,12 @@ func (p *persistentStorage) persistUpperBoundPeriodically(ctx context.Context) e
 }
 
 func (p *persistentStorage) handleDDLJob(job *model.Job) error {
+	if job.Type == model.ActionRecoverSchema {
+		if err := p.fillRecoveredTableInfos(job); err != nil {
+			return err
+		}
+	}
+
 	p.mu.Lock()
 
 	if shouldSkipDDL(job, p.tableMap) {
@@ -709,6 +717,99 @@ func (p *persistentStorage) handleDDLJob(job *model.Job) error {
 	return nil
 }
 
+// fillRecoveredTableInfos fills the tables recovered by `FLASHBACK DATABASE` to job.BinlogInfo.MultipleTableInfos,
+// because TiDB only records the schema info in the binlog info of the finished job.
+func (p *persistentStorage) fillRecoveredTableInfos(job *model.Job) error {
+	if len(job.BinlogInfo.MultipleTableInfos) != 0 {
+		return nil
+	}
+	args, err := model.GetRecoverArgs(job)
+	if err == nil && args.RecoverInfo != nil && !args.RecoverInfo.LoadTablesOnExecute {
+		tableInfos := make([]*model.TableInfo, 0, len(args.RecoverTableInfos()))
+		for _, info := range args.RecoverTableInfos() {
+			tableInfos = append(tableInfos, info.TableInfo)
+		}
+		job.BinlogInfo.MultipleTableInfos = tableInfos
+		return nil
+	}
+	if err != nil {
+		log.Warn("get recover schema args failed, read recovered tables from snapshot",
+			zap.Int64("jobID", job.ID), zap.Error(err))
+	}
+	// The recovered tables may be loaded by the ddl owner when the job is executed,
+	// so read them from the snapshot at the finished ts of the job.
+	tableInfos, err := logpuller.GetSnapshotMeta(p.kvStorage, job.BinlogInfo.FinishedTS).ListTables(job.SchemaID)
+	if err != nil {
+		log.Warn("list recovered tables failed",
+			zap.Int64("jobID", job.ID),
+			zap.Int64("schemaID", job.SchemaID),
+			zap.Uint64("finishedTs", job.BinlogInfo.FinishedTS),
+			zap.Error(err))
+		return errors.Trace(err)
+	}
+	job.BinlogInfo.MultipleTableInfos = tableInfos
+	return nil
+}
+
+// getSequenceValues returns the values of all the sequences which are not filtered at snapTs,
+// the values are read from the auto id meta of the upstream.
+func (p *persistentStorage) getSequenceValues(snapTs uint64, tableFilter filter.Filter) ([]commonEvent.SequenceValue, error) {
+	type sequence struct {
+		schemaID   int64
+		tableID    int64
+		schemaName string
+	}
+	p.mu.RLock()
+	sequences := make([]sequence, 0)
+	for tableID, tableInfo := range p.tableMap {
+		if !tableInfo.IsSequence {
+			continue
+		}
+		databaseInfo, ok := p.databaseMap[tableInfo.SchemaID]
+		if !ok {
+			continue
+		}
+		if tableFilter != nil && tableFilter.ShouldIgnoreTable(databaseInfo.Name, tableInfo.Name, nil) {
+			continue
+		}
+		sequences = append(sequences, sequence{
+			schemaID:   tableInfo.SchemaID,
+			tableID:    tableID,
+			schemaName: databaseInfo.Name,
+		})
+	}
+	p.mu.RUnlock()
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+
+	snapMeta := logpuller.GetSnapshotMeta(p.kvStorage, snapTs)
+	values := make([]commonEvent.SequenceValue, 0, len(sequences))
+	for _, seq := range sequences {
+		// The sequence may be created or dropped after snapTs.
+		tableInfo, err := snapMeta.GetTable(seq.schemaID, seq.tableID)
+		if err != nil {
+			if meta.ErrDBNotExists.Equal(errors.Cause(err)) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		if tableInfo == nil || !tableInfo.IsSequence() {
+			continue
+		}
+		value, err := snapMeta.GetAutoIDAccessors(seq.schemaID, seq.tableID).SequenceValue().Get()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		values = append(values, commonEvent.SequenceValue{
+			SchemaName:   seq.schemaName,
+			SequenceName: tableInfo.Name.O,
+			Value:        value,
+		})
+	}
+	return values, nil
+}
+
 func shouldSkipDDL(job *model.Job, tableMap map[int64]*BasicTableInfo) bool {
 	switch model.ActionType(job.Type) {
 	// Skipping ActionCreateTable and ActionCreateTables when the table already exists:
@@ -719,7 +820,7 @@ func shouldSkipDDL(job *model.Job, tableMap map[int64]*BasicTableInfo) bool {
 	//    One of these actions could be garbage collected, leaving the table present in the snapshot.
 	//    Therefore, the only reliable way to determine if a later DDL operation is redundant
 	//    is by verifying whether the table already exists.
-	case model.ActionCreateTable:
+	case model.ActionCreateTable, model.ActionCreateSequence:
 		// Note: partition table's logical table id is also in tableMap
 		if _, ok := tableMap[job.BinlogInfo.TableInfo.ID]; ok {
 			log.Info("table already exists. ignore DDL",
@@ -749,26 +850,10 @@ func shouldSkipDDL(job *model.Job, tableMap map[int64]*BasicTableInfo) bool {
 		model.ActionRepairTable,
 		model.ActionSetTiFlashReplica,
 		model.ActionUpdateTiFlashReplicaStatus,
-		model.ActionCreateSequence,
-		model.ActionAlterSequence,
-		model.ActionDropSequence,
 		model.ActionModifyTableAutoIDCache,
 		model.ActionRebaseAutoRandomBase,
-		model.ActionAddCheckConstraint,
-		model.ActionDropCheckConstraint,
-		model.ActionAlterCheckConstraint,
-		model.ActionAlterTableAttributes,
-		model.ActionAlterTablePartitionAttributes,
-		model.ActionCreatePlacementPolicy,
-		model.ActionAlterPlacementPolicy,
-		model.ActionDropPlacementPolicy,
-		model.ActionAlterTablePartitionPlacement,
-		model.ActionModifySchemaDefaultPlacement,
-		model.ActionAlterTablePlacement,
 		model.ActionAlterCacheTable,
 		model.ActionAlterNoCacheTable,
-		model.ActionFlashbackCluster,
-		model.ActionRecoverSchema,
 		model.ActionCreateResourceGroup,
 		model.ActionAlterResourceGroup,
 		model.ActionDropResourceGroup:
@@ -14,6 +14,7 @@
 package schemastore
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -21,10 +22,13 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	pmodel "github.com/pingcap/tidb/pkg/parser/model"
-	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tidb/pkg/util/mock"
 	"go.uber.org/zap"
 )
 
@@ -445,6 +449,24 @@ var allDDLHandlers = map[model.ActionType]*persistStorageDDLHandler{
 		extractTableInfoFunc:       extractTableInfoFuncForAlterTablePartitioning,
 		buildDDLEventFunc:          buildDDLEventForAlterTablePartitioning,
 	},
+	model.ActionRecoverSchema: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForRecoverSchema,
+		updateDDLHistoryFunc:       updateDDLHistoryForCreateTables,
+		updateFullTableInfoFunc:    updateFullTableInfoForMultiTablesDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataForRecoverSchema,
+		iterateEventTablesFunc:     iterateEventTablesForCreateTables,
+		extractTableInfoFunc:       extractTableInfoFuncForCreateTables,
+		buildDDLEventFunc:          buildDDLEventForRecoverSchema,
+	},
+	model.ActionFlashbackCluster: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventCommon,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoIgnore,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForFlashbackCluster,
+	},
 	model.ActionRemovePartitioning: {
 		buildPersistedDDLEventFunc: buildPersistedDDLEventForRemovePartitioning,
 		updateDDLHistoryFunc:       updateDDLHistoryForRemovePartitioning,
@@ -454,6 +476,132 @@ var allDDLHandlers = map[model.ActionType]*persistStorageDDLHandler{
 		extractTableInfoFunc:       extractTableInfoFuncForRemovePartitioning,
 		buildDDLEventFunc:          buildDDLEventForRemovePartitioning,
 	},
+	model.ActionAddCheckConstraint: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionDropCheckConstraint: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionAlterCheckConstraint: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionAlterTableAttributes: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionAlterTablePartitionAttributes: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionAlterTablePlacement: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionAlterTablePartitionPlacement: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForNormalDDLOnSingleTable,
+		updateDDLHistoryFunc:       updateDDLHistoryForNormalDDLOnSingleTable,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesForSingleTableDDL,
+		extractTableInfoFunc:       extractTableInfoFuncForSingleTableDDL,
+		buildDDLEventFunc:          buildDDLEventForNormalDDLOnSingleTableForTiDB,
+	},
+	model.ActionModifySchemaDefaultPlacement: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForSchemaDDL,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoIgnore,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForModifySchemaDefaultPlacement,
+	},
+	model.ActionCreatePlacementPolicy: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForPlacementPolicy,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoIgnore,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForPlacementPolicy,
+	},
+	model.ActionAlterPlacementPolicy: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForPlacementPolicy,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoIgnore,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForPlacementPolicy,
+	},
+	model.ActionDropPlacementPolicy: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForPlacementPolicy,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoIgnore,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForPlacementPolicy,
+	},
+	model.ActionCreateSequence: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForSequenceDDL,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataForNewTableDDL,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForSequenceDDL,
+	},
+	model.ActionAlterSequence: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForSequenceDDL,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoForSingleTableDDL,
+		updateSchemaMetadataFunc:   updateSchemaMetadataIgnore,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForSequenceDDL,
+	},
+	model.ActionDropSequence: {
+		buildPersistedDDLEventFunc: buildPersistedDDLEventForDropSequence,
+		updateDDLHistoryFunc:       updateDDLHistoryForTableTriggerOnlyDDL,
+		updateFullTableInfoFunc:    updateFullTableInfoForDropTable,
+		updateSchemaMetadataFunc:   updateSchemaMetadataForDropTable,
+		iterateEventTablesFunc:     iterateEventTablesIgnore,
+		extractTableInfoFunc:       extractTableInfoFuncIgnore,
+		buildDDLEventFunc:          buildDDLEventForSequenceDDL,
+	},
 }
 
 func isPartitionTable(tableInfo *model.TableInfo) bool {
@@ -716,6 +864,12 @@ func buildPersistedDDLEventForCreateTables(args buildPersistedDDLEventFuncArgs)
 	return event
 }
 
+func buildPersistedDDLEventForRecoverSchema(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
+	event := buildPersistedDDLEventForSchemaDDL(args)
+	event.MultipleTableInfos = args.job.BinlogInfo.MultipleTableInfos
+	return event
+}
+
 func buildPersistedDDLEventForAlterTablePartitioning(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
 	event := buildPersistedDDLEventCommon(args)
 	event.ExtraTableID = event.TableID
@@ -760,6 +914,33 @@ func buildPersistedDDLEventForRemovePartitioning(args buildPersistedDDLEventFunc
 // =======
 // updateDDLHistoryFunc begin
 // =======
+func buildPersistedDDLEventForPlacementPolicy(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
+	event := buildPersistedDDLEventCommon(args)
+	// Note: the schema id in the placement policy job is the id of the policy.
+	event.SchemaID = 0
+	return event
+}
+
+func buildPersistedDDLEventForSequenceDDL(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
+	event := buildPersistedDDLEventCommon(args)
+	event.SchemaName = getSchemaName(args.databaseMap, event.SchemaID)
+	// Note: the sequence may be absent from the table map if it was created when
+	// sequences were not tracked yet, so prefer the name in the job.
+	if event.TableInfo != nil {
+		event.TableName = event.TableInfo.Name.O
+	} else {
+		event.TableName = getTableName(args.tableMap, event.TableID)
+	}
+	return event
+}
+
+func buildPersistedDDLEventForDropSequence(args buildPersistedDDLEventFuncArgs) PersistedDDLEvent {
+	event := buildPersistedDDLEventForSequenceDDL(args)
+	// The query in job maybe "DROP SEQUENCE test1.seq1, test2.seq2", we need rebuild it here.
+	event.Query = fmt.Sprintf("DROP SEQUENCE `%s`.`%s`", event.SchemaName, event.TableName)
+	return event
+}
+
 func getCreatedIDs(oldIDs []int64, newIDs []int64) []int64 {
 	oldIDsMap := make(map[int64]interface{}, len(oldIDs))
 	for _, id := range oldIDs {
@@ -1009,8 +1190,9 @@ func updateSchemaMetadataForNewTableDDL(args updateSchemaMetadataFuncArgs) {
 	schemaID := args.event.SchemaID
 	args.addTableToDB(tableID, schemaID)
 	args.tableMap[tableID] = &BasicTableInfo{
-		SchemaID: schemaID,
-		Name:     args.event.TableInfo.Name.O,
+		SchemaID:   schemaID,
+		Name:       args.event.TableInfo.Name.O,
+		IsSequence: args.event.TableInfo.IsSequence(),
 	}
 	if isPartitionTable(args.event.TableInfo) {
 		partitionInfo := make(BasicPartitionInfo)
@@ -1135,8 +1317,9 @@ func updateSchemaMetadataForCreateTables(args updateSchemaMetadataFuncArgs) {
 	for _, info := range args.event.MultipleTableInfos {
 		args.addTableToDB(info.ID, args.event.SchemaID)
 		args.tableMap[info.ID] = &BasicTableInfo{
-			SchemaID: args.event.SchemaID,
-			Name:     info.Name.O,
+			SchemaID:   args.event.SchemaID,
+			Name:       info.Name.O,
+			IsSequence: info.IsSequence(),
 		}
 		if isPartitionTable(info) {
 			partitionInfo := make(BasicPartitionInfo)
@@ -1148,6 +1331,11 @@ func updateSchemaMetadataForCreateTables(args updateSchemaMetadataFuncArgs) {
 	}
 }
 
+func updateSchemaMetadataForRecoverSchema(args updateSchemaMetadataFuncArgs) {
+	updateSchemaMetadataForCreateSchema(args)
+	updateSchemaMetadataForCreateTables(args)
+}
+
 func updateSchemaMetadataForReorganizePartition(args updateSchemaMetadataFuncArgs) {
 	tableID := args.event.TableID
 	physicalIDs := getAllPartitionIDs(args.event.TableInfo)
@@ -2211,6 +2399,140 @@ func buildDDLEventForCreateTables(rawEvent *PersistedDDLEvent, tableFilter filte
 	return ddlEvent, true
 }
 
+func buildDDLEventForRecoverSchema(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	if tableFilter != nil && tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.SchemaName, "", nil) {
+		return commonEvent.DDLEvent{}, false
+	}
+	ddlEvent, _ := buildDDLEventCommon(rawEvent, tableFilter, WithoutTiDBOnly)
+	ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+	}
+	// The recovered tables keep their original table ids,
+	// the dispatchers of them are created just like newly created tables.
+	ddlEvent.NeedAddedTables = make([]commonEvent.Table, 0, len(rawEvent.MultipleTableInfos))
+	addName := make([]commonEvent.SchemaTableName, 0, len(rawEvent.MultipleTableInfos))
+	tableInfos := make([]*common.TableInfo, 0, len(rawEvent.MultipleTableInfos))
+	recoveredTables := make([]*model.TableInfo, 0, len(rawEvent.MultipleTableInfos))
+	for _, info := range rawEvent.MultipleTableInfos {
+		// the recovered sequences are created by the query, but they don't need dispatchers.
+		if info.IsSequence() {
+			continue
+		}
+		if tableFilter != nil && tableFilter.ShouldIgnoreTable(rawEvent.SchemaName, info.Name.O, info) {
+			log.Info("build ddl event for recover schema filter table",
+				zap.String("schemaName", rawEvent.SchemaName),
+				zap.String("tableName", info.Name.O))
+			continue
+		}
+		if isPartitionTable(info) {
+			for _, partitionID := range getAllPartitionIDs(info) {
+				ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+					SchemaID: rawEvent.SchemaID,
+					TableID:  partitionID,
+				})
+			}
+		} else {
+			ddlEvent.NeedAddedTables = append(ddlEvent.NeedAddedTables, commonEvent.Table{
+				SchemaID: rawEvent.SchemaID,
+				TableID:  info.ID,
+			})
+		}
+		addName = append(addName, commonEvent.SchemaTableName{
+			SchemaName: rawEvent.SchemaName,
+			TableName:  info.Name.O,
+		})
+		tableInfos = append(tableInfos, common.WrapTableInfo(rawEvent.SchemaID, rawEvent.SchemaName, info))
+		recoveredTables = append(recoveredTables, info)
+	}
+	ddlEvent.TableNameChange = &commonEvent.TableNameChange{
+		AddName: addName,
+	}
+	ddlEvent.MultipleTableInfos = tableInfos
+	// `FLASHBACK DATABASE` can only be executed by TiDB,
+	// other downstreams create the schema and the recovered tables instead.
+	queries, err := buildCreateQueriesForRecoverSchema(rawEvent.SchemaName, recoveredTables)
+	if err != nil {
+		log.Warn("build create queries for recover schema failed",
+			zap.String("schemaName", rawEvent.SchemaName),
+			zap.String("query", rawEvent.Query),
+			zap.Error(err))
+		ddlEvent.Err = err
+		return ddlEvent, true
+	}
+	ddlEvent.NonTiDBQueries = queries
+	return ddlEvent, true
+}
+
+// buildCreateQueriesForRecoverSchema returns the `CREATE DATABASE` query of the schema,
+// followed by the `CREATE TABLE` query of each table in tableInfos.
+func buildCreateQueriesForRecoverSchema(schemaName string, tableInfos []*model.TableInfo) ([]string, error) {
+	queries := make([]string, 0, len(tableInfos)+1)
+	queries = append(queries, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", common.QuoteName(schemaName)))
+	sctx := mock.NewContext()
+	for _, info := range tableInfos {
+		var buf bytes.Buffer
+		if err := executor.ConstructResultOfShowCreateTable(sctx, info, autoid.Allocators{}, &buf); err != nil {
+			return nil, cerror.Trace(err)
+		}
+		queries = append(queries, buf.String())
+	}
+	return queries, nil
+}
+
+// buildDDLEventForFlashbackCluster builds a ddl event with error,
+// because the changefeed can't keep consistent with the upstream after it is rolled back.
+func buildDDLEventForFlashbackCluster(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	ddlEvent, _ := buildDDLEventCommon(rawEvent, tableFilter, WithoutTiDBOnly)
+	ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+	}
+	ddlEvent.Err = cerror.ErrSyncFlashbackClusterFailed.GenWithStackByArgs(rawEvent.Query)
+	return ddlEvent, true
+}
+
+// buildDDLEventForModifySchemaDefaultPlacement only blocks the table trigger dispatcher,
+// because the default placement of a schema only takes effect on the tables created later.
+func buildDDLEventForModifySchemaDefaultPlacement(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	if tableFilter != nil && tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), rawEvent.SchemaName, "", nil) {
+		return commonEvent.DDLEvent{}, false
+	}
+	ddlEvent, _ := buildDDLEventCommon(rawEvent, tableFilter, WithTiDBOnly)
+	ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+	}
+	return ddlEvent, true
+}
+
+func buildDDLEventForPlacementPolicy(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	if tableFilter != nil && tableFilter.ShouldDiscardDDL(model.ActionType(rawEvent.Type), "", "", nil) {
+		return commonEvent.DDLEvent{}, false
+	}
+	ddlEvent, _ := buildDDLEventCommon(rawEvent, tableFilter, WithTiDBOnly)
+	ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+	}
+	return ddlEvent, true
+}
+
+// buildDDLEventForSequenceDDL builds the ddl event for create/alter/drop sequence.
+// Sequences only exist in TiDB and have no data to replicate,
+// so the ddl only blocks the table trigger dispatcher.
+func buildDDLEventForSequenceDDL(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
+	ddlEvent, ok := buildDDLEventCommon(rawEvent, tableFilter, WithTiDBOnly)
+	if !ok {
+		return ddlEvent, false
+	}
+	ddlEvent.BlockedTables = &commonEvent.InfluencedTables{
+		InfluenceType: commonEvent.InfluenceTypeNormal,
+		TableIDs:      []int64{heartbeatpb.DDLSpan.TableID},
+	}
+	return ddlEvent, true
+}
+
 func buildDDLEventForAlterTablePartitioning(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) (commonEvent.DDLEvent, bool) {
 	// TODO: only tidb?
 	ddlEvent, ok := buildDDLEventCommon(rawEvent, tableFilter, WithTiDBOnly)
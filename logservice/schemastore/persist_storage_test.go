@@ -24,10 +24,14 @@ import (
 	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/log"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -90,6 +94,439 @@ func TestApplyDDLJobs(t *testing.T) {
 			nil,
 			nil,
 		},
+		// test recover schema can recover table info and partition info
+		{
+			"recover schema",
+			nil,
+			func() []*model.Job {
+				return []*model.Job{
+					buildCreateSchemaJobForTest(100, "test", 1000),                               // create schema 100
+					buildCreateTableJobForTest(100, 200, "t1", 1010),                             // create table 200
+					buildCreatePartitionTableJobForTest(100, 300, "t2", []int64{301, 302}, 1020), // create partition table 300
+					buildDropSchemaJobForTest(100, 1030),                                         // drop schema 100
+					buildRecoverSchemaJobForTest(100, "test", []*model.TableInfo{
+						newEligibleTableInfoForTest(200, "t1"),
+						newEligiblePartitionTableInfoForTest(300, "t2", []model.PartitionDefinition{{ID: 301}, {ID: 302}}),
+					}, 1040), // recover schema 100
+				}
+			}(),
+			map[int64]*BasicTableInfo{
+				200: {
+					SchemaID: 100,
+					Name:     "t1",
+				},
+				300: {
+					SchemaID: 100,
+					Name:     "t2",
+				},
+			},
+			map[int64]BasicPartitionInfo{
+				300: {
+					301: nil,
+					302: nil,
+				},
+			},
+			map[int64]*BasicDatabaseInfo{
+				100: {
+					Name: "test",
+					Tables: map[int64]bool{
+						200: true,
+						300: true,
+					},
+				},
+			},
+			map[int64][]uint64{
+				200: {1010, 1030, 1040},
+				301: {1020, 1030, 1040},
+				302: {1020, 1030, 1040},
+			},
+			[]uint64{1000, 1010, 1020, 1030, 1040},
+			[]PhysicalTableQueryTestCase{
+				{
+					snapTs: 1035,
+					result: []commonEvent.Table{},
+				},
+				{
+					snapTs: 1040,
+					result: []commonEvent.Table{
+						{
+							SchemaID: 100,
+							TableID:  200,
+							SchemaTableName: &commonEvent.SchemaTableName{
+								SchemaName: "test",
+								TableName:  "t1",
+							},
+						},
+						{
+							SchemaID: 100,
+							TableID:  301,
+							SchemaTableName: &commonEvent.SchemaTableName{
+								SchemaName: "test",
+								TableName:  "t2",
+							},
+						},
+						{
+							SchemaID: 100,
+							TableID:  302,
+							SchemaTableName: &commonEvent.SchemaTableName{
+								SchemaName: "test",
+								TableName:  "t2",
+							},
+						},
+					},
+				},
+			},
+			nil,
+			[]FetchTableTriggerDDLEventsTestCase{
+				{
+					startTs: 1030,
+					limit:   10,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionRecoverSchema),
+							FinishedTs: 1040,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+							NeedAddedTables: []commonEvent.Table{
+								{
+									SchemaID: 100,
+									TableID:  200,
+								},
+								{
+									SchemaID: 100,
+									TableID:  301,
+								},
+								{
+									SchemaID: 100,
+									TableID:  302,
+								},
+							},
+							TableNameChange: &commonEvent.TableNameChange{
+								AddName: []commonEvent.SchemaTableName{
+									{
+										SchemaName: "test",
+										TableName:  "t1",
+									},
+									{
+										SchemaName: "test",
+										TableName:  "t2",
+									},
+								},
+							},
+						},
+					},
+				},
+				// filter t2
+				{
+					tableFilter: buildTableFilterByNameForTest("test", "t1"),
+					startTs:     1030,
+					limit:       10,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionRecoverSchema),
+							FinishedTs: 1040,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+							NeedAddedTables: []commonEvent.Table{
+								{
+									SchemaID: 100,
+									TableID:  200,
+								},
+							},
+							TableNameChange: &commonEvent.TableNameChange{
+								AddName: []commonEvent.SchemaTableName{
+									{
+										SchemaName: "test",
+										TableName:  "t1",
+									},
+								},
+							},
+						},
+					},
+				},
+				// filter the whole schema
+				{
+					tableFilter: buildTableFilterByNameForTest("test2", "*"),
+					startTs:     1030,
+					limit:       10,
+					result:      []commonEvent.DDLEvent{},
+				},
+			},
+		},
+		// test flashback cluster only sends a ddl event with error to table trigger
+		{
+			"flashback cluster",
+			nil,
+			func() []*model.Job {
+				return []*model.Job{
+					buildCreateSchemaJobForTest(100, "test", 1000),   // create schema 100
+					buildCreateTableJobForTest(100, 200, "t1", 1010), // create table 200
+					buildFlashbackClusterJobForTest(1020),            // flashback cluster
+				}
+			}(),
+			map[int64]*BasicTableInfo{
+				200: {
+					SchemaID: 100,
+					Name:     "t1",
+				},
+			},
+			nil,
+			map[int64]*BasicDatabaseInfo{
+				100: {
+					Name: "test",
+					Tables: map[int64]bool{
+						200: true,
+					},
+				},
+			},
+			map[int64][]uint64{
+				200: {1010},
+			},
+			[]uint64{1000, 1010, 1020},
+			nil,
+			nil,
+			[]FetchTableTriggerDDLEventsTestCase{
+				{
+					startTs: 1010,
+					limit:   10,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionFlashbackCluster),
+							FinishedTs: 1020,
+							Err:        cerror.ErrSyncFlashbackClusterFailed,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+					},
+				},
+			},
+		},
+		// test sequences are tracked in table map and their ddls are only sent to table trigger
+		{
+			"create/alter/drop sequence",
+			[]mockDBInfo{
+				{
+					dbInfo: &model.DBInfo{
+						ID:   100,
+						Name: pmodel.NewCIStr("test"),
+					},
+					tables: []*model.TableInfo{
+						newSequenceInfoForTest(199, "s0"),
+					},
+				},
+			},
+			func() []*model.Job {
+				return []*model.Job{
+					buildCreateTableJobForTest(100, 200, "t1", 1010),                          // create table 200
+					buildSequenceJobForTest(model.ActionCreateSequence, 100, 300, "s1", 1020), // create sequence 300
+					buildSequenceJobForTest(model.ActionCreateSequence, 100, 301, "s2", 1030), // create sequence 301
+					buildSequenceJobForTest(model.ActionAlterSequence, 100, 300, "s1", 1040),  // alter sequence 300
+					buildSequenceJobForTest(model.ActionDropSequence, 100, 301, "s2", 1050),   // drop sequence 301
+				}
+			}(),
+			map[int64]*BasicTableInfo{
+				199: {
+					SchemaID:   100,
+					Name:       "s0",
+					IsSequence: true,
+				},
+				200: {
+					SchemaID: 100,
+					Name:     "t1",
+				},
+				300: {
+					SchemaID:   100,
+					Name:       "s1",
+					IsSequence: true,
+				},
+			},
+			nil,
+			map[int64]*BasicDatabaseInfo{
+				100: {
+					Name: "test",
+					Tables: map[int64]bool{
+						199: true,
+						200: true,
+						300: true,
+					},
+				},
+			},
+			map[int64][]uint64{
+				200: {1010},
+			},
+			[]uint64{1010, 1020, 1030, 1040, 1050},
+			[]PhysicalTableQueryTestCase{
+				{
+					snapTs: 1050,
+					result: []commonEvent.Table{
+						{
+							SchemaID: 100,
+							TableID:  200,
+							SchemaTableName: &commonEvent.SchemaTableName{
+								SchemaName: "test",
+								TableName:  "t1",
+							},
+						},
+					},
+				},
+			},
+			nil,
+			[]FetchTableTriggerDDLEventsTestCase{
+				{
+					startTs: 1010,
+					limit:   10,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionCreateSequence),
+							FinishedTs: 1020,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+						{
+							Type:       byte(model.ActionCreateSequence),
+							FinishedTs: 1030,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+						{
+							Type:       byte(model.ActionAlterSequence),
+							FinishedTs: 1040,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+						{
+							Type:       byte(model.ActionDropSequence),
+							FinishedTs: 1050,
+							Query:      "DROP SEQUENCE `test`.`s2`",
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+					},
+				},
+			},
+		},
+		// test the optional ddls are only sent when their classes are forwarded
+		{
+			"optional ddls",
+			nil,
+			func() []*model.Job {
+				return []*model.Job{
+					buildCreateSchemaJobForTest(100, "test", 1000),                                        // create schema 100
+					buildCreateTableJobForTest(100, 200, "t1", 1010),                                      // create table 200
+					buildSingleTableDDLJobForTest(model.ActionAddCheckConstraint, 100, 200, "t1", 1020),   // add check constraint to table 200
+					buildSingleTableDDLJobForTest(model.ActionAlterTableAttributes, 100, 200, "t1", 1030), // alter attributes of table 200
+					buildPlacementPolicyJobForTest(model.ActionCreatePlacementPolicy, 500, 1040),          // create placement policy
+					buildModifySchemaDefaultPlacementJobForTest(100, "test", 1050),                        // alter placement policy of schema 100
+					buildSingleTableDDLJobForTest(model.ActionAlterTablePlacement, 100, 200, "t1", 1060),  // alter placement policy of table 200
+				}
+			}(),
+			map[int64]*BasicTableInfo{
+				200: {
+					SchemaID: 100,
+					Name:     "t1",
+				},
+			},
+			nil,
+			map[int64]*BasicDatabaseInfo{
+				100: {
+					Name: "test",
+					Tables: map[int64]bool{
+						200: true,
+					},
+				},
+			},
+			map[int64][]uint64{
+				200: {1010, 1020, 1030, 1060},
+			},
+			[]uint64{1000, 1010, 1040, 1050},
+			nil,
+			[]FetchTableDDLEventsTestCase{
+				{
+					tableID:     200,
+					tableFilter: buildTableFilterWithForwardDDLClassesForTest(),
+					startTs:     1010,
+					endTs:       1060,
+					result:      []commonEvent.DDLEvent{},
+				},
+				{
+					tableID:     200,
+					tableFilter: buildTableFilterWithForwardDDLClassesForTest(config.DDLClassCheckConstraint, config.DDLClassPlacementPolicy),
+					startTs:     1010,
+					endTs:       1060,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionAddCheckConstraint),
+							FinishedTs: 1020,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{200},
+							},
+						},
+						{
+							Type:       byte(model.ActionAlterTablePlacement),
+							FinishedTs: 1060,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{200},
+							},
+						},
+					},
+				},
+			},
+			[]FetchTableTriggerDDLEventsTestCase{
+				{
+					tableFilter: buildTableFilterWithForwardDDLClassesForTest(config.DDLClassCheckConstraint),
+					startTs:     1010,
+					limit:       10,
+					result:      []commonEvent.DDLEvent{},
+				},
+				{
+					tableFilter: buildTableFilterWithForwardDDLClassesForTest(config.DDLClassPlacementPolicy),
+					startTs:     1010,
+					limit:       10,
+					result: []commonEvent.DDLEvent{
+						{
+							Type:       byte(model.ActionCreatePlacementPolicy),
+							FinishedTs: 1040,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+						{
+							Type:       byte(model.ActionModifySchemaDefaultPlacement),
+							FinishedTs: 1050,
+							TiDBOnly:   true,
+							BlockedTables: &commonEvent.InfluencedTables{
+								InfluenceType: commonEvent.InfluenceTypeNormal,
+								TableIDs:      []int64{0},
+							},
+						},
+					},
+				},
+			},
+		},
 		// test create table/drop table/truncate table
 		{
 			"create/drop/truncate table",
@@ -1837,6 +2274,14 @@ func TestApplyDDLJobs(t *testing.T) {
 						if expectedDDLEvent.Query != "" && expectedDDLEvent.Query != actualDDLEvent.Query {
 							return false
 						}
+						// check error
+						if expectedDDLEvent.Err != nil && actualDDLEvent.Err == nil {
+							return false
+						}
+						// check TiDBOnly
+						if expectedDDLEvent.TiDBOnly && !actualDDLEvent.TiDBOnly {
+							return false
+						}
 						// check BlockedTables
 						if expectedDDLEvent.BlockedTables == nil && actualDDLEvent.BlockedTables != nil {
 							return false
@@ -2289,3 +2734,71 @@ func TestGCPersistStorage(t *testing.T) {
 
 	// TODO: test obsolete data can be removed
 }
+
+func TestBuildDDLEventForRecoverSchema(t *testing.T) {
+	newTableInfo := func(tableID int64, tableName string) *model.TableInfo {
+		columnInfo := &model.ColumnInfo{
+			ID:        1,
+			Name:      pmodel.NewCIStr("id"),
+			FieldType: *types.NewFieldType(mysql.TypeLong),
+			State:     model.StatePublic,
+		}
+		columnInfo.SetFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		return &model.TableInfo{
+			ID:         tableID,
+			Name:       pmodel.NewCIStr(tableName),
+			Columns:    []*model.ColumnInfo{columnInfo},
+			PKIsHandle: true,
+			State:      model.StatePublic,
+		}
+	}
+	rawEvent := &PersistedDDLEvent{
+		Type:       byte(model.ActionRecoverSchema),
+		SchemaID:   100,
+		SchemaName: "test",
+		Query:      "FLASHBACK DATABASE `test`",
+		FinishedTs: 1040,
+		MultipleTableInfos: []*model.TableInfo{
+			newTableInfo(200, "t1"),
+			newTableInfo(300, "t2"),
+		},
+	}
+
+	ddlEvent, ok := buildDDLEventForRecoverSchema(rawEvent, nil)
+	require.True(t, ok)
+	require.Nil(t, ddlEvent.Err)
+	require.Equal(t, rawEvent.Query, ddlEvent.Query)
+	createTableQuery := func(tableName string) string {
+		return "CREATE TABLE `" + tableName + "` (\n" +
+			"  `id` int(11) NOT NULL,\n" +
+			"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"
+	}
+	require.Equal(t, []string{
+		"CREATE DATABASE IF NOT EXISTS `test`",
+		createTableQuery("t1"),
+		createTableQuery("t2"),
+	}, ddlEvent.NonTiDBQueries)
+
+	// the event is split into the ddls which create the schema and the tables
+	events := ddlEvent.GetEvents()
+	require.Len(t, events, 3)
+	require.Equal(t, byte(model.ActionCreateSchema), events[0].Type)
+	require.Equal(t, "test", events[0].SchemaName)
+	require.Equal(t, ddlEvent.NonTiDBQueries[0], events[0].Query)
+	for i, tableName := range []string{"t1", "t2"} {
+		require.Equal(t, byte(model.ActionCreateTable), events[i+1].Type)
+		require.Equal(t, "test", events[i+1].SchemaName)
+		require.Equal(t, tableName, events[i+1].TableName)
+		require.Equal(t, ddlEvent.NonTiDBQueries[i+1], events[i+1].Query)
+		require.Equal(t, uint64(1040), events[i+1].FinishedTs)
+	}
+
+	// the filtered tables are not created
+	ddlEvent, ok = buildDDLEventForRecoverSchema(rawEvent, buildTableFilterByNameForTest("test", "t2"))
+	require.True(t, ok)
+	require.Equal(t, []string{
+		"CREATE DATABASE IF NOT EXISTS `test`",
+		createTableQuery("t2"),
+	}, ddlEvent.NonTiDBQueries)
+}
@@ -16,6 +16,7 @@ package schemastore
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 
@@ -141,6 +142,18 @@ func mockWriteKVSnapOnDisk(db *pebble.DB, snapTs uint64, dbInfos []mockDBInfo) {
 	writeGcTs(db, snapTs)
 }
 
+func buildTableFilterWithForwardDDLClassesForTest(classes ...string) filter.Filter {
+	filterConfig := &config.FilterConfig{
+		Rules:             []string{"*.*"},
+		ForwardDDLClasses: classes,
+	}
+	tableFilter, err := filter.NewFilter(filterConfig, "", false, false)
+	if err != nil {
+		log.Panic("build filter failed", zap.Error(err))
+	}
+	return tableFilter
+}
+
 func buildTableFilterByNameForTest(schemaName, tableName string) filter.Filter {
 	filterRule := fmt.Sprintf("%s.%s", schemaName, tableName)
 	filterConfig := &config.FilterConfig{
@@ -203,6 +216,68 @@ func buildDropSchemaJobForTest(schemaID int64, finishedTs uint64) *model.Job {
 	}
 }
 
+func buildRecoverSchemaJobForTest(schemaID int64, schemaName string, tableInfos []*model.TableInfo, finishedTs uint64) *model.Job {
+	recoverTableInfos := make([]*model.RecoverTableInfo, 0, len(tableInfos))
+	for _, tableInfo := range tableInfos {
+		recoverTableInfos = append(recoverTableInfos, &model.RecoverTableInfo{
+			SchemaID:  schemaID,
+			TableInfo: tableInfo,
+		})
+	}
+	job := &model.Job{
+		Version:  model.JobVersion2,
+		Type:     model.ActionRecoverSchema,
+		SchemaID: schemaID,
+		BinlogInfo: &model.HistoryInfo{
+			DBInfo: &model.DBInfo{
+				ID:   schemaID,
+				Name: pmodel.NewCIStr(schemaName),
+			},
+			FinishedTS: finishedTs,
+		},
+	}
+	job.FillArgs(&model.RecoverArgs{
+		RecoverInfo: &model.RecoverSchemaInfo{
+			RecoverTableInfos: recoverTableInfos,
+		},
+	})
+	return job
+}
+
+func buildFlashbackClusterJobForTest(finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type: model.ActionFlashbackCluster,
+		BinlogInfo: &model.HistoryInfo{
+			FinishedTS: finishedTs,
+		},
+	}
+}
+
+func newSequenceInfoForTest(tableID int64, sequenceName string) *model.TableInfo {
+	return &model.TableInfo{
+		ID:   tableID,
+		Name: pmodel.NewCIStr(sequenceName),
+		Sequence: &model.SequenceInfo{
+			Start:     1,
+			Increment: 1,
+			MinValue:  1,
+			MaxValue:  math.MaxInt64,
+		},
+	}
+}
+
+func buildSequenceJobForTest(jobType model.ActionType, schemaID, tableID int64, sequenceName string, finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type:     jobType,
+		SchemaID: schemaID,
+		TableID:  tableID,
+		BinlogInfo: &model.HistoryInfo{
+			TableInfo:  newSequenceInfoForTest(tableID, sequenceName),
+			FinishedTS: finishedTs,
+		},
+	}
+}
+
 func buildCreateTableJobForTest(schemaID, tableID int64, tableName string, finishedTs uint64) *model.Job {
 	return &model.Job{
 		Type:     model.ActionCreateTable,
@@ -496,6 +571,42 @@ func buildAlterTTLJobForTest(schemaID, tableID int64, finishedTs uint64) *model.
 	}
 }
 
+func buildSingleTableDDLJobForTest(jobType model.ActionType, schemaID, tableID int64, tableName string, finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type:     jobType,
+		SchemaID: schemaID,
+		TableID:  tableID,
+		BinlogInfo: &model.HistoryInfo{
+			TableInfo:  newEligibleTableInfoForTest(tableID, tableName),
+			FinishedTS: finishedTs,
+		},
+	}
+}
+
+func buildModifySchemaDefaultPlacementJobForTest(schemaID int64, schemaName string, finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type:     model.ActionModifySchemaDefaultPlacement,
+		SchemaID: schemaID,
+		BinlogInfo: &model.HistoryInfo{
+			DBInfo: &model.DBInfo{
+				ID:   schemaID,
+				Name: pmodel.NewCIStr(schemaName),
+			},
+			FinishedTS: finishedTs,
+		},
+	}
+}
+
+func buildPlacementPolicyJobForTest(jobType model.ActionType, policyID int64, finishedTs uint64) *model.Job {
+	return &model.Job{
+		Type:     jobType,
+		SchemaID: policyID,
+		BinlogInfo: &model.HistoryInfo{
+			FinishedTS: finishedTs,
+		},
+	}
+}
+
 func buildRemoveTTLJobForTest(schemaID, tableID int64, finishedTs uint64) *model.Job {
 	return &model.Job{
 		Type:     model.ActionAlterTTLRemove,
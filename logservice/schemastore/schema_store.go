@@ -54,6 +54,9 @@ type SchemaStore interface {
 	FetchTableDDLEvents(tableID int64, tableFilter filter.Filter, start, end uint64) ([]commonEvent.DDLEvent, error)
 
 	FetchTableTriggerDDLEvents(tableFilter filter.Filter, start uint64, limit int) ([]commonEvent.DDLEvent, uint64, error)
+
+	// GetSequenceValues returns the current values of the sequences which are not filtered at snapTs
+	GetSequenceValues(snapTs uint64, tableFilter filter.Filter) ([]commonEvent.SequenceValue, error)
 }
 
 type DDLEventState struct {
@@ -175,7 +178,7 @@ func (s *schemaStore) updateResolvedTsPeriodically(ctx context.Context) error {
 				zap.Uint64("resolvedTs", pendingTs),
 				zap.Int("resolvedEventsLen", len(resolvedEvents)))
 
-			for _, event := range resolvedEvents {
+			for i, event := range resolvedEvents {
 				if event.Job.BinlogInfo.FinishedTS <= s.finishedDDLTs ||
 					event.Job.BinlogInfo.SchemaVersion == 0 /* means the ddl is ignored in upstream */ {
 					log.Info("skip already applied ddl job",
@@ -202,11 +205,23 @@ func (s *schemaStore) updateResolvedTsPeriodically(ctx context.Context) error {
 					zap.Any("tableInfo", event.Job.BinlogInfo.TableInfo),
 					zap.Uint64("storeFinishedDDLTS", s.finishedDDLTs))
 
+				if err := s.dataStorage.handleDDLJob(event.Job); err != nil {
+					// put the failed event and all the events after it back to the cache,
+					// and keep the resolved ts unchanged, so they will be retried in the next round.
+					log.Warn("handle ddl job failed, retry it later",
+						zap.Int64("jobID", event.Job.ID),
+						zap.Any("type", event.Job.Type),
+						zap.String("job", event.Job.Query),
+						zap.Uint64("jobFinishTs", event.Job.BinlogInfo.FinishedTS),
+						zap.Error(err))
+					for _, e := range resolvedEvents[i:] {
+						s.unsortedCache.addDDLEvent(e)
+					}
+					return
+				}
 				// need to update the following two members for every event to filter out later duplicate events
 				s.schemaVersion = event.Job.BinlogInfo.SchemaVersion
 				s.finishedDDLTs = event.Job.BinlogInfo.FinishedTS
-
-				s.dataStorage.handleDDLJob(event.Job)
 			}
 		}
 		// When register a new table, it will load all ddl jobs from disk for the table,
@@ -286,6 +301,10 @@ func (s *schemaStore) FetchTableDDLEvents(tableID int64, tableFilter filter.Filt
 }
 
 // FetchTableTriggerDDLEvents returns the next ddl events which finishedTs are within the range (start, end]
+func (s *schemaStore) GetSequenceValues(snapTs uint64, tableFilter filter.Filter) ([]commonEvent.SequenceValue, error) {
+	return s.dataStorage.getSequenceValues(snapTs, tableFilter)
+}
+
 func (s *schemaStore) FetchTableTriggerDDLEvents(tableFilter filter.Filter, start uint64, limit int) ([]commonEvent.DDLEvent, uint64, error) {
 	if limit == 0 {
 		log.Panic("limit cannot be 0")
@@ -107,6 +107,9 @@ type BasicDatabaseInfo struct {
 type BasicTableInfo struct {
 	SchemaID int64
 	Name     string
+	// IsSequence is true if the table is a sequence object,
+	// a sequence has no data to replicate, so no dispatcher is created for it.
+	IsSequence bool
 }
 
 type BasicPartitionInfo map[int64]interface{}
@@ -18,6 +18,8 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -49,8 +51,10 @@ func transformDDLJobQuery(job *model.Job) (string, error) {
 		restoreFlags |= format.RestoreKeyWordUppercase
 		// wrap string with single quote
 		restoreFlags |= format.RestoreStringSingleQuotes
-		// remove placement rule
-		restoreFlags |= format.SkipPlacementRuleForRestore
+		// remove placement rule, unless the ddl is used to set placement rules
+		if class, ok := filter.GetOptionalDDLClass(job.Type); !ok || class != config.DDLClassPlacementPolicy {
+			restoreFlags |= format.SkipPlacementRuleForRestore
+		}
 		// force disable ttl
 		restoreFlags |= format.RestoreWithTTLEnableOff
 		if err = stmt.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
@@ -0,0 +1,402 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstreamlog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/ticdc/pkg/upstream"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+const (
+	// upstreamCheckInterval is the interval to check whether an upstream is initialized.
+	upstreamCheckInterval = 100 * time.Millisecond
+	// acquireRetryInterval is the interval to retry creating the log service of an upstream.
+	acquireRetryInterval = time.Second
+	// minRecreateBackoff and maxRecreateBackoff bound the backoff to recreate a failed log service,
+	// the backoff doubles on each failure, and is reset if the log service ran longer than the max.
+	minRecreateBackoff = 5 * time.Second
+	maxRecreateBackoff = time.Minute
+)
+
+// Service holds the log service components and the clients of one upstream.
+type Service struct {
+	UpstreamID uint64
+
+	PDClock     pdutil.Clock
+	PDAPIClient pdutil.PDAPIClient
+	RegionCache *tikv.RegionCache
+
+	SubscriptionClient *logpuller.SubscriptionClient
+	SchemaStore        schemastore.SchemaStore
+	EventStore         eventstore.EventStore
+	SnapshotReader     logpuller.SnapshotReader
+
+	// submodules are run by the Manager, they are the SubscriptionClient, SchemaStore and EventStore.
+	submodules []common.SubModule
+	// ctx and cancel are used to run and stop the modules of the log service run by the Manager.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// wg waits for the modules to exit.
+	wg sync.WaitGroup
+	// err is the first error of the modules, the log service is not available after
+	// a module fails, and it's recreated after a backoff. The fields below are
+	// protected by Manager.mu.
+	err       error
+	startedAt time.Time
+	failedAt  time.Time
+}
+
+// Manager manages the log services of all upstreams served by the server.
+// The log service of the default upstream is created and run by the server,
+// the others are created on demand, when the first changefeed of the upstream
+// is scheduled to the server, and run by the Manager. If a module of a non-default
+// upstream fails, only the log service of the upstream is stopped, the server and
+// the other upstreams keep running, and the log service is recreated after a backoff.
+type Manager struct {
+	// all log services should be spawned from this ctx.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	dataDir string
+
+	upstreams *upstream.Manager
+	wg        sync.WaitGroup
+
+	// loadService loads the upstream and creates its log service, it's replaced in tests.
+	loadService func(upstreamID uint64, namespace string) (*Service, error)
+	// the backoff to recreate a failed log service, it's shortened in tests.
+	minRecreateBackoff time.Duration
+	maxRecreateBackoff time.Duration
+
+	mu             sync.Mutex
+	defaultService *Service
+	services       map[uint64]*Service
+	// preparing holds the upstreams whose log service is being created.
+	preparing map[uint64]struct{}
+	// failures is the number of continuous failures of the log service of each upstream.
+	failures map[uint64]int
+}
+
+// NewManager creates a new Manager, the data of the log services
+// of non-default upstreams is stored in a sub directory of dataDir.
+func NewManager(ctx context.Context, dataDir string, upstreams *upstream.Manager) *Manager {
+	ctx, cancel := context.WithCancel(ctx)
+	m := &Manager{
+		ctx:                ctx,
+		cancel:             cancel,
+		dataDir:            dataDir,
+		upstreams:          upstreams,
+		minRecreateBackoff: minRecreateBackoff,
+		maxRecreateBackoff: maxRecreateBackoff,
+		services:           make(map[uint64]*Service),
+		preparing:          make(map[uint64]struct{}),
+		failures:           make(map[uint64]int),
+	}
+	m.loadService = m.load
+	return m
+}
+
+// SetDefault sets the log service of the default upstream.
+func (m *Manager) SetDefault(svc *Service) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultService = svc
+}
+
+// Get returns the log service of the upstream if it is ready and not failed.
+// 0 refers to the default upstream.
+func (m *Manager) Get(upstreamID uint64) (*Service, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getLocked(upstreamID)
+}
+
+func (m *Manager) getLocked(upstreamID uint64) (*Service, bool) {
+	if m.defaultService != nil &&
+		(upstreamID == 0 || upstreamID == m.defaultService.UpstreamID) {
+		return m.defaultService, true
+	}
+	svc, ok := m.services[upstreamID]
+	if !ok || svc.err != nil {
+		return nil, false
+	}
+	return svc, true
+}
+
+// Err returns the error of the log service of the upstream if it failed
+// and is not recreated yet.
+func (m *Manager) Err(upstreamID uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if svc, ok := m.services[upstreamID]; ok {
+		return svc.err
+	}
+	return nil
+}
+
+// IsDefault returns true if the upstreamID refers to the default upstream.
+func (m *Manager) IsDefault(upstreamID uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return upstreamID == 0 ||
+		(m.defaultService != nil && upstreamID == m.defaultService.UpstreamID)
+}
+
+// Prepare returns the log service of the upstream if it is ready. Otherwise, it
+// starts to create the log service in background and returns false, the caller
+// is expected to try again later. A failed log service is closed and recreated
+// once its backoff elapses, Err returns the error until then.
+// The namespace is used to load the upstream info.
+func (m *Manager) Prepare(upstreamID uint64, namespace string) (*Service, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if svc, ok := m.getLocked(upstreamID); ok {
+		return svc, true
+	}
+	if _, ok := m.preparing[upstreamID]; ok {
+		return nil, false
+	}
+	failed, ok := m.services[upstreamID]
+	if ok {
+		backoff := m.recreateBackoff(m.failures[upstreamID])
+		if time.Since(failed.failedAt) < backoff {
+			return nil, false
+		}
+		delete(m.services, upstreamID)
+		log.Info("recreate the failed log service of upstream",
+			zap.Uint64("upstreamID", upstreamID),
+			zap.Int("failures", m.failures[upstreamID]),
+			zap.Duration("backoff", backoff))
+	}
+	m.preparing[upstreamID] = struct{}{}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		if failed != nil {
+			// the new log service reuses the data directory, so the failed one must be closed first.
+			failed.wg.Wait()
+			m.closeService(context.Background(), failed)
+		}
+		m.create(upstreamID, namespace)
+	}()
+	return nil, false
+}
+
+// recreateBackoff returns the backoff to recreate a log service after the failures.
+func (m *Manager) recreateBackoff(failures int) time.Duration {
+	backoff := m.minRecreateBackoff
+	for i := 1; i < failures && backoff < m.maxRecreateBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, m.maxRecreateBackoff)
+}
+
+// Acquire blocks until the log service of the upstream is ready,
+// it returns the error if the log service failed or the ctx is canceled.
+func (m *Manager) Acquire(ctx context.Context, upstreamID uint64, namespace string) (*Service, error) {
+	ticker := time.NewTicker(acquireRetryInterval)
+	defer ticker.Stop()
+	for {
+		if svc, ok := m.Prepare(upstreamID, namespace); ok {
+			return svc, nil
+		}
+		if err := m.Err(upstreamID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-m.ctx.Done():
+			return nil, errors.Trace(m.ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Manager) create(upstreamID uint64, namespace string) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.preparing, upstreamID)
+		m.mu.Unlock()
+	}()
+
+	svc, err := m.loadService(upstreamID, namespace)
+	if err != nil {
+		log.Warn("create log service failed",
+			zap.Uint64("upstreamID", upstreamID),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		return
+	}
+	m.mu.Lock()
+	svc.startedAt = time.Now()
+	m.services[upstreamID] = svc
+	m.mu.Unlock()
+	m.run(svc)
+	log.Info("log service of upstream is ready", zap.Uint64("upstreamID", upstreamID))
+}
+
+// load loads the upstream, waits for it to be initialized and creates its log service.
+func (m *Manager) load(upstreamID uint64, namespace string) (*Service, error) {
+	if m.upstreams == nil {
+		return nil, errors.New("upstream manager is not set")
+	}
+	up, err := m.upstreams.GetOrLoad(m.ctx, upstreamID, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ticker := time.NewTicker(upstreamCheckInterval)
+	defer ticker.Stop()
+	for !up.IsNormal() {
+		if up.Error() != nil || up.IsClosed() {
+			return nil, errors.Annotate(up.Error(), "upstream is not available")
+		}
+		select {
+		case <-m.ctx.Done():
+			return nil, errors.Trace(m.ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return m.newService(up)
+}
+
+func (m *Manager) newService(up *upstream.Upstream) (*Service, error) {
+	root := filepath.Join(m.dataDir, fmt.Sprintf("upstream-%d", up.ID))
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pdAPIClient, err := pdutil.NewPDAPIClient(up.PDClient, up.SecurityConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	subscriptionClient := logpuller.NewSubscriptionClient(
+		&logpuller.SubscriptionClientConfig{
+			RegionRequestWorkerPerStore: 8,
+		}, up.PDClient, up.RegionCache, up.PDClock,
+		txnutil.NewLockerResolver(up.KVStorage.(tikv.Storage)), up.SecurityConfig,
+	)
+	svc := &Service{
+		UpstreamID:         up.ID,
+		PDClock:            up.PDClock,
+		PDAPIClient:        pdAPIClient,
+		RegionCache:        up.RegionCache,
+		SubscriptionClient: subscriptionClient,
+		SchemaStore:        schemastore.New(ctx, root, subscriptionClient, up.PDClient, up.PDClock, up.KVStorage),
+		EventStore:         eventstore.NewForUpstream(root, subscriptionClient, up.PDClock),
+		SnapshotReader:     logpuller.NewSnapshotReader(up.KVStorage),
+		ctx:                ctx,
+		cancel:             cancel,
+	}
+	svc.submodules = []common.SubModule{svc.SubscriptionClient, svc.SchemaStore, svc.EventStore}
+	return svc, nil
+}
+
+func (m *Manager) run(svc *Service) {
+	for _, module := range svc.submodules {
+		m.wg.Add(1)
+		svc.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			defer svc.wg.Done()
+			err := module.Run(svc.ctx)
+			if err != nil && errors.Cause(err) != context.Canceled {
+				m.fail(svc, module, err)
+			}
+		}()
+	}
+}
+
+// fail marks the log service of the upstream as failed and stops its other modules,
+// the changefeeds of the upstream can not make progress on the server until the
+// log service is recreated.
+func (m *Manager) fail(svc *Service, module common.SubModule, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if svc.err != nil {
+		return
+	}
+	svc.err = err
+	svc.failedAt = time.Now()
+	svc.cancel()
+	if svc.failedAt.Sub(svc.startedAt) > m.maxRecreateBackoff {
+		m.failures[svc.UpstreamID] = 0
+	}
+	m.failures[svc.UpstreamID]++
+	log.Error("log service module of upstream exited with error, stop the log service of the upstream",
+		zap.Uint64("upstreamID", svc.UpstreamID),
+		zap.String("module", module.Name()),
+		zap.Int("failures", m.failures[svc.UpstreamID]),
+		zap.Error(err))
+}
+
+// closeService closes the modules and the clients of the log service.
+func (m *Manager) closeService(ctx context.Context, svc *Service) {
+	for _, module := range svc.submodules {
+		if err := module.Close(ctx); err != nil {
+			log.Warn("close log service module failed",
+				zap.Uint64("upstreamID", svc.UpstreamID),
+				zap.String("module", module.Name()),
+				zap.Error(err))
+		}
+	}
+	if svc.PDAPIClient != nil {
+		svc.PDAPIClient.Close()
+	}
+}
+
+// Name implements common.SubModule.
+func (m *Manager) Name() string {
+	return appcontext.UpstreamLogManager
+}
+
+// Run implements common.SubModule. The errors of the log services run by the
+// Manager are not returned, since they only affect their own upstreams.
+func (m *Manager) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Close implements common.SubModule, it closes the log services
+// run by the Manager and all the non-default upstreams.
+func (m *Manager) Close(ctx context.Context) error {
+	m.cancel()
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, svc := range m.services {
+		m.closeService(ctx, svc)
+	}
+	if m.upstreams != nil {
+		m.upstreams.Close()
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstreamlog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+type mockModule struct {
+	errCh  chan error
+	closed atomic.Bool
+}
+
+func (m *mockModule) Name() string { return "mock" }
+
+func (m *mockModule) Run(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case err := <-m.errCh:
+		return err
+	}
+}
+
+func (m *mockModule) Close(_ context.Context) error {
+	m.closed.Store(true)
+	return nil
+}
+
+// newManagerForTest creates a Manager whose log services only run a mock module,
+// it returns the modules created in order.
+func newManagerForTest(t *testing.T) (*Manager, func() []*mockModule) {
+	m := NewManager(context.Background(), t.TempDir(), nil)
+	m.minRecreateBackoff = 100 * time.Millisecond
+	m.maxRecreateBackoff = 400 * time.Millisecond
+
+	var mu sync.Mutex
+	var modules []*mockModule
+	m.loadService = func(upstreamID uint64, _ string) (*Service, error) {
+		module := &mockModule{errCh: make(chan error, 1)}
+		mu.Lock()
+		modules = append(modules, module)
+		mu.Unlock()
+		ctx, cancel := context.WithCancel(m.ctx)
+		return &Service{
+			UpstreamID: upstreamID,
+			submodules: []common.SubModule{module},
+			ctx:        ctx,
+			cancel:     cancel,
+		}, nil
+	}
+	t.Cleanup(func() { require.NoError(t, m.Close(context.Background())) })
+	return m, func() []*mockModule {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*mockModule(nil), modules...)
+	}
+}
+
+func prepareUntilReady(t *testing.T, m *Manager, upstreamID uint64) *Service {
+	var svc *Service
+	require.Eventually(t, func() bool {
+		var ok bool
+		svc, ok = m.Prepare(upstreamID, "default")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	return svc
+}
+
+func TestManagerPrepare(t *testing.T) {
+	m, modules := newManagerForTest(t)
+	defaultService := &Service{UpstreamID: 1}
+	m.SetDefault(defaultService)
+
+	// The default upstream is always ready.
+	for _, upstreamID := range []uint64{0, 1} {
+		svc, ok := m.Prepare(upstreamID, "default")
+		require.True(t, ok)
+		require.Same(t, defaultService, svc)
+		require.True(t, m.IsDefault(upstreamID))
+	}
+
+	// The log service of other upstreams is created in background.
+	_, ok := m.Get(2)
+	require.False(t, ok)
+	require.False(t, m.IsDefault(2))
+	svc := prepareUntilReady(t, m, 2)
+	require.Equal(t, uint64(2), svc.UpstreamID)
+	got, ok := m.Get(2)
+	require.True(t, ok)
+	require.Same(t, svc, got)
+	require.NoError(t, m.Err(2))
+
+	// It's created only once.
+	prepareUntilReady(t, m, 2)
+	require.Len(t, modules(), 1)
+}
+
+func TestManagerRecreateFailedService(t *testing.T) {
+	m, modules := newManagerForTest(t)
+	failed := prepareUntilReady(t, m, 2)
+
+	modules()[0].errCh <- errors.New("module failed")
+	require.Eventually(t, func() bool {
+		return m.Err(2) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := m.Get(2)
+	require.False(t, ok)
+
+	// The failed log service is not recreated before the backoff.
+	_, ok = m.Prepare(2, "default")
+	require.False(t, ok)
+	require.Len(t, modules(), 1)
+	require.Error(t, m.Err(2))
+
+	// It's closed and recreated after the backoff.
+	svc := prepareUntilReady(t, m, 2)
+	require.NotSame(t, failed, svc)
+	require.True(t, modules()[0].closed.Load())
+	require.Len(t, modules(), 2)
+	require.NoError(t, m.Err(2))
+
+	// The backoff doubles if the log service fails again soon.
+	require.Equal(t, m.minRecreateBackoff, m.recreateBackoff(1))
+	require.Equal(t, 2*m.minRecreateBackoff, m.recreateBackoff(2))
+	require.Equal(t, m.maxRecreateBackoff, m.recreateBackoff(10))
+	modules()[1].errCh <- errors.New("module failed again")
+	require.Eventually(t, func() bool {
+		return m.Err(2) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	m.mu.Lock()
+	require.Equal(t, 2, m.failures[2])
+	m.mu.Unlock()
+	prepareUntilReady(t, m, 2)
+	require.Len(t, modules(), 3)
+}
+
+func TestManagerAcquire(t *testing.T) {
+	m, modules := newManagerForTest(t)
+	svc, err := m.Acquire(context.Background(), 2, "default")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), svc.UpstreamID)
+
+	// Acquire returns the error of the failed log service.
+	modules()[0].errCh <- errors.New("module failed")
+	require.Eventually(t, func() bool {
+		return m.Err(2) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = m.Acquire(context.Background(), 2, "default")
+	require.ErrorContains(t, err, "module failed")
+
+	// Acquire returns once the ctx is canceled while the log service is not ready.
+	release := make(chan struct{})
+	m.loadService = func(upstreamID uint64, _ string) (*Service, error) {
+		<-release
+		return nil, errors.New("upstream is not available")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.Acquire(ctx, 3, "default")
+		done <- err
+	}()
+	cancel()
+	select {
+	case err = <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Acquire is not canceled")
+	}
+	close(release)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/maintainer/split"
 	"github.com/pingcap/ticdc/pkg/bootstrap"
@@ -124,6 +125,8 @@ type Maintainer struct {
 	changefeedRemoved atomic.Bool
 
 	lastPrintStatusTime time.Time
+	// lastThroughputQuotaTime is the last time the throughput quota is sent to the nodes.
+	lastThroughputQuotaTime time.Time
 	// lastCheckpointTsTime time.Time
 
 	// newChangefeed indicates if this is a fresh changefeed instance:
@@ -141,6 +144,7 @@ type Maintainer struct {
 	changefeedCheckpointTsLagGauge prometheus.Gauge
 	changefeedResolvedTsGauge      prometheus.Gauge
 	changefeedResolvedTsLagGauge   prometheus.Gauge
+	replicationDelayGauge          prometheus.Gauge
 	changefeedStatusGauge          prometheus.Gauge
 	scheduledTaskGauge             prometheus.Gauge
 	runningTaskGauge               prometheus.Gauge
@@ -157,6 +161,7 @@ func NewMaintainer(cfID common.ChangeFeedID,
 	pdAPI pdutil.PDAPIClient,
 	pdClock pdutil.Clock,
 	regionCache split.RegionCache,
+	schemaStore schemastore.SchemaStore,
 	checkpointTs uint64,
 	newChangefeed bool,
 ) *Maintainer {
@@ -195,12 +200,14 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		changefeedCheckpointTsLagGauge: metrics.ChangefeedCheckpointTsLagGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		changefeedResolvedTsGauge:      metrics.ChangefeedResolvedTsGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		changefeedResolvedTsLagGauge:   metrics.ChangefeedResolvedTsLagGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
+		replicationDelayGauge:          metrics.ChangefeedReplicationDelayGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		changefeedStatusGauge:          metrics.ChangefeedStatusGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		scheduledTaskGauge:             metrics.ScheduleTaskGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		runningTaskGauge:               metrics.RunningScheduleTaskGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		tableCountGauge:                metrics.TableGauge.WithLabelValues(cfID.Namespace(), cfID.Name()),
 		handleEventDuration:            metrics.MaintainerHandleEventDuration.WithLabelValues(cfID.Namespace(), cfID.Name()),
 	}
+	m.controller.schemaStore = schemaStore
 	m.nodeChanged.changed = false
 	m.runningErrors.m = make(map[node.ID]*heartbeatpb.RunningError)
 
@@ -245,7 +252,7 @@ func NewMaintainerForRemove(cfID common.ChangeFeedID,
 		Config:       config.GetDefaultReplicaConfig(),
 	}
 	m := NewMaintainer(cfID, conf, unused, selfNode, taskScheduler, pdAPI,
-		pdClock, regionCache, 1, false)
+		pdClock, regionCache, nil, 1, false)
 	m.cascadeRemoving = true
 	return m
 }
@@ -375,6 +382,7 @@ func (m *Maintainer) cleanupMetrics() {
 	metrics.ChangefeedCheckpointTsLagGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.ChangefeedResolvedTsGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.ChangefeedResolvedTsLagGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
+	metrics.ChangefeedReplicationDelayGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.ChangefeedStatusGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.ScheduleTaskGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
 	metrics.RunningScheduleTaskGauge.DeleteLabelValues(m.id.Namespace(), m.id.Name())
@@ -544,6 +552,10 @@ func (m *Maintainer) calCheckpointTs() {
 		}
 		newWatermark.UpdateMin(m.checkpointTsByCapture[id])
 	}
+	// The changefeed stops at the target ts, the watermark never exceeds it.
+	if targetTs := m.config.TargetTs; targetTs != 0 {
+		newWatermark.UpdateMin(heartbeatpb.Watermark{CheckpointTs: targetTs, ResolvedTs: targetTs})
+	}
 
 	m.setWatermark(*newWatermark)
 }
@@ -551,15 +563,24 @@ func (m *Maintainer) calCheckpointTs() {
 func (m *Maintainer) updateMetrics() {
 	watermark := m.getWatermark()
 
+	// The downstream is kept behind the upstream intentionally if the replication delay is set,
+	// so the delay is reported separately and excluded from the lag.
+	// The lag is 0 if the downstream is not behind more than the delay.
+	var replicationDelay float64
+	if m.config.Config != nil && m.config.Config.ReplicationDelay != nil {
+		replicationDelay = m.config.Config.ReplicationDelay.Seconds()
+	}
+	m.replicationDelayGauge.Set(replicationDelay)
+
 	pdTime := m.pdClock.CurrentTime()
 	phyCkpTs := oracle.ExtractPhysical(watermark.CheckpointTs)
 	m.changefeedCheckpointTsGauge.Set(float64(phyCkpTs))
-	lag := float64(oracle.GetPhysical(pdTime)-phyCkpTs) / 1e3
+	lag := max(float64(oracle.GetPhysical(pdTime)-phyCkpTs)/1e3-replicationDelay, 0)
 	m.changefeedCheckpointTsLagGauge.Set(lag)
 
 	phyResolvedTs := oracle.ExtractPhysical(watermark.ResolvedTs)
 	m.changefeedResolvedTsGauge.Set(float64(phyResolvedTs))
-	lag = float64(oracle.GetPhysical(pdTime)-phyResolvedTs) / 1e3
+	lag = max(float64(oracle.GetPhysical(pdTime)-phyResolvedTs)/1e3-replicationDelay, 0)
 	m.changefeedResolvedTsLagGauge.Set(lag)
 
 	m.changefeedStatusGauge.Set(float64(m.scheduleState.Load()))
@@ -838,6 +859,7 @@ func (m *Maintainer) createBootstrapMessageFactory() bootstrap.NewBootstrapMessa
 func (m *Maintainer) onPeriodTask() {
 	// send scheduling messages
 	m.handleResendMessage()
+	m.sendThroughputQuota()
 	m.collectMetrics()
 	m.calCheckpointTs()
 }
@@ -933,7 +955,29 @@ func (m *Maintainer) MoveTable(tableId int64, targetNode node.ID) error {
 	return m.controller.moveTable(tableId, targetNode)
 }
 
+// MoveSpan moves a single span of a table to a specific node.
+func (m *Maintainer) MoveSpan(dispatcherID common.DispatcherID, targetNode node.ID) error {
+	return m.controller.moveSpan(dispatcherID, targetNode)
+}
+
+// SplitTable splits a table into several spans, by the split keys if they are given,
+// otherwise by the region count.
+func (m *Maintainer) SplitTable(tableId int64, regionsPerSpan int, splitKeys [][]byte) error {
+	return m.controller.splitTable(tableId, regionsPerSpan, splitKeys)
+}
+
+// MergeTable merges all the spans of a table into one span.
+func (m *Maintainer) MergeTable(tableId int64) error {
+	return m.controller.mergeTable(tableId)
+}
+
 // GetTables returns all tables.
 func (m *Maintainer) GetTables() []*replica.SpanReplication {
 	return m.controller.replicationDB.GetAllTasks()
 }
+
+// IsScheduling returns true if the span replicated by the dispatcher is being scheduled,
+// e.g. it's being moved, split or merged.
+func (m *Maintainer) IsScheduling(dispatcherID common.DispatcherID) bool {
+	return m.controller.isScheduling(dispatcherID)
+}
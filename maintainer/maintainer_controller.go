@@ -52,11 +52,17 @@ type Controller struct {
 	messageCenter       messaging.MessageCenter
 	nodeManager         *watcher.NodeManager
 	pdClock             pdutil.Clock
+	// schemaStore is the schema store of the changefeed's upstream,
+	// nil means the schema store of the default upstream.
+	schemaStore schemastore.SchemaStore
 
 	splitter               *split.Splitter
+	regionCache            split.RegionCache
 	enableTableAcrossNodes bool
 	startCheckpointTs      uint64
 	ddlDispatcherID        common.DispatcherID
+	// placement constrains the nodes the dispatchers can be scheduled to, nil means no constraint
+	placement *scheduler.Placement
 
 	cfConfig     *config.ReplicaConfig
 	changefeedID common.ChangeFeedID
@@ -80,18 +86,24 @@ func NewController(changefeedID common.ChangeFeedID,
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 
 	enableTableAcrossNodes := false
-	var splitter *split.Splitter
+	var (
+		splitter  *split.Splitter
+		placement *scheduler.Placement
+	)
 	if cfConfig != nil && cfConfig.Scheduler.EnableTableAcrossNodes {
 		enableTableAcrossNodes = true
 		splitter = split.NewSplitter(changefeedID, pdAPIClient, regionCache, cfConfig.Scheduler)
 	}
+	if cfConfig != nil {
+		placement = scheduler.NewPlacement(cfConfig.Scheduler)
+	}
 
-	replicaSetDB := replica.NewReplicaSetDB(changefeedID, ddlSpan, enableTableAcrossNodes)
+	replicaSetDB := replica.NewReplicaSetDB(changefeedID, ddlSpan, pdClock, enableTableAcrossNodes)
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 
 	oc := operator.NewOperatorController(changefeedID, mc, replicaSetDB, nodeManager, batchSize)
 	sc := NewScheduleController(
-		changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, splitter,
+		changefeedID, batchSize, oc, replicaSetDB, nodeManager, balanceInterval, splitter, placement,
 	)
 
 	return &Controller{
@@ -108,7 +120,9 @@ func NewController(changefeedID common.ChangeFeedID,
 		cfConfig:               cfConfig,
 		pdClock:                pdClock,
 		splitter:               splitter,
+		regionCache:            regionCache,
 		enableTableAcrossNodes: enableTableAcrossNodes,
+		placement:              placement,
 	}
 }
 
@@ -182,7 +196,7 @@ func (c *Controller) AddNewTable(table commonEvent.Table, startTs uint64) {
 	tableSpans := []*heartbeatpb.TableSpan{tableSpan}
 	if c.enableTableAcrossNodes {
 		// split the whole table span base on the configuration, todo: background split table
-		tableSpans = c.splitter.SplitSpans(context.Background(), tableSpan, len(c.nodeManager.GetAliveNodes()))
+		tableSpans = c.splitter.SplitSpans(context.Background(), tableSpan, len(c.placement.FilterNodes(c.nodeManager.GetAliveNodes())))
 	}
 	c.addNewSpans(table.SchemaID, tableSpans, startTs)
 }
@@ -524,7 +538,10 @@ func (c *Controller) loadTables(startTs uint64) ([]commonEvent.Table, error) {
 		return nil, errors.Cause(err)
 	}
 
-	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	schemaStore := c.schemaStore
+	if schemaStore == nil {
+		schemaStore = appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	}
 	tables, err := schemaStore.GetAllPhysicalTables(startTs, f)
 	log.Info("get table ids", zap.Int("count", len(tables)), zap.String("changefeed", c.changefeedID.Name()))
 	return tables, err
@@ -539,6 +556,52 @@ func (c *Controller) moveTable(tableId int64, targetNode node.ID) error {
 		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("tableID", tableId)
 	}
 
+	replications := c.replicationDB.GetTasksByTableID(tableId)
+	if len(replications) != 1 {
+		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("unexpected number of replications found for table in this node; tableID is %s, replication count is %s", tableId, len(replications))
+	}
+	replication := replications[0]
+	if err := c.addMoveOperator(replication, targetNode); err != nil {
+		return err
+	}
+
+	// check the op is finished or not
+	count := 0
+	maxTry := 30
+	for c.operatorController.GetOperator(replication.ID) != nil && count < maxTry {
+		time.Sleep(1 * time.Second)
+		count += 1
+		log.Info("wait for move table operator finished", zap.Int("count", count))
+	}
+
+	if c.operatorController.GetOperator(replication.ID) != nil {
+		return apperror.ErrMoveTableTimeout.GenWithStackByArgs("move table operator is timeout")
+	}
+
+	return nil
+}
+
+// moveSpan moves a single span of a table to the target node,
+// it works for both the complete table and the table splited.
+// It returns once the move operator is added, the progress can be checked by the scheduling
+// state of the span in the table list.
+func (c *Controller) moveSpan(dispatcherID common.DispatcherID, targetNode node.ID) error {
+	replication := c.replicationDB.GetTaskByID(dispatcherID)
+	if replication == nil || c.isDDLDispatcher(dispatcherID) {
+		return apperror.ErrTableIsNotFounded.GenWithStack("dispatcher %s is not found", dispatcherID)
+	}
+	if err := c.addMoveOperator(replication, targetNode); err != nil {
+		return err
+	}
+	log.Info("move span operator added",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Stringer("dispatcherID", dispatcherID),
+		zap.String("targetNode", targetNode.String()))
+	return nil
+}
+
+// addMoveOperator adds an operator to move the replication to the target node.
+func (c *Controller) addMoveOperator(replication *replica.SpanReplication, targetNode node.ID) error {
 	nodes := c.nodeManager.GetAliveNodes()
 	hasNode := false
 	for _, node := range nodes {
@@ -550,33 +613,121 @@ func (c *Controller) moveTable(tableId int64, targetNode node.ID) error {
 	if !hasNode {
 		return apperror.ErrNodeIsNotFound.GenWithStackByArgs("targetNode", targetNode)
 	}
+	if !c.placement.Match(nodes[targetNode]) {
+		return apperror.ErrNodeNotMatchPlacement.GenWithStackByArgs("targetNode", targetNode, "placement", c.placement)
+	}
 
-	replications := c.replicationDB.GetTasksByTableID(tableId)
-	if len(replications) != 1 {
-		return apperror.ErrTableIsNotFounded.GenWithStackByArgs("unexpected number of replications found for table in this node; tableID is %s, replication count is %s", tableId, len(replications))
+	op := c.operatorController.NewMoveOperator(replication, replication.GetNodeID(), targetNode)
+	if !c.operatorController.AddOperator(op) {
+		return apperror.ErrTableIsScheduling.GenWithStack(
+			"add move operator failed, dispatcher %s", replication.ID)
 	}
+	return nil
+}
 
-	replication := replications[0]
+// splitTable splits a table into several spans. The table is split by the split keys
+// if they are given, otherwise each span covers at most regionsPerSpan regions.
+// The split keys must be in comparable format.
+func (c *Controller) splitTable(tableId int64, regionsPerSpan int, splitKeys [][]byte) error {
+	replications, spans, err := c.buildSplitTableSpans(tableId, regionsPerSpan, splitKeys)
+	if err != nil {
+		return err
+	}
+	return c.mergeSplitTable(replications, spans)
+}
 
-	op := c.operatorController.NewMoveOperator(replication, replication.GetNodeID(), targetNode)
-	c.operatorController.AddOperator(op)
+func (c *Controller) buildSplitTableSpans(
+	tableId int64, regionsPerSpan int, splitKeys [][]byte,
+) ([]*replica.SpanReplication, []*heartbeatpb.TableSpan, error) {
+	replications, totalSpan, err := c.getCompleteTableReplications(tableId)
+	if err != nil {
+		return nil, nil, err
+	}
+	var spans []*heartbeatpb.TableSpan
+	if len(splitKeys) > 0 {
+		spans, err = split.SplitSpanByKeys(totalSpan, splitKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if regionsPerSpan <= 0 {
+			return nil, nil, apperror.ErrTableCannotBeSplit.GenWithStack(
+				"regions per span must be larger than 0, but got %d", regionsPerSpan)
+		}
+		if c.regionCache == nil {
+			return nil, nil, apperror.ErrTableCannotBeSplit.GenWithStack("region cache is not available")
+		}
+		spans = split.SplitSpanByRegionCount(context.Background(),
+			c.changefeedID, c.regionCache, totalSpan, regionsPerSpan)
+	}
+	if len(spans) <= 1 {
+		return nil, nil, apperror.ErrTableCannotBeSplit.GenWithStack(
+			"table %d can not be split into more than one span", tableId)
+	}
+	return replications, spans, nil
+}
 
-	// check the op is finished or not
-	count := 0
-	maxTry := 30
-	for !op.IsFinished() && count < maxTry {
-		time.Sleep(1 * time.Second)
-		count += 1
-		log.Info("wait for move table operator finished", zap.Int("count", count))
+// mergeTable merges all the spans of a table into one span.
+func (c *Controller) mergeTable(tableId int64) error {
+	replications, totalSpan, err := c.getCompleteTableReplications(tableId)
+	if err != nil {
+		return err
 	}
+	if len(replications) == 1 {
+		// the table is not split, nothing to do
+		return nil
+	}
+	return c.mergeSplitTable(replications, []*heartbeatpb.TableSpan{totalSpan})
+}
 
-	if !op.IsFinished() {
-		return apperror.ErrMoveTableTimeout.GenWithStackByArgs("move table operator is timeout")
+// getCompleteTableReplications returns all the replications of a table and the total span of it,
+// it returns an error if the replications don't cover the whole table or any of them is being scheduled.
+func (c *Controller) getCompleteTableReplications(tableId int64) ([]*replica.SpanReplication, *heartbeatpb.TableSpan, error) {
+	if !c.replicationDB.IsTableExists(tableId) {
+		return nil, nil, apperror.ErrTableIsNotFounded.GenWithStack("table %d is not found", tableId)
+	}
+	span := spanz.TableIDToComparableSpan(tableId)
+	totalSpan := &heartbeatpb.TableSpan{
+		TableID:  span.TableID,
+		StartKey: span.StartKey,
+		EndKey:   span.EndKey,
+	}
+	replications := c.replicationDB.GetTasksByTableID(tableId)
+	spanMap := utils.NewBtreeMap[*heartbeatpb.TableSpan, *replica.SpanReplication](heartbeatpb.LessTableSpan)
+	for _, r := range replications {
+		if r.GetNodeID() == "" || c.operatorController.GetOperator(r.ID) != nil {
+			return nil, nil, apperror.ErrTableIsScheduling.GenWithStack("table %d is being scheduled", tableId)
+		}
+		spanMap.ReplaceOrInsert(r.Span, r)
+	}
+	if holes := split.FindHoles(spanMap, totalSpan); len(holes) != 0 {
+		return nil, nil, apperror.ErrTableIsScheduling.GenWithStack(
+			"table %d is not fully covered, holes: %d", tableId, len(holes))
 	}
+	return replications, totalSpan, nil
+}
 
+// mergeSplitTable replaces the replications with the new spans. It returns once the
+// operator is added, the progress can be checked by the spans of the table and their
+// scheduling state in the table list.
+func (c *Controller) mergeSplitTable(replications []*replica.SpanReplication, spans []*heartbeatpb.TableSpan) error {
+	if !c.operatorController.AddMergeSplitOperator(replications, spans) {
+		return apperror.ErrTableIsScheduling.GenWithStack(
+			"add merge split operator failed, table %d", spans[0].TableID)
+	}
+	log.Info("merge split operator added",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Int64("tableID", spans[0].TableID),
+		zap.Int("replications", len(replications)),
+		zap.Int("spans", len(spans)))
 	return nil
 }
 
+// isScheduling returns true if the span replicated by the dispatcher is being scheduled.
+func (c *Controller) isScheduling(dispatcherID common.DispatcherID) bool {
+	return c.operatorController.GetOperator(dispatcherID) != nil
+}
+
 func (c *Controller) isDDLDispatcher(dispatcherID common.DispatcherID) bool {
 	return dispatcherID == c.ddlDispatcherID
 }
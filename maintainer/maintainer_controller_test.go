@@ -793,8 +793,11 @@ func TestDynamicMergeTableBasic(t *testing.T) {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
+	// the checkers use the clock of the controller
+	mockPDClock := pdutil.NewClockWithValue4Test(time.Unix(0, 0))
+	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
 	s := NewController(cfID, 1,
-		pdAPI, pdClock, nil, nil, &config.ReplicaConfig{
+		pdAPI, mockPDClock, nil, nil, &config.ReplicaConfig{
 			Scheduler: &config.ChangefeedSchedulerConfig{
 				EnableTableAcrossNodes: true,
 				RegionThreshold:        0,
@@ -804,9 +807,6 @@ func TestDynamicMergeTableBasic(t *testing.T) {
 		}, ddlSpan, 1000, 0)
 	s.taskPool = &mockThreadPool{}
 
-	mockPDClock := pdutil.NewClockWithValue4Test(time.Unix(0, 0))
-	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
-
 	totalTables := 10
 	victim := rand.Intn(totalTables) + 1
 	var holeSpan *heartbeatpb.TableSpan
@@ -918,6 +918,64 @@ func TestDynamicMergeTableBasic(t *testing.T) {
 	require.Equal(t, totalTables, s.replicationDB.GetAbsentSize())
 }
 
+func TestManualSplitTable(t *testing.T) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	nodeManager.GetAliveNodes()["node1"] = &node.Info{ID: "node1"}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	pdClock := pdutil.NewClock4Test()
+	ddlSpan := replica.NewWorkingSpanReplication(cfID, tableTriggerEventDispatcherID,
+		pdClock, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	s := NewController(cfID, 1, nil, pdClock, nil, nil, nil, ddlSpan, 1000, 0)
+
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: totalSpan.StartKey, EndKey: totalSpan.EndKey}
+	dispatcherID := common.NewDispatcherID()
+	s.replicationDB.AddReplicatingSpan(replica.NewWorkingSpanReplication(cfID, dispatcherID, pdClock, 1, span,
+		&heartbeatpb.TableSpanStatus{
+			ID:              dispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    10,
+		}, "node1"))
+
+	// the table is not found
+	_, _, err := s.buildSplitTableSpans(2, 1, nil)
+	require.Error(t, err)
+	// split by region count needs the region cache
+	_, _, err = s.buildSplitTableSpans(1, 1, nil)
+	require.Error(t, err)
+	// the split key is out of the table span
+	_, _, err = s.buildSplitTableSpans(1, 0, [][]byte{spanz.TableIDToComparableSpan(2).StartKey})
+	require.Error(t, err)
+	// the table is not split, nothing to merge
+	require.NoError(t, s.mergeTable(1))
+	// the ddl span and unknown dispatchers can't be moved
+	require.Error(t, s.moveSpan(tableTriggerEventDispatcherID, "node1"))
+	require.Error(t, s.moveSpan(common.NewDispatcherID(), "node1"))
+
+	replications, spans, err := s.buildSplitTableSpans(1, 0, [][]byte{appendNew(span.StartKey, 'a')})
+	require.NoError(t, err)
+	require.Len(t, replications, 1)
+	require.Equal(t, []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: span.StartKey, EndKey: appendNew(span.StartKey, 'a')},
+		{TableID: 1, StartKey: appendNew(span.StartKey, 'a'), EndKey: span.EndKey},
+	}, spans)
+
+	require.False(t, s.isScheduling(dispatcherID))
+	// the split returns once the operator is added
+	require.NoError(t, s.mergeSplitTable(replications, spans))
+	require.True(t, s.isScheduling(dispatcherID))
+	// the table is being split, can't split it again
+	_, _, err = s.buildSplitTableSpans(1, 0, [][]byte{appendNew(span.StartKey, 'b')})
+	require.Error(t, err)
+	require.Error(t, s.mergeTable(1))
+}
+
 func appendNew(origin []byte, c byte) []byte {
 	nb := bytes.Clone(origin)
 	return append(nb, c)
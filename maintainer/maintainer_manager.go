@@ -21,6 +21,8 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -234,8 +236,25 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 			zap.Uint64("checkpointTs", req.CheckpointTs),
 			zap.Any("config", cfConfig))
 	}
+	pdAPI, pdClock, regionCache := m.pdAPI, m.pdClock, m.regionCache
+	var schemaStore schemastore.SchemaStore
+	if cfConfig.UpstreamID != 0 {
+		logServices := appcontext.GetService[*upstreamlog.Manager](appcontext.UpstreamLogManager)
+		logService, ok := logServices.Prepare(cfConfig.UpstreamID, cfID.Namespace())
+		if !ok {
+			// The coordinator keeps sending the request until the maintainer is added,
+			// so just wait for the log service of the upstream to be ready.
+			log.Info("log service of the upstream is not ready, add maintainer later",
+				zap.Stringer("changefeed", cfID),
+				zap.Uint64("upstreamID", cfConfig.UpstreamID),
+				zap.Error(logServices.Err(cfConfig.UpstreamID)))
+			return nil
+		}
+		pdAPI, pdClock, regionCache = logService.PDAPIClient, logService.PDClock, logService.RegionCache
+		schemaStore = logService.SchemaStore
+	}
 	maintainer := NewMaintainer(cfID, m.conf, cfConfig, m.selfNode, m.taskScheduler,
-		m.pdAPI, m.pdClock, m.regionCache, req.CheckpointTs, req.IsNewChangefeed)
+		pdAPI, pdClock, regionCache, schemaStore, req.CheckpointTs, req.IsNewChangefeed)
 	m.maintainers.Store(cfID, maintainer)
 	maintainer.pushEvent(&Event{changefeedID: cfID, eventType: EventInit})
 	return nil
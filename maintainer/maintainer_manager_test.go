@@ -27,6 +27,7 @@ import (
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
@@ -498,3 +499,7 @@ func newMockEtcdClient(ownerID string) *mockEtcdClient {
 func (m *mockEtcdClient) GetOwnerID(ctx context.Context) (model.CaptureID, error) {
 	return model.CaptureID(m.ownerID), nil
 }
+
+func (m *mockEtcdClient) GetNodeInfo(ctx context.Context, id model.CaptureID) (*node.Info, error) {
+	return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(id)
+}
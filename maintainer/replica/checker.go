@@ -73,7 +73,7 @@ func (c CheckResult) String() string {
 }
 
 func getNewGroupChecker(
-	cfID common.ChangeFeedID, enableTableAcrossNodes bool,
+	cfID common.ChangeFeedID, pdClock pdutil.Clock, enableTableAcrossNodes bool,
 ) func(replica.GroupID) replica.GroupChecker[common.DispatcherID, *SpanReplication] {
 	if !enableTableAcrossNodes {
 		return replica.NewEmptyChecker[common.DispatcherID, *SpanReplication]
@@ -84,7 +84,7 @@ func getNewGroupChecker(
 		case replica.GroupDefault:
 			return newHotSpanChecker(cfID)
 		case replica.GroupTable:
-			return newImbalanceChecker(cfID)
+			return newImbalanceChecker(cfID, pdClock)
 		}
 		log.Panic("unknown group type", zap.String("changefeed", cfID.Name()), zap.Int8("groupType", int8(groupType)))
 		return nil
@@ -236,7 +236,7 @@ type rebalanceChecker struct {
 	pdClock pdutil.Clock
 }
 
-func newImbalanceChecker(cfID common.ChangeFeedID) *rebalanceChecker {
+func newImbalanceChecker(cfID common.ChangeFeedID, pdClock pdutil.Clock) *rebalanceChecker {
 	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
 	return &rebalanceChecker{
 		changefeedID:           cfID,
@@ -249,7 +249,7 @@ func newImbalanceChecker(cfID common.ChangeFeedID) *rebalanceChecker {
 		softImbalanceThreshold:      2 * defaultHardImbalanceThreshold,
 		softRebalanceScoreThreshold: DefaultScoreThreshold,
 		softMergeScoreThreshold:     DefaultScoreThreshold,
-		pdClock:                     appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock),
+		pdClock:                     pdClock,
 	}
 }
 
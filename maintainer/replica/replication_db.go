@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/scheduler/replica"
 	"go.uber.org/zap"
 )
@@ -52,14 +53,15 @@ type ReplicationDB struct {
 	newGroupChecker func(groupID replica.GroupID) replica.GroupChecker[common.DispatcherID, *SpanReplication]
 }
 
-// NewReplicaSetDB creates a new ReplicationDB and initializes the maps
+// NewReplicaSetDB creates a new ReplicationDB and initializes the maps,
+// pdClock is the clock of the upstream of the changefeed.
 func NewReplicaSetDB(
-	changefeedID common.ChangeFeedID, ddlSpan *SpanReplication, enableTableAcrossNodes bool,
+	changefeedID common.ChangeFeedID, ddlSpan *SpanReplication, pdClock pdutil.Clock, enableTableAcrossNodes bool,
 ) *ReplicationDB {
 	db := &ReplicationDB{
 		changefeedID:    changefeedID,
 		ddlSpan:         ddlSpan,
-		newGroupChecker: getNewGroupChecker(changefeedID, enableTableAcrossNodes),
+		newGroupChecker: getNewGroupChecker(changefeedID, pdClock, enableTableAcrossNodes),
 	}
 
 	db.reset(db.ddlSpan)
@@ -231,5 +231,5 @@ func newDBWithCheckerForTest(t *testing.T) *ReplicationDB {
 			ComponentStatus: heartbeatpb.ComponentState_Working,
 			CheckpointTs:    1,
 		}, "node1")
-	return NewReplicaSetDB(cfID, ddlSpan, true)
+	return NewReplicaSetDB(cfID, ddlSpan, pdClock, true)
 }
@@ -38,7 +38,12 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 	nodeM *watcher.NodeManager,
 	balanceInterval time.Duration,
 	splitter *split.Splitter,
+	placement *scheduler.Placement,
 ) *scheduler.Controller {
+	var getPlacement func(*replica.SpanReplication) *scheduler.Placement
+	if placement != nil {
+		getPlacement = func(*replica.SpanReplication) *scheduler.Placement { return placement }
+	}
 	schedulers := map[string]scheduler.Scheduler{
 		scheduler.BasicScheduler: scheduler.NewBasicScheduler(
 			changefeedID.String(),
@@ -47,6 +52,7 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 			db,
 			nodeM,
 			oc.NewAddOperator,
+			getPlacement,
 		),
 		scheduler.BalanceScheduler: scheduler.NewBalanceScheduler(
 			changefeedID.String(),
@@ -56,6 +62,7 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 			nodeM,
 			balanceInterval,
 			oc.NewMoveOperator,
+			getPlacement,
 		),
 	}
 	if splitter != nil {
@@ -67,6 +74,7 @@ func NewScheduleController(changefeedID common.ChangeFeedID,
 			db,
 			nodeM,
 			balanceInterval,
+			placement,
 		)
 	}
 	return scheduler.NewController(schedulers)
@@ -80,6 +88,7 @@ type splitScheduler struct {
 	opController *operator.Controller
 	db           *replica.ReplicationDB
 	nodeManager  *watcher.NodeManager
+	placement    *scheduler.Placement
 
 	maxCheckTime  time.Duration
 	checkInterval time.Duration
@@ -91,7 +100,7 @@ type splitScheduler struct {
 func newSplitScheduler(
 	changefeedID common.ChangeFeedID, batchSize int, splitter *split.Splitter,
 	oc *operator.Controller, db *replica.ReplicationDB, nodeManager *watcher.NodeManager,
-	checkInterval time.Duration,
+	checkInterval time.Duration, placement *scheduler.Placement,
 ) *splitScheduler {
 	return &splitScheduler{
 		changefeedID:  changefeedID,
@@ -99,6 +108,7 @@ func newSplitScheduler(
 		opController:  oc,
 		db:            db,
 		nodeManager:   nodeManager,
+		placement:     placement,
 		batchSize:     batchSize,
 		maxCheckTime:  time.Second * 500,
 		checkInterval: checkInterval,
@@ -162,7 +172,7 @@ func (s *splitScheduler) doCheck(ret pkgReplica.GroupCheckResult, start time.Tim
 		case replica.OpMergeAndSplit:
 			log.Info("Into OP MergeAndSplit")
 			// expectedSpanNum := split.NextExpectedSpansNumber(len(ret.Replications))
-			spans := s.splitter.SplitSpans(context.Background(), totalSpan, len(s.nodeManager.GetAliveNodes()))
+			spans := s.splitter.SplitSpans(context.Background(), totalSpan, len(s.placement.FilterNodes(s.nodeManager.GetAliveNodes())))
 			if len(spans) > 1 {
 				log.Info("split span",
 					zap.String("changefeed", s.changefeedID.Name()),
@@ -63,9 +63,54 @@ func (m *regionCountSplitter) split(
 		return []*heartbeatpb.TableSpan{span}
 	}
 
-	stepper := newEvenlySplitStepper(
-		getSpansNumber(len(regions), captureNum),
-		len(regions))
+	spans := m.splitRegions(bo, span, regions, getSpansNumber(len(regions), captureNum))
+	log.Info("split span by region count",
+		zap.String("changefeed", m.changefeedID.Name()),
+		zap.String("span", span.String()),
+		zap.Int("spans", len(spans)),
+		zap.Int("totalCaptures", captureNum),
+		zap.Int("regionCount", len(regions)),
+		zap.Int("regionThreshold", m.regionThreshold),
+		zap.Int("spanRegionLimit", spanRegionLimit))
+	return spans
+}
+
+// SplitSpanByRegionCount splits the span into several spans, each of them
+// covers at most regionsPerSpan regions. It is used to split a table manually.
+func SplitSpanByRegionCount(
+	ctx context.Context, changefeedID common.ChangeFeedID,
+	regionCache RegionCache, span *heartbeatpb.TableSpan, regionsPerSpan int,
+) []*heartbeatpb.TableSpan {
+	m := newRegionCountSplitter(changefeedID, regionCache, 0)
+	bo := tikv.NewBackoffer(ctx, 500)
+	regions, err := m.regionCache.ListRegionIDsInKeyRange(bo, span.StartKey, span.EndKey)
+	if err != nil {
+		log.Warn("list regions failed, skip split span",
+			zap.String("changefeed", m.changefeedID.Name()),
+			zap.String("span", span.String()),
+			zap.Error(err))
+		return []*heartbeatpb.TableSpan{span}
+	}
+	spanCount := (len(regions) + regionsPerSpan - 1) / regionsPerSpan
+	if spanCount <= 1 {
+		return []*heartbeatpb.TableSpan{span}
+	}
+	spans := m.splitRegions(bo, span, regions, spanCount)
+	log.Info("split span by region count manually",
+		zap.String("changefeed", m.changefeedID.Name()),
+		zap.String("span", span.String()),
+		zap.Int("spans", len(spans)),
+		zap.Int("regionCount", len(regions)),
+		zap.Int("regionsPerSpan", regionsPerSpan))
+	return spans
+}
+
+// splitRegions splits the span into spanCount spans, the regions are evenly
+// distributed to the spans. It returns the origin span if any error occurs.
+func (m *regionCountSplitter) splitRegions(
+	bo *tikv.Backoffer, span *heartbeatpb.TableSpan, regions []uint64, spanCount int,
+) []*heartbeatpb.TableSpan {
+	stepper := newEvenlySplitStepper(spanCount, len(regions))
 
 	spans := make([]*heartbeatpb.TableSpan, 0, stepper.SpanCount())
 	start, end := 0, stepper.Step()
@@ -119,14 +164,6 @@ func (m *regionCountSplitter) split(
 	// Make sure spans does not exceed [startKey, endKey).
 	spans[0].StartKey = span.StartKey
 	spans[len(spans)-1].EndKey = span.EndKey
-	log.Info("split span by region count",
-		zap.String("changefeed", m.changefeedID.Name()),
-		zap.String("span", span.String()),
-		zap.Int("spans", len(spans)),
-		zap.Int("totalCaptures", captureNum),
-		zap.Int("regionCount", len(regions)),
-		zap.Int("regionThreshold", m.regionThreshold),
-		zap.Int("spanRegionLimit", spanRegionLimit))
 	return spans
 }
 
@@ -259,6 +259,34 @@ func TestSplitSpanRegionOutOfOrder(t *testing.T) {
 		t, []*heartbeatpb.TableSpan{{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t2")}}, spans)
 }
 
+func TestSplitSpanByRegionCount(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMockRegionCache(nil)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_0"), EndKey: []byte("t1_1")}, 1)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_1"), EndKey: []byte("t1_2")}, 2)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_2"), EndKey: []byte("t1_3")}, 3)
+	cache.regions.ReplaceOrInsert(tablepb.Span{StartKey: []byte("t1_3"), EndKey: []byte("t2_0")}, 4)
+
+	cfID := common.NewChangeFeedIDWithName("test")
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t2")}
+
+	spans := SplitSpanByRegionCount(context.Background(), cfID, cache, span, 2)
+	require.Equal(t, []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t1_2")},
+		{TableID: 1, StartKey: []byte("t1_2"), EndKey: []byte("t2")},
+	}, spans)
+
+	spans = SplitSpanByRegionCount(context.Background(), cfID, cache, span, 3)
+	require.Len(t, spans, 2)
+	require.Equal(t, span.StartKey, spans[0].StartKey)
+	require.Equal(t, span.EndKey, spans[1].EndKey)
+
+	// all the regions can be covered by one span
+	spans = SplitSpanByRegionCount(context.Background(), cfID, cache, span, 4)
+	require.Equal(t, []*heartbeatpb.TableSpan{span}, spans)
+}
+
 // mockCache mocks tikv.RegionCache.
 type mockCache struct {
 	regions *spanz.BtreeMap[uint64]
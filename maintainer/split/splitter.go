@@ -16,10 +16,13 @@ package split
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"slices"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/pdutil"
@@ -98,6 +101,35 @@ func (s *Splitter) SplitSpans(ctx context.Context,
 	return spans
 }
 
+// SplitSpanByKeys splits the span by the given keys, the keys must be in
+// comparable format and strictly inside the span. Duplicate keys are ignored.
+func SplitSpanByKeys(span *heartbeatpb.TableSpan, keys [][]byte) ([]*heartbeatpb.TableSpan, error) {
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, bytes.Compare)
+	sorted = slices.CompactFunc(sorted, bytes.Equal)
+
+	spans := make([]*heartbeatpb.TableSpan, 0, len(sorted)+1)
+	startKey := span.StartKey
+	for _, key := range sorted {
+		if bytes.Compare(key, span.StartKey) <= 0 || bytes.Compare(key, span.EndKey) >= 0 {
+			return nil, apperror.ErrInvalidSplitKey.GenWithStack(
+				"split key %s is out of span %s", hex.EncodeToString(key), span.String())
+		}
+		spans = append(spans, &heartbeatpb.TableSpan{
+			TableID:  span.TableID,
+			StartKey: startKey,
+			EndKey:   key,
+		})
+		startKey = key
+	}
+	spans = append(spans, &heartbeatpb.TableSpan{
+		TableID:  span.TableID,
+		StartKey: startKey,
+		EndKey:   span.EndKey,
+	})
+	return spans, nil
+}
+
 // FindHoles returns an array of Span that are not covered in the range
 func FindHoles(currentSpan utils.Map[*heartbeatpb.TableSpan, *replica.SpanReplication], totalSpan *heartbeatpb.TableSpan) []*heartbeatpb.TableSpan {
 	lastSpan := &heartbeatpb.TableSpan{
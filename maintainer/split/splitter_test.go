@@ -95,3 +95,21 @@ func TestMapFindHole(t *testing.T) {
 		require.Equalf(t, cs.expectedHole, holes, "case %d, %#v", i, cs)
 	}
 }
+
+func TestSplitSpanByKeys(t *testing.T) {
+	span := &heartbeatpb.TableSpan{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t2")}
+
+	spans, err := SplitSpanByKeys(span, [][]byte{[]byte("t1_5"), []byte("t1_2"), []byte("t1_5")})
+	require.NoError(t, err)
+	require.Equal(t, []*heartbeatpb.TableSpan{
+		{TableID: 1, StartKey: []byte("t1"), EndKey: []byte("t1_2")},
+		{TableID: 1, StartKey: []byte("t1_2"), EndKey: []byte("t1_5")},
+		{TableID: 1, StartKey: []byte("t1_5"), EndKey: []byte("t2")},
+	}, spans)
+
+	// the split keys must be strictly inside the span
+	_, err = SplitSpanByKeys(span, [][]byte{[]byte("t1")})
+	require.Error(t, err)
+	_, err = SplitSpanByKeys(span, [][]byte{[]byte("t1_2"), []byte("t3")})
+	require.Error(t, err)
+}
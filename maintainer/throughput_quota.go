@@ -0,0 +1,84 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/pkg/util"
+)
+
+// throughputQuotaInterval is the interval to send the throughput quota to the nodes,
+// the quota is resent periodically, so a lost message or a node which starts to host
+// the dispatchers of the changefeed gets its share soon.
+const throughputQuotaInterval = time.Second
+
+// sendThroughputQuota splits the throughput limits of the changefeed across the nodes
+// which the tables of the changefeed are scheduled to, and sends each node its share.
+func (m *Maintainer) sendThroughputQuota() {
+	if !m.bootstrapped.Load() || m.config.Config == nil ||
+		time.Since(m.lastThroughputQuotaTime) < throughputQuotaInterval {
+		return
+	}
+	maxRows := util.GetOrZero(m.config.Config.MaxRowsPerSecond)
+	maxBytes := util.GetOrZero(m.config.Config.MaxBytesPerSecond)
+	if maxRows == 0 && maxBytes == 0 {
+		return
+	}
+	m.lastThroughputQuotaTime = time.Now()
+
+	nodes := make([]node.ID, 0, len(m.bootstrapper.GetAllNodes()))
+	for id := range m.bootstrapper.GetAllNodes() {
+		if m.controller.GetTaskSizeByNodeID(id) > 0 {
+			nodes = append(nodes, id)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	slices.Sort(nodes)
+	rowsQuota := splitThroughputQuota(maxRows, len(nodes))
+	bytesQuota := splitThroughputQuota(maxBytes, len(nodes))
+	msgs := make([]*messaging.TargetMessage, 0, len(nodes))
+	for i, id := range nodes {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id, messaging.HeartbeatCollectorTopic,
+			&heartbeatpb.ThroughputQuota{
+				ChangefeedID:      m.id.ToPB(),
+				MaxRowsPerSecond:  rowsQuota[i],
+				MaxBytesPerSecond: bytesQuota[i],
+			}))
+	}
+	m.sendMessages(msgs)
+}
+
+// splitThroughputQuota splits the limit into n shares, the sum of the shares is the limit.
+// Every share is at least 1 if the limit is set, since 0 means no limit.
+func splitThroughputQuota(limit uint64, n int) []uint64 {
+	shares := make([]uint64, n)
+	if limit == 0 {
+		return shares
+	}
+	for i := range shares {
+		shares[i] = limit / uint64(n)
+		if uint64(i) < limit%uint64(n) {
+			shares[i]++
+		}
+		shares[i] = max(shares[i], 1)
+	}
+	return shares
+}
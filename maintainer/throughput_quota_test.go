@@ -0,0 +1,29 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitThroughputQuota(t *testing.T) {
+	require.Equal(t, []uint64{0, 0, 0}, splitThroughputQuota(0, 3))
+	require.Equal(t, []uint64{100}, splitThroughputQuota(100, 1))
+	require.Equal(t, []uint64{34, 33, 33}, splitThroughputQuota(100, 3))
+	require.Equal(t, []uint64{25, 25, 25, 25}, splitThroughputQuota(100, 4))
+	// every node gets at least 1, the limit is not disabled by a 0 share
+	require.Equal(t, []uint64{1, 1, 1}, splitThroughputQuota(2, 3))
+}
@@ -50,6 +50,13 @@ type ChangefeedInterface interface {
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
 	// Move Table to target node, it just for make test case now. **Not for public use.**
 	MoveTable(ctx context.Context, namespace string, name string, tableID int64, targetNode string) error
+	// MoveSplitTable moves a single span of a table to target node, the span is specified by the dispatcher id.
+	MoveSplitTable(ctx context.Context, namespace string, name string, dispatcherID string, targetNode string) error
+	// SplitTable splits a table into several spans, by the split keys if they are given,
+	// otherwise each span covers at most regionsPerSpan regions.
+	SplitTable(ctx context.Context, namespace string, name string, tableID int64, regionsPerSpan int, splitKeys []string) error
+	// MergeTable merges all the spans of a table into one span.
+	MergeTable(ctx context.Context, namespace string, name string, tableID int64) error
 }
 
 // changefeeds implements ChangefeedInterface
@@ -173,3 +180,43 @@ func (c *changefeeds) MoveTable(ctx context.Context,
 		Do(ctx).Error()
 	return err
 }
+
+// MoveSplitTable moves a single span of a table to target node.
+func (c *changefeeds) MoveSplitTable(ctx context.Context,
+	namespace string, name string, dispatcherID string, targetNode string,
+) error {
+	url := fmt.Sprintf("changefeeds/%s/move_table?namespace=%s", name, namespace)
+	err := c.client.Post().
+		WithURI(url).
+		WithParam("dispatcherID", dispatcherID).
+		WithParam("targetNodeID", targetNode).
+		Do(ctx).Error()
+	return err
+}
+
+// SplitTable splits a table into several spans.
+func (c *changefeeds) SplitTable(ctx context.Context,
+	namespace string, name string, tableID int64, regionsPerSpan int, splitKeys []string,
+) error {
+	url := fmt.Sprintf("changefeeds/%s/split_table?namespace=%s", name, namespace)
+	req := c.client.Post().
+		WithURI(url).
+		WithParam("tableID", strconv.FormatInt(tableID, 10)).
+		WithParam("regionsPerSpan", strconv.Itoa(regionsPerSpan))
+	for _, key := range splitKeys {
+		req = req.WithParam("splitKey", key)
+	}
+	return req.Do(ctx).Error()
+}
+
+// MergeTable merges all the spans of a table into one span.
+func (c *changefeeds) MergeTable(ctx context.Context,
+	namespace string, name string, tableID int64,
+) error {
+	url := fmt.Sprintf("changefeeds/%s/merge_table?namespace=%s", name, namespace)
+	err := c.client.Post().
+		WithURI(url).
+		WithParam("tableID", strconv.FormatInt(tableID, 10)).
+		Do(ctx).Error()
+	return err
+}
@@ -51,10 +51,30 @@ var (
 		errors.RFCCodeText("CDC:ErrMoveTableTimeout"),
 	)
 
+	ErrTableIsScheduling = errors.Normalize(
+		"table is being scheduled, please retry later",
+		errors.RFCCodeText("CDC:ErrTableIsScheduling"),
+	)
+
+	ErrInvalidSplitKey = errors.Normalize(
+		"split key is invalid",
+		errors.RFCCodeText("CDC:ErrInvalidSplitKey"),
+	)
+
+	ErrTableCannotBeSplit = errors.Normalize(
+		"table can not be split",
+		errors.RFCCodeText("CDC:ErrTableCannotBeSplit"),
+	)
+
 	ErrNodeIsNotFound = errors.Normalize(
 		"node is not found",
 		errors.RFCCodeText("CDC:ErrNodeIsNotFound"),
 	)
+
+	ErrNodeNotMatchPlacement = errors.Normalize(
+		"node does not match the placement of the changefeed",
+		errors.RFCCodeText("CDC:ErrNodeNotMatchPlacement"),
+	)
 )
 
 type ErrorType int
@@ -163,6 +183,7 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	cerrors.ErrExpressionParseFailed,
 	cerrors.ErrSchemaSnapshotNotFound,
 	cerrors.ErrSyncRenameTableFailed,
+	cerrors.ErrSyncFlashbackClusterFailed,
 	cerrors.ErrChangefeedUnretryable,
 	cerrors.ErrCorruptedDataMutation,
 	cerrors.ErrDispatcherFailed,
@@ -57,7 +57,9 @@ func IsIgnorableMySQLDDLError(err error) bool {
 		infoschema.ErrKeyNotExists.Code(), dbterror.ErrCantDropFieldOrKey.Code(),
 		infoschema.ErrColumnNotExists.Code(),
 		mysql.ErrDupKeyName, mysql.ErrSameNamePartition,
-		mysql.ErrDropPartitionNonExistent, mysql.ErrMultiplePriKey:
+		mysql.ErrDropPartitionNonExistent, mysql.ErrMultiplePriKey,
+		infoschema.ErrPlacementPolicyExists.Code(),
+		dbterror.ErrCheckConstraintDupName.Code(), dbterror.ErrConstraintNotFound.Code():
 		return true
 	default:
 		return false
@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	"sync"
+
+	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+)
+
+// Projection decides which columns of a table are decoded by the event service.
+// The event service and the dispatcher must build the projection from the same
+// column projection config, so the rows are encoded and decoded with the same schema.
+// A nil Projection keeps all the columns.
+type Projection struct {
+	selectors                  *ColumnSelectors
+	deleteOnlyHandleKeyColumns bool
+
+	mu sync.Mutex
+	// The table info only changes after a DDL, so we only cache the last one.
+	source    *common.TableInfo
+	projected *common.TableInfo
+}
+
+// NewProjection creates a projection from the column projection config,
+// it returns nil if all the columns are needed.
+func NewProjection(cfg *eventpb.ColumnProjection) (*Projection, error) {
+	if cfg == nil || (len(cfg.ColumnSelectors) == 0 && !cfg.DeleteOnlyHandleKeyColumns) {
+		return nil, nil
+	}
+	selectors := make([]*ColumnSelector, 0, len(cfg.ColumnSelectors))
+	for _, r := range cfg.ColumnSelectors {
+		selector, err := newColumnSelector(&ticonfig.ColumnSelector{
+			Matcher: r.Matcher,
+			Columns: r.Columns,
+		}, cfg.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	return &Projection{
+		selectors:                  &ColumnSelectors{selectors: selectors},
+		deleteOnlyHandleKeyColumns: cfg.DeleteOnlyHandleKeyColumns,
+	}, nil
+}
+
+// ToColumnProjectionPB converts the sink config to the column projection config,
+// it returns nil if the sink config doesn't need to project any column.
+func ToColumnProjectionPB(sinkConfig *ticonfig.SinkConfig) *eventpb.ColumnProjection {
+	if sinkConfig == nil {
+		return nil
+	}
+	// The partition dispatchers may calculate the partition by any columns,
+	// so all the columns are needed to keep the partition stable.
+	for _, rule := range sinkConfig.DispatchRules {
+		if len(rule.Columns) != 0 || rule.IndexName != "" {
+			return nil
+		}
+	}
+	deleteOnlyHandleKeyColumns := util.GetOrZero(sinkConfig.DeleteOnlyOutputHandleKeyColumns)
+	if len(sinkConfig.ColumnSelectors) == 0 && !deleteOnlyHandleKeyColumns {
+		return nil
+	}
+	cfg := &eventpb.ColumnProjection{
+		CaseSensitive:              sinkConfig.CaseSensitive,
+		DeleteOnlyHandleKeyColumns: deleteOnlyHandleKeyColumns,
+	}
+	for _, r := range sinkConfig.ColumnSelectors {
+		cfg.ColumnSelectors = append(cfg.ColumnSelectors, &eventpb.ColumnSelector{
+			Matcher: r.Matcher,
+			Columns: r.Columns,
+		})
+	}
+	return cfg
+}
+
+// ProjectTableInfo returns the table info which only contains the selected columns
+// and the handle / unique key columns of the table.
+func (p *Projection) ProjectTableInfo(tableInfo *common.TableInfo) *common.TableInfo {
+	if p == nil || tableInfo == nil || len(p.selectors.selectors) == 0 {
+		return tableInfo
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.source != nil && sameTableInfoVersion(p.source, tableInfo) {
+		return p.projected
+	}
+	selector := p.selectors.GetSelector(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	projected := tableInfo
+	if _, ok := selector.(*DefaultColumnSelector); !ok {
+		projected = tableInfo.Project(selector.Select)
+	}
+	p.source, p.projected = tableInfo, projected
+	return projected
+}
+
+// DeleteOnlyHandleKeyColumns returns true if only the handle key columns of the delete event are needed.
+func (p *Projection) DeleteOnlyHandleKeyColumns() bool {
+	return p != nil && p.deleteOnlyHandleKeyColumns
+}
+
+// sameTableInfoVersion returns true if the two table infos are the same version of the same table,
+// the schema store may return different table info objects for the same version.
+func sameTableInfoVersion(a, b *common.TableInfo) bool {
+	if a == b {
+		return true
+	}
+	return a.SchemaID == b.SchemaID &&
+		a.UpdateTS() == b.UpdateTS() &&
+		a.TableName.Schema == b.TableName.Schema &&
+		a.TableName.Table == b.TableName.Table &&
+		a.TableName.TableID == b.TableName.TableID
+}
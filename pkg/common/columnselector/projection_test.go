@@ -0,0 +1,54 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columnselector
+
+import (
+	"testing"
+
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToColumnProjectionPB(t *testing.T) {
+	sinkConfig := &ticonfig.SinkConfig{}
+	require.Nil(t, ToColumnProjectionPB(sinkConfig))
+
+	sinkConfig.ColumnSelectors = []*ticonfig.ColumnSelector{
+		{Matcher: []string{"test.*"}, Columns: []string{"a", "b"}},
+	}
+	sinkConfig.DeleteOnlyOutputHandleKeyColumns = util.AddressOf(true)
+	cfg := ToColumnProjectionPB(sinkConfig)
+	require.NotNil(t, cfg)
+	require.True(t, cfg.DeleteOnlyHandleKeyColumns)
+	require.Len(t, cfg.ColumnSelectors, 1)
+	require.Equal(t, []string{"a", "b"}, cfg.ColumnSelectors[0].Columns)
+
+	projection, err := NewProjection(cfg)
+	require.NoError(t, err)
+	require.True(t, projection.DeleteOnlyHandleKeyColumns())
+
+	// The partition is calculated by the columns, so all the columns are needed.
+	sinkConfig.DispatchRules = []*ticonfig.DispatchRule{
+		{Matcher: []string{"test.*"}, PartitionRule: "columns", Columns: []string{"c"}},
+	}
+	require.Nil(t, ToColumnProjectionPB(sinkConfig))
+
+	// A nil projection keeps all the columns.
+	projection, err = NewProjection(nil)
+	require.NoError(t, err)
+	require.Nil(t, projection)
+	require.False(t, projection.DeleteOnlyHandleKeyColumns())
+	require.Nil(t, projection.ProjectTableInfo(nil))
+}
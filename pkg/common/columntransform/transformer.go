@@ -0,0 +1,315 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+)
+
+type rule struct {
+	tableF  filter.Filter
+	columnM filter.ColumnFilter
+	config  *config.ColumnTransform
+}
+
+// columnTransform transforms the value of the column at offset.
+type columnTransform struct {
+	offset int
+	apply  func(row chunk.Row, d types.Datum) (types.Datum, error)
+}
+
+// tableTransformer is the transforms of a version of a table.
+type tableTransformer struct {
+	tableInfo *common.TableInfo
+	columns   []columnTransform
+}
+
+// Transformer applies the column transforms to the rows of the dml events,
+// the first transform whose matcher and columns match a column is used.
+// A nil Transformer keeps all the values.
+type Transformer struct {
+	rules []*rule
+
+	// evalMu protects sessCtx from evaluating expressions concurrently.
+	evalMu  sync.Mutex
+	sessCtx sessionctx.Context
+
+	mu sync.Mutex
+	// tables caches the transforms of the last table info of each table, tableID -> tableTransformer
+	tables map[int64]*tableTransformer
+}
+
+// NewTransformer creates a Transformer, it returns nil if there is no column transform.
+func NewTransformer(sinkConfig *config.SinkConfig, timezone string) (*Transformer, error) {
+	if sinkConfig == nil || len(sinkConfig.ColumnTransforms) == 0 {
+		return nil, nil
+	}
+	t := &Transformer{
+		rules:   make([]*rule, 0, len(sinkConfig.ColumnTransforms)),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": timezone}),
+		tables:  make(map[int64]*tableTransformer),
+	}
+	for _, cfg := range sinkConfig.ColumnTransforms {
+		tableF, err := filter.Parse(cfg.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.Matcher)
+		}
+		if !sinkConfig.CaseSensitive {
+			tableF = filter.CaseInsensitive(tableF)
+		}
+		columnM, err := filter.ParseColumnFilter(cfg.Columns)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err, cfg.Columns)
+		}
+		t.rules = append(t.rules, &rule{tableF: tableF, columnM: columnM, config: cfg})
+	}
+	return t, nil
+}
+
+// Transform replaces the rows of the event with the transformed ones.
+func (t *Transformer) Transform(event *commonEvent.DMLEvent) error {
+	if t == nil || event.Rows == nil {
+		return nil
+	}
+	tt, err := t.getTableTransformer(event.TableInfo)
+	if err != nil {
+		return err
+	}
+	if len(tt.columns) == 0 {
+		return nil
+	}
+
+	fieldTypes := event.TableInfo.GetFieldSlice()
+	rows := chunk.NewChunkWithCapacity(fieldTypes, event.Rows.NumRows())
+	for i := 0; i < event.Rows.NumRows(); i++ {
+		row := event.Rows.GetRow(i)
+		datums := row.GetDatumRow(fieldTypes)
+		for _, c := range tt.columns {
+			datums[c.offset], err = c.apply(row, datums[c.offset])
+			if err != nil {
+				return err
+			}
+		}
+		for j := range datums {
+			rows.AppendDatum(j, &datums[j])
+		}
+	}
+	event.Rows = rows
+	return nil
+}
+
+func (t *Transformer) getTableTransformer(tableInfo *common.TableInfo) (*tableTransformer, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tableID := tableInfo.TableName.TableID
+	if tt, ok := t.tables[tableID]; ok && tt.tableInfo == tableInfo {
+		return tt, nil
+	}
+	tt, err := t.newTableTransformer(tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	t.tables[tableID] = tt
+	return tt, nil
+}
+
+func (t *Transformer) newTableTransformer(tableInfo *common.TableInfo) (*tableTransformer, error) {
+	tt := &tableTransformer{tableInfo: tableInfo}
+	var rules []*rule
+	for _, r := range t.rules {
+		if r.tableF.MatchTable(tableInfo.GetSchemaName(), tableInfo.GetTableName()) {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) == 0 {
+		return tt, nil
+	}
+
+	// The rows are dispatched and detected conflicts by the handle key and unique key
+	// columns, so they must be kept unchanged.
+	keyColumns := make(map[int64]struct{})
+	for _, idx := range tableInfo.GetIndices() {
+		if !idx.Primary && !idx.Unique {
+			continue
+		}
+		for _, idxCol := range idx.Columns {
+			keyColumns[tableInfo.GetColumns()[idxCol.Offset].ID] = struct{}{}
+		}
+	}
+	for offset, col := range tableInfo.GetColumns() {
+		if col == nil {
+			continue
+		}
+		var matched *rule
+		for _, r := range rules {
+			if r.columnM.MatchColumn(col.Name.O) {
+				matched = r
+				break
+			}
+		}
+		if matched == nil {
+			continue
+		}
+		flag := tableInfo.ForceGetColumnFlagType(col.ID)
+		if _, ok := keyColumns[col.ID]; ok || flag.IsHandleKey() || flag.IsPrimaryKey() || flag.IsUniqueKey() {
+			return nil, cerror.ErrColumnTransformFailed.GenWithStackByArgs(
+				fmt.Sprintf("column %s of table %s is a key column, it can't be transformed",
+					col.Name.O, tableInfo.TableName.String()))
+		}
+		apply, err := t.newApplyFunc(matched.config, tableInfo, col)
+		if err != nil {
+			return nil, err
+		}
+		tt.columns = append(tt.columns, columnTransform{offset: offset, apply: apply})
+	}
+	return tt, nil
+}
+
+func (t *Transformer) newApplyFunc(
+	cfg *config.ColumnTransform, tableInfo *common.TableInfo, col *model.ColumnInfo,
+) (func(row chunk.Row, d types.Datum) (types.Datum, error), error) {
+	switch cfg.Type {
+	case config.ColumnTransformMask, config.ColumnTransformHash, config.ColumnTransformTruncate:
+		if !types.IsString(col.GetType()) {
+			return nil, cerror.ErrColumnTransformFailed.GenWithStackByArgs(
+				fmt.Sprintf("column %s of table %s is not a string column, it can't be transformed by %s",
+					col.Name.O, tableInfo.TableName.String(), cfg.Type))
+		}
+		var transform func(b []byte) []byte
+		switch cfg.Type {
+		case config.ColumnTransformMask:
+			transform = func(b []byte) []byte { return mask(b, cfg.Length, isBinary(col)) }
+		case config.ColumnTransformHash:
+			transform = func(b []byte) []byte { return hash(b, cfg.Salt, col.GetFlen()) }
+		default:
+			transform = func(b []byte) []byte { return truncate(b, cfg.Length, isBinary(col)) }
+		}
+		return func(_ chunk.Row, d types.Datum) (types.Datum, error) {
+			if d.IsNull() {
+				return d, nil
+			}
+			b := transform(d.GetBytes())
+			if d.Kind() == types.KindBytes {
+				return types.NewBytesDatum(b), nil
+			}
+			return types.NewCollationStringDatum(string(b), d.Collation()), nil
+		}, nil
+	case config.ColumnTransformConstant:
+		var value types.Datum
+		if cfg.Value != nil {
+			var err error
+			d := types.NewStringDatum(*cfg.Value)
+			value, err = d.ConvertTo(t.sessCtx.GetExprCtx().GetEvalCtx().TypeCtx(), &col.FieldType)
+			if err != nil {
+				return nil, cerror.WrapError(cerror.ErrColumnTransformFailed, err,
+					fmt.Sprintf("can't convert %s to the type of column %s", *cfg.Value, col.Name.O))
+			}
+		}
+		return func(_ chunk.Row, _ types.Datum) (types.Datum, error) {
+			return value, nil
+		}, nil
+	case config.ColumnTransformExpression:
+		info := &model.TableInfo{
+			ID:      tableInfo.TableName.TableID,
+			Name:    pmodel.NewCIStr(tableInfo.TableName.Table),
+			Columns: tableInfo.GetColumns(),
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(t.sessCtx.GetExprCtx(), cfg.Expression, info)
+		if err != nil {
+			return nil, cerror.ErrExpressionParseFailed.FastGenByArgs(cfg.Expression)
+		}
+		return func(row chunk.Row, _ types.Datum) (types.Datum, error) {
+			t.evalMu.Lock()
+			defer t.evalMu.Unlock()
+			evalCtx := t.sessCtx.GetExprCtx().GetEvalCtx()
+			d, err := expr.Eval(evalCtx, row)
+			if err != nil {
+				return d, cerror.WrapError(cerror.ErrColumnTransformFailed, err, cfg.Expression)
+			}
+			d, err = d.ConvertTo(evalCtx.TypeCtx(), &col.FieldType)
+			if err != nil {
+				return d, cerror.WrapError(cerror.ErrColumnTransformFailed, err, cfg.Expression)
+			}
+			return d, nil
+		}, nil
+	default:
+		return nil, cerror.ErrColumnTransformFailed.GenWithStackByArgs(
+			fmt.Sprintf("unknown column transform type %s", cfg.Type))
+	}
+}
+
+func isBinary(col *model.ColumnInfo) bool {
+	return types.IsBinaryStr(&col.FieldType)
+}
+
+// mask replaces all the characters except the last keep ones with '*'.
+func mask(b []byte, keep int, binary bool) []byte {
+	if binary {
+		res := make([]byte, len(b))
+		copy(res, b)
+		for i := 0; i < len(res)-keep; i++ {
+			res[i] = '*'
+		}
+		return res
+	}
+	runes := []rune(string(b))
+	for i := 0; i < len(runes)-keep; i++ {
+		runes[i] = '*'
+	}
+	return []byte(string(runes))
+}
+
+// hash returns the hex encoded SHA-256 hash of the salt and the value,
+// the result is truncated to flen if it's longer than the column.
+func hash(b []byte, salt string, flen int) []byte {
+	h := sha256.New()
+	h.Write([]byte(salt))
+	h.Write(b)
+	res := []byte(hex.EncodeToString(h.Sum(nil)))
+	if flen > 0 && flen < len(res) {
+		res = res[:flen]
+	}
+	return res
+}
+
+// truncate keeps the first length characters.
+func truncate(b []byte, length int, binary bool) []byte {
+	if binary {
+		if len(b) > length {
+			return b[:length]
+		}
+		return b
+	}
+	runes := []rune(string(b))
+	if len(runes) > length {
+		return []byte(string(runes[:length]))
+	}
+	return b
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package columntransform
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, email varchar(64), ssn char(11), " +
+		"name varchar(32), phone varchar(16), age int, note text)")
+	require.NotNil(t, job)
+
+	sinkConfig := &ticonfig.SinkConfig{
+		ColumnTransforms: []*ticonfig.ColumnTransform{
+			{Matcher: []string{"test.t"}, Columns: []string{"email"}, Type: ticonfig.ColumnTransformHash, Salt: "salt"},
+			{Matcher: []string{"test.*"}, Columns: []string{"ssn"}, Type: ticonfig.ColumnTransformConstant},
+			{Matcher: []string{"test.*"}, Columns: []string{"name"}, Type: ticonfig.ColumnTransformTruncate, Length: 2},
+			{Matcher: []string{"test.*"}, Columns: []string{"phone"}, Type: ticonfig.ColumnTransformMask, Length: 4},
+			{Matcher: []string{"test.*"}, Columns: []string{"age"}, Type: ticonfig.ColumnTransformExpression, Expression: "age + 1"},
+			{Matcher: []string{"test.*"}, Columns: []string{"note"}, Type: ticonfig.ColumnTransformConstant, Value: util.AddressOf("hidden")},
+		},
+	}
+	transformer, err := NewTransformer(sinkConfig, "UTC")
+	require.NoError(t, err)
+
+	event := helper.DML2Event("test", "t",
+		"insert into t values (1, 'a@b.com', '123-45-6789', '张三丰', '13800001234', 20, 'note')")
+	require.NoError(t, transformer.Transform(event))
+	row, ok := event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, int64(1), row.Row.GetInt64(0))
+	require.Equal(t, string(hash([]byte("a@b.com"), "salt", 64)), row.Row.GetString(1))
+	require.True(t, row.Row.IsNull(2))
+	require.Equal(t, "张三", row.Row.GetString(3))
+	require.Equal(t, "*******1234", row.Row.GetString(4))
+	require.Equal(t, int64(21), row.Row.GetInt64(5))
+	require.Equal(t, "hidden", row.Row.GetString(6))
+
+	// the rows of the tables which are not matched are kept
+	helper.Tk().MustExec("create database other")
+	job = helper.DDL2Job("create table other.t (id int primary key, name varchar(32))")
+	require.NotNil(t, job)
+	event = helper.DML2Event("other", "t", "insert into other.t values (1, 'name')")
+	require.NoError(t, transformer.Transform(event))
+	row, ok = event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, "name", row.Row.GetString(1))
+
+	// a nil transformer keeps all the values
+	transformer, err = NewTransformer(&ticonfig.SinkConfig{}, "UTC")
+	require.NoError(t, err)
+	require.Nil(t, transformer)
+	require.NoError(t, transformer.Transform(event))
+}
+
+func TestTransformInvalidColumns(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, email varchar(64) unique, age int)")
+	require.NotNil(t, job)
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'a@b.com', 20)")
+
+	cases := []*ticonfig.ColumnTransform{
+		// the key columns can't be transformed
+		{Matcher: []string{"test.t"}, Columns: []string{"id"}, Type: ticonfig.ColumnTransformConstant},
+		{Matcher: []string{"test.t"}, Columns: []string{"email"}, Type: ticonfig.ColumnTransformHash},
+		// the string transforms are only available for string columns
+		{Matcher: []string{"test.t"}, Columns: []string{"age"}, Type: ticonfig.ColumnTransformMask},
+		{Matcher: []string{"test.t"}, Columns: []string{"age"}, Type: ticonfig.ColumnTransformConstant, Value: util.AddressOf("abc")},
+		{Matcher: []string{"test.t"}, Columns: []string{"age"}, Type: ticonfig.ColumnTransformExpression, Expression: "unknown + 1"},
+	}
+	for _, c := range cases {
+		transformer, err := NewTransformer(&ticonfig.SinkConfig{
+			ColumnTransforms: []*ticonfig.ColumnTransform{c},
+		}, "UTC")
+		require.NoError(t, err)
+		require.Error(t, transformer.Transform(event), c)
+	}
+}
+
+func TestTransformWithVirtualColumns(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	// the virtual column is not in the row, the offsets of the columns after it
+	// are different in the row and in the table info.
+	job := helper.DDL2Job("create table t (v int as (id + 1) virtual, name varchar(32), " +
+		"id int primary key, email varchar(64) unique)")
+	require.NotNil(t, job)
+	event := helper.DML2Event("test", "t", "insert into t(name, id, email) values ('name', 1, 'a@b.com')")
+
+	transformer, err := NewTransformer(&ticonfig.SinkConfig{
+		ColumnTransforms: []*ticonfig.ColumnTransform{
+			{Matcher: []string{"test.t"}, Columns: []string{"name"}, Type: ticonfig.ColumnTransformTruncate, Length: 2},
+		},
+	}, "UTC")
+	require.NoError(t, err)
+	require.NoError(t, transformer.Transform(event))
+	row, ok := event.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, "na", row.Row.GetString(1))
+	require.Equal(t, int64(1), row.Row.GetInt64(2))
+	require.Equal(t, "a@b.com", row.Row.GetString(3))
+
+	// the key columns after the virtual column are still rejected
+	for _, column := range []string{"id", "email"} {
+		transformer, err = NewTransformer(&ticonfig.SinkConfig{
+			ColumnTransforms: []*ticonfig.ColumnTransform{
+				{Matcher: []string{"test.t"}, Columns: []string{column}, Type: ticonfig.ColumnTransformConstant},
+			},
+		}, "UTC")
+		require.NoError(t, err)
+		require.Error(t, transformer.Transform(event), column)
+	}
+}
+
+func TestStringTransforms(t *testing.T) {
+	require.Equal(t, []byte("****5678"), mask([]byte("12345678"), 4, false))
+	require.Equal(t, []byte("**"), mask([]byte("中文"), 0, false))
+	require.Equal(t, []byte("abc"), mask([]byte("abc"), 5, true))
+	require.Equal(t, []byte("中"), truncate([]byte("中文"), 1, false))
+	require.Equal(t, []byte{0xe4}, truncate([]byte("中文"), 1, true))
+	require.Len(t, hash([]byte("a"), "", 0), 64)
+	require.Len(t, hash([]byte("a"), "", 8), 8)
+	require.Equal(t, hash([]byte("a"), "salt", 0), hash([]byte("a"), "salt", 0))
+	require.NotEqual(t, hash([]byte("a"), "salt", 0), hash([]byte("a"), "pepper", 0))
+}
@@ -30,6 +30,7 @@ const (
 	SubscriptionClient      = "SubscriptionClient"
 	SchemaStore             = "SchemaStore"
 	EventStore              = "EventStore"
+	UpstreamLogManager      = "UpstreamLogManager"
 	EventService            = "EventService"
 	DispatcherDynamicStream = "DispatcherDynamicStream"
 	MaintainerManager       = "MaintainerManager"
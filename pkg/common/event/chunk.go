@@ -126,6 +126,53 @@ func (m *mounter) rawKVToChunkV1(value []byte, tableInfo *common.TableInfo, chk
 	return nil
 }
 
+// rawKVToChunkHandleKeyOnly is used to decode the handle key columns of the row data,
+// the other columns are set to null. If the table has no handle key, all the columns are decoded.
+func (m *mounter) rawKVToChunkHandleKeyOnly(value []byte, tableInfo *common.TableInfo, chk *chunk.Chunk, handle kv.Handle) error {
+	if len(value) == 0 {
+		return nil
+	}
+	columns := tableInfo.GetColumns()
+	flags := tableInfo.GetColumnsFlag()
+	fieldTps := make(map[int64]*types.FieldType)
+	for _, col := range columns {
+		if flags[col.ID].IsHandleKey() {
+			fieldTps[col.ID] = &col.FieldType
+		}
+	}
+	if len(fieldTps) == 0 {
+		if !rowcodec.IsNewFormat(value) {
+			return m.rawKVToChunkV1(value, tableInfo, chk, handle)
+		}
+		return m.rawKVToChunkV2(value, tableInfo, chk, handle)
+	}
+
+	row, err := tablecodec.DecodeRowToDatumMap(value, fieldTps, m.tz)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	handleColIDs, _, _ := tableInfo.GetRowColInfos()
+	row, err = tablecodec.DecodeHandleToDatumMap(handle, handleColIDs, fieldTps, m.tz, row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, col := range columns {
+		if _, ok := fieldTps[col.ID]; !ok {
+			chk.AppendNull(i)
+			continue
+		}
+		d, ok := row[col.ID]
+		if !ok {
+			d, _, _, _, err = getDefaultOrZeroValue(col, m.tz)
+			if err != nil {
+				return err
+			}
+		}
+		chk.AppendDatum(i, &d)
+	}
+	return nil
+}
+
 func tryDecodeFromHandle(tableInfo *common.TableInfo, schemaColIdx int, col *model.ColumnInfo, handle kv.Handle, chk *chunk.Chunk,
 	decoder *codec.Decoder, pkCols []int64, prefixColIDs []int64,
 ) (bool, error) {
@@ -75,6 +75,10 @@ type DDLEvent struct {
 	TableNameChange *TableNameChange `json:"table_name_change"`
 
 	TiDBOnly bool `json:"tidb_only"`
+	// NonTiDBQueries replaces Query when the downstream is not TiDB.
+	// It is only set for the ddls which can't be executed by other downstreams, such as `FLASHBACK DATABASE`,
+	// the first query creates the schema and the others create the tables in MultipleTableInfos.
+	NonTiDBQueries []string `json:"non_tidb_queries"`
 	// Call when event flush is completed
 	PostTxnFlushed []func() `json:"-"`
 	// eventSize is the size of the event in bytes. It is set when it's unmarshaled.
@@ -185,6 +189,34 @@ func (d *DDLEvent) GetEvents() []*DDLEvent {
 			})
 		}
 		return events
+	case model.ActionRecoverSchema:
+		if len(d.NonTiDBQueries) == 0 {
+			break
+		}
+		if len(d.NonTiDBQueries) != len(d.MultipleTableInfos)+1 {
+			log.Panic("non tidb queries length should be equal to multipleTableInfos length plus one",
+				zap.Strings("nonTiDBQueries", d.NonTiDBQueries), zap.Any("multipleTableInfos", d.MultipleTableInfos))
+		}
+		events := make([]*DDLEvent, 0, len(d.NonTiDBQueries))
+		events = append(events, &DDLEvent{
+			Version:    d.Version,
+			Type:       byte(model.ActionCreateSchema),
+			SchemaName: d.SchemaName,
+			Query:      d.NonTiDBQueries[0],
+			FinishedTs: d.FinishedTs,
+		})
+		for i, info := range d.MultipleTableInfos {
+			events = append(events, &DDLEvent{
+				Version:    d.Version,
+				Type:       byte(model.ActionCreateTable),
+				SchemaName: info.GetSchemaName(),
+				TableName:  info.GetTableName(),
+				TableInfo:  info,
+				Query:      d.NonTiDBQueries[i+1],
+				FinishedTs: d.FinishedTs,
+			})
+		}
+		return events
 	default:
 	}
 	return []*DDLEvent{d}
@@ -17,6 +17,7 @@ import (
 	"encoding/binary"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"go.uber.org/zap"
@@ -62,6 +63,8 @@ type DMLEvent struct {
 	TableInfo *common.TableInfo `json:"table_info"`
 	// The following fields are set and used by dispatcher.
 	ReplicatingTs uint64 `json:"replicating_ts"`
+	// TableSpan is the table span of the dispatcher which receives the event.
+	TableSpan *heartbeatpb.TableSpan `json:"-"`
 	// PostTxnFlushed is the functions to be executed after the transaction is flushed.
 	// It is set and used by dispatcher.
 	PostTxnFlushed []func() `json:"-"`
@@ -50,6 +50,9 @@ type Mounter interface {
 	// If the rawKV is an insert event, it will only decode the value.
 	// If the rawKV is an update event, it will decode both the value and the old value.
 	DecodeToChunk(rawKV *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error)
+	// DecodeToChunkDeleteOnlyHandleKey is the same as DecodeToChunk, except that only the handle key
+	// columns of a delete event are decoded, and the other columns of the delete event are null.
+	DecodeToChunkDeleteOnlyHandleKey(rawKV *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error)
 }
 
 type mounter struct {
@@ -65,6 +68,15 @@ func NewMounter(tz *time.Location) Mounter {
 
 // DecodeToChunk decodes the raw KV entry to a chunk, it returns the number of rows decoded.
 func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error) {
+	return m.decodeToChunk(raw, tableInfo, chk, false)
+}
+
+// DecodeToChunkDeleteOnlyHandleKey decodes the raw KV entry to a chunk, it returns the number of rows decoded.
+func (m *mounter) DecodeToChunkDeleteOnlyHandleKey(raw *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error) {
+	return m.decodeToChunk(raw, tableInfo, chk, true)
+}
+
+func (m *mounter) decodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableInfo, chk *chunk.Chunk, deleteOnlyHandleKey bool) (int, error) {
 	recordID, err := tablecodec.DecodeRowKey(raw.Key)
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -79,7 +91,12 @@ func (m *mounter) DecodeToChunk(raw *common.RawKVEntry, tableInfo *common.TableI
 	// }
 	count := 0
 	if len(raw.OldValue) != 0 {
-		if !rowcodec.IsNewFormat(raw.OldValue) {
+		if deleteOnlyHandleKey && len(raw.Value) == 0 {
+			err := m.rawKVToChunkHandleKeyOnly(raw.OldValue, tableInfo, chk, recordID)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+		} else if !rowcodec.IsNewFormat(raw.OldValue) {
 			err := m.rawKVToChunkV1(raw.OldValue, tableInfo, chk, recordID)
 			if err != nil {
 				return 0, errors.Trace(err)
@@ -14,13 +14,17 @@
 package event
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -729,3 +733,82 @@ func TestBinary(t *testing.T) {
 	binaryFormat := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A}
 	require.Equal(t, binaryFormat, v)
 }
+
+func TestDecodeWithColumnProjection(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int not null, c varchar(10), d text, unique key uk_b(b), key idx_c(c))`)
+	require.NotNil(t, job)
+	tableInfo := helper.GetTableInfo(job)
+	rawKVs := helper.DML2RawKv("test", "t", `insert into test.t values (1, 2, "c", "d")`)
+	require.Len(t, rawKVs, 1)
+
+	// Only the column c is selected, the primary key and the unique key are kept.
+	projection, err := columnselector.NewProjection(&eventpb.ColumnProjection{
+		ColumnSelectors: []*eventpb.ColumnSelector{{Matcher: []string{"test.t"}, Columns: []string{"c"}}},
+	})
+	require.NoError(t, err)
+	projected := projection.ProjectTableInfo(tableInfo)
+	require.Len(t, projected.GetColumns(), 3)
+	for i, name := range []string{"a", "b", "c"} {
+		require.Equal(t, name, projected.GetColumns()[i].Name.O)
+		require.Equal(t, i, projected.GetColumns()[i].Offset)
+	}
+	require.Len(t, projected.GetIndices(), 2)
+	require.Equal(t, tableInfo.UpdateTS(), projected.UpdateTS())
+	// The projected table info is cached.
+	require.Same(t, projected, projection.ProjectTableInfo(tableInfo))
+
+	dmlEvent := NewDMLEvent(common.NewDispatcherID(), tableInfo.TableName.TableID, rawKVs[0].StartTs, rawKVs[0].CRTs, projected)
+	require.NoError(t, dmlEvent.AppendRow(rawKVs[0], NewMounter(time.UTC).DecodeToChunk))
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, 3, row.Row.Len())
+	require.Equal(t, int64(1), row.Row.GetInt64(0))
+	require.Equal(t, int64(2), row.Row.GetInt64(1))
+	require.Equal(t, "c", row.Row.GetString(2))
+}
+
+func TestDecodeDeleteOnlyHandleKey(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	mounter := NewMounter(time.UTC)
+	for _, ddl := range []string{
+		// The primary key is the handle.
+		`create table test.t1(a int primary key, b int, c varchar(10))`,
+		// The not null unique key is the handle key.
+		`create table test.t2(a int not null, b int, c varchar(10), unique key uk_a(a))`,
+	} {
+		job := helper.DDL2Job(ddl)
+		require.NotNil(t, job)
+		tableInfo := helper.GetTableInfo(job)
+		rawKVs := helper.DML2RawKv("test", job.TableName, fmt.Sprintf(`insert into test.%s values (1, 2, "c")`, job.TableName))
+		require.Len(t, rawKVs, 1)
+		deleteKV := &common.RawKVEntry{
+			OpType:   common.OpTypeDelete,
+			Key:      rawKVs[0].Key,
+			OldValue: rawKVs[0].Value,
+			StartTs:  rawKVs[0].StartTs,
+			CRTs:     rawKVs[0].CRTs,
+		}
+
+		chk := chunk.NewChunkWithCapacity(tableInfo.GetFieldSlice(), 2)
+		// The insert event is decoded as usual.
+		count, err := mounter.DecodeToChunkDeleteOnlyHandleKey(rawKVs[0], tableInfo, chk)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		count, err = mounter.DecodeToChunkDeleteOnlyHandleKey(deleteKV, tableInfo, chk)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		insertRow, deleteRow := chk.GetRow(0), chk.GetRow(1)
+		require.Equal(t, int64(1), insertRow.GetInt64(0))
+		require.Equal(t, int64(2), insertRow.GetInt64(1))
+		require.Equal(t, "c", insertRow.GetString(2))
+		require.Equal(t, int64(1), deleteRow.GetInt64(0))
+		require.True(t, deleteRow.IsNull(1))
+		require.True(t, deleteRow.IsNull(2))
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"unsafe"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -232,6 +233,7 @@ type MQRowEvent struct {
 
 type RowEvent struct {
 	TableInfo      *common.TableInfo
+	TableSpan      *heartbeatpb.TableSpan
 	CommitTs       uint64
 	Event          RowChange
 	ColumnSelector columnselector.Selector
@@ -19,13 +19,23 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 )
 
+// SequenceValue is the value of a sequence in upstream at the sync point.
+type SequenceValue struct {
+	SchemaName   string `json:"schema_name"`
+	SequenceName string `json:"sequence_name"`
+	Value        int64  `json:"value"`
+}
+
 // Implement Event / FlushEvent / BlockEvent interface
 type SyncPointEvent struct {
 	// State is the state of sender when sending this event.
-	State          EventSenderState    `json:"state"`
-	DispatcherID   common.DispatcherID `json:"dispatcher_id"`
-	CommitTs       uint64              `json:"commit_ts"`
-	PostTxnFlushed []func()            `msg:"-"`
+	State        EventSenderState    `json:"state"`
+	DispatcherID common.DispatcherID `json:"dispatcher_id"`
+	CommitTs     uint64              `json:"commit_ts"`
+	// Sequences is only set for the table trigger dispatcher,
+	// the values of the sequences are set to downstream with the sync point.
+	Sequences      []SequenceValue `json:"sequences,omitempty"`
+	PostTxnFlushed []func()        `msg:"-"`
 }
 
 func (e *SyncPointEvent) GetType() int {
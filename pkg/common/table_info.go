@@ -24,6 +24,7 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	datumTypes "github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tiflow/pkg/util"
@@ -404,6 +405,12 @@ func (ti *TableInfo) GetPKIndexOffset() []int {
 	return ti.columnSchema.PKIndexOffset
 }
 
+// GetHandleKeyIndexOffset returns the offsets of the handle key columns in the row,
+// the handle key is the primary key or the best NOT NULL unique key of the table.
+func (ti *TableInfo) GetHandleKeyIndexOffset() []int {
+	return ti.columnSchema.HandleKeyIndexOffset
+}
+
 func (ti *TableInfo) UpdateTS() uint64 {
 	return ti.columnSchema.UpdateTS
 }
@@ -585,8 +592,10 @@ func (ti *TableInfo) OffsetsByNames(names []string) ([]int, bool) {
 	return result, true
 }
 
+// HasHandleKey returns true if the table has a primary key or a NOT NULL unique key,
+// which can be used to identify a row.
 func (ti *TableInfo) HasHandleKey() bool {
-	return ti.columnSchema.GetPkColInfo() != nil
+	return len(ti.columnSchema.HandleKeyIndexOffset) > 0
 }
 
 func (ti *TableInfo) GetPkColInfo() *model.ColumnInfo {
@@ -663,3 +672,69 @@ func BuildTiDBTableInfoWithoutVirtualColumns(source *TableInfo) *TableInfo {
 	tableInfo.InitPrivateFields()
 	return tableInfo
 }
+
+// Rename builds a TableInfo which has the same columns and indices as the table info,
+// but is named schema.table. It's used to write the rows to another table.
+func (ti *TableInfo) Rename(schema, table string) *TableInfo {
+	info := NewTableInfo(ti.SchemaID, schema, table,
+		ti.TableName.TableID, ti.TableName.IsPartition, ti.columnSchema.Clone())
+	info.InitPrivateFields()
+	return info
+}
+
+// Project builds a TableInfo which only contains the columns kept by the keep function,
+// the primary key and unique key columns are always kept, so the row can still be
+// identified. The indices whose columns are not all kept are dropped.
+// It returns the source table info itself if all the columns are kept.
+func (ti *TableInfo) Project(keep func(col *model.ColumnInfo) bool) *TableInfo {
+	srcColumns := ti.columnSchema.Columns
+	columns := make([]*model.ColumnInfo, 0, len(srcColumns))
+	// offset in the source table info -> offset in the projected table info
+	offsets := make(map[int]int, len(srcColumns))
+	for i, col := range srcColumns {
+		flag := ti.columnSchema.ColumnsFlag[col.ID]
+		if !flag.IsPrimaryKey() && !flag.IsUniqueKey() && !flag.IsHandleKey() &&
+			col.ID != model.ExtraHandleID && !keep(col) {
+			continue
+		}
+		colInfo := col.Clone()
+		colInfo.Offset = len(columns)
+		offsets[i] = colInfo.Offset
+		columns = append(columns, colInfo)
+	}
+	if len(columns) == len(srcColumns) {
+		return ti
+	}
+
+	indices := make([]*model.IndexInfo, 0, len(ti.columnSchema.Indices))
+	for _, idx := range ti.columnSchema.Indices {
+		allKept := true
+		for _, idxCol := range idx.Columns {
+			if _, ok := offsets[idxCol.Offset]; !ok {
+				allKept = false
+				break
+			}
+		}
+		if !allKept {
+			continue
+		}
+		indexInfo := idx.Clone()
+		for _, idxCol := range indexInfo.Columns {
+			idxCol.Offset = offsets[idxCol.Offset]
+		}
+		indices = append(indices, indexInfo)
+	}
+
+	info := &model.TableInfo{
+		ID:             ti.TableName.TableID,
+		Name:           pmodel.NewCIStr(ti.TableName.Table),
+		Columns:        columns,
+		Indices:        indices,
+		PKIsHandle:     ti.columnSchema.PKIsHandle,
+		IsCommonHandle: ti.columnSchema.IsCommonHandle,
+		UpdateTS:       ti.columnSchema.UpdateTS,
+	}
+	columnSchema := GetSharedColumnSchemaStorage().GetOrSetColumnSchema(info)
+	return NewTableInfo(ti.SchemaID, ti.TableName.Schema, ti.TableName.Table,
+		ti.TableName.TableID, ti.TableName.IsPartition, columnSchema)
+}
@@ -375,6 +375,11 @@ type columnSchema struct {
 
 	PKIndexOffset []int `json:"pk_index_offset"`
 
+	// HandleKeyIndexOffset store the offset of the columns in row changed events for
+	// the handle key, which is the primary key or the best NOT NULL unique key chosen
+	// by findHandleIndex. It is empty if the table is not eligible.
+	HandleKeyIndexOffset []int `json:"handle_key_index_offset"`
+
 	// The following 3 fields, should only be used to decode datum from the raw value bytes, do not abuse those field.
 	// RowColInfos extend the model.ColumnInfo with some extra information
 	// it's the same length and order with the model.TableInfo.Columns
@@ -501,6 +506,7 @@ func newColumnSchema(tableInfo *model.TableInfo, digest Digest) *columnSchema {
 	}
 	colSchema.initRowColInfosWithoutVirtualCols()
 	colSchema.findHandleIndex(tableInfo.Name.O)
+	colSchema.initHandleKeyIndexOffset()
 	colSchema.initColumnsFlag()
 
 	colSchema.InitPreSQLs(tableInfo.Name.O)
@@ -634,6 +640,30 @@ func (s *columnSchema) findHandleIndex(tableName string) {
 	}
 }
 
+func (s *columnSchema) initHandleKeyIndexOffset() {
+	if s.HandleIndexID == HandleIndexPKIsHandle {
+		s.HandleKeyIndexOffset = s.PKIndexOffset
+		return
+	}
+	for _, idx := range s.Indices {
+		if idx.ID != s.HandleIndexID {
+			continue
+		}
+		offset := make([]int, 0, len(idx.Columns))
+		for _, idxCol := range idx.Columns {
+			colInfo := s.Columns[idxCol.Offset]
+			if !IsColCDCVisible(colInfo) {
+				// the handle key can't contain virtual generated column,
+				// just make sure the table is treated as no handle key.
+				return
+			}
+			offset = append(offset, s.RowColumnsOffset[colInfo.ID])
+		}
+		s.HandleKeyIndexOffset = offset
+		return
+	}
+}
+
 func (s *columnSchema) initColumnsFlag() {
 	for _, colInfo := range s.Columns {
 		var flag ColumnFlagType
@@ -843,6 +873,7 @@ func (s *columnSchema) getColumnSchemaWithoutVirtualColumns() *columnSchema {
 		ColumnsFlag:                   s.ColumnsFlag,
 		HandleIndexID:                 s.HandleIndexID,
 		IndexColumnsOffset:            s.IndexColumnsOffset,
+		HandleKeyIndexOffset:          s.HandleKeyIndexOffset,
 		RowColInfos:                   s.RowColInfos,
 		RowColFieldTps:                s.RowColFieldTps,
 		HandleColID:                   s.HandleColID,
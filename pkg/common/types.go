@@ -271,4 +271,5 @@ const (
 	PulsarSinkType
 	CloudStorageSinkType
 	BlackHoleSinkType
+	WebhookSinkType
 )
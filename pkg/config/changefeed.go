@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	putil "github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/ticdc/pkg/version"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/sink"
@@ -49,8 +50,20 @@ type ChangefeedConfig struct {
 	SyncPointInterval  time.Duration `json:"sync_point_interval" default:"1m"`
 	SyncPointRetention time.Duration `json:"sync_point_retention" default:"24h"`
 	SinkConfig         *SinkConfig   `json:"sink_config"`
+	// Priority is the priority class of the changefeed.
+	Priority ChangefeedPriority `json:"priority"`
+	// InitialSnapshot indicates whether the tables are loaded from a snapshot at StartTS.
+	InitialSnapshot bool `json:"initial_snapshot"`
+	// ReplicationDelay is the duration the events are delayed before they are replicated.
+	ReplicationDelay time.Duration `json:"replication_delay"`
+	// MaxRowsPerSecond and MaxBytesPerSecond limit the throughput written to the sink by the changefeed.
+	MaxRowsPerSecond  uint64 `json:"max_rows_per_second"`
+	MaxBytesPerSecond uint64 `json:"max_bytes_per_second"`
 	// Epoch is the epoch of a changefeed, changes on every restart.
 	Epoch uint64 `json:"epoch"`
+	// UpstreamID is the ID of the TiDB cluster the changefeed replicates from,
+	// 0 means the default upstream of the server.
+	UpstreamID UpstreamID `json:"upstream_id"`
 }
 
 // String implements fmt.Stringer interface, but hide some sensitive information
@@ -117,8 +130,14 @@ func (info *ChangeFeedInfo) ToChangefeedConfig() *ChangefeedConfig {
 		EnableSyncPoint:    util.GetOrZero(info.Config.EnableSyncPoint),
 		SyncPointInterval:  util.GetOrZero(info.Config.SyncPointInterval),
 		SyncPointRetention: util.GetOrZero(info.Config.SyncPointRetention),
+		ReplicationDelay:   util.GetOrZero(info.Config.ReplicationDelay),
+		MaxRowsPerSecond:   util.GetOrZero(info.Config.MaxRowsPerSecond),
+		MaxBytesPerSecond:  util.GetOrZero(info.Config.MaxBytesPerSecond),
 		MemoryQuota:        info.Config.MemoryQuota,
+		Priority:           info.Config.Priority,
+		InitialSnapshot:    util.GetOrZero(info.Config.InitialSnapshot),
 		Epoch:              info.Epoch,
+		UpstreamID:         info.UpstreamID,
 		// other fields are not necessary for dispatcherManager
 	}
 }
@@ -328,6 +347,7 @@ func (info *ChangeFeedInfo) rmDBOnlyFields() {
 	info.Config.Consistent = nil
 	info.Config.Sink.SafeMode = nil
 	info.Config.Sink.MySQLConfig = nil
+	info.Config.Sink.RouteRules = nil
 }
 
 // FixIncompatible fixes incompatible changefeed meta info.
@@ -426,7 +446,7 @@ func (info *ChangeFeedInfo) fixMySQLSinkProtocol() {
 	query := uri.Query()
 	protocolStr := query.Get(ProtocolKey)
 	if protocolStr != "" || info.Config.Sink.Protocol != nil {
-		maskedSinkURI, _ := util.MaskSinkURI(info.SinkURI)
+		maskedSinkURI, _ := putil.MaskSinkURI(info.SinkURI)
 		log.Warn("sink URI or sink config contains protocol, but scheme is not mq",
 			zap.String("sinkURI", maskedSinkURI),
 			zap.String("protocol", protocolStr),
@@ -478,7 +498,7 @@ func (info *ChangeFeedInfo) fixMQSinkProtocol() {
 
 func (info *ChangeFeedInfo) updateSinkURIAndConfigProtocol(uri *url.URL, newProtocol string, newQuery url.Values) {
 	newRawQuery := newQuery.Encode()
-	maskedURI, _ := util.MaskSinkURI(uri.String())
+	maskedURI, _ := putil.MaskSinkURI(uri.String())
 	log.Info("handle incompatible protocol from sink URI",
 		zap.String("oldURI", maskedURI),
 		zap.String("newProtocol", newProtocol))
@@ -17,11 +17,24 @@ import (
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 )
 
+const (
+	// DDLClassCheckConstraint is the class of DDLs which add, drop or alter check constraints.
+	DDLClassCheckConstraint = "check-constraint"
+	// DDLClassTableAttributes is the class of DDLs which alter the attributes of tables or partitions.
+	DDLClassTableAttributes = "table-attributes"
+	// DDLClassPlacementPolicy is the class of DDLs which create, alter or drop placement policies,
+	// and set the placement policies of databases, tables or partitions.
+	DDLClassPlacementPolicy = "placement-policy"
+)
+
 // FilterConfig represents filter config for a changefeed
 type FilterConfig struct {
 	Rules            []string           `toml:"rules" json:"rules"`
 	IgnoreTxnStartTs []uint64           `toml:"ignore-txn-start-ts" json:"ignore-txn-start-ts"`
 	EventFilters     []*EventFilterRule `toml:"event-filters" json:"event-filters"`
+	// ForwardDDLClasses lists the classes of TiDB specific DDLs which are not replicated by default.
+	// The DDLs of these classes are only executed when the downstream is TiDB.
+	ForwardDDLClasses []string `toml:"forward-ddl-classes" json:"forward-ddl-classes,omitempty"`
 }
 
 func NewDefaultFilterConfig() *FilterConfig {
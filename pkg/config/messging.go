@@ -16,15 +16,41 @@ package config
 const (
 	// size of channel to cache the messages to be sent and received
 	defaultCacheSize = 1024 * 16 // 16K messages
+
+	defaultMessageCompressionThreshold = 1024            // 1KB
+	defaultMaxBatchCount               = 64              // 64 messages
+	defaultMaxBatchBytes               = 8 * 1024 * 1024 // 8MB
+)
+
+const (
+	// MessageCompressionNone means the messages are not compressed.
+	MessageCompressionNone = "none"
+	// MessageCompressionSnappy compresses the messages by snappy.
+	MessageCompressionSnappy = "snappy"
+	// MessageCompressionZstd compresses the messages by zstd.
+	MessageCompressionZstd = "zstd"
 )
 
 type MessageCenterConfig struct {
 	// The size of the channel for pending messages to be sent and received.
 	CacheChannelSize int
+	// Compression is the algorithm to compress the event messages sent to the remote servers,
+	// it can be none, snappy or zstd. The messages are not compressed if the remote server doesn't support it.
+	Compression string
+	// CompressionThreshold is the minimum payload size of an event message to be compressed.
+	CompressionThreshold int
+	// MaxBatchCount and MaxBatchBytes limit the messages sent together in one grpc message.
+	// Only the messages already waiting to be sent are batched, so batching never delays a message.
+	MaxBatchCount int
+	MaxBatchBytes int
 }
 
 func NewDefaultMessageCenterConfig() *MessageCenterConfig {
 	return &MessageCenterConfig{
-		CacheChannelSize: defaultCacheSize,
+		CacheChannelSize:     defaultCacheSize,
+		Compression:          MessageCompressionSnappy,
+		CompressionThreshold: defaultMessageCompressionThreshold,
+		MaxBatchCount:        defaultMaxBatchCount,
+		MaxBatchBytes:        defaultMaxBatchBytes,
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// ChangefeedPriority is the priority class of a changefeed. The changefeeds with
+// higher priority get a larger share of the event collector memory, their scan
+// tasks get a larger share of the scan slots of the event service, and they are scheduled earlier
+// by the coordinator, e.g. after a node failure.
+type ChangefeedPriority string
+
+const (
+	// ChangefeedPriorityHigh is used for the latency sensitive changefeeds.
+	ChangefeedPriorityHigh ChangefeedPriority = "high"
+	// ChangefeedPriorityNormal is the default priority.
+	ChangefeedPriorityNormal ChangefeedPriority = "normal"
+	// ChangefeedPriorityBatch is used for the bulk changefeeds, e.g. a backfill.
+	ChangefeedPriorityBatch ChangefeedPriority = "batch"
+
+	// ChangefeedPriorityLevelCount is the number of the priority levels.
+	ChangefeedPriorityLevelCount = 3
+)
+
+// Validate returns an error if the priority is unknown, an empty priority
+// is treated as normal.
+func (p ChangefeedPriority) Validate() error {
+	switch p {
+	case "", ChangefeedPriorityHigh, ChangefeedPriorityNormal, ChangefeedPriorityBatch:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+		"priority must be one of high, normal and batch, but got " + string(p))
+}
+
+// Level returns the level of the priority in [0, ChangefeedPriorityLevelCount),
+// a smaller level means a higher priority.
+func (p ChangefeedPriority) Level() int {
+	switch p {
+	case ChangefeedPriorityHigh:
+		return 0
+	case ChangefeedPriorityBatch:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// ScaleMemoryQuota returns the memory quota of the changefeed in the event collector,
+// the high priority changefeeds get twice the quota and the batch ones get a half.
+func (p ChangefeedPriority) ScaleMemoryQuota(quota uint64) uint64 {
+	switch p {
+	case ChangefeedPriorityHigh:
+		return quota * 2
+	case ChangefeedPriorityBatch:
+		return quota / 2
+	default:
+		return quota
+	}
+}
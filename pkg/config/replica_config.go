@@ -137,6 +137,7 @@ type replicaConfig struct {
 	ForceReplicate   bool   `toml:"force-replicate" json:"force-replicate"`
 	CheckGCSafePoint bool   `toml:"check-gc-safe-point" json:"check-gc-safe-point"`
 	// EnableSyncPoint is only available when the downstream is a Database.
+	// The sequences are only replicated when it's enabled, since their values are synced at the sync points.
 	EnableSyncPoint    *bool `toml:"enable-sync-point" json:"enable-sync-point,omitempty"`
 	EnableTableMonitor *bool `toml:"enable-table-monitor" json:"enable-table-monitor"`
 	// IgnoreIneligibleTable is used to store the user's config when creating a changefeed.
@@ -147,6 +148,17 @@ type replicaConfig struct {
 	// replicate data of same tables from TiDB-1 to TiDB-2 and vice versa.
 	// This feature is only available for TiDB.
 	BDRMode *bool `toml:"bdr-mode" json:"bdr-mode,omitempty"`
+	// InitialSnapshot indicates whether the changefeed loads a snapshot of every table
+	// at its start-ts before replicating the incremental changes.
+	InitialSnapshot *bool `toml:"initial-snapshot" json:"initial-snapshot,omitempty"`
+	// ReplicationDelay keeps the downstream deliberately behind the upstream,
+	// an event is replicated only after its commit time plus the delay is in the past.
+	ReplicationDelay *time.Duration `toml:"replication-delay" json:"replication-delay,omitempty"`
+	// MaxRowsPerSecond and MaxBytesPerSecond limit the throughput of the changefeed
+	// written to the sink, 0 means no limit. The maintainer splits the limits across
+	// the nodes which the tables of the changefeed are scheduled to.
+	MaxRowsPerSecond  *uint64 `toml:"max-rows-per-second" json:"max-rows-per-second,omitempty"`
+	MaxBytesPerSecond *uint64 `toml:"max-bytes-per-second" json:"max-bytes-per-second,omitempty"`
 	// SyncPointInterval is only available when the downstream is DB.
 	SyncPointInterval *time.Duration `toml:"sync-point-interval" json:"sync-point-interval,omitempty"`
 	// SyncPointRetention is only available when the downstream is DB.
@@ -158,6 +170,8 @@ type replicaConfig struct {
 	Consistent *ConsistentConfig `toml:"consistent" json:"consistent,omitempty"`
 	// Scheduler is the configuration for scheduler.
 	Scheduler *ChangefeedSchedulerConfig `toml:"scheduler" json:"scheduler,omitempty"`
+	// Priority is the priority class of the changefeed, it can be high, normal or batch.
+	Priority ChangefeedPriority `toml:"priority" json:"priority,omitempty"`
 	// Integrity is only available when the downstream is MQ.
 	Integrity                    *integrity.Config   `toml:"integrity" json:"integrity"`
 	ChangefeedErrorStuckDuration *time.Duration      `toml:"changefeed-error-stuck-duration" json:"changefeed-error-stuck-duration,omitempty"`
@@ -278,6 +292,12 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 						minSyncPointRetention.String()))
 		}
 	}
+	if c.ReplicationDelay != nil && *c.ReplicationDelay < 0 {
+		return cerror.ErrInvalidReplicaConfig.
+			FastGenByArgs(
+				fmt.Sprintf("The ReplicationDelay:%s must not be negative",
+					c.ReplicationDelay.String()))
+	}
 	if c.MemoryQuota == uint64(0) {
 		c.FixMemoryQuota()
 	}
@@ -290,6 +310,10 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 		}
 	}
 
+	if err := c.Priority.Validate(); err != nil {
+		return err
+	}
+
 	if c.Integrity != nil {
 		switch strings.ToLower(sinkURI.Scheme) {
 		case sink.KafkaScheme, sink.KafkaSSLScheme:
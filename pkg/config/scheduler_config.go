@@ -31,10 +31,21 @@ type ChangefeedSchedulerConfig struct {
 	WriteKeyThreshold int `toml:"write-key-threshold" json:"write-key-threshold"`
 	// SplitNumberPerNode is the number of splits per node.
 	SplitNumberPerNode int `toml:"split-number-per-node" json:"split-number-per-node"`
+	// NodeSelector restricts the maintainer and the dispatchers of the changefeed
+	// to the nodes whose labels contain all of the key/value pairs, e.g. {role = "mq"}.
+	NodeSelector map[string]string `toml:"node-selector" json:"node-selector,omitempty"`
+	// SpreadByLabel spreads the tables of the changefeed evenly across the distinct
+	// values of the node label, e.g. "zone".
+	SpreadByLabel string `toml:"spread-by-label" json:"spread-by-label,omitempty"`
 }
 
 // Validate validates the config.
 func (c *ChangefeedSchedulerConfig) Validate() error {
+	for key, value := range c.NodeSelector {
+		if key == "" || value == "" {
+			return errors.New("node-selector keys and values must not be empty")
+		}
+	}
 	if !c.EnableTableAcrossNodes {
 		return nil
 	}
@@ -60,6 +60,8 @@ const (
 
 var (
 	clusterIDRe = regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`)
+	// labelRe is the pattern of the server label keys and values.
+	labelRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
 
 	// ReservedClusterIDs contains a list of reserved cluster id,
 	// these words are the part of old cdc etcd key prefix
@@ -157,6 +159,11 @@ type ServerConfig struct {
 	ClusterID              string               `toml:"cluster-id" json:"cluster-id"`
 	GcTunerMemoryThreshold uint64               `toml:"gc-tuner-memory-threshold" json:"gc-tuner-memory-threshold"`
 
+	// Labels are published in the node info, changefeeds can use them to
+	// constrain which nodes their maintainer and dispatchers are placed on.
+	// e.g. labels = { zone = "az1", role = "heavy" }
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
+
 	// Deprecated: we don't use this field anymore.
 	PerTableMemoryQuota uint64 `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
 	// Deprecated: we don't use this field anymore.
@@ -230,6 +237,12 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	for key, value := range c.Labels {
+		if !labelRe.MatchString(key) || !labelRe.MatchString(value) {
+			return cerror.ErrInvalidServerOption.GenWithStack(
+				"invalid label %s=%s, label keys and values must match the pattern %s", key, value, labelRe.String())
+		}
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
@@ -137,8 +137,12 @@ type SinkConfig struct {
 
 	// DispatchRules is only available when the downstream is MQ.
 	DispatchRules []*DispatchRule `toml:"dispatchers" json:"dispatchers,omitempty"`
+	// RouteRules is only available when the downstream is DB.
+	RouteRules []*RouteRule `toml:"routes" json:"routes,omitempty"`
 
 	ColumnSelectors []*ColumnSelector `toml:"column-selectors" json:"column-selectors,omitempty"`
+	// ColumnTransforms are applied to the rows before they are written to any kind of downstream.
+	ColumnTransforms []*ColumnTransform `toml:"column-transforms" json:"column-transforms,omitempty"`
 	// SchemaRegistry is only available when the downstream is MQ using avro protocol.
 	SchemaRegistry *string `toml:"schema-registry" json:"schema-registry,omitempty"`
 	// EncoderConcurrency is only available when the downstream is MQ.
@@ -175,6 +179,7 @@ type SinkConfig struct {
 	PulsarConfig       *PulsarConfig       `toml:"pulsar-config" json:"pulsar-config,omitempty"`
 	MySQLConfig        *MySQLConfig        `toml:"mysql-config" json:"mysql-config,omitempty"`
 	CloudStorageConfig *CloudStorageConfig `toml:"cloud-storage-config" json:"cloud-storage-config,omitempty"`
+	WebhookConfig      *WebhookConfig      `toml:"webhook-config" json:"webhook-config,omitempty"`
 
 	// AdvanceTimeoutInSec is a duration in second. If a table sink progress hasn't been
 	// advanced for this given duration, the sink will be canceled and re-established.
@@ -221,6 +226,9 @@ func (s *SinkConfig) MaskSensitiveData() {
 	if s.PulsarConfig != nil {
 		s.PulsarConfig.MaskSensitiveData()
 	}
+	if s.WebhookConfig != nil {
+		s.WebhookConfig.MaskSensitiveData()
+	}
 }
 
 // ShouldSendBootstrapMsg returns whether the sink should send bootstrap message.
@@ -405,6 +413,32 @@ type ColumnSelector struct {
 	Columns []string `toml:"columns" json:"columns"`
 }
 
+// ColumnTransform represents a transform applied to the columns of the matched tables.
+// Type is one of "mask", "hash", "truncate", "constant" and "expression":
+//   - mask replaces all the characters with '*' except the last Length ones.
+//   - hash replaces the value with the hex encoded SHA-256 hash of Salt and the value.
+//   - truncate keeps the first Length characters.
+//   - constant replaces the value with Value, or NULL if Value is not set.
+//   - expression replaces the value with the result of Expression evaluated on the row.
+type ColumnTransform struct {
+	Matcher    []string `toml:"matcher" json:"matcher"`
+	Columns    []string `toml:"columns" json:"columns"`
+	Type       string   `toml:"type" json:"type"`
+	Salt       string   `toml:"salt" json:"salt,omitempty"`
+	Length     int      `toml:"length" json:"length,omitempty"`
+	Value      *string  `toml:"value" json:"value,omitempty"`
+	Expression string   `toml:"expression" json:"expression,omitempty"`
+}
+
+// RouteRule routes the tables matched by Matcher to another schema and table
+// in the downstream. SchemaRule and TableRule are expressions which can contain
+// the `{schema}` and `{table}` placeholders, an empty rule keeps the original name.
+type RouteRule struct {
+	Matcher    []string `toml:"matcher" json:"matcher"`
+	SchemaRule string   `toml:"schema" json:"schema"`
+	TableRule  string   `toml:"table" json:"table"`
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `toml:"enable-tidb-extension" json:"enable-tidb-extension,omitempty"`
@@ -456,6 +490,8 @@ type KafkaConfig struct {
 
 	// OutputRawChangeEvent controls whether to split the update pk/uk events.
 	OutputRawChangeEvent *bool `toml:"output-raw-change-event" json:"output-raw-change-event,omitempty"`
+	// EnableTransaction controls whether to send the messages in kafka transactions.
+	EnableTransaction *bool `toml:"enable-transaction" json:"enable-transaction,omitempty"`
 }
 
 // GetOutputRawChangeEvent returns the value of OutputRawChangeEvent
@@ -682,6 +718,11 @@ type MySQLConfig struct {
 	EnableBatchDML               *bool   `toml:"enable-batch-dml" json:"enable-batch-dml,omitempty"`
 	EnableMultiStatement         *bool   `toml:"enable-multi-statement" json:"enable-multi-statement,omitempty"`
 	EnableCachePreparedStatement *bool   `toml:"enable-cache-prepared-statement" json:"enable-cache-prepared-statement,omitempty"`
+	// DeadLetterQueue is the target to write the transactions which can't be applied to
+	// the downstream, instead of failing the changefeed. It can be "downstream" to write
+	// to a table in the downstream, or an external storage uri such as "file:///tmp/dlq"
+	// and "s3://bucket/prefix". The dead letter queue is disabled if it's empty.
+	DeadLetterQueue *string `toml:"dead-letter-queue" json:"dead-letter-queue,omitempty"`
 }
 
 // CloudStorageConfig represents a cloud storage sink configuration
@@ -707,11 +748,48 @@ func (c *CloudStorageConfig) GetOutputRawChangeEvent() bool {
 	return *c.OutputRawChangeEvent
 }
 
+// WebhookConfig represents a webhook sink configuration
+type WebhookConfig struct {
+	// Headers are the extra headers added to each request.
+	Headers           map[string]string `toml:"headers" json:"headers,omitempty"`
+	BasicAuthUser     *string           `toml:"basic-auth-user" json:"basic-auth-user,omitempty"`
+	BasicAuthPassword *string           `toml:"basic-auth-password" json:"basic-auth-password,omitempty"`
+	BearerToken       *string           `toml:"bearer-token" json:"bearer-token,omitempty"`
+
+	// MaxBatchSize is the max number of messages sent in one request.
+	MaxBatchSize *int    `toml:"max-batch-size" json:"max-batch-size,omitempty"`
+	MaxRetries   *int    `toml:"max-retries" json:"max-retries,omitempty"`
+	Timeout      *string `toml:"timeout" json:"timeout,omitempty"`
+	// RetryBackoffBaseInMs and RetryBackoffMaxInMs control the exponential backoff
+	// between the retries of a failed request.
+	RetryBackoffBaseInMs *int64 `toml:"retry-backoff-base-in-ms" json:"retry-backoff-base-in-ms,omitempty"`
+	RetryBackoffMaxInMs  *int64 `toml:"retry-backoff-max-in-ms" json:"retry-backoff-max-in-ms,omitempty"`
+}
+
+// MaskSensitiveData masks sensitive data in WebhookConfig
+func (w *WebhookConfig) MaskSensitiveData() {
+	if w.BasicAuthPassword != nil {
+		w.BasicAuthPassword = aws.String("******")
+	}
+	if w.BearerToken != nil {
+		w.BearerToken = aws.String("******")
+	}
+	for key := range w.Headers {
+		if strings.EqualFold(key, "Authorization") {
+			w.Headers[key] = "******"
+		}
+	}
+}
+
 func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 	if err := s.validateAndAdjustSinkURI(sinkURI); err != nil {
 		return err
 	}
 
+	if err := validateRouteRules(s.RouteRules, s.CaseSensitive); err != nil {
+		return err
+	}
+
 	if sink.IsMySQLCompatibleScheme(sinkURI.Scheme) {
 		return nil
 	}
@@ -778,6 +856,10 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 		}
 	}
 
+	if err := validateColumnTransforms(s.ColumnTransforms); err != nil {
+		return err
+	}
+
 	if util.GetOrZero(s.EncoderConcurrency) < 0 {
 		return cerror.ErrSinkInvalidConfig.GenWithStack(
 			"encoder-concurrency should greater than 0, but got %d", s.EncoderConcurrency)
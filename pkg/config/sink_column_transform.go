@@ -0,0 +1,70 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+const (
+	// ColumnTransformMask masks the characters of the value.
+	ColumnTransformMask = "mask"
+	// ColumnTransformHash replaces the value with its hash.
+	ColumnTransformHash = "hash"
+	// ColumnTransformTruncate truncates the value.
+	ColumnTransformTruncate = "truncate"
+	// ColumnTransformConstant replaces the value with a constant.
+	ColumnTransformConstant = "constant"
+	// ColumnTransformExpression replaces the value with the result of an expression.
+	ColumnTransformExpression = "expression"
+)
+
+// validateColumnTransforms checks the column transforms, the types of the columns
+// are checked when the transforms are applied to the tables.
+func validateColumnTransforms(transforms []*ColumnTransform) error {
+	for _, t := range transforms {
+		if len(t.Matcher) == 0 || len(t.Columns) == 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"matcher and columns of the column transform must be set, transform: %v", t)
+		}
+		if _, err := filter.Parse(t.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if _, err := filter.ParseColumnFilter(t.Columns); err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		switch t.Type {
+		case ColumnTransformMask, ColumnTransformHash, ColumnTransformConstant:
+		case ColumnTransformTruncate:
+			if t.Length <= 0 {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"length of the truncate column transform must be greater than 0, transform: %v", t)
+			}
+		case ColumnTransformExpression:
+			if t.Expression == "" {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"expression of the column transform is empty, transform: %v", t)
+			}
+		default:
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"unknown column transform type %s, transform: %v", t.Type, t)
+		}
+		if t.Length < 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"length of the column transform can't be negative, transform: %v", t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+const (
+	// RouteSchemaPlaceholder is replaced with the source schema name in a route rule.
+	RouteSchemaPlaceholder = "{schema}"
+	// RouteTablePlaceholder is replaced with the source table name in a route rule.
+	RouteTablePlaceholder = "{table}"
+)
+
+// routeExpressionRE is used to match a valid schema or table expression of a route rule,
+// '{' and '}' are only allowed in the placeholders.
+var routeExpressionRE = regexp.MustCompile(`^([^{}]|\{schema\}|\{table\})*$`)
+
+// MergesTables returns true if the rule routes more than one table to the same
+// downstream table, which happens when the table expression has no `{table}` placeholder.
+func (r *RouteRule) MergesTables() bool {
+	return r.TableRule != "" && !strings.Contains(r.TableRule, RouteTablePlaceholder)
+}
+
+// CoversSchemas returns true if the rule matches all the tables of the schemas it matches,
+// that is, the table part of every pattern in the matcher is `*`. Only such rules route
+// the schema-level DDLs, like CREATE DATABASE and DROP DATABASE.
+func (r *RouteRule) CoversSchemas() bool {
+	for _, pattern := range r.Matcher {
+		if _, table, _ := splitMatcherPattern(pattern); table != "*" {
+			return false
+		}
+	}
+	return len(r.Matcher) > 0
+}
+
+// SourceSchemas returns the schemas matched by the rule, it returns false
+// if the rule matches schemas by wildcards.
+func (r *RouteRule) SourceSchemas() ([]string, bool) {
+	var schemas []string
+	for _, pattern := range r.Matcher {
+		schema, _, excluded := splitMatcherPattern(pattern)
+		if excluded {
+			continue
+		}
+		if isWildcardName(schema) {
+			return nil, false
+		}
+		if !slices.Contains(schemas, schema) {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas, true
+}
+
+// SourceTable returns the schema and table of the only table matched by the rule,
+// it returns false if the rule may match more than one table.
+func (r *RouteRule) SourceTable() (string, string, bool) {
+	if len(r.Matcher) != 1 {
+		return "", "", false
+	}
+	schema, table, excluded := splitMatcherPattern(r.Matcher[0])
+	if excluded || isWildcardName(schema) || isWildcardName(table) {
+		return "", "", false
+	}
+	return schema, table, true
+}
+
+// validateRouteRules checks the route rules. Merging tables is only allowed for the
+// shards of the same logical table, that is, all the tables merged into the same
+// downstream table must have the same name, since the DDLs of different tables would
+// conflict with each other on the merged table.
+func validateRouteRules(rules []*RouteRule, caseSensitive bool) error {
+	// downstream table -> the name of the upstream tables merged into it
+	mergedTables := make(map[string]string)
+	for _, rule := range rules {
+		if len(rule.Matcher) == 0 {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"matcher of the route rule is empty, rule: %v", rule)
+		}
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		if !routeExpressionRE.MatchString(rule.SchemaRule) || !routeExpressionRE.MatchString(rule.TableRule) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"invalid route rule %v, only %s and %s placeholders are allowed",
+				rule, RouteSchemaPlaceholder, RouteTablePlaceholder)
+		}
+		if strings.Contains(rule.SchemaRule, RouteTablePlaceholder) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"invalid route rule %v, schema can't contain the %s placeholder",
+				rule, RouteTablePlaceholder)
+		}
+		// The schema-level DDLs are only routed by the rules which match whole schemas,
+		// if a rule also matches some tables of other schemas, the tables are created in
+		// the routed schema but the schemas are created and dropped with the origin names.
+		if rule.SchemaRule != "" && !rule.CoversSchemas() && matchesWholeSchema(rule.Matcher) {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"route rule %v matches both whole schemas and tables, "+
+					"use separate rules for them", rule)
+		}
+		if !rule.MergesTables() {
+			continue
+		}
+
+		source := ""
+		for _, pattern := range rule.Matcher {
+			// the excluded tables are never routed
+			if strings.HasPrefix(pattern, "!") {
+				continue
+			}
+			table, ok := matcherTableName(pattern)
+			if ok && !caseSensitive {
+				table = strings.ToLower(table)
+			}
+			if !ok || (source != "" && source != table) {
+				return cerror.ErrSinkInvalidConfig.GenWithStack(
+					"route rule %v merges different tables into table %s, "+
+						"the DDLs of them conflict with each other", rule, rule.TableRule)
+			}
+			source = table
+		}
+		if strings.Contains(rule.SchemaRule, RouteSchemaPlaceholder) {
+			continue
+		}
+		target := rule.SchemaRule + "." + rule.TableRule
+		if !caseSensitive {
+			target = strings.ToLower(target)
+		}
+		if merged, ok := mergedTables[target]; ok && merged != source {
+			return cerror.ErrSinkInvalidConfig.GenWithStack(
+				"route rules merge table %s and %s into table %s, "+
+					"the DDLs of them conflict with each other", merged, source, target)
+		}
+		mergedTables[target] = source
+	}
+	return nil
+}
+
+// matcherTableName returns the table name of a matcher pattern, it returns false
+// if the pattern matches tables of different names.
+func matcherTableName(pattern string) (string, bool) {
+	_, table, _ := splitMatcherPattern(pattern)
+	if isWildcardName(table) {
+		return "", false
+	}
+	return table, true
+}
+
+// matchesWholeSchema returns true if any pattern of the matcher matches all the tables of schemas.
+func matchesWholeSchema(matcher []string) bool {
+	for _, pattern := range matcher {
+		if _, table, excluded := splitMatcherPattern(pattern); !excluded && table == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitMatcherPattern splits a matcher pattern into the schema and the table part,
+// excluded is true if the pattern excludes the tables it matches.
+func splitMatcherPattern(pattern string) (schema string, table string, excluded bool) {
+	excluded = strings.HasPrefix(pattern, "!")
+	pattern = strings.TrimPrefix(pattern, "!")
+	if i := strings.LastIndex(pattern, "."); i >= 0 {
+		schema, table = pattern[:i], pattern[i+1:]
+	} else {
+		table = pattern
+	}
+	return strings.Trim(schema, "`\""), strings.Trim(table, "`\""), excluded
+}
+
+// isWildcardName returns true if the name in a matcher pattern may match different names.
+func isWildcardName(name string) bool {
+	return name == "" || strings.ContainsAny(name, "*?[]\\")
+}
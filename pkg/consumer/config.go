@@ -0,0 +1,179 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/util"
+	sinkutil "github.com/pingcap/tiflow/cdc/sink/util"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/avro"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/codec/open"
+	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
+)
+
+// KafkaConfig is the config of the kafka consumer.
+type KafkaConfig struct {
+	// ConsumerID identifies the progress of the consumer stored in the downstream,
+	// consumers with different ids can replicate the same topic to one downstream.
+	ConsumerID string
+	// Reader is the config to connect to the kafka cluster.
+	Reader KafkaReaderConfig
+	// Protocol is the protocol used to encode the messages of the topic.
+	Protocol config.Protocol
+	// CodecConfig is the config used to decode the messages.
+	CodecConfig *common.Config
+	// SchemaRegistryURI is the uri of the schema registry, it's required by the avro protocol.
+	SchemaRegistryURI string
+}
+
+// NewKafkaConfig creates the KafkaConfig by the kafka uri and the replica config of the
+// changefeed which produces the messages, the uri is in the same format as the sink uri.
+func NewKafkaConfig(
+	upstreamURI *url.URL, replicaConfig *config.ReplicaConfig, timezone *time.Location,
+) (*KafkaConfig, error) {
+	topic := strings.Trim(upstreamURI.Path, "/")
+	if topic == "" || strings.Contains(topic, "/") {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack("invalid topic %s in the uri", topic)
+	}
+	s := upstreamURI.Query().Get("protocol")
+	if s == "" {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack("the protocol is not found in the uri")
+	}
+	protocol, err := config.ParseSinkProtocolFromString(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	codecConfig := common.NewConfig(protocol)
+	if err = codecConfig.Apply(upstreamURI, replicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	codecConfig.TimeZone = timezone
+	if protocol == config.ProtocolAvro {
+		codecConfig.AvroEnableWatermark = true
+	}
+	return &KafkaConfig{
+		ConsumerID: topic,
+		Reader: KafkaReaderConfig{
+			Addresses: strings.Split(upstreamURI.Host, ","),
+			Topic:     topic,
+		},
+		Protocol:    protocol,
+		CodecConfig: codecConfig,
+	}, nil
+}
+
+// StorageConfig is the config of the storage consumer.
+type StorageConfig struct {
+	// ConsumerID identifies the progress of the consumer stored in the downstream.
+	ConsumerID string
+	// Protocol is the protocol used to encode the data files.
+	Protocol config.Protocol
+	// CodecConfig is the config used to decode the data files.
+	CodecConfig *common.Config
+	// FileExtension is the extension of the data files.
+	FileExtension string
+	// DateSeparator is the date separator used in the path of the data files.
+	DateSeparator string
+	// FileIndexWidth is the width of the index in the name of the data files.
+	FileIndexWidth int
+	// WorkerCount is the number of tables applied in parallel.
+	WorkerCount int
+	// ScanInterval is the interval to scan the storage for new files.
+	ScanInterval time.Duration
+	// DiscoveryInterval is the interval to walk the storage for new tables, table
+	// versions and directories, the known directories are scanned every ScanInterval.
+	DiscoveryInterval time.Duration
+}
+
+// NewStorageConfig creates the StorageConfig by the storage uri and the replica config of
+// the changefeed which writes the files, the uri is in the same format as the sink uri.
+func NewStorageConfig(
+	storageURI *url.URL, replicaConfig *config.ReplicaConfig, timezone *time.Location,
+) (*StorageConfig, error) {
+	if err := replicaConfig.ValidateAndAdjust(storageURI); err != nil {
+		return nil, errors.Trace(err)
+	}
+	protocol, err := config.ParseSinkProtocolFromString(util.GetOrZero(replicaConfig.Sink.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if protocol != config.ProtocolCsv && protocol != config.ProtocolCanalJSON {
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(protocol)
+	}
+	codecConfig := common.NewConfig(protocol)
+	if err = codecConfig.Apply(storageURI, replicaConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	codecConfig.TimeZone = timezone
+	// the commit ts is only available in the tidb extension of canal-json.
+	codecConfig.EnableTiDBExtension = true
+
+	fileIndexWidth := util.GetOrZero(replicaConfig.Sink.FileIndexWidth)
+	if fileIndexWidth == 0 {
+		fileIndexWidth = config.DefaultFileIndexWidth
+	}
+	id := url.URL{Scheme: storageURI.Scheme, Host: storageURI.Host, Path: storageURI.Path}
+	return &StorageConfig{
+		ConsumerID:        id.String(),
+		Protocol:          protocol,
+		CodecConfig:       codecConfig,
+		FileExtension:     sinkutil.GetFileExtension(protocol),
+		DateSeparator:     util.GetOrZero(replicaConfig.Sink.DateSeparator),
+		FileIndexWidth:    fileIndexWidth,
+		WorkerCount:       defaultStorageWorkerCount,
+		ScanInterval:      defaultStorageScanInterval,
+		DiscoveryInterval: defaultStorageDiscoveryInterval,
+	}, nil
+}
+
+// newDecoder creates the decoder of the protocol, upstreamTiDB is used to fetch the
+// large messages which are only partially written to the topic, it can be nil.
+func newDecoder(ctx context.Context, cfg *KafkaConfig, upstreamTiDB *sql.DB) (codec.RowEventDecoder, error) {
+	var (
+		decoder codec.RowEventDecoder
+		err     error
+	)
+	switch cfg.Protocol {
+	case config.ProtocolOpen, config.ProtocolDefault:
+		decoder, err = open.NewBatchDecoder(ctx, cfg.CodecConfig, upstreamTiDB)
+	case config.ProtocolCanalJSON:
+		decoder, err = canal.NewBatchDecoder(ctx, cfg.CodecConfig, upstreamTiDB)
+	case config.ProtocolAvro:
+		schemaM, err := avro.NewConfluentSchemaManager(ctx, cfg.SchemaRegistryURI, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		decoder = avro.NewDecoder(cfg.CodecConfig, schemaM, cfg.Reader.Topic, upstreamTiDB)
+	case config.ProtocolSimple:
+		decoder, err = simple.NewDecoder(ctx, cfg.CodecConfig, upstreamTiDB)
+	default:
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(cfg.Protocol)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return decoder, nil
+}
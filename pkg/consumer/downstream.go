@@ -0,0 +1,240 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"go.uber.org/zap"
+)
+
+// ddlPartitionID is the partition id of the checkpoint of the executed DDLs.
+const ddlPartitionID = -1
+
+// checkpoint is the progress of a partition stored in the downstream.
+type checkpoint struct {
+	// resumeOffset is the offset to resume reading the partition from. For the DDL
+	// checkpoint, it's the number of DDLs executed at the watermark.
+	resumeOffset int64
+	// watermark is the commit ts before which all rows of the partition are applied.
+	// For the DDL checkpoint, it's the commit ts of the last executed DDL.
+	watermark uint64
+}
+
+// downstream applies the rows and DDLs to the downstream database, and stores the
+// checkpoints of the partitions in the same database. The rows and the checkpoint
+// of a partition are written in one transaction, so they are always consistent.
+type downstream struct {
+	db         *sql.DB
+	consumerID string
+}
+
+func newDownstream(db *sql.DB, consumerID string) *downstream {
+	return &downstream{
+		db:         db,
+		consumerID: consumerID,
+	}
+}
+
+func (d *downstream) quotedTable() string {
+	return common.QuoteSchema(filter.TiCDCSystemSchema, filter.ConsumerProgressTable)
+}
+
+func (d *downstream) createTable(ctx context.Context) error {
+	_, err := d.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+common.QuoteName(filter.TiCDCSystemSchema))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create consumer progress database"))
+	}
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		consumer_id varchar(255) NOT NULL,
+		partition_id int NOT NULL,
+		resume_offset bigint NOT NULL,
+		watermark bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, partition_id)
+	);`
+	_, err = d.db.ExecContext(ctx, fmt.Sprintf(query, d.quotedTable()))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create consumer progress table"))
+	}
+	return nil
+}
+
+// loadCheckpoints returns the checkpoints of the consumer indexed by the partition id.
+func (d *downstream) loadCheckpoints(ctx context.Context) (map[int32]checkpoint, error) {
+	rows, err := d.db.QueryContext(ctx,
+		"SELECT partition_id, resume_offset, watermark FROM "+d.quotedTable()+" WHERE consumer_id = ?", d.consumerID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+
+	checkpoints := make(map[int32]checkpoint)
+	for rows.Next() {
+		var (
+			partition int32
+			cp        checkpoint
+		)
+		if err = rows.Scan(&partition, &cp.resumeOffset, &cp.watermark); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		checkpoints[partition] = cp
+	}
+	return checkpoints, errors.Trace(rows.Err())
+}
+
+func (d *downstream) saveCheckpointSQL() string {
+	return "INSERT INTO " + d.quotedTable() + " (consumer_id, partition_id, resume_offset, watermark) VALUES (?,?,?,?)" +
+		" ON DUPLICATE KEY UPDATE resume_offset = VALUES(resume_offset), watermark = VALUES(watermark)"
+}
+
+// flush applies the rows of the partition and saves its checkpoint in one transaction.
+func (d *downstream) flush(
+	ctx context.Context, partition int32, rows []*model.RowChangedEvent, cp checkpoint,
+) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to begin transaction"))
+	}
+	rollback := func() {
+		if rbErr := tx.Rollback(); rbErr != nil && errors.Cause(rbErr) != context.Canceled {
+			log.Warn("failed to rollback", zap.Int32("partition", partition), zap.Error(rbErr))
+		}
+	}
+	for _, row := range rows {
+		query, args := prepareRowSQL(row)
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			rollback()
+			return cerror.WrapError(cerror.ErrMySQLTxnError,
+				errors.WithMessage(err, fmt.Sprintf("failed to apply the row, query info: %s; ", query)))
+		}
+	}
+	_, err = tx.ExecContext(ctx, d.saveCheckpointSQL(), d.consumerID, partition, cp.resumeOffset, cp.watermark)
+	if err != nil {
+		rollback()
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to save the checkpoint"))
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to commit transaction"))
+	}
+	return nil
+}
+
+// execDDL executes the DDL and then saves the DDL checkpoint. A DDL can't be executed in a
+// transaction with the checkpoint, so it may be executed again after a crash, which is
+// tolerated since the errors of the executed DDLs are ignorable.
+func (d *downstream) execDDL(ctx context.Context, ddl *model.DDLEvent, cp checkpoint) error {
+	if err := execDDL(ctx, d.db, ddl.TableInfo.TableName.Schema, ddl.Type, ddl.Query); err != nil {
+		return err
+	}
+	_, err := d.db.ExecContext(ctx, d.saveCheckpointSQL(), d.consumerID, ddlPartitionID, cp.resumeOffset, cp.watermark)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to save the ddl checkpoint"))
+	}
+	return nil
+}
+
+// execDDL executes the DDL in the schema, the ignorable errors are logged and skipped
+// since the DDL may be executed already.
+func execDDL(ctx context.Context, db *sql.DB, schema string, ddlType timodel.ActionType, query string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to begin transaction"))
+	}
+	if schema != "" && ddlType != timodel.ActionCreateSchema && ddlType != timodel.ActionDropSchema {
+		_, err = tx.ExecContext(ctx, "USE "+common.QuoteName(schema)+";")
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, query)
+	}
+	if err == nil {
+		err = tx.Commit()
+	} else if rbErr := tx.Rollback(); rbErr != nil {
+		log.Warn("failed to rollback", zap.String("query", query), zap.Error(rbErr))
+	}
+	if err != nil {
+		if !apperror.IsIgnorableMySQLDDLError(err) {
+			return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("Query info: %s; ", query)))
+		}
+		log.Warn("execute ddl failed, but the error can be ignored",
+			zap.String("schema", schema), zap.String("query", query), zap.Error(err))
+	}
+	return nil
+}
+
+// prepareRowSQL generates the statement to apply the row, inserts are written as
+// REPLACE so the statement can be applied to a table which contains the row already.
+func prepareRowSQL(row *model.RowChangedEvent) (string, []any) {
+	tableInfo := row.TableInfo
+	tidbTableInfo := tableInfo.TableInfo
+	// the row doesn't contain the data of virtual columns.
+	if tableInfo.HasVirtualColumns() {
+		tidbTableInfo = model.BuildTiDBTableInfoWithoutVirtualColumns(tidbTableInfo)
+	}
+	preValues := convertValues(row.PreColumns, tableInfo)
+	postValues := convertValues(row.Columns, tableInfo)
+
+	switch {
+	case row.IsDelete():
+		change := sqlmodel.NewRowChange(&tableInfo.TableName, nil, preValues, nil, tidbTableInfo, nil, nil)
+		return sqlmodel.GenDeleteSQL(change)
+	case row.IsUpdate():
+		change := sqlmodel.NewRowChange(&tableInfo.TableName, nil, preValues, postValues, tidbTableInfo, nil, nil)
+		return change.GenSQL(sqlmodel.DMLUpdate)
+	default:
+		change := sqlmodel.NewRowChange(&tableInfo.TableName, nil, nil, postValues, tidbTableInfo, nil, nil)
+		return sqlmodel.GenInsertSQL(sqlmodel.DMLReplace, change)
+	}
+}
+
+// convertValues returns the values of the columns, the strings decoded as bytes are
+// converted back to strings.
+func convertValues(cols []*model.ColumnData, tableInfo *model.TableInfo) []any {
+	if len(cols) == 0 {
+		return nil
+	}
+	values := make([]any, 0, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			values = append(values, nil)
+			continue
+		}
+		value := col.Value
+		switch v := value.(type) {
+		case []byte:
+			colInfo := tableInfo.ForceGetColumnInfo(col.ColumnID)
+			if colInfo.GetCharset() != "" && colInfo.GetCharset() != charset.CharsetBin {
+				value = string(v)
+			}
+		case types.VectorFloat32:
+			value = v.String()
+		}
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/simple"
+	"go.uber.org/zap"
+)
+
+// pendingRow is a row which is received but not applied yet.
+type pendingRow struct {
+	row    *model.RowChangedEvent
+	offset int64
+}
+
+// pendingDDL is a DDL which is received but not executed yet.
+type pendingDDL struct {
+	ddl    *model.DDLEvent
+	offset int64
+	// seq is the sequence of the DDL among the DDLs with the same commit ts.
+	seq int64
+}
+
+type partitionProgress struct {
+	partition int32
+	decoder   codec.RowEventDecoder
+	// watermark is the max watermark received from the partition.
+	watermark uint64
+	// checkpoint is the progress of the partition stored in the downstream.
+	checkpoint checkpoint
+	// nextOffset is the offset of the next message to read.
+	nextOffset int64
+	// rows are the pending rows of the partition in the order of the offsets.
+	rows []pendingRow
+}
+
+// resolve removes the pending rows whose commit ts are not greater than the ts and returns them.
+func (p *partitionProgress) resolve(ts uint64) []*model.RowChangedEvent {
+	var resolved []*model.RowChangedEvent
+	remain := p.rows[:0]
+	for _, r := range p.rows {
+		if r.row.CommitTs <= ts {
+			resolved = append(resolved, r.row)
+			continue
+		}
+		remain = append(remain, r)
+	}
+	for i := len(remain); i < len(p.rows); i++ {
+		p.rows[i] = pendingRow{}
+	}
+	p.rows = remain
+	return resolved
+}
+
+// KafkaConsumer replicates the messages of a kafka topic to a MySQL compatible downstream.
+//
+// The rows of a partition are applied when the watermarks of all partitions pass them, together
+// with the checkpoint of the partition in one transaction. The checkpoint records the watermark
+// and the offset to resume from, so the consumer can resume exactly where it left off after a
+// crash: the partitions are read again from the resume offsets, and the rows which are not
+// newer than the watermarks are skipped. The DDLs are read from the first partition only, and
+// a DDL is executed after the rows before it are applied on all partitions.
+type KafkaConsumer struct {
+	cfg          *KafkaConfig
+	reader       Reader
+	downstream   *downstream
+	upstreamTiDB *sql.DB
+
+	partitions []*partitionProgress
+	ddls       []*pendingDDL
+	// lastDDL is the last DDL received, it's used to drop the duplicated DDLs.
+	lastDDL *pendingDDL
+	// ddlCheckpoint is the checkpoint of the executed DDLs.
+	ddlCheckpoint checkpoint
+}
+
+// NewKafkaConsumer creates a KafkaConsumer, the messages are read by the reader and
+// written to the downstream. upstreamTiDB is used by the decoders to fetch the large
+// messages which are only partially written to the topic, it can be nil.
+func NewKafkaConsumer(cfg *KafkaConfig, reader Reader, downstreamDB *sql.DB, upstreamTiDB *sql.DB) *KafkaConsumer {
+	return &KafkaConsumer{
+		cfg:          cfg,
+		reader:       reader,
+		downstream:   newDownstream(downstreamDB, cfg.ConsumerID),
+		upstreamTiDB: upstreamTiDB,
+	}
+}
+
+// Run consumes the messages until the context is done or an error occurs.
+func (c *KafkaConsumer) Run(ctx context.Context) error {
+	if err := c.initialize(ctx); err != nil {
+		return err
+	}
+	for {
+		msg, err := c.reader.ReadMessage(ctx)
+		if err != nil {
+			return err
+		}
+		if err = c.handleMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// initialize loads the checkpoints from the downstream and assigns the partitions to
+// the reader from the resume offsets.
+func (c *KafkaConsumer) initialize(ctx context.Context) error {
+	if err := c.downstream.createTable(ctx); err != nil {
+		return err
+	}
+	checkpoints, err := c.downstream.loadCheckpoints(ctx)
+	if err != nil {
+		return err
+	}
+	partitionNum, err := c.reader.PartitionNum(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.ddlCheckpoint = checkpoints[ddlPartitionID]
+	c.partitions = make([]*partitionProgress, 0, partitionNum)
+	offsets := make([]int64, 0, partitionNum)
+	for i := int32(0); i < partitionNum; i++ {
+		decoder, err := newDecoder(ctx, c.cfg, c.upstreamTiDB)
+		if err != nil {
+			return err
+		}
+		cp, ok := checkpoints[i]
+		offset := cp.resumeOffset
+		if !ok {
+			offset = OffsetEarliest
+		}
+		c.partitions = append(c.partitions, &partitionProgress{
+			partition:  i,
+			decoder:    decoder,
+			watermark:  cp.watermark,
+			checkpoint: cp,
+			nextOffset: cp.resumeOffset,
+		})
+		offsets = append(offsets, offset)
+	}
+	log.Info("kafka consumer initialized",
+		zap.String("consumerID", c.cfg.ConsumerID),
+		zap.String("topic", c.cfg.Reader.Topic),
+		zap.String("protocol", c.cfg.Protocol.String()),
+		zap.Int32("partitionNum", partitionNum),
+		zap.Int64s("offsets", offsets),
+		zap.Uint64("ddlCheckpoint", c.ddlCheckpoint.watermark))
+	return c.reader.Assign(offsets)
+}
+
+func (c *KafkaConsumer) handleMessage(ctx context.Context, msg *Message) error {
+	if msg.Partition < 0 || int(msg.Partition) >= len(c.partitions) {
+		return errors.Errorf("unexpected partition %d, the topic has %d partitions", msg.Partition, len(c.partitions))
+	}
+	progress := c.partitions[msg.Partition]
+	progress.nextOffset = msg.Offset + 1
+	decodeErr := func(err error) error {
+		return cerror.WrapError(cerror.ErrDecodeFailed, err,
+			fmt.Sprintf("partition %d, offset %d", msg.Partition, msg.Offset))
+	}
+
+	if err := progress.decoder.AddKeyValue(msg.Key, msg.Value); err != nil {
+		return decodeErr(err)
+	}
+	needFlush := false
+	for {
+		ty, hasNext, err := progress.decoder.HasNext()
+		if err != nil {
+			return decodeErr(err)
+		}
+		if !hasNext {
+			break
+		}
+		switch ty {
+		case model.MessageTypeDDL:
+			ddl, err := progress.decoder.NextDDLEvent()
+			if err != nil {
+				return decodeErr(err)
+			}
+			// the simple protocol caches the rows whose table info is not received yet,
+			// they can be decoded after the DDL which carries the table info.
+			if dec, ok := progress.decoder.(*simple.Decoder); ok {
+				for _, row := range dec.GetCachedEvents() {
+					c.appendRow(progress, row, msg.Offset)
+				}
+			}
+			// the query is empty if it's a bootstrap event of the simple protocol.
+			if ddl.Query == "" {
+				continue
+			}
+			// the DDLs are dispatched to all partitions, so only the first partition is handled.
+			if msg.Partition == 0 {
+				c.appendDDL(ddl, msg.Offset)
+			}
+			needFlush = true
+		case model.MessageTypeRow:
+			row, err := progress.decoder.NextRowChangedEvent()
+			if err != nil {
+				return decodeErr(err)
+			}
+			// the row of the simple protocol is nil if its table info is not received yet.
+			if row == nil {
+				continue
+			}
+			c.appendRow(progress, row, msg.Offset)
+		case model.MessageTypeResolved:
+			watermark, err := progress.decoder.NextResolvedEvent()
+			if err != nil {
+				return decodeErr(err)
+			}
+			if watermark > progress.watermark {
+				progress.watermark = watermark
+			}
+			needFlush = true
+		default:
+			return errors.Errorf("unknown message type %d at partition %d, offset %d", ty, msg.Partition, msg.Offset)
+		}
+	}
+	if !needFlush {
+		return nil
+	}
+	return c.flush(ctx)
+}
+
+func (c *KafkaConsumer) appendRow(progress *partitionProgress, row *model.RowChangedEvent, offset int64) {
+	// the row is applied already, it's read again after restarted or it's a replayed message.
+	if row.CommitTs <= progress.checkpoint.watermark {
+		log.Debug("row is applied already, ignore it",
+			zap.Int32("partition", progress.partition), zap.Int64("offset", offset),
+			zap.Uint64("commitTs", row.CommitTs), zap.Uint64("watermark", progress.checkpoint.watermark))
+		return
+	}
+	progress.rows = append(progress.rows, pendingRow{row: row, offset: offset})
+}
+
+func (c *KafkaConsumer) appendDDL(ddl *model.DDLEvent, offset int64) {
+	seq := int64(0)
+	if c.lastDDL != nil {
+		last := c.lastDDL.ddl
+		if ddl.CommitTs < last.CommitTs || (ddl.CommitTs == last.CommitTs && ddl.Query == last.Query) {
+			log.Warn("ddl is duplicated, ignore it",
+				zap.Int64("offset", offset), zap.Uint64("commitTs", ddl.CommitTs), zap.String("query", ddl.Query))
+			return
+		}
+		if ddl.CommitTs == last.CommitTs {
+			seq = c.lastDDL.seq + 1
+		}
+	}
+	pending := &pendingDDL{ddl: ddl, offset: offset, seq: seq}
+	c.lastDDL = pending
+
+	// the ddl is executed already, it's read again after restarted.
+	if ddl.CommitTs < c.ddlCheckpoint.watermark ||
+		(ddl.CommitTs == c.ddlCheckpoint.watermark && seq < c.ddlCheckpoint.resumeOffset) {
+		log.Info("ddl is executed already, ignore it",
+			zap.Int64("offset", offset), zap.Uint64("commitTs", ddl.CommitTs), zap.String("query", ddl.Query))
+		return
+	}
+	c.ddls = append(c.ddls, pending)
+}
+
+func (c *KafkaConsumer) minWatermark() uint64 {
+	watermark := uint64(math.MaxUint64)
+	for _, p := range c.partitions {
+		watermark = min(watermark, p.watermark)
+	}
+	return watermark
+}
+
+// flush executes the DDLs and applies the rows which are passed by the watermarks of all partitions.
+func (c *KafkaConsumer) flush(ctx context.Context) error {
+	for len(c.ddls) > 0 {
+		pending := c.ddls[0]
+		if pending.ddl.CommitTs > c.minWatermark() {
+			break
+		}
+		// the rows before the DDL must be applied before it.
+		for _, p := range c.partitions {
+			if err := c.flushPartition(ctx, p, pending.ddl.CommitTs); err != nil {
+				return err
+			}
+		}
+		cp := checkpoint{resumeOffset: pending.seq + 1, watermark: pending.ddl.CommitTs}
+		if err := c.downstream.execDDL(ctx, pending.ddl, cp); err != nil {
+			return err
+		}
+		log.Info("ddl executed",
+			zap.Int64("offset", pending.offset),
+			zap.Uint64("commitTs", pending.ddl.CommitTs),
+			zap.String("query", pending.ddl.Query))
+		c.ddlCheckpoint = cp
+		c.ddls = c.ddls[1:]
+	}
+
+	watermark := c.minWatermark()
+	for _, p := range c.partitions {
+		if err := c.flushPartition(ctx, p, watermark); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushPartition applies the pending rows of the partition whose commit ts are not greater
+// than the ts, and forwards the checkpoint of the partition.
+func (c *KafkaConsumer) flushPartition(ctx context.Context, p *partitionProgress, ts uint64) error {
+	ts = max(ts, p.checkpoint.watermark)
+	rows := p.resolve(ts)
+
+	// the messages before the resume offset are not needed after restarted.
+	resumeOffset := p.nextOffset
+	if len(p.rows) > 0 {
+		resumeOffset = min(resumeOffset, p.rows[0].offset)
+	}
+	if p.partition == 0 && len(c.ddls) > 0 {
+		resumeOffset = min(resumeOffset, c.ddls[0].offset)
+	}
+	cp := checkpoint{resumeOffset: resumeOffset, watermark: ts}
+	if len(rows) == 0 && cp == p.checkpoint {
+		return nil
+	}
+	if err := c.downstream.flush(ctx, p.partition, rows, cp); err != nil {
+		return err
+	}
+	p.checkpoint = cp
+	return nil
+}
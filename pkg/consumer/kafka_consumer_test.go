@@ -0,0 +1,220 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKafkaConsumer(t *testing.T, partitionNum int32) (*KafkaConsumer, *MemoryReader, sqlmock.Sqlmock) {
+	uri, err := url.Parse("kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true")
+	require.NoError(t, err)
+	cfg, err := NewKafkaConfig(uri, config.GetDefaultReplicaConfig(), time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "topic", cfg.ConsumerID)
+	require.Equal(t, []string{"127.0.0.1:9092"}, cfg.Reader.Addresses)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	reader := NewMemoryReader(partitionNum)
+	return NewKafkaConsumer(cfg, reader, db, nil), reader, mock
+}
+
+func canalDDL(query string, commitTs uint64) []byte {
+	return []byte(fmt.Sprintf(`{"id":0,"database":"test","table":"t","pkNames":null,"isDdl":true,"type":"CREATE",`+
+		`"es":1,"ts":1,"sql":%q,"sqlType":null,"mysqlType":null,"data":null,"old":null,"_tidb":{"commitTs":%d}}`,
+		query, commitTs))
+}
+
+func canalInsert(id int, commitTs uint64) []byte {
+	return []byte(fmt.Sprintf(`{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,"type":"INSERT",`+
+		`"es":1,"ts":1,"sql":"","sqlType":{"id":4},"mysqlType":{"id":"int"},"data":[{"id":"%d"}],"old":null,`+
+		`"_tidb":{"commitTs":%d}}`, id, commitTs))
+}
+
+func canalWatermark(ts uint64) []byte {
+	return []byte(fmt.Sprintf(`{"id":0,"database":"","table":"","pkNames":null,"isDdl":false,"type":"TIDB_WATERMARK",`+
+		`"es":1,"ts":1,"sql":"","sqlType":null,"mysqlType":null,"data":null,"old":null,"_tidb":{"watermarkTs":%d}}`, ts))
+}
+
+const (
+	createTestTable = "CREATE TABLE t (id int primary key)"
+	replaceSQL      = "REPLACE INTO `test`.`t` (`id`) VALUES (?)"
+	checkpointSQL   = "INSERT INTO `tidb_cdc`.`consumer_progress_v1`"
+)
+
+func expectInitialize(mock sqlmock.Sqlmock, checkpoints *sqlmock.Rows) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `tidb_cdc`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `tidb_cdc`.`consumer_progress_v1`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT partition_id, resume_offset, watermark FROM `tidb_cdc`.`consumer_progress_v1`")).
+		WithArgs("topic").WillReturnRows(checkpoints)
+}
+
+func expectFlush(mock sqlmock.Sqlmock, partition int32, rows []int, resumeOffset int64, watermark uint64) {
+	mock.ExpectBegin()
+	for _, id := range rows {
+		mock.ExpectExec(regexp.QuoteMeta(replaceSQL)).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(checkpointSQL)).
+		WithArgs("topic", partition, resumeOffset, watermark).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func consumeAll(ctx context.Context, t *testing.T, c *KafkaConsumer, reader *MemoryReader) {
+	require.NoError(t, c.initialize(ctx))
+	for reader.Pending() > 0 {
+		msg, err := reader.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.NoError(t, c.handleMessage(ctx, msg))
+	}
+}
+
+func TestKafkaConsumerApplyRowsAndDDL(t *testing.T) {
+	ctx := context.Background()
+	c, reader, mock := newTestKafkaConsumer(t, 2)
+
+	// the DDL is dispatched to all partitions.
+	reader.Produce(0, nil, canalDDL(createTestTable, 90))
+	reader.Produce(1, nil, canalDDL(createTestTable, 90))
+	reader.Produce(0, nil, canalInsert(1, 100))
+	reader.Produce(1, nil, canalInsert(2, 100))
+	reader.Produce(0, nil, canalWatermark(110))
+	reader.Produce(1, nil, canalWatermark(110))
+
+	expectInitialize(mock, sqlmock.NewRows([]string{"partition_id", "resume_offset", "watermark"}))
+	// the DDL read from the second partition is ignored, only its offset is forwarded.
+	expectFlush(mock, 1, nil, 1, 0)
+	// the DDL is executed after all partitions pass it, the first partition
+	// resumes from the DDL before it's executed.
+	expectFlush(mock, 0, nil, 0, 90)
+	expectFlush(mock, 1, nil, 1, 90)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("USE `test`;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(createTestTable)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta(checkpointSQL)).
+		WithArgs("topic", ddlPartitionID, 1, 90).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectFlush(mock, 0, []int{1}, 3, 110)
+	expectFlush(mock, 1, []int{2}, 3, 110)
+
+	consumeAll(ctx, t, c, reader)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Empty(t, c.ddls)
+	require.Equal(t, checkpoint{resumeOffset: 1, watermark: 90}, c.ddlCheckpoint)
+}
+
+func TestKafkaConsumerResume(t *testing.T) {
+	ctx := context.Background()
+	c, reader, mock := newTestKafkaConsumer(t, 1)
+
+	reader.Produce(0, nil, canalDDL(createTestTable, 90))
+	reader.Produce(0, nil, canalInsert(1, 100))
+	reader.Produce(0, nil, canalInsert(2, 120))
+	reader.Produce(0, nil, canalWatermark(130))
+
+	// the DDL and the first row are applied before the crash.
+	expectInitialize(mock, sqlmock.NewRows([]string{"partition_id", "resume_offset", "watermark"}).
+		AddRow(0, 0, 100).AddRow(ddlPartitionID, 1, 90))
+	expectFlush(mock, 0, nil, 1, 100)
+	expectFlush(mock, 0, []int{2}, 4, 130)
+
+	consumeAll(ctx, t, c, reader)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the partition is read from the resume offset stored in the downstream.
+	c, reader, mock = newTestKafkaConsumer(t, 1)
+	reader.Produce(0, nil, canalDDL(createTestTable, 90))
+	reader.Produce(0, nil, canalInsert(1, 100))
+	reader.Produce(0, nil, canalInsert(2, 120))
+	reader.Produce(0, nil, canalWatermark(130))
+	reader.Produce(0, nil, canalInsert(3, 140))
+	reader.Produce(0, nil, canalWatermark(150))
+
+	expectInitialize(mock, sqlmock.NewRows([]string{"partition_id", "resume_offset", "watermark"}).
+		AddRow(0, 4, 130).AddRow(ddlPartitionID, 1, 90))
+	expectFlush(mock, 0, []int{3}, 6, 150)
+
+	consumeAll(ctx, t, c, reader)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKafkaConsumerRunReturnsError(t *testing.T) {
+	ctx := context.Background()
+	c, reader, mock := newTestKafkaConsumer(t, 1)
+
+	reader.Produce(0, nil, canalInsert(1, 100))
+	reader.Produce(0, nil, canalWatermark(110))
+
+	expectInitialize(mock, sqlmock.NewRows([]string{"partition_id", "resume_offset", "watermark"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(replaceSQL)).WithArgs(1).WillReturnError(errors.New("table not exists"))
+	mock.ExpectRollback()
+
+	err := c.Run(ctx)
+	require.ErrorContains(t, err, "table not exists")
+	require.NoError(t, mock.ExpectationsWereMet())
+	// the checkpoint is not forwarded if the rows are not applied.
+	require.Equal(t, checkpoint{}, c.partitions[0].checkpoint)
+
+	// the invalid message is reported instead of panic.
+	c, reader, mock = newTestKafkaConsumer(t, 1)
+	reader.Produce(0, nil, []byte("invalid"))
+	expectInitialize(mock, sqlmock.NewRows([]string{"partition_id", "resume_offset", "watermark"}))
+	require.Error(t, c.Run(ctx))
+}
+
+func TestMemoryReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := NewMemoryReader(2)
+	require.Equal(t, int64(0), reader.Produce(1, nil, []byte("a")))
+	require.Equal(t, int64(0), reader.Produce(0, nil, []byte("b")))
+	require.Equal(t, int64(1), reader.Produce(1, nil, []byte("c")))
+	require.Equal(t, 3, reader.Pending())
+
+	// the messages are read in the produced order.
+	for _, expected := range []string{"a", "b", "c"} {
+		msg, err := reader.ReadMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(msg.Value))
+	}
+
+	require.Error(t, reader.Assign([]int64{0}))
+	// the stored offset is out of range.
+	require.Error(t, reader.Assign([]int64{3, 0}))
+	require.NoError(t, reader.Assign([]int64{OffsetEarliest, 2}))
+	require.Equal(t, 1, reader.Pending())
+	require.NoError(t, reader.Assign([]int64{1, 1}))
+	require.Equal(t, 1, reader.Pending())
+	msg, err := reader.ReadMessage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Message{Partition: 1, Offset: 1, Value: []byte("c")}, msg)
+
+	cancel()
+	_, err = reader.ReadMessage(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
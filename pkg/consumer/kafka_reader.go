@@ -0,0 +1,153 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// kafkaReadTimeout is the max time to block in a read, so the context can be checked in time.
+	kafkaReadTimeout = 100 * time.Millisecond
+	// kafkaMetadataTimeoutMs is the timeout to fetch the metadata of the topic.
+	kafkaMetadataTimeoutMs = 3000
+	// kafkaMetadataRetryTimes is the max times to retry to fetch the metadata of the topic.
+	kafkaMetadataRetryTimes = 30
+)
+
+// KafkaReaderConfig is the config to connect to the kafka cluster.
+type KafkaReaderConfig struct {
+	Addresses []string
+	Topic     string
+	CA        string
+	Cert      string
+	Key       string
+}
+
+// kafkaReader reads the messages from a kafka topic by assigning the partitions directly,
+// it never joins a consumer group or commits offsets to the kafka cluster.
+type kafkaReader struct {
+	topic  string
+	client *kafka.Consumer
+}
+
+// NewKafkaReader creates a Reader which reads messages from the kafka cluster.
+func NewKafkaReader(cfg *KafkaReaderConfig) (Reader, error) {
+	configMap := &kafka.ConfigMap{
+		"bootstrap.servers": strings.Join(cfg.Addresses, ","),
+		// the group is required by the client, but the offsets are never committed to it.
+		"group.id": "ticdc-consumer-" + cfg.Topic,
+		// The offsets are checked when assigning the partitions, fail instead of
+		// skipping or reading the messages again if they are out of range.
+		"auto.offset.reset":        "error",
+		"enable.auto.offset.store": false,
+		"enable.auto.commit":       false,
+	}
+	if len(cfg.CA) != 0 {
+		_ = configMap.SetKey("security.protocol", "SSL")
+		_ = configMap.SetKey("ssl.ca.location", cfg.CA)
+		_ = configMap.SetKey("ssl.key.location", cfg.Key)
+		_ = configMap.SetKey("ssl.certificate.location", cfg.Cert)
+	}
+	client, err := kafka.NewConsumer(configMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &kafkaReader{
+		topic:  cfg.Topic,
+		client: client,
+	}, nil
+}
+
+func (r *kafkaReader) PartitionNum(ctx context.Context) (int32, error) {
+	for i := 0; i < kafkaMetadataRetryTimes; i++ {
+		resp, err := r.client.GetMetadata(&r.topic, false, kafkaMetadataTimeoutMs)
+		if err != nil {
+			if kerr, ok := err.(kafka.Error); !ok || kerr.Code() != kafka.ErrTransport {
+				return 0, errors.Trace(err)
+			}
+			log.Info("retry to get the partition number", zap.String("topic", r.topic), zap.Error(err))
+		} else if topic, ok := resp.Topics[r.topic]; ok && len(topic.Partitions) > 0 {
+			return int32(len(topic.Partitions)), nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, errors.Trace(ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+	return 0, errors.Errorf("get the partition number of topic %s timeout", r.topic)
+}
+
+func (r *kafkaReader) Assign(offsets []int64) error {
+	partitions := make([]kafka.TopicPartition, 0, len(offsets))
+	for i, offset := range offsets {
+		kafkaOffset := kafka.OffsetBeginning
+		if offset != OffsetEarliest {
+			low, high, err := r.client.QueryWatermarkOffsets(r.topic, int32(i), kafkaMetadataTimeoutMs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if offset < low || offset > high {
+				return errors.Errorf("the stored offset %d of partition %d is out of range [%d, %d], "+
+					"the messages may be deleted by the retention policy", offset, i, low, high)
+			}
+			kafkaOffset = kafka.Offset(offset)
+		}
+		partitions = append(partitions, kafka.TopicPartition{
+			Topic:     &r.topic,
+			Partition: int32(i),
+			Offset:    kafkaOffset,
+		})
+	}
+	return errors.Trace(r.client.Assign(partitions))
+}
+
+func (r *kafkaReader) ReadMessage(ctx context.Context) (*Message, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		default:
+		}
+		msg, err := r.client.ReadMessage(kafkaReadTimeout)
+		if err != nil {
+			kerr, ok := err.(kafka.Error)
+			if !ok || kerr.IsFatal() || kerr.Code() == kafka.ErrAutoOffsetReset {
+				return nil, errors.Trace(err)
+			}
+			if kerr.Code() != kafka.ErrTimedOut {
+				log.Warn("read message failed, retry it", zap.String("topic", r.topic), zap.Error(err))
+			}
+			continue
+		}
+		return &Message{
+			Partition: msg.TopicPartition.Partition,
+			Offset:    int64(msg.TopicPartition.Offset),
+			Key:       msg.Key,
+			Value:     msg.Value,
+		}, nil
+	}
+}
+
+func (r *kafkaReader) Close() error {
+	return errors.Trace(r.client.Close())
+}
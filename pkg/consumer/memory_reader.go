@@ -0,0 +1,139 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+)
+
+// MemoryReader is a Reader which keeps the messages in memory, it's used as
+// a kafka stand-in to run the consumer without a kafka cluster.
+type MemoryReader struct {
+	mu sync.Mutex
+	// partitions is the messages of each partition, the index of a message is its offset.
+	partitions [][]*Message
+	// sequences is the produced order of the messages of each partition.
+	sequences [][]uint64
+	// positions is the offset of the next message to read of each partition.
+	positions []int64
+	sequence  uint64
+	notify    chan struct{}
+}
+
+// NewMemoryReader creates a MemoryReader with partitionNum partitions.
+func NewMemoryReader(partitionNum int32) *MemoryReader {
+	return &MemoryReader{
+		partitions: make([][]*Message, partitionNum),
+		sequences:  make([][]uint64, partitionNum),
+		positions:  make([]int64, partitionNum),
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// Produce appends a message to the partition and returns its offset.
+func (r *MemoryReader) Produce(partition int32, key, value []byte) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offset := int64(len(r.partitions[partition]))
+	r.partitions[partition] = append(r.partitions[partition], &Message{
+		Partition: partition,
+		Offset:    offset,
+		Key:       key,
+		Value:     value,
+	})
+	r.sequences[partition] = append(r.sequences[partition], r.sequence)
+	r.sequence++
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+	return offset
+}
+
+// Pending returns the number of messages which are not read yet.
+func (r *MemoryReader) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := 0
+	for i, messages := range r.partitions {
+		pending += len(messages) - int(r.positions[i])
+	}
+	return pending
+}
+
+func (r *MemoryReader) PartitionNum(_ context.Context) (int32, error) {
+	return int32(len(r.partitions)), nil
+}
+
+func (r *MemoryReader) Assign(offsets []int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(offsets) != len(r.partitions) {
+		return errors.Errorf("assign %d partitions, but the topic has %d partitions", len(offsets), len(r.partitions))
+	}
+	for i, offset := range offsets {
+		if offset == OffsetEarliest {
+			offset = 0
+		}
+		if offset < 0 || offset > int64(len(r.partitions[i])) {
+			return errors.Errorf("offset %d of partition %d is out of range [0, %d]", offset, i, len(r.partitions[i]))
+		}
+	}
+	for i, offset := range offsets {
+		r.positions[i] = max(offset, 0)
+	}
+	return nil
+}
+
+// ReadMessage returns the unread messages in the order they are produced,
+// it blocks until a message is produced if all messages are read.
+func (r *MemoryReader) ReadMessage(ctx context.Context) (*Message, error) {
+	for {
+		if msg := r.next(); msg != nil {
+			return msg, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-r.notify:
+		}
+	}
+}
+
+func (r *MemoryReader) next() *Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	partition := -1
+	for i, position := range r.positions {
+		if position >= int64(len(r.partitions[i])) {
+			continue
+		}
+		if partition < 0 || r.sequences[i][position] < r.sequences[partition][r.positions[partition]] {
+			partition = i
+		}
+	}
+	if partition < 0 {
+		return nil
+	}
+	msg := r.partitions[partition][r.positions[partition]]
+	r.positions[partition]++
+	return msg
+}
+
+func (r *MemoryReader) Close() error {
+	return nil
+}
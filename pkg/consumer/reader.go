@@ -0,0 +1,43 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import "context"
+
+// OffsetEarliest is the offset to read a partition from the first message available,
+// it's used if no offset of the partition is stored.
+const OffsetEarliest int64 = -1
+
+// Message is a message read from a partition of the topic.
+type Message struct {
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
+
+// Reader reads the messages of a topic from the given offsets. The offsets are
+// managed by the consumer itself, so the reader must not commit them anywhere.
+type Reader interface {
+	// PartitionNum returns the number of partitions of the topic.
+	PartitionNum(ctx context.Context) (int32, error)
+	// Assign makes the reader start to read partition i from offsets[i]. It returns an
+	// error if a stored offset is out of range, e.g. the messages are deleted by the
+	// retention policy, since reading from other offsets loses or duplicates data.
+	Assign(offsets []int64) error
+	// ReadMessage blocks until a message is read or the context is done.
+	ReadMessage(ctx context.Context) (*Message, error)
+	// Close closes the reader.
+	Close() error
+}
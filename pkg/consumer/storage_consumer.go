@@ -0,0 +1,573 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultStorageWorkerCount       = 4
+	defaultStorageScanInterval      = 10 * time.Second
+	defaultStorageDiscoveryInterval = time.Minute
+)
+
+// storageTable is the progress of a table in the storage.
+type storageTable struct {
+	name         tableName
+	dispatcherID common.DispatcherID
+	// version is the last applied version of the table, whose schema file is applied.
+	version uint64
+	// current is the schema of the applied version, it's nil until the schema file is found.
+	current *tableSchema
+	// pending are the schemas of the versions newer than the applied version.
+	pending map[uint64]*tableSchema
+	// maxIndex is the max index of the data files found in each directory.
+	maxIndex map[fileKey]uint64
+	// applied is the index of the last applied data file in each directory.
+	applied map[fileKey]uint64
+}
+
+// hasNewerDate returns whether a directory of a newer date is found for the partition
+// of the table version. The sink never writes the directory of an older date again
+// after it writes the directory of a newer date.
+func (t *storageTable) hasNewerDate(key fileKey) bool {
+	for k := range t.maxIndex {
+		if k.version == key.version && k.partition == key.partition && k.date > key.date {
+			return true
+		}
+	}
+	return false
+}
+
+func newStorageTable(name tableName) *storageTable {
+	return &storageTable{
+		name:         name,
+		dispatcherID: common.NewDispatcherID(),
+		pending:      make(map[uint64]*tableSchema),
+		maxIndex:     make(map[fileKey]uint64),
+		applied:      make(map[fileKey]uint64),
+	}
+}
+
+// advance moves the table to the version whose schema file is applied, the files
+// of the older versions are never read again.
+func (t *storageTable) advance(version uint64) {
+	t.version = version
+	t.current = t.pending[version]
+	for v := range t.pending {
+		if v <= version {
+			delete(t.pending, v)
+		}
+	}
+	for key := range t.maxIndex {
+		if key.version < version {
+			delete(t.maxIndex, key)
+		}
+	}
+	for key := range t.applied {
+		if key.version < version {
+			delete(t.applied, key)
+		}
+	}
+}
+
+// StorageConsumer replicates the files written by the cloud storage sink to a MySQL
+// compatible downstream.
+//
+// The storage is walked every DiscoveryInterval to find the new tables, table versions and
+// directories, and the known directories are probed every ScanInterval from the index of the
+// last found data file, so the files are not listed again. The data files of the tables are
+// applied in parallel, and the DDL in the schema file of a new table version is executed after
+// the data files of the older versions of all tables are applied, in the order of the table
+// versions. The index of the last applied data file in each directory is stored in the downstream,
+// so the consumer can resume after a crash, the rows of the last file may be applied again, which
+// is tolerated since the rows are applied in safe mode. The progress of a table version is removed
+// once the schema file of a newer version is applied, the progress of a directory is removed once
+// its files are applied and a directory of a newer date is found, and only the version is kept
+// for the dropped tables.
+type StorageConsumer struct {
+	cfg          *StorageConfig
+	storage      storage.ExternalStorage
+	mysqlCfg     *mysql.MysqlConfig
+	db           *sql.DB
+	progress     *storageProgress
+	changefeedID common.ChangeFeedID
+
+	tables map[tableName]*storageTable
+	// dropped is the version at which the table is dropped, the files of the table
+	// whose versions are not newer are ignored.
+	dropped       map[tableName]uint64
+	lastDiscovery time.Time
+	writers       chan *mysql.MysqlWriter
+}
+
+// NewStorageConsumer creates a StorageConsumer, the files are read from the storage and
+// written to the downstream db by the mysql sink with the config.
+func NewStorageConsumer(
+	cfg *StorageConfig, storage storage.ExternalStorage, mysqlCfg *mysql.MysqlConfig, db *sql.DB,
+) *StorageConsumer {
+	// the files may be applied again after a crash.
+	mysqlCfg.SafeMode = true
+	return &StorageConsumer{
+		cfg:          cfg,
+		storage:      storage,
+		mysqlCfg:     mysqlCfg,
+		db:           db,
+		progress:     newStorageProgress(db, cfg.ConsumerID),
+		changefeedID: common.NewChangeFeedIDWithName("storage-consumer"),
+		tables:       make(map[tableName]*storageTable),
+		dropped:      make(map[tableName]uint64),
+	}
+}
+
+// Run applies the files until the context is done or an error occurs.
+func (c *StorageConsumer) Run(ctx context.Context) error {
+	if err := c.initialize(ctx); err != nil {
+		return err
+	}
+	defer c.close()
+
+	ticker := time.NewTicker(c.cfg.ScanInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.scan(ctx); err != nil {
+			return err
+		}
+		if err := c.flush(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// initialize loads the progress of the tables from the downstream and creates the writers.
+func (c *StorageConsumer) initialize(ctx context.Context) error {
+	if err := c.progress.createTable(ctx); err != nil {
+		return err
+	}
+	progress, err := c.progress.load(ctx)
+	if err != nil {
+		return err
+	}
+	for name, files := range progress {
+		for key := range files {
+			if key.partition == droppedPartition {
+				c.dropped[name] = key.version
+			}
+		}
+		if _, ok := c.dropped[name]; ok {
+			continue
+		}
+		t := c.getTable(name)
+		for key, index := range files {
+			if key.partition == schemaFilePartition {
+				t.version = max(t.version, key.version)
+				continue
+			}
+			t.applied[key] = index
+			t.maxIndex[key] = index
+		}
+	}
+
+	statistics := metrics.NewStatistics(c.changefeedID, "StorageConsumer")
+	c.writers = make(chan *mysql.MysqlWriter, c.cfg.WorkerCount)
+	for i := 0; i < c.cfg.WorkerCount; i++ {
+		c.writers <- mysql.NewMysqlWriter(ctx, c.db, c.mysqlCfg, c.changefeedID, statistics, false)
+	}
+	log.Info("storage consumer initialized",
+		zap.String("consumerID", c.cfg.ConsumerID),
+		zap.String("protocol", c.cfg.Protocol.String()),
+		zap.Int("tableCount", len(c.tables)),
+		zap.Int("droppedTableCount", len(c.dropped)),
+		zap.Int("workerCount", c.cfg.WorkerCount))
+	return nil
+}
+
+func (c *StorageConsumer) close() {
+	close(c.writers)
+	for writer := range c.writers {
+		writer.Close()
+	}
+}
+
+func (c *StorageConsumer) getTable(name tableName) *storageTable {
+	t, ok := c.tables[name]
+	if !ok {
+		t = newStorageTable(name)
+		// the table is created again after it's dropped.
+		if version, ok := c.dropped[name]; ok {
+			t.version = version
+			delete(c.dropped, name)
+		}
+		c.tables[name] = t
+	}
+	return t
+}
+
+// isDropped returns whether the table is dropped at a version not older than the given one.
+func (c *StorageConsumer) isDropped(name tableName, version uint64) bool {
+	dropped, ok := c.dropped[name]
+	return ok && version <= dropped
+}
+
+// scan finds the new schema files and data files. The storage is walked only every
+// DiscoveryInterval since all files are listed, the new data files in the known
+// directories are found by probing the files after the last found index.
+func (c *StorageConsumer) scan(ctx context.Context) error {
+	if time.Since(c.lastDiscovery) >= c.cfg.DiscoveryInterval {
+		if err := c.discover(ctx); err != nil {
+			return err
+		}
+	}
+	return c.probe(ctx)
+}
+
+// discover walks the storage to find the new tables, table versions and directories.
+func (c *StorageConsumer) discover(ctx context.Context) error {
+	for _, t := range c.tables {
+		for _, schema := range t.pending {
+			schema.ready = true
+		}
+	}
+	c.lastDiscovery = time.Now()
+	return c.storage.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		if cloudstorage.IsSchemaFile(path) {
+			return c.handleSchemaFile(ctx, path)
+		}
+		if strings.HasSuffix(path, c.cfg.FileExtension) {
+			c.handleDataFile(path)
+		}
+		return nil
+	})
+}
+
+func (c *StorageConsumer) handleSchemaFile(ctx context.Context, path string) error {
+	var key cloudstorage.SchemaPathKey
+	checksum, err := key.ParseSchemaFilePath(path)
+	if err != nil {
+		log.Warn("ignore the unexpected schema file", zap.String("path", path), zap.Error(err))
+		return nil
+	}
+	name := tableName{schema: key.Schema, table: key.Table}
+	if c.isDropped(name, key.TableVersion) {
+		return nil
+	}
+	t := c.getTable(name)
+	if key.TableVersion < t.version || t.pending[key.TableVersion] != nil ||
+		(key.TableVersion == t.version && t.current != nil) {
+		return nil
+	}
+
+	content, err := c.storage.ReadFile(ctx, path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema, err := newTableSchema(content, key, checksum)
+	if err != nil {
+		return err
+	}
+	if key.TableVersion == t.version {
+		t.current = schema
+		return nil
+	}
+	t.pending[key.TableVersion] = schema
+	return nil
+}
+
+func (c *StorageConsumer) handleDataFile(path string) {
+	var key cloudstorage.DmlPathKey
+	index, err := key.ParseDMLFilePath(c.cfg.DateSeparator, path)
+	if err != nil {
+		log.Warn("ignore the unexpected data file", zap.String("path", path), zap.Error(err))
+		return
+	}
+	name := tableName{schema: key.Schema, table: key.Table}
+	if c.isDropped(name, key.TableVersion) {
+		return
+	}
+	t := c.getTable(name)
+	if key.TableVersion < t.version {
+		return
+	}
+	fk := fileKey{version: key.TableVersion, partition: key.PartitionNum, date: key.Date}
+	if _, ok := t.maxIndex[fk]; !ok && t.hasNewerDate(fk) {
+		// the files of the directory are applied and its progress is removed.
+		return
+	}
+	t.maxIndex[fk] = max(t.maxIndex[fk], index)
+}
+
+// probe finds the new data files in the known directories. The sink writes the data
+// files of a directory in the order of the index without gaps, so the files after the
+// last found index are checked one by one until a file doesn't exist.
+func (c *StorageConsumer) probe(ctx context.Context) error {
+	for _, t := range c.tables {
+		for key, index := range t.maxIndex {
+			for {
+				exists, err := c.storage.FileExists(ctx, c.dataFilePath(t.name, key, index+1))
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if !exists {
+					break
+				}
+				index++
+			}
+			t.maxIndex[key] = index
+		}
+	}
+	return nil
+}
+
+func (c *StorageConsumer) dataFilePath(name tableName, key fileKey, index uint64) string {
+	pathKey := cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{
+			Schema:       name.schema,
+			Table:        name.table,
+			TableVersion: key.version,
+		},
+		PartitionNum: key.partition,
+		Date:         key.date,
+	}
+	return pathKey.GenerateDMLFilePath(index, c.cfg.FileExtension, c.cfg.FileIndexWidth)
+}
+
+// flush applies the data files found, and the schema files of the new table versions
+// in the order of the versions.
+func (c *StorageConsumer) flush(ctx context.Context) error {
+	for {
+		if err := c.applyFiles(ctx); err != nil {
+			return err
+		}
+		version, tables := c.nextVersion()
+		if len(tables) == 0 {
+			return nil
+		}
+		for _, t := range tables {
+			// the table is dropped by the DROP DATABASE of the same version.
+			if c.tables[t.name] != t {
+				continue
+			}
+			if err := c.applySchema(ctx, t, version); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// applyFiles applies the data files of the applied versions of the tables in parallel.
+func (c *StorageConsumer) applyFiles(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, t := range c.tables {
+		// the files can't be decoded until the schema file of the table version is found.
+		if t.current == nil || t.current.tableInfo == nil {
+			continue
+		}
+		g.Go(func() error {
+			writer := <-c.writers
+			defer func() { c.writers <- writer }()
+			return c.applyTableFiles(ctx, writer, t)
+		})
+	}
+	return g.Wait()
+}
+
+func (c *StorageConsumer) applyTableFiles(ctx context.Context, writer *mysql.MysqlWriter, t *storageTable) error {
+	var keys []fileKey
+	for key, index := range t.maxIndex {
+		if key.version == t.version && index > t.applied[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].partition != keys[j].partition {
+			return keys[i].partition < keys[j].partition
+		}
+		return keys[i].date < keys[j].date
+	})
+	for _, key := range keys {
+		for index := t.applied[key] + 1; index <= t.maxIndex[key]; index++ {
+			if err := c.applyFile(ctx, writer, t, key, index); err != nil {
+				return err
+			}
+			t.applied[key] = index
+		}
+	}
+	return c.removeAppliedDates(ctx, t)
+}
+
+// removeAppliedDates removes the progress of the directories of the older dates, whose
+// files are all applied. The directory of the newer date is found before the directory
+// is probed in this scan, so no file is written to the directory after that.
+func (c *StorageConsumer) removeAppliedDates(ctx context.Context, t *storageTable) error {
+	for key, index := range t.maxIndex {
+		if key.version != t.version || t.applied[key] < index || !t.hasNewerDate(key) {
+			continue
+		}
+		if err := c.progress.removeFile(ctx, t.name, key); err != nil {
+			return err
+		}
+		delete(t.maxIndex, key)
+		delete(t.applied, key)
+	}
+	return nil
+}
+
+// applyFile writes the rows of the data file to the downstream, and then saves the progress.
+func (c *StorageConsumer) applyFile(
+	ctx context.Context, writer *mysql.MysqlWriter, t *storageTable, key fileKey, index uint64,
+) error {
+	path := c.dataFilePath(t.name, key, index)
+	content, err := c.storage.ReadFile(ctx, path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows, err := decodeFile(ctx, c.cfg, t.current, content)
+	if err != nil {
+		return errors.Annotatef(err, "failed to decode %s", path)
+	}
+
+	var (
+		events   []*commonEvent.DMLEvent
+		event    *commonEvent.DMLEvent
+		rowCount int
+	)
+	tableInfo := t.current.tableInfo
+	for _, row := range rows {
+		// the rows of a transaction are in one event, and a batch is flushed in one transaction.
+		if event == nil || event.CommitTs != row.CommitTs {
+			if rowCount >= c.mysqlCfg.MaxTxnRow {
+				if err = writer.Flush(events); err != nil {
+					return err
+				}
+				events, rowCount = events[:0], 0
+			}
+			event = commonEvent.NewDMLEvent(t.dispatcherID, tableInfo.TableName.TableID, row.StartTs, row.CommitTs, tableInfo)
+			events = append(events, event)
+		}
+		if err = appendRow(event, row); err != nil {
+			return errors.Annotatef(err, "failed to decode %s", path)
+		}
+		rowCount++
+	}
+	if len(events) > 0 {
+		if err = writer.Flush(events); err != nil {
+			return err
+		}
+	}
+	if err = c.progress.saveFile(ctx, t.name, key, index); err != nil {
+		return err
+	}
+	log.Info("data file applied", zap.String("path", path), zap.Int("rowCount", len(rows)))
+	return nil
+}
+
+// nextVersion returns the oldest version among the pending versions of all tables, and
+// the tables which have the version. The databases are returned before the tables.
+func (c *StorageConsumer) nextVersion() (uint64, []*storageTable) {
+	var version uint64
+	for _, t := range c.tables {
+		for v := range t.pending {
+			if version == 0 || v < version {
+				version = v
+			}
+		}
+	}
+	var tables []*storageTable
+	for _, t := range c.tables {
+		schema, ok := t.pending[version]
+		if !ok {
+			continue
+		}
+		// the schema file may be found before some data files of the older versions.
+		if !schema.ready {
+			return 0, nil
+		}
+		tables = append(tables, t)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		if (tables[i].name.table == "") != (tables[j].name.table == "") {
+			return tables[i].name.table == ""
+		}
+		if tables[i].name.schema != tables[j].name.schema {
+			return tables[i].name.schema < tables[j].name.schema
+		}
+		return tables[i].name.table < tables[j].name.table
+	})
+	return version, tables
+}
+
+// applySchema executes the DDL in the schema file of the version and saves the progress.
+func (c *StorageConsumer) applySchema(ctx context.Context, t *storageTable, version uint64) error {
+	def := t.pending[version].def
+	if def.Query != "" {
+		if err := execDDL(ctx, c.db, def.Schema, timodel.ActionType(def.Type), def.Query); err != nil {
+			return err
+		}
+		log.Info("ddl executed", zap.String("query", def.Query), zap.Uint64("version", version))
+	}
+	switch timodel.ActionType(def.Type) {
+	case timodel.ActionDropTable:
+		return c.dropTables(ctx, version, t)
+	case timodel.ActionDropSchema:
+		tables := []*storageTable{t}
+		for _, other := range c.tables {
+			if other != t && other.name.schema == t.name.schema && other.version < version {
+				tables = append(tables, other)
+			}
+		}
+		return c.dropTables(ctx, version, tables...)
+	}
+	if err := c.progress.saveVersion(ctx, t.name, version); err != nil {
+		return err
+	}
+	t.advance(version)
+	return nil
+}
+
+// dropTables removes the state of the tables dropped at the version, only the version
+// is kept, so the files of the older versions are never applied again.
+func (c *StorageConsumer) dropTables(ctx context.Context, version uint64, tables ...*storageTable) error {
+	for _, t := range tables {
+		if err := c.progress.saveDropped(ctx, t.name, version); err != nil {
+			return err
+		}
+		delete(c.tables, t.name)
+		c.dropped[t.name] = version
+		log.Info("table dropped", zap.String("schema", t.name.schema),
+			zap.String("table", t.name.table), zap.Uint64("version", version))
+	}
+	return nil
+}
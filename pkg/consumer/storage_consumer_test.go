@@ -0,0 +1,339 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/mysql"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorageConsumer(
+	t *testing.T, dir string, dateSeparator config.DateSeparator,
+) (*StorageConsumer, storage.ExternalStorage, sqlmock.Sqlmock) {
+	uri, err := url.Parse(fmt.Sprintf("file://%s?protocol=csv", dir))
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.DateSeparator = util.AddressOf(dateSeparator.String())
+	cfg, err := NewStorageConfig(uri, replicaConfig, time.UTC)
+	require.NoError(t, err)
+	// walk the storage in every scan.
+	cfg.DiscoveryInterval = 0
+	require.Equal(t, "file://"+dir, cfg.ConsumerID)
+	require.Equal(t, ".csv", cfg.FileExtension)
+
+	ctx := context.Background()
+	store, err := helper.GetExternalStorageFromURI(ctx, uri.String())
+	require.NoError(t, err)
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	mysqlCfg := &mysql.MysqlConfig{MaxAllowedPacket: int64(variable.DefMaxAllowedPacket), MaxTxnRow: 256}
+	return NewStorageConsumer(cfg, store, mysqlCfg, db), store, mock
+}
+
+// writeSchemaFile writes the schema file of the table version, the table is empty for the database.
+func writeSchemaFile(ctx context.Context, t *testing.T, store storage.ExternalStorage,
+	table string, version uint64, ddlType timodel.ActionType, query string,
+) {
+	def := cloudstorage.TableDefinition{
+		Schema:       "test",
+		Table:        table,
+		Version:      1,
+		TableVersion: version,
+		Query:        query,
+		Type:         byte(ddlType),
+	}
+	if table != "" {
+		def.Columns = []cloudstorage.TableCol{
+			{Name: "id", Tp: "INT", IsPK: "true", Precision: "11"},
+			{Name: "v", Tp: "VARCHAR", Precision: "16", Nullable: "true"},
+		}
+		def.TotalColumns = len(def.Columns)
+	}
+	path, err := def.GenerateSchemaFilePath()
+	require.NoError(t, err)
+	content, err := def.MarshalWithQuery()
+	require.NoError(t, err)
+	require.NoError(t, store.WriteFile(ctx, path, content))
+}
+
+func writeDataFile(ctx context.Context, t *testing.T, store storage.ExternalStorage,
+	version uint64, index uint64, content string,
+) {
+	writeDateDataFile(ctx, t, store, version, "", index, content)
+}
+
+func writeDateDataFile(ctx context.Context, t *testing.T, store storage.ExternalStorage,
+	version uint64, date string, index uint64, content string,
+) {
+	key := cloudstorage.DmlPathKey{
+		SchemaPathKey: cloudstorage.SchemaPathKey{Schema: "test", Table: "t", TableVersion: version},
+		Date:          date,
+	}
+	path := key.GenerateDMLFilePath(index, ".csv", config.DefaultFileIndexWidth)
+	require.NoError(t, store.WriteFile(ctx, path, []byte(content)))
+}
+
+const (
+	storageProgressSQL = "INSERT INTO `tidb_cdc`.`storage_consumer_progress_v1`"
+	createTestSchema   = "CREATE DATABASE test"
+)
+
+func writeTestFiles(ctx context.Context, t *testing.T, store storage.ExternalStorage) {
+	writeSchemaFile(ctx, t, store, "", 90, timodel.ActionCreateSchema, createTestSchema)
+	writeSchemaFile(ctx, t, store, "t", 100, timodel.ActionCreateTable, "CREATE TABLE t (id int primary key, v varchar(16))")
+	writeDataFile(ctx, t, store, 100, 1, "\"I\",\"t\",\"test\",1,\"a\"\r\n\"I\",\"t\",\"test\",2,\"b\"\r\n")
+	writeDataFile(ctx, t, store, 100, 2, "\"D\",\"t\",\"test\",1,\"a\"\r\n")
+	writeSchemaFile(ctx, t, store, "t", 200, timodel.ActionTruncateTable, "TRUNCATE TABLE t")
+	writeDataFile(ctx, t, store, 200, 1, "\"I\",\"t\",\"test\",3,\"c\"\r\n")
+}
+
+func expectStorageInitialize(mock sqlmock.Sqlmock, id string, progress *sqlmock.Rows) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `tidb_cdc`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `tidb_cdc`.`storage_consumer_progress_v1`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT schema_name, table_name, table_version, partition_num, date, file_index")).
+		WithArgs(id).WillReturnRows(progress)
+}
+
+func newStorageProgressRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"schema_name", "table_name", "table_version", "partition_num", "date", "file_index"})
+}
+
+func expectSchemaApplied(mock sqlmock.Sqlmock, id, table string, version uint64, query string) {
+	mock.ExpectBegin()
+	if table != "" {
+		mock.ExpectExec(regexp.QuoteMeta("USE `test`;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(storageProgressSQL)).
+		WithArgs(id, "test", table, version, schemaFilePartition, "", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	// the progress of the older versions is removed.
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tidb_cdc`.`storage_consumer_progress_v1`")).
+		WithArgs(id, "test", table, version).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+}
+
+func expectFileApplied(mock sqlmock.Sqlmock, id string, version, index uint64, query string, args ...driver.Value) {
+	expectDateFileApplied(mock, id, version, "", index, query, args...)
+}
+
+func expectDateFileApplied(
+	mock sqlmock.Sqlmock, id string, version uint64, date string, index uint64, query string, args ...driver.Value,
+) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta(storageProgressSQL)).
+		WithArgs(id, "test", "t", version, 0, date, index).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestStorageConsumerApplyFilesAndDDL(t *testing.T) {
+	ctx := context.Background()
+	c, store, mock := newTestStorageConsumer(t, t.TempDir(), config.DateSeparatorNone)
+	writeTestFiles(ctx, t, store)
+	id := c.cfg.ConsumerID
+
+	expectStorageInitialize(mock, id, newStorageProgressRows())
+	require.NoError(t, c.initialize(ctx))
+	// the schema files are applied after the storage is scanned again, since the data
+	// files of the older versions may be missed by the scan which finds them.
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	expectSchemaApplied(mock, id, "", 90, createTestSchema)
+	expectSchemaApplied(mock, id, "t", 100, "CREATE TABLE t")
+	expectFileApplied(mock, id, 100, 1, "REPLACE INTO `test`.`t` (`id`,`v`) VALUES (?,?),(?,?)", 1, "a", 2, "b")
+	expectFileApplied(mock, id, 100, 2, "DELETE FROM `test`.`t` WHERE", 1)
+	expectSchemaApplied(mock, id, "t", 200, "TRUNCATE TABLE t")
+	expectFileApplied(mock, id, 200, 1, "REPLACE INTO `test`.`t` (`id`,`v`) VALUES (?,?)", 3, "c")
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	table := c.tables[tableName{schema: "test", table: "t"}]
+	require.Equal(t, uint64(200), table.version)
+	require.Empty(t, table.pending)
+	require.Equal(t, map[fileKey]uint64{{version: 200}: 1}, table.applied)
+	require.Equal(t, map[fileKey]uint64{{version: 200}: 1}, table.maxIndex)
+}
+
+func TestStorageConsumerResume(t *testing.T) {
+	ctx := context.Background()
+	c, store, mock := newTestStorageConsumer(t, t.TempDir(), config.DateSeparatorNone)
+	writeTestFiles(ctx, t, store)
+	id := c.cfg.ConsumerID
+
+	// all files are applied before the crash.
+	expectStorageInitialize(mock, id, newStorageProgressRows().
+		AddRow("test", "", 90, schemaFilePartition, "", 0).
+		AddRow("test", "t", 200, schemaFilePartition, "", 0).
+		AddRow("test", "t", 200, 0, "", 1))
+	require.NoError(t, c.initialize(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// only the new file is applied, it's found by probing the known directory
+	// without walking the storage.
+	c.cfg.DiscoveryInterval = time.Hour
+	writeDataFile(ctx, t, store, 200, 2, "\"I\",\"t\",\"test\",4,\"d\"\r\n")
+	expectFileApplied(mock, id, 200, 2, "REPLACE INTO `test`.`t` (`id`,`v`) VALUES (?,?)", 4, "d")
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the new table is found by the next walk.
+	writeSchemaFile(ctx, t, store, "t2", 300, timodel.ActionCreateTable, "CREATE TABLE t2 (id int primary key, v varchar(16))")
+	require.NoError(t, c.scan(ctx))
+	require.NotContains(t, c.tables, tableName{schema: "test", table: "t2"})
+	c.lastDiscovery = time.Time{}
+	require.NoError(t, c.scan(ctx))
+	require.Contains(t, c.tables, tableName{schema: "test", table: "t2"})
+}
+
+func TestStorageConsumerRemoveAppliedDates(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, store, mock := newTestStorageConsumer(t, dir, config.DateSeparatorDay)
+	writeSchemaFile(ctx, t, store, "", 90, timodel.ActionCreateSchema, createTestSchema)
+	writeSchemaFile(ctx, t, store, "t", 100, timodel.ActionCreateTable, "CREATE TABLE t (id int primary key, v varchar(16))")
+	writeDateDataFile(ctx, t, store, 100, "2025-01-01", 1, "\"I\",\"t\",\"test\",1,\"a\"\r\n")
+	writeDateDataFile(ctx, t, store, 100, "2025-01-02", 1, "\"I\",\"t\",\"test\",2,\"b\"\r\n")
+	id := c.cfg.ConsumerID
+
+	expectStorageInitialize(mock, id, newStorageProgressRows())
+	require.NoError(t, c.initialize(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+
+	// the progress of the older date is removed after its files are applied.
+	expectSchemaApplied(mock, id, "", 90, createTestSchema)
+	expectSchemaApplied(mock, id, "t", 100, "CREATE TABLE t")
+	expectDateFileApplied(mock, id, 100, "2025-01-01", 1, "REPLACE INTO `test`.`t` (`id`,`v`) VALUES (?,?)", 1, "a")
+	expectDateFileApplied(mock, id, 100, "2025-01-02", 1, "REPLACE INTO `test`.`t` (`id`,`v`) VALUES (?,?)", 2, "b")
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tidb_cdc`.`storage_consumer_progress_v1`")).
+		WithArgs(id, "test", "t", 100, 0, "2025-01-01").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+	table := c.tables[tableName{schema: "test", table: "t"}]
+	require.Equal(t, map[fileKey]uint64{{version: 100, date: "2025-01-02"}: 1}, table.applied)
+	require.Equal(t, map[fileKey]uint64{{version: 100, date: "2025-01-02"}: 1}, table.maxIndex)
+
+	// the directory of the older date is not applied again after restarted.
+	c, _, mock = newTestStorageConsumer(t, dir, config.DateSeparatorDay)
+	expectStorageInitialize(mock, id, newStorageProgressRows().
+		AddRow("test", "", 90, schemaFilePartition, "", 0).
+		AddRow("test", "t", 100, schemaFilePartition, "", 0).
+		AddRow("test", "t", 100, 0, "2025-01-02", 1))
+	require.NoError(t, c.initialize(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+	table = c.tables[tableName{schema: "test", table: "t"}]
+	require.Equal(t, map[fileKey]uint64{{version: 100, date: "2025-01-02"}: 1}, table.maxIndex)
+}
+
+func TestStorageConsumerDropTable(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, store, mock := newTestStorageConsumer(t, dir, config.DateSeparatorNone)
+	writeTestFiles(ctx, t, store)
+	writeSchemaFile(ctx, t, store, "t", 300, timodel.ActionDropTable, "DROP TABLE t")
+	id := c.cfg.ConsumerID
+	name := tableName{schema: "test", table: "t"}
+
+	expectStorageInitialize(mock, id, newStorageProgressRows().
+		AddRow("test", "", 90, schemaFilePartition, "", 0).
+		AddRow("test", "t", 200, schemaFilePartition, "", 0).
+		AddRow("test", "t", 200, 0, "", 1))
+	require.NoError(t, c.initialize(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+
+	// only the version is kept for the dropped table.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("USE `test`;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE t")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(storageProgressSQL)).
+		WithArgs(id, "test", "t", 300, droppedPartition, "", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tidb_cdc`.`storage_consumer_progress_v1`")).
+		WithArgs(id, "test", "t", 300).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.NotContains(t, c.tables, name)
+	require.Equal(t, map[tableName]uint64{name: 300}, c.dropped)
+
+	// the files of the dropped table are ignored.
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NotContains(t, c.tables, name)
+
+	// the table is dropped after restarted.
+	c, _, mock = newTestStorageConsumer(t, dir, config.DateSeparatorNone)
+	expectStorageInitialize(mock, id, newStorageProgressRows().
+		AddRow("test", "", 90, schemaFilePartition, "", 0).
+		AddRow("test", "t", 300, droppedPartition, "", 0))
+	require.NoError(t, c.initialize(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.NotContains(t, c.tables, name)
+
+	// the table is created again.
+	writeSchemaFile(ctx, t, store, "t", 400, timodel.ActionCreateTable, "CREATE TABLE t (id int primary key, v varchar(16))")
+	expectSchemaApplied(mock, id, "t", 400, "CREATE TABLE t")
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.scan(ctx))
+	require.NoError(t, c.flush(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, uint64(400), c.tables[name].version)
+	require.Empty(t, c.dropped)
+}
+
+func TestStorageConsumerChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	c, store, mock := newTestStorageConsumer(t, t.TempDir(), config.DateSeparatorNone)
+	require.NoError(t, store.WriteFile(ctx, "test/t/meta/schema_100_0000000001.json", []byte(`{"Table":"t","Schema":"test"}`)))
+
+	expectStorageInitialize(mock, c.cfg.ConsumerID, newStorageProgressRows())
+	require.NoError(t, c.initialize(ctx))
+	require.ErrorContains(t, c.scan(ctx), "mismatches")
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	tcloudstorage "github.com/pingcap/tiflow/pkg/sink/cloudstorage"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/canal"
+	"github.com/pingcap/tiflow/pkg/sink/codec/csv"
+)
+
+// tableSchema is the schema of a table version read from the schema file.
+type tableSchema struct {
+	def *cloudstorage.TableDefinition
+	// tableInfo is used to build the events written to the downstream.
+	tableInfo *common.TableInfo
+	// decodeTableInfo is used to decode the data files.
+	decodeTableInfo *model.TableInfo
+	// ready is whether the schema file is found by a previous scan, all data files of
+	// the older versions are written before the schema file, so they must be found by
+	// the scans after the one which finds the schema file.
+	ready bool
+}
+
+// newTableSchema parses the content of the schema file, the checksum is verified to
+// make sure the file is complete.
+func newTableSchema(content []byte, key cloudstorage.SchemaPathKey, checksum uint32) (*tableSchema, error) {
+	var def cloudstorage.TableDefinition
+	if err := json.Unmarshal(content, &def); err != nil {
+		return nil, cerror.WrapError(cerror.ErrDecodeFailed, err, "schema file of "+key.GetKey())
+	}
+	checksumInFile, err := def.Sum32(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if checksumInFile != checksum || def.TableVersion != key.TableVersion {
+		return nil, errors.Errorf("the schema file of %s at version %d mismatches, checksum %d, expected %d",
+			key.GetKey(), key.TableVersion, checksumInFile, checksum)
+	}
+	schema := &tableSchema{def: &def}
+	// the schema files of the databases only contain the DDLs.
+	if !def.IsTableSchema() {
+		return schema, nil
+	}
+	schema.tableInfo, err = def.ToTableInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the statements to write the rows are built by the sink from the private fields.
+	schema.tableInfo.InitPrivateFields()
+	// the decoders of tiflow require the table info of tiflow, which is built from
+	// the same definition.
+	var tdef tcloudstorage.TableDefinition
+	if err = json.Unmarshal(content, &tdef); err != nil {
+		return nil, cerror.WrapError(cerror.ErrDecodeFailed, err, "schema file of "+key.GetKey())
+	}
+	schema.decodeTableInfo, err = tdef.ToTableInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schema, nil
+}
+
+// decodeFile decodes the rows in the content of the data file.
+func decodeFile(
+	ctx context.Context, cfg *StorageConfig, schema *tableSchema, content []byte,
+) ([]*model.RowChangedEvent, error) {
+	var (
+		decoder codec.RowEventDecoder
+		err     error
+	)
+	switch cfg.Protocol {
+	case config.ProtocolCsv:
+		decoder, err = csv.NewBatchDecoder(ctx, cfg.CodecConfig, schema.decodeTableInfo, content)
+	case config.ProtocolCanalJSON:
+		decoder, err = canal.NewBatchDecoder(ctx, cfg.CodecConfig, nil)
+		if err == nil {
+			err = decoder.AddKeyValue(nil, content)
+		}
+	default:
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStackByArgs(cfg.Protocol)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var rows []*model.RowChangedEvent
+	for {
+		ty, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !hasNext {
+			return rows, nil
+		}
+		if ty != model.MessageTypeRow {
+			continue
+		}
+		row, err := decoder.NextRowChangedEvent()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// appendRow appends the decoded row to the event, the values are matched to the
+// columns of the event by the column names.
+func appendRow(event *commonEvent.DMLEvent, row *model.RowChangedEvent) error {
+	rowType := commonEvent.RowTypeInsert
+	if row.IsDelete() {
+		rowType = commonEvent.RowTypeDelete
+	}
+	if row.IsUpdate() {
+		rowType = commonEvent.RowTypeUpdate
+		if err := appendValues(event, row.TableInfo, row.PreColumns); err != nil {
+			return err
+		}
+		event.RowTypes = append(event.RowTypes, rowType)
+	}
+	cols := row.Columns
+	if row.IsDelete() {
+		cols = row.PreColumns
+	}
+	if err := appendValues(event, row.TableInfo, cols); err != nil {
+		return err
+	}
+	event.RowTypes = append(event.RowTypes, rowType)
+	event.Length++
+	return nil
+}
+
+func appendValues(event *commonEvent.DMLEvent, tableInfo *model.TableInfo, cols []*model.ColumnData) error {
+	values := make(map[string]any, len(cols))
+	for _, col := range cols {
+		if col == nil {
+			continue
+		}
+		values[tableInfo.ForceGetColumnName(col.ColumnID)] = col.Value
+	}
+	for i, colInfo := range event.TableInfo.GetColumns() {
+		value, ok := values[colInfo.Name.O]
+		if !ok || value == nil {
+			event.Rows.AppendNull(i)
+			continue
+		}
+		d := types.NewDatum(value)
+		d, err := d.ConvertTo(types.DefaultStmtNoWarningContext, &colInfo.FieldType)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrDecodeFailed, err, "column "+colInfo.Name.O)
+		}
+		event.Rows.AppendDatum(i, &d)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+)
+
+// schemaFilePartition is the partition of the progress which records that the schema
+// file of the table version is applied, it's also used to put the schema file in
+// front of the data files of the same table version.
+const schemaFilePartition = -1
+
+// droppedPartition is the partition of the progress which records that the table is
+// dropped at the table version, the files of the older versions are never read again.
+const droppedPartition = -2
+
+// tableName is the name of a table in the storage, the table is empty for the schema
+// files of the databases.
+type tableName struct {
+	schema string
+	table  string
+}
+
+// fileKey identifies the directory of the data files of a table version.
+type fileKey struct {
+	version   uint64
+	partition int64
+	date      string
+}
+
+// storageProgress stores the applied files of the tables in the downstream. For each
+// table, it records the last applied version, whose schema file is applied, and the
+// index of the last applied data file in each directory of the version. Only the
+// version is kept for the dropped tables.
+type storageProgress struct {
+	db         *sql.DB
+	consumerID string
+}
+
+func newStorageProgress(db *sql.DB, consumerID string) *storageProgress {
+	return &storageProgress{
+		db:         db,
+		consumerID: consumerID,
+	}
+}
+
+func (p *storageProgress) quotedTable() string {
+	return common.QuoteSchema(filter.TiCDCSystemSchema, filter.StorageConsumerProgressTable)
+}
+
+func (p *storageProgress) createTable(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+common.QuoteName(filter.TiCDCSystemSchema))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create consumer progress database"))
+	}
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		consumer_id varchar(255) NOT NULL,
+		schema_name varchar(255) NOT NULL,
+		table_name varchar(255) NOT NULL,
+		table_version bigint unsigned NOT NULL,
+		partition_num bigint NOT NULL,
+		date varchar(32) NOT NULL,
+		file_index bigint unsigned NOT NULL,
+		updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (consumer_id, schema_name, table_name, table_version, partition_num, date)
+	);`
+	_, err = p.db.ExecContext(ctx, fmt.Sprintf(query, p.quotedTable()))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create consumer progress table"))
+	}
+	return nil
+}
+
+// load returns the applied files of the tables.
+func (p *storageProgress) load(ctx context.Context) (map[tableName]map[fileKey]uint64, error) {
+	rows, err := p.db.QueryContext(ctx,
+		"SELECT schema_name, table_name, table_version, partition_num, date, file_index FROM "+
+			p.quotedTable()+" WHERE consumer_id = ?", p.consumerID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+
+	progress := make(map[tableName]map[fileKey]uint64)
+	for rows.Next() {
+		var (
+			name  tableName
+			key   fileKey
+			index uint64
+		)
+		if err = rows.Scan(&name.schema, &name.table, &key.version, &key.partition, &key.date, &index); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		if progress[name] == nil {
+			progress[name] = make(map[fileKey]uint64)
+		}
+		progress[name][key] = index
+	}
+	return progress, errors.Trace(rows.Err())
+}
+
+func (p *storageProgress) saveSQL() string {
+	return "INSERT INTO " + p.quotedTable() +
+		" (consumer_id, schema_name, table_name, table_version, partition_num, date, file_index) VALUES (?,?,?,?,?,?,?)" +
+		" ON DUPLICATE KEY UPDATE file_index = VALUES(file_index)"
+}
+
+// saveFile records the data file is applied.
+func (p *storageProgress) saveFile(ctx context.Context, name tableName, key fileKey, index uint64) error {
+	_, err := p.db.ExecContext(ctx, p.saveSQL(),
+		p.consumerID, name.schema, name.table, key.version, key.partition, key.date, index)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to save the consumer progress"))
+	}
+	return nil
+}
+
+// removeFile removes the progress of the directory, whose data files are all applied.
+func (p *storageProgress) removeFile(ctx context.Context, name tableName, key fileKey) error {
+	_, err := p.db.ExecContext(ctx, "DELETE FROM "+p.quotedTable()+
+		" WHERE consumer_id = ? AND schema_name = ? AND table_name = ? AND table_version = ? AND partition_num = ? AND date = ?",
+		p.consumerID, name.schema, name.table, key.version, key.partition, key.date)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to remove the consumer progress"))
+	}
+	return nil
+}
+
+// saveVersion records the schema file of the table version is applied, and removes
+// the progress of the older versions, which are never read again.
+func (p *storageProgress) saveVersion(ctx context.Context, name tableName, version uint64) error {
+	return p.replaceVersion(ctx, name, version, schemaFilePartition)
+}
+
+// saveDropped records the table is dropped at the table version, and removes the
+// progress of the older versions.
+func (p *storageProgress) saveDropped(ctx context.Context, name tableName, version uint64) error {
+	return p.replaceVersion(ctx, name, version, droppedPartition)
+}
+
+func (p *storageProgress) replaceVersion(ctx context.Context, name tableName, version uint64, partition int64) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to begin transaction"))
+	}
+	_, err = tx.ExecContext(ctx, p.saveSQL(), p.consumerID, name.schema, name.table, version, partition, "", 0)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM "+p.quotedTable()+
+			" WHERE consumer_id = ? AND schema_name = ? AND table_name = ? AND table_version < ?",
+			p.consumerID, name.schema, name.table, version)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to save the consumer progress"))
+	}
+	if err = tx.Commit(); err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to commit transaction"))
+	}
+	return nil
+}
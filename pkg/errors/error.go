@@ -52,6 +52,10 @@ var (
 		"new store failed",
 		errors.RFCCodeText("CDC:ErrNewStore"),
 	)
+	ErrScanSnapshot = errors.Normalize(
+		"scan the snapshot of table span %s at ts %d failed",
+		errors.RFCCodeText("CDC:ErrScanSnapshot"),
+	)
 
 	// codec related errors
 	ErrEncodeFailed = errors.Normalize(
@@ -77,6 +81,11 @@ var (
 		errors.RFCCodeText("CDC:ErrColumnSelectorFailed"),
 	)
 
+	ErrColumnTransformFailed = errors.Normalize(
+		"column transform failed, %s",
+		errors.RFCCodeText("CDC:ErrColumnTransformFailed"),
+	)
+
 	// ErrVersionIncompatible is an error for running CDC on an incompatible Cluster.
 	ErrVersionIncompatible = errors.Normalize(
 		"version is incompatible: %s",
@@ -103,6 +112,10 @@ var (
 		"kafka async send message failed",
 		errors.RFCCodeText("CDC:ErrKafkaAsyncSendMessage"),
 	)
+	ErrKafkaTransaction = errors.Normalize(
+		"kafka transaction failed",
+		errors.RFCCodeText("CDC:ErrKafkaTransaction"),
+	)
 	ErrKafkaInvalidPartitionNum = errors.Normalize(
 		"invalid partition num %d",
 		errors.RFCCodeText("CDC:ErrKafkaInvalidPartitionNum"),
@@ -144,6 +157,18 @@ var (
 		"invalid topic expression",
 		errors.RFCCodeText("CDC:ErrPulsarTopicExprInvalid"),
 	)
+	ErrWebhookInvalidConfig = errors.Normalize(
+		"webhook config invalid",
+		errors.RFCCodeText("CDC:ErrWebhookInvalidConfig"),
+	)
+	ErrWebhookSendMessage = errors.Normalize(
+		"webhook send message failed",
+		errors.RFCCodeText("CDC:ErrWebhookSendMessage"),
+	)
+	ErrWebhookProducerClosed = errors.Normalize(
+		"webhook producer closed",
+		errors.RFCCodeText("CDC:ErrWebhookProducerClosed"),
+	)
 	ErrCodecInvalidConfig = errors.Normalize(
 		"Codec invalid config",
 		errors.RFCCodeText("CDC:ErrCodecInvalidConfig"),
@@ -181,6 +206,10 @@ var (
 		"MySQL config invalid",
 		errors.RFCCodeText("CDC:ErrMySQLInvalidConfig"),
 	)
+	ErrSyncPointNotFound = errors.Normalize(
+		"sync point of changefeed '%s' is not found in downstream",
+		errors.RFCCodeText("CDC:ErrSyncPointNotFound"),
+	)
 	ErrAvroToEnvelopeError = errors.Normalize(
 		"to envelope failed",
 		errors.RFCCodeText("CDC:ErrAvroToEnvelopeError"),
@@ -446,12 +475,22 @@ var (
 		"invalid ignore event type: '%s'",
 		errors.RFCCodeText("CDC:ErrInvalidIgnoreEventType"),
 	)
+	ErrInvalidForwardDDLClass = errors.Normalize(
+		"invalid forward ddl class: '%s'",
+		errors.RFCCodeText("CDC:ErrInvalidForwardDDLClass"),
+	)
 	ErrSyncRenameTableFailed = errors.Normalize(
 		"table's old name is not in filter rule, and its new name in filter rule "+
 			"table id '%d', ddl query: [%s], it's an unexpected behavior, "+
 			"if you want to replicate this table, please add its old name to filter rule.",
 		errors.RFCCodeText("CDC:ErrSyncRenameTableFailed"),
 	)
+	ErrSyncFlashbackClusterFailed = errors.Normalize(
+		"flashback cluster can't be replicated, ddl query: [%s], "+
+			"the upstream data is rolled back and the changefeed can not keep consistent with it, "+
+			"please restore the downstream to the same point and recreate the changefeed.",
+		errors.RFCCodeText("CDC:ErrSyncFlashbackClusterFailed"),
+	)
 
 	// changefeed config error
 	ErrInvalidReplicaConfig = errors.Normalize(
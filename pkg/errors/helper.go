@@ -86,6 +86,7 @@ var changefeedUnRetryableErrors = []*errors.Error{
 	ErrExpressionParseFailed,
 	ErrSchemaSnapshotNotFound,
 	ErrSyncRenameTableFailed,
+	ErrSyncFlashbackClusterFailed,
 	ErrChangefeedUnretryable,
 	ErrCorruptedDataMutation,
 	ErrDispatcherFailed,
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/pd/pkg/utils/tempurl"
@@ -117,7 +118,9 @@ type CDCEtcdClient interface {
 
 	GetEnsureGCServiceID(tag string) string
 
-	PutCaptureInfo(context.Context, *model.CaptureInfo, clientv3.LeaseID) error
+	GetNodeInfo(ctx context.Context, id model.CaptureID) (*node.Info, error)
+
+	PutCaptureInfo(context.Context, *node.Info, clientv3.LeaseID) error
 
 	DeleteCaptureInfo(context.Context, model.CaptureID) error
 
@@ -505,17 +508,44 @@ func (c *CDCEtcdClientImpl) SaveChangeFeedInfo(ctx context.Context,
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
 
+// GetNodeInfo get the node info of a capture from etcd, unlike GetCaptureInfo,
+// it keeps the fields which are not recognized by model.CaptureInfo, such as labels.
+// return ErrCaptureNotExist if the capture not exists.
+func (c *CDCEtcdClientImpl) GetNodeInfo(
+	ctx context.Context, id model.CaptureID,
+) (*node.Info, error) {
+	key := GetEtcdKeyCaptureInfo(c.ClusterID, id)
+
+	resp, err := c.Client.Get(ctx, key)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrPDEtcdAPIError, err)
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, errors.ErrCaptureNotExist.GenWithStackByArgs(key)
+	}
+
+	info := new(node.Info)
+	err = info.Unmarshal(resp.Kvs[0].Value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
 // PutCaptureInfo put capture info into etcd,
 // this happens when the capture starts.
+// The node info is a superset of model.CaptureInfo, so it can still be
+// decoded as a capture info by the etcd watchers.
 func (c *CDCEtcdClientImpl) PutCaptureInfo(
-	ctx context.Context, info *model.CaptureInfo, leaseID clientv3.LeaseID,
+	ctx context.Context, info *node.Info, leaseID clientv3.LeaseID,
 ) error {
 	data, err := info.Marshal()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	key := GetEtcdKeyCaptureInfo(c.ClusterID, info.ID)
+	key := GetEtcdKeyCaptureInfo(c.ClusterID, info.ID.String())
 	_, err = c.Client.Put(ctx, key, string(data), clientv3.WithLease(leaseID))
 	return errors.WrapError(errors.ErrPDEtcdAPIError, err)
 }
@@ -12,6 +12,7 @@ import (
 	common "github.com/pingcap/ticdc/pkg/common"
 	config "github.com/pingcap/ticdc/pkg/config"
 	etcd "github.com/pingcap/ticdc/pkg/etcd"
+	node "github.com/pingcap/ticdc/pkg/node"
 	model "github.com/pingcap/tiflow/cdc/model"
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -300,8 +301,23 @@ func (mr *MockCDCEtcdClientMockRecorder) GetUpstreamInfo(ctx, upstreamID, namesp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetUpstreamInfo), ctx, upstreamID, namespace)
 }
 
+// GetNodeInfo mocks base method.
+func (m *MockCDCEtcdClient) GetNodeInfo(ctx context.Context, id model.CaptureID) (*node.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeInfo", ctx, id)
+	ret0, _ := ret[0].(*node.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeInfo indicates an expected call of GetNodeInfo.
+func (mr *MockCDCEtcdClientMockRecorder) GetNodeInfo(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeInfo", reflect.TypeOf((*MockCDCEtcdClient)(nil).GetNodeInfo), ctx, id)
+}
+
 // PutCaptureInfo mocks base method.
-func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 *model.CaptureInfo, arg2 clientv3.LeaseID) error {
+func (m *MockCDCEtcdClient) PutCaptureInfo(arg0 context.Context, arg1 *node.Info, arg2 clientv3.LeaseID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PutCaptureInfo", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
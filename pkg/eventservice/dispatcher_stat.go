@@ -18,12 +18,15 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -40,6 +43,8 @@ type dispatcherStat struct {
 	// startTableInfo is the table info of the dispatcher when it is registered or reset.
 	startTableInfo atomic.Pointer[common.TableInfo]
 	filter         filter.Filter
+	// projection decides the columns decoded for the dispatcher.
+	projection *columnselector.Projection
 	// The reset ts send by the dispatcher.
 	// It is also the start ts of the dispatcher.
 	resetTs atomic.Uint64
@@ -51,6 +56,12 @@ type dispatcherStat struct {
 	// We use this value to generate data range for the next scan task.
 	// Note: Please don't changed this value directly, use updateSentResolvedTs instead.
 	sentResolvedTs atomic.Uint64
+	// targetTs is the target ts of the changefeed, the events and watermarks after it
+	// are never sent to the dispatcher. 0 means the changefeed has no target ts.
+	targetTs uint64
+	// replicationDelay is the duration the events are delayed before they are sent to the dispatcher,
+	// the events are kept in the event store until their commit time plus the delay is in the past.
+	replicationDelay time.Duration
 	// checkpointTs is the ts that reported by the downstream dispatcher.
 	// events <= checkpointTs will not needed anymore, so we can inform eventStore to GC them.
 	// TODO: maintain it
@@ -74,8 +85,16 @@ type dispatcherStat struct {
 	enableSyncPoint   bool
 	nextSyncPoint     uint64
 	syncPointInterval time.Duration
+	// syncPointSequences is the sequence values at the next sync point,
+	// they are loaded in background before the sync point is sent to the table trigger dispatcher.
+	syncPointSequences atomic.Pointer[syncPointSequences]
+	// loadingSyncPointSequences is set to true when the sequence values are being loaded.
+	loadingSyncPointSequences atomic.Bool
 
 	// Scan task related
+	// priorityLevel is the level of the changefeed priority, the scan tasks of the
+	// dispatchers with a smaller level are handled preferentially.
+	priorityLevel int
 	// taskScanning is used to indicate whether the scan task is running.
 	// If so, we should wait until it is done before we send next resolvedTs event of
 	// this dispatcher.
@@ -84,6 +103,19 @@ type dispatcherStat struct {
 	// isRemoved is used to indicate whether the dispatcher is removed.
 	// If so, we should ignore the errors related to this dispatcher.
 	isRemoved atomic.Bool
+
+	// initial snapshot related
+	// snapshotTs is the ts of the initial snapshot of the table, it is 0 if the
+	// dispatcher doesn't need an initial snapshot.
+	snapshotTs uint64
+	// snapshotNextKey is the key to continue the snapshot scan with.
+	// It is only accessed by the scan task of the dispatcher.
+	snapshotNextKey []byte
+	// snapshotFinished is set to true after all the rows of the snapshot are sent.
+	snapshotFinished atomic.Bool
+	// snapshotRestart is set to true when the dispatcher is reset to the snapshot ts,
+	// the snapshot should be sent from the beginning again.
+	snapshotRestart atomic.Bool
 }
 
 func newDispatcherStat(
@@ -94,14 +126,22 @@ func newDispatcherStat(
 	changefeedStatus *changefeedStatus,
 ) *dispatcherStat {
 	dispStat := &dispatcherStat{
-		id:             info.GetID(),
-		changefeedStat: changefeedStatus,
-		workerIndex:    workerIndex,
-		info:           info,
-		filter:         filter,
+		id:               info.GetID(),
+		changefeedStat:   changefeedStatus,
+		workerIndex:      workerIndex,
+		info:             info,
+		filter:           filter,
+		projection:       info.GetColumnProjection(),
+		priorityLevel:    info.GetPriority().Level(),
+		targetTs:         info.GetTargetTs(),
+		replicationDelay: info.GetReplicationDelay(),
 	}
 	changefeedStatus.addDispatcher()
 
+	if info.NeedInitialSnapshot() {
+		dispStat.snapshotTs = startTs
+	}
+
 	if info.SyncPointEnabled() {
 		dispStat.enableSyncPoint = true
 		dispStat.nextSyncPoint = info.GetSyncPointTs()
@@ -143,6 +183,17 @@ func (a *dispatcherStat) resetState(resetTs uint64) {
 	a.seq.Store(0)
 	a.taskScanning.Store(false)
 	a.isRunning.Store(true)
+	// The downstream wants the events from the snapshot ts again,
+	// so the snapshot must be resent.
+	if a.snapshotTs != 0 && resetTs <= a.snapshotTs {
+		a.snapshotFinished.Store(false)
+		a.snapshotRestart.Store(true)
+	}
+}
+
+// needSnapshotScan returns true if the initial snapshot of the dispatcher is not sent yet.
+func (a *dispatcherStat) needSnapshotScan() bool {
+	return a.snapshotTs != 0 && !a.snapshotFinished.Load()
 }
 
 // onResolvedTs try to update the resolved ts of the dispatcher.
@@ -164,7 +215,8 @@ func (a *dispatcherStat) getDataRange() (common.DataRange, bool) {
 		startTs = a.resetTs.Load()
 	}
 
-	if startTs >= a.eventStoreResolvedTs.Load() {
+	endTs := a.capByTargetTs(a.eventStoreResolvedTs.Load())
+	if startTs >= endTs {
 		return common.DataRange{}, false
 	}
 	// Range: (startTs, EndTs],
@@ -172,17 +224,55 @@ func (a *dispatcherStat) getDataRange() (common.DataRange, bool) {
 	r := common.DataRange{
 		Span:    a.info.GetTableSpan(),
 		StartTs: startTs,
-		EndTs:   a.eventStoreResolvedTs.Load(),
+		EndTs:   endTs,
 	}
 	return r, true
 }
 
+// capByReplicationDelay returns the smaller one of the ts and the max ts that can be sent
+// at the current time, considering the replication delay of the changefeed.
+func (a *dispatcherStat) capByReplicationDelay(ts uint64, currentTime time.Time) uint64 {
+	if a.replicationDelay <= 0 {
+		return ts
+	}
+	delayedTs := oracle.GoTimeToTS(currentTime.Add(-a.replicationDelay))
+	if ts > delayedTs {
+		return delayedTs
+	}
+	return ts
+}
+
+// capByTargetTs returns the smaller one of the ts and the target ts of the changefeed.
+func (a *dispatcherStat) capByTargetTs(ts uint64) uint64 {
+	if a.targetTs != 0 && ts > a.targetTs {
+		return a.targetTs
+	}
+	return ts
+}
+
+// needSyncPointSequences returns true if the sync point events sent to the dispatcher carry the sequence values,
+// the sync point is written by the table trigger dispatcher, so only it needs them.
+func (a *dispatcherStat) needSyncPointSequences() bool {
+	return a.enableSyncPoint && a.info.GetTableSpan().Equal(heartbeatpb.DDLSpan)
+}
+
+// getFollowingSyncPoint returns the sync point after the next sync point.
+func (a *dispatcherStat) getFollowingSyncPoint() uint64 {
+	return oracle.GoTimeToTS(oracle.GetTimeFromTS(a.nextSyncPoint).Add(a.syncPointInterval))
+}
+
 func (a *dispatcherStat) IsRunning() bool {
 	return a.isRunning.Load() && a.changefeedStat.isRunning.Load()
 }
 
 type scanTask = *dispatcherStat
 
+// syncPointSequences is the values of the sequences at a sync point.
+type syncPointSequences struct {
+	syncPointTs uint64
+	values      []pevent.SequenceValue
+}
+
 func (t scanTask) GetKey() common.DispatcherID {
 	return t.id
 }
@@ -15,11 +15,13 @@ package eventservice
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 func TestNewDispatcherStat(t *testing.T) {
@@ -107,6 +109,51 @@ func TestDispatcherStatGetDataRange(t *testing.T) {
 	require.Equal(t, uint64(150), r.StartTs)
 }
 
+func TestDispatcherStatGetDataRangeWithTargetTs(t *testing.T) {
+	t.Parallel()
+
+	info := newMockDispatcherInfo(t, common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.targetTs = 180
+	changefeedStatus := &changefeedStatus{
+		changefeedID: info.GetChangefeedID(),
+	}
+	stat := newDispatcherStat(100, info, info.filter, 1, changefeedStatus)
+	stat.eventStoreResolvedTs.Store(200)
+
+	// The end ts is capped by the target ts
+	r, ok := stat.getDataRange()
+	require.True(t, ok)
+	require.Equal(t, uint64(100), r.StartTs)
+	require.Equal(t, uint64(180), r.EndTs)
+
+	// No more data range after the target ts is sent
+	stat.sentResolvedTs.Store(180)
+	_, ok = stat.getDataRange()
+	require.False(t, ok)
+}
+
+func TestDispatcherStatCapByReplicationDelay(t *testing.T) {
+	t.Parallel()
+
+	info := newMockDispatcherInfo(t, common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	changefeedStatus := &changefeedStatus{
+		changefeedID: info.GetChangefeedID(),
+	}
+	now := time.Now()
+	ts := oracle.GoTimeToTS(now)
+
+	// No replication delay
+	stat := newDispatcherStat(100, info, info.filter, 1, changefeedStatus)
+	require.Equal(t, ts, stat.capByReplicationDelay(ts, now))
+
+	// The events within the delay are held
+	info.replicationDelay = time.Hour
+	stat = newDispatcherStat(100, info, info.filter, 1, changefeedStatus)
+	require.Equal(t, oracle.GoTimeToTS(now.Add(-time.Hour)), stat.capByReplicationDelay(ts, now))
+	oldTs := oracle.GoTimeToTS(now.Add(-2 * time.Hour))
+	require.Equal(t, oldTs, stat.capByReplicationDelay(oldTs, now))
+}
+
 func TestDispatcherStatUpdateWatermark(t *testing.T) {
 	startTs := uint64(100)
 	info := newMockDispatcherInfo(t, common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
@@ -23,16 +23,17 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
+	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
+	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -45,6 +46,8 @@ const (
 
 	defaultMaxBatchSize            = 128
 	defaultFlushResolvedTsInterval = 25 * time.Millisecond
+	// defaultSnapshotBatchSize is the max number of rows in a dml event of the initial snapshot.
+	defaultSnapshotBatchSize = 1024
 )
 
 var (
@@ -69,7 +72,9 @@ type eventBroker struct {
 	// eventStore is the source of the events, eventBroker get the events from the eventStore.
 	eventStore  eventstore.EventStore
 	schemaStore schemastore.SchemaStore
-	mounter     pevent.Mounter
+	// snapshotReader is used to read the initial snapshot of the tables.
+	snapshotReader logpuller.SnapshotReader
+	mounter        pevent.Mounter
 	// msgSender is used to send the events to the dispatchers.
 	msgSender messaging.MessageSender
 	pdClock   pdutil.Clock
@@ -80,8 +85,9 @@ type eventBroker struct {
 	dispatchers sync.Map
 	// dispatcherID -> dispatcherStat map, track all table trigger dispatchers.
 	tableTriggerDispatchers sync.Map
-	// taskChan is used to send the scan tasks to the scan workers.
-	taskChan chan scanTask
+	// taskChan is used to send the scan tasks to the scan workers, it is indexed
+	// by the priority level of the changefeed.
+	taskChan [config.ChangefeedPriorityLevelCount]chan scanTask
 
 	// sendMessageWorkerCount is the number of the send message workers to spawn.
 	sendMessageWorkerCount int
@@ -92,6 +98,10 @@ type eventBroker struct {
 	// and a goroutine is responsible for sending the message to the dispatchers.
 	messageCh []chan *wrapEvent
 
+	// syncPointSequenceCh is used to send the table trigger dispatchers whose sequence values
+	// at the next sync point need to be loaded, the values are read from the upstream in background.
+	syncPointSequenceCh chan syncPointSequenceTask
+
 	// cancel is used to cancel the goroutines spawned by the eventBroker.
 	cancel context.CancelFunc
 	g      *errgroup.Group
@@ -107,6 +117,8 @@ func newEventBroker(
 	id uint64,
 	eventStore eventstore.EventStore,
 	schemaStore schemastore.SchemaStore,
+	snapshotReader logpuller.SnapshotReader,
+	pdClock pdutil.Clock,
 	mc messaging.MessageSender,
 	tz *time.Location,
 ) *eventBroker {
@@ -123,23 +135,20 @@ func newEventBroker(
 	g, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 
-	// TODO: Retrieve the correct pdClock from the context once multiple upstreams are supported.
-	// For now, since there is only one upstream, using the default pdClock is sufficient.
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
-
 	c := &eventBroker{
 		tidbClusterID:           id,
 		eventStore:              eventStore,
 		pdClock:                 pdClock,
 		mounter:                 pevent.NewMounter(tz),
 		schemaStore:             schemaStore,
+		snapshotReader:          snapshotReader,
 		changefeedMap:           sync.Map{},
 		dispatchers:             sync.Map{},
 		tableTriggerDispatchers: sync.Map{},
 		msgSender:               mc,
-		taskChan:                make(chan scanTask, conf.ScanTaskQueueSize),
 		sendMessageWorkerCount:  sendMessageWorkerCount,
 		messageCh:               make([]chan *wrapEvent, sendMessageWorkerCount),
+		syncPointSequenceCh:     make(chan syncPointSequenceTask, basicChannelSize),
 		scanWorkerCount:         scanWorkerCount,
 		cancel:                  cancel,
 		g:                       g,
@@ -150,6 +159,10 @@ func newEventBroker(
 		metricEventServiceSentResolvedTs:     metrics.EventServiceResolvedTsLagGauge.WithLabelValues("sent"),
 	}
 
+	for i := range c.taskChan {
+		c.taskChan[i] = make(chan scanTask, conf.ScanTaskQueueSize)
+	}
+
 	for i := 0; i < c.sendMessageWorkerCount; i++ {
 		c.messageCh[i] = make(chan *wrapEvent, basicChannelSize*4)
 	}
@@ -166,6 +179,11 @@ func newEventBroker(
 		return nil
 	})
 
+	g.Go(func() error {
+		c.runSyncPointSequenceLoader(ctx)
+		return nil
+	})
+
 	g.Go(func() error {
 		c.logUnresetDispatchers(ctx)
 		return nil
@@ -191,12 +209,16 @@ func newEventBroker(
 	return c
 }
 
+// sendWatermark sends the watermark to the dispatcher, it returns false if the watermark
+// is held since the sync point before it can't be emitted yet.
 func (c *eventBroker) sendWatermark(
 	server node.ID,
 	d *dispatcherStat,
 	watermark uint64,
-) {
-	c.emitSyncPointEventIfNeeded(watermark, d, server)
+) bool {
+	if !c.emitSyncPointEventIfNeeded(watermark, d, server) {
+		return false
+	}
 	re := pevent.NewResolvedEvent(watermark, d.id)
 	resolvedEvent := newWrapResolvedEvent(
 		server,
@@ -204,6 +226,7 @@ func (c *eventBroker) sendWatermark(
 		d.getEventSenderState())
 	c.getMessageCh(d.workerIndex) <- resolvedEvent
 	metricEventServiceSendResolvedTsCount.Inc()
+	return true
 }
 
 func (c *eventBroker) sendReadyEvent(
@@ -233,14 +256,86 @@ func (c *eventBroker) getMessageCh(workerIndex int) chan *wrapEvent {
 }
 
 func (c *eventBroker) runScanWorker(ctx context.Context) {
+	picker := newScanTaskPicker()
 	for {
+		// Handle the pending tasks of the higher priority changefeeds preferentially.
+		if task, ok := c.pollScanTask(picker); ok {
+			c.doScan(ctx, task)
+			continue
+		}
 		select {
 		case <-ctx.Done():
 			return
-		case task := <-c.taskChan:
+		case task := <-c.taskChan[0]:
+			c.doScan(ctx, task)
+		case task := <-c.taskChan[1]:
 			c.doScan(ctx, task)
+		case task := <-c.taskChan[2]:
+			c.doScan(ctx, task)
+		}
+	}
+}
+
+// scanTaskWeights is the number of the scan tasks picked from each priority level in a
+// round, indexed by the priority level.
+var scanTaskWeights = [config.ChangefeedPriorityLevelCount]int{4, 2, 1}
+
+// scanTaskPicker picks the pending scan tasks by weighted round-robin across the priority
+// levels. The higher priority tasks are picked first in a round, but a level is picked at
+// most its weight times in a round, so the lower priority changefeeds still get the scan
+// slots when the higher priority ones are busy.
+// Each scan worker has its own picker.
+type scanTaskPicker struct {
+	credits [config.ChangefeedPriorityLevelCount]int
+}
+
+func newScanTaskPicker() *scanTaskPicker {
+	return &scanTaskPicker{credits: scanTaskWeights}
+}
+
+// pollScanTask returns a pending scan task picked by the picker without blocking.
+func (c *eventBroker) pollScanTask(picker *scanTaskPicker) (scanTask, bool) {
+	for round := 0; round < 2; round++ {
+		for level, ch := range c.taskChan {
+			if picker.credits[level] <= 0 {
+				continue
+			}
+			select {
+			case task := <-ch:
+				picker.credits[level]--
+				return task, true
+			default:
+			}
 		}
+		// the levels with credits have no pending task, start a new round
+		picker.credits = scanTaskWeights
 	}
+	return nil, false
+}
+
+// pushScanTask pushes the scan task to the task queue of its changefeed priority.
+func (c *eventBroker) pushScanTask(task scanTask) {
+	c.taskChan[task.priorityLevel] <- task
+}
+
+// tryPushScanTask pushes the scan task to the task queue without blocking,
+// it returns false if the task queue is full.
+func (c *eventBroker) tryPushScanTask(task scanTask) bool {
+	select {
+	case c.taskChan[task.priorityLevel] <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// pendingScanTaskCount returns the number of the pending scan tasks of all priorities.
+func (c *eventBroker) pendingScanTaskCount() int {
+	count := 0
+	for _, ch := range c.taskChan {
+		count += len(ch)
+	}
+	return count
 }
 
 // TODO: maybe event driven model is better. It is coupled with the detail implementation of
@@ -268,13 +363,35 @@ func (c *eventBroker) tickTableTriggerDispatchers(ctx context.Context) {
 				if err != nil {
 					log.Panic("get table trigger events failed", zap.Error(err))
 				}
+				// The ddl events after the target ts of the changefeed are never sent,
+				// and the ddl events are delayed as the dml events if the replication delay is set.
+				maxTs := dispatcherStat.capByReplicationDelay(dispatcherStat.capByTargetTs(endTs), c.pdClock.CurrentTime())
+				maxTs = c.capBySyncPointSequences(dispatcherStat, maxTs)
 				for _, e := range ddlEvents {
-					c.sendDDL(ctx, remoteID, e, dispatcherStat)
+					if e.FinishedTs > maxTs {
+						break
+					}
+					if isSequenceDDL(&e) && !dispatcherStat.enableSyncPoint {
+						// The sequence values are only synced at the sync points, a sequence replicated
+						// without its values collides with the replicated ids after failover.
+						log.Warn("sync point is disabled, skip the sequence ddl",
+							zap.Stringer("dispatcher", dispatcherStat.id),
+							zap.String("query", e.Query),
+							zap.Uint64("commitTs", e.FinishedTs))
+						continue
+					}
+					if !c.sendDDL(ctx, remoteID, e, dispatcherStat) {
+						// The ddl is held by the sync point, the ddls before it are all sent.
+						maxTs = min(maxTs, dispatcherStat.nextSyncPoint)
+						break
+					}
 				}
+				endTs = maxTs
 				if endTs > startTs {
 					// After all the events are sent, we send the watermark to the dispatcher.
-					c.sendWatermark(remoteID, dispatcherStat, endTs)
-					dispatcherStat.updateSentResolvedTs(endTs)
+					if c.sendWatermark(remoteID, dispatcherStat, endTs) {
+						dispatcherStat.updateSentResolvedTs(endTs)
+					}
 				}
 				return true
 			})
@@ -301,8 +418,12 @@ func (c *eventBroker) logUnresetDispatchers(ctx context.Context) {
 	}
 }
 
-func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DDLEvent, d *dispatcherStat) {
-	c.emitSyncPointEventIfNeeded(e.FinishedTs, d, remoteID)
+// sendDDL sends the ddl event to the dispatcher, it returns false if the ddl event
+// is held since the sync point before it can't be emitted yet.
+func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DDLEvent, d *dispatcherStat) bool {
+	if !c.emitSyncPointEventIfNeeded(e.FinishedTs, d, remoteID) {
+		return false
+	}
 	e.DispatcherID = d.id
 	e.Seq = d.seq.Add(1)
 	log.Info("send ddl event to dispatcher",
@@ -315,10 +436,10 @@ func (c *eventBroker) sendDDL(ctx context.Context, remoteID node.ID, e pevent.DD
 	ddlEvent := newWrapDDLEvent(remoteID, &e, d.getEventSenderState())
 	select {
 	case <-ctx.Done():
-		return
 	case c.getMessageCh(d.workerIndex) <- ddlEvent:
 		metricEventServiceSendDDLCount.Inc()
 	}
+	return true
 }
 
 // checkNeedScan checks if the dispatcher needs to scan the event store.
@@ -346,6 +467,12 @@ func (c *eventBroker) checkNeedScan(task scanTask, mustCheck bool) (bool, common
 		return false, common.DataRange{}
 	}
 
+	// The initial snapshot must be sent before any event after the snapshot ts,
+	// so no watermark is sent to the dispatcher until then.
+	if task.needSnapshotScan() {
+		return true, common.DataRange{}
+	}
+
 	// 1. Get the data range of the dispatcher.
 	dataRange, needScan := task.getDataRange()
 	if !needScan {
@@ -358,6 +485,10 @@ func (c *eventBroker) checkNeedScan(task scanTask, mustCheck bool) (bool, common
 		dataRange.EndTs = ddlState.ResolvedTs
 	}
 
+	// 3. Constrain the data range by the replication delay of the changefeed,
+	// the delayed events are kept in the event store and scanned later.
+	dataRange.EndTs = task.capByReplicationDelay(dataRange.EndTs, c.pdClock.CurrentTime())
+
 	// Note: Maybe we should still send a resolvedTs to downstream to tell that
 	// the dispatcher is alive?
 	if dataRange.EndTs <= dataRange.StartTs {
@@ -413,19 +544,102 @@ func (c *eventBroker) checkAndSendHandshake(task scanTask) bool {
 // emitSyncPointEventIfNeeded emits a sync point event if the current ts is greater than the next sync point, and updates the next sync point.
 // We need call this function every time we send a event(whether dml/ddl/resolvedTs),
 // thus to ensure the sync point event is in correct order for each dispatcher.
-func (c *eventBroker) emitSyncPointEventIfNeeded(ts uint64, d *dispatcherStat, remoteID node.ID) {
+// It returns false if the sync point event can't be emitted yet since the sequence values
+// at the sync point are not loaded, the caller must hold the event until they are loaded.
+func (c *eventBroker) emitSyncPointEventIfNeeded(ts uint64, d *dispatcherStat, remoteID node.ID) bool {
 	if d.enableSyncPoint && ts > d.nextSyncPoint {
 		// Send the sync point event.
-		syncPointEvent := newWrapSyncPointEvent(
-			remoteID,
-			&pevent.SyncPointEvent{
-				DispatcherID: d.id,
-				CommitTs:     d.nextSyncPoint,
-			},
-			d.getEventSenderState())
+		e := &pevent.SyncPointEvent{
+			DispatcherID: d.id,
+			CommitTs:     d.nextSyncPoint,
+		}
+		// The sync point is written by the table trigger dispatcher,
+		// so the sequence values only need to be sent to it.
+		if d.needSyncPointSequences() {
+			sequences := d.syncPointSequences.Load()
+			if sequences == nil || sequences.syncPointTs != d.nextSyncPoint {
+				c.loadSyncPointSequences(d, d.nextSyncPoint)
+				return false
+			}
+			e.Sequences = sequences.values
+		}
+		syncPointEvent := newWrapSyncPointEvent(remoteID, e, d.getEventSenderState())
 		c.getMessageCh(d.workerIndex) <- syncPointEvent
-		d.nextSyncPoint = oracle.GoTimeToTS(oracle.GetTimeFromTS(d.nextSyncPoint).Add(d.syncPointInterval))
+		d.nextSyncPoint = d.getFollowingSyncPoint()
+	}
+	return true
+}
+
+// isSequenceDDL returns true if the ddl creates, alters or drops a sequence.
+func isSequenceDDL(e *pevent.DDLEvent) bool {
+	switch e.GetDDLType() {
+	case model.ActionCreateSequence, model.ActionAlterSequence, model.ActionDropSequence:
+		return true
+	}
+	return false
+}
+
+// capBySyncPointSequences returns the max ts of the events that can be sent to the dispatcher.
+// The events after the next sync point are held until the sequence values at the sync point are loaded,
+// and only one sync point is sent at a time, so the events after the following sync point are always held.
+func (c *eventBroker) capBySyncPointSequences(d *dispatcherStat, ts uint64) uint64 {
+	if !d.needSyncPointSequences() || ts <= d.nextSyncPoint {
+		return ts
+	}
+	sequences := d.syncPointSequences.Load()
+	if sequences == nil || sequences.syncPointTs != d.nextSyncPoint {
+		c.loadSyncPointSequences(d, d.nextSyncPoint)
+		return d.nextSyncPoint
 	}
+	return min(ts, d.getFollowingSyncPoint())
+}
+
+type syncPointSequenceTask struct {
+	dispatcher  *dispatcherStat
+	syncPointTs uint64
+}
+
+// loadSyncPointSequences asks the loader to read the sequence values at the sync point,
+// it does nothing if the values of the dispatcher are being loaded.
+func (c *eventBroker) loadSyncPointSequences(d *dispatcherStat, syncPointTs uint64) {
+	if !d.loadingSyncPointSequences.CompareAndSwap(false, true) {
+		return
+	}
+	select {
+	case c.syncPointSequenceCh <- syncPointSequenceTask{dispatcher: d, syncPointTs: syncPointTs}:
+	default:
+		// try again in the next tick
+		d.loadingSyncPointSequences.Store(false)
+	}
+}
+
+func (c *eventBroker) runSyncPointSequenceLoader(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-c.syncPointSequenceCh:
+			c.doLoadSyncPointSequences(task)
+		}
+	}
+}
+
+func (c *eventBroker) doLoadSyncPointSequences(task syncPointSequenceTask) {
+	d := task.dispatcher
+	defer d.loadingSyncPointSequences.Store(false)
+	values, err := c.schemaStore.GetSequenceValues(task.syncPointTs, d.filter)
+	if err != nil {
+		// The events after the sync point are held until the values are loaded, so just retry it later.
+		log.Warn("get sequence values failed, retry later",
+			zap.Stringer("dispatcher", d.id),
+			zap.Uint64("syncPointTs", task.syncPointTs),
+			zap.Error(err))
+		return
+	}
+	d.syncPointSequences.Store(&syncPointSequences{
+		syncPointTs: task.syncPointTs,
+		values:      values,
+	})
 }
 
 // TODO: handle error properly.
@@ -434,7 +648,14 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 	remoteID := node.ID(task.info.GetServerID())
 	dispatcherID := task.id
 
+	// requeue is true if the task has more data to scan, it's pushed back to the task
+	// queue, so the scan of a big table doesn't block the other dispatchers.
+	// If the queue is full, the scan is continued by the next notification.
+	requeue := false
 	defer func() {
+		if requeue && c.tryPushScanTask(task) {
+			return
+		}
 		task.taskScanning.Store(false)
 	}()
 
@@ -453,6 +674,22 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		return
 	}
 
+	if task.needSnapshotScan() {
+		hasMore, err := c.doSnapshotScan(ctx, task)
+		if err != nil {
+			// The scan is retried by the next scan task of the dispatcher.
+			log.Warn("scan the initial snapshot failed, retry later",
+				zap.Stringer("changefeedID", task.changefeedStat.changefeedID),
+				zap.Stringer("dispatcherID", task.id),
+				zap.String("span", common.FormatTableSpan(task.info.GetTableSpan())),
+				zap.Uint64("snapshotTs", task.snapshotTs),
+				zap.Error(err))
+			return
+		}
+		requeue = hasMore
+		return
+	}
+
 	// TODO: distinguish only dml or only ddl scenario
 	ddlEvents, err := c.schemaStore.
 		FetchTableDDLEvents(
@@ -532,6 +769,11 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		return true
 	}
 
+	decode := c.mounter.DecodeToChunk
+	if task.projection.DeleteOnlyHandleKeyColumns() {
+		decode = c.mounter.DecodeToChunkDeleteOnlyHandleKey
+	}
+
 	// 3. Send the events to the dispatcher.
 	var dml *pevent.DMLEvent
 	for {
@@ -575,10 +817,67 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 				}
 				log.Panic("get table info failed, unknown reason", zap.Error(err))
 			}
+			// Only decode the columns needed by the dispatcher.
+			tableInfo = task.projection.ProjectTableInfo(tableInfo)
 			dml = pevent.NewDMLEvent(dispatcherID, tableID, e.StartTs, e.CRTs, tableInfo)
 		}
-		dml.AppendRow(e, c.mounter.DecodeToChunk)
+		dml.AppendRow(e, decode)
+	}
+}
+
+// doSnapshotScan sends a batch of the rows of the table at the snapshot ts to the dispatcher as insert events.
+// It returns true if there are more rows to scan, they are scanned by the following scan tasks,
+// which continue from the last sent row.
+func (c *eventBroker) doSnapshotScan(ctx context.Context, task scanTask) (bool, error) {
+	remoteID := node.ID(task.info.GetServerID())
+	span := task.info.GetTableSpan()
+	snapshotTs := task.snapshotTs
+	if task.snapshotRestart.CompareAndSwap(true, false) {
+		task.snapshotNextKey = nil
+	}
+
+	tableInfo, err := c.schemaStore.GetTableInfo(span.TableID, snapshotTs)
+	if err != nil {
+		if task.isRemoved.Load() {
+			log.Warn("get table info failed, since the dispatcher is removed", zap.Error(err))
+			return false, nil
+		} else if errors.Is(err, &schemastore.TableDeletedError{}) {
+			// There are no rows to send if the table is deleted.
+			log.Warn("get table info failed, since the table is deleted", zap.Error(err))
+			task.snapshotFinished.Store(true)
+			return false, nil
+		}
+		return false, err
+	}
+	// Only decode the columns needed by the dispatcher.
+	tableInfo = task.projection.ProjectTableInfo(tableInfo)
+
+	rows, nextKey, err := c.snapshotReader.Scan(ctx, span, snapshotTs, task.snapshotNextKey, defaultSnapshotBatchSize)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) > 0 {
+		dml := pevent.NewDMLEvent(task.id, span.TableID, snapshotTs, snapshotTs, tableInfo)
+		for _, row := range rows {
+			if err = dml.AppendRow(row, c.mounter.DecodeToChunk); err != nil {
+				return false, err
+			}
+		}
+		dml.Seq = task.seq.Add(1)
+		c.getMessageCh(task.workerIndex) <- newWrapDMLEvent(remoteID, dml, task.getEventSenderState())
+		metricEventServiceSendKvCount.Add(float64(dml.Len()))
+	}
+	task.snapshotNextKey = nextKey
+	if nextKey != nil {
+		return true, nil
 	}
+	task.snapshotFinished.Store(true)
+	log.Info("initial snapshot is sent to the dispatcher",
+		zap.Stringer("changefeedID", task.changefeedStat.changefeedID),
+		zap.Stringer("dispatcherID", task.id),
+		zap.String("span", common.FormatTableSpan(span)),
+		zap.Uint64("snapshotTs", snapshotTs))
+	return false, nil
 }
 
 func (c *eventBroker) runSendMessageWorker(ctx context.Context, workerIndex int) {
@@ -743,7 +1042,7 @@ func (c *eventBroker) updateMetrics(ctx context.Context) {
 			c.metricEventServiceResolvedTsLag.Set(lag)
 			lag = float64(oracle.GetPhysical(pdTime)-oracle.ExtractPhysical(sentMinWaterMark)) / 1e3
 			c.metricEventServiceSentResolvedTs.Set(lag)
-			metricEventBrokerPendingScanTaskCount.Set(float64(len(c.taskChan)))
+			metricEventBrokerPendingScanTaskCount.Set(float64(c.pendingScanTaskCount()))
 		}
 	}
 }
@@ -784,7 +1083,7 @@ func (c *eventBroker) onNotify(d *dispatcherStat, resolvedTs uint64, latestCommi
 		needScan, _ := c.checkNeedScan(d, false)
 		if needScan {
 			d.taskScanning.Store(true)
-			c.taskChan <- d
+			c.pushScanTask(d)
 		}
 	}
 }
@@ -15,6 +15,7 @@ package eventservice
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -22,12 +23,13 @@ import (
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
 )
 
 func newTableSpan(tableID int64, start, end string) *heartbeatpb.TableSpan {
@@ -40,11 +42,10 @@ func newTableSpan(tableID int64, start, end string) *heartbeatpb.TableSpan {
 
 func newEventBrokerForTest() (*eventBroker, *mockEventStore, *mockSchemaStore) {
 	mockPDClock := pdutil.NewClock4Test()
-	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
 	es := newMockEventStore(100)
 	ss := newMockSchemaStore()
 	mc := newMockMessageCenter()
-	return newEventBroker(context.Background(), 1, es, ss, mc, time.UTC), es, ss
+	return newEventBroker(context.Background(), 1, es, ss, newMockSnapshotReader(), mockPDClock, mc, time.UTC), es, ss
 }
 
 func newMockDispatcherInfoForTest(t *testing.T) *mockDispatcherInfo {
@@ -135,7 +136,7 @@ func TestOnNotify(t *testing.T) {
 	broker.onNotify(disp, notifyMsgs3.resolvedTs, notifyMsgs3.latestCommitTs)
 	require.Equal(t, uint64(102), disp.eventStoreResolvedTs.Load())
 	require.True(t, disp.taskScanning.Load())
-	task := <-broker.taskChan
+	task := <-broker.taskChan[disp.priorityLevel]
 	require.Equal(t, task.id, disp.id)
 	log.Info("Pass case 3")
 	// Case 4: When the scan task is running, even there is a large latestCommitTs,
@@ -146,7 +147,7 @@ func TestOnNotify(t *testing.T) {
 	after := time.After(50 * time.Millisecond)
 	select {
 	case <-after:
-	case <-broker.taskChan:
+	case <-broker.taskChan[disp.priorityLevel]:
 		require.Fail(t, "should not trigger a new scan task")
 	}
 	log.Info("Pass case 4")
@@ -228,3 +229,236 @@ func TestHandleResolvedTs(t *testing.T) {
 	msg := <-mc.messageCh
 	require.Equal(t, msg.Type, messaging.TypeBatchResolvedTs)
 }
+
+func TestScanTaskPriority(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	// Close the broker, so the scan workers don't consume the tasks.
+	broker.close()
+
+	newDispatcher := func(priority config.ChangefeedPriority) *dispatcherStat {
+		info := newMockDispatcherInfoForTest(t)
+		info.priority = priority
+		changefeedStatus := broker.getOrSetChangefeedStatus(info.GetChangefeedID())
+		return newDispatcherStat(100, info, nil, 0, changefeedStatus)
+	}
+	batch := newDispatcher(config.ChangefeedPriorityBatch)
+	normal := newDispatcher("")
+	high := newDispatcher(config.ChangefeedPriorityHigh)
+
+	broker.pushScanTask(batch)
+	broker.pushScanTask(normal)
+	broker.pushScanTask(high)
+	require.Equal(t, 3, broker.pendingScanTaskCount())
+
+	picker := newScanTaskPicker()
+	for _, expected := range []*dispatcherStat{high, normal, batch} {
+		task, ok := broker.pollScanTask(picker)
+		require.True(t, ok)
+		require.Equal(t, expected.id, task.id)
+	}
+	_, ok := broker.pollScanTask(picker)
+	require.False(t, ok)
+}
+
+func TestScanTaskWeightedRoundRobin(t *testing.T) {
+	broker, _, _ := newEventBrokerForTest()
+	// Close the broker, so the scan workers don't consume the tasks.
+	broker.close()
+
+	newDispatcher := func(priority config.ChangefeedPriority) *dispatcherStat {
+		info := newMockDispatcherInfoForTest(t)
+		info.priority = priority
+		changefeedStatus := broker.getOrSetChangefeedStatus(info.GetChangefeedID())
+		return newDispatcherStat(100, info, nil, 0, changefeedStatus)
+	}
+	priorities := []config.ChangefeedPriority{
+		config.ChangefeedPriorityHigh, config.ChangefeedPriorityNormal, config.ChangefeedPriorityBatch,
+	}
+	for i := 0; i < 10; i++ {
+		for _, priority := range priorities {
+			broker.pushScanTask(newDispatcher(priority))
+		}
+	}
+
+	picker := newScanTaskPicker()
+	pollRound := func() map[int]int {
+		picked := make(map[int]int)
+		for i := 0; i < 7; i++ {
+			task, ok := broker.pollScanTask(picker)
+			require.True(t, ok)
+			picked[task.priorityLevel]++
+		}
+		return picked
+	}
+	// All levels are busy, the lower priority levels still get the scan slots.
+	require.Equal(t, map[int]int{0: 4, 1: 2, 2: 1}, pollRound())
+	require.Equal(t, map[int]int{0: 4, 1: 2, 2: 1}, pollRound())
+	// The high priority level only has 2 pending tasks, a new round starts after
+	// the other levels use up their credits.
+	require.Equal(t, map[int]int{0: 2, 1: 4, 2: 1}, pollRound())
+
+	// The batch tasks are drained eventually.
+	for {
+		task, ok := broker.pollScanTask(picker)
+		if !ok {
+			break
+		}
+		require.NotEqual(t, 0, task.priorityLevel)
+	}
+	require.Equal(t, 0, broker.pendingScanTaskCount())
+}
+
+func TestInitialSnapshotScan(t *testing.T) {
+	broker, _, ss := newEventBrokerForTest()
+	// Close the broker, so we can catch all message in the test.
+	broker.close()
+
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+	ddlEvent, kvEvents := genEvents(helper, t, `create table test.t(id int primary key, c char(50))`, []string{
+		`insert into test.t(id,c) values (0, "c0")`,
+		`insert into test.t(id,c) values (1, "c1")`,
+		`insert into test.t(id,c) values (2, "c2")`,
+	}...)
+	tableID := ddlEvent.TableID
+	ss.AppendDDLEvent(tableID, ddlEvent)
+	reader := broker.snapshotReader.(*mockSnapshotReader)
+	reader.rows[tableID] = kvEvents
+	reader.batchSize = 2
+
+	snapshotTs := kvEvents[len(kvEvents)-1].CRTs
+	info := newMockDispatcherInfo(t, common.NewDispatcherID(), tableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.startTs = snapshotTs
+	info.initialSnapshot = true
+	changefeedStatus := broker.getOrSetChangefeedStatus(info.GetChangefeedID())
+	disp := newDispatcherStat(snapshotTs, info, nil, 0, changefeedStatus)
+	disp.resetState(snapshotTs)
+	disp.isHandshaked.Store(true)
+	disp.onResolvedTs(snapshotTs + 10)
+
+	receiveSnapshotRows := func() []uint64 {
+		var seqs []uint64
+		rows := 0
+		for rows < len(kvEvents) {
+			msg := <-broker.getMessageCh(disp.workerIndex)
+			dml, ok := msg.e.(*event.DMLEvent)
+			require.True(t, ok, "only snapshot rows are expected before the snapshot is sent")
+			require.Equal(t, snapshotTs, dml.CommitTs)
+			rows += int(dml.Len())
+			seqs = append(seqs, dml.Seq)
+		}
+		require.Equal(t, len(kvEvents), rows)
+		return seqs
+	}
+
+	picker := newScanTaskPicker()
+	// runScanTasks runs the scan task and the requeued ones until the task queue is empty.
+	runScanTasks := func() {
+		broker.doScan(context.Background(), disp)
+		for {
+			task, ok := broker.pollScanTask(picker)
+			if !ok {
+				return
+			}
+			broker.doScan(context.Background(), task)
+		}
+	}
+
+	// The snapshot must be sent before any watermark after the snapshot ts.
+	needScan, _ := broker.checkNeedScan(disp, true)
+	require.True(t, needScan)
+	// Each scan task only sends a batch of rows, and the task is requeued to scan the rest.
+	disp.taskScanning.Store(true)
+	broker.doScan(context.Background(), disp)
+	require.Len(t, broker.getMessageCh(disp.workerIndex), 1)
+	require.Equal(t, 1, broker.pendingScanTaskCount())
+	require.True(t, disp.taskScanning.Load())
+	require.True(t, disp.needSnapshotScan())
+
+	// The requeued task doesn't scan if the dispatcher is paused.
+	disp.isRunning.Store(false)
+	task, ok := broker.pollScanTask(picker)
+	require.True(t, ok)
+	broker.doScan(context.Background(), task)
+	require.False(t, disp.taskScanning.Load())
+	require.Equal(t, 0, broker.pendingScanTaskCount())
+	require.True(t, disp.needSnapshotScan())
+	disp.isRunning.Store(true)
+
+	// The scan continues from the last sent row after the dispatcher is resumed.
+	runScanTasks()
+	msg := <-broker.getMessageCh(disp.workerIndex)
+	require.Equal(t, uint64(1), msg.e.(*event.DMLEvent).Seq)
+	// Drain the watermark sent when the dispatcher is paused.
+	msg = <-broker.getMessageCh(disp.workerIndex)
+	require.Equal(t, snapshotTs, msg.resolvedTsEvent.ResolvedTs)
+	msg = <-broker.getMessageCh(disp.workerIndex)
+	require.Equal(t, uint64(2), msg.e.(*event.DMLEvent).Seq)
+	require.EqualValues(t, 1, msg.e.(*event.DMLEvent).Len())
+	require.False(t, disp.needSnapshotScan())
+	require.Equal(t, snapshotTs, disp.sentResolvedTs.Load())
+
+	// The snapshot is sent again if the dispatcher is reset to the snapshot ts.
+	disp.resetState(snapshotTs)
+	disp.isHandshaked.Store(true)
+	require.True(t, disp.needSnapshotScan())
+	runScanTasks()
+	require.Len(t, receiveSnapshotRows(), 2)
+	require.False(t, disp.needSnapshotScan())
+}
+
+func TestSyncPointSequencesLoadedInBackground(t *testing.T) {
+	broker, _, ss := newEventBrokerForTest()
+	// Close the broker, so we can catch all message in the test.
+	broker.close()
+
+	syncPointTs := oracle.GoTimeToTS(time.Now())
+	info := newMockDispatcherInfo(t, common.NewDispatcherID(), heartbeatpb.DDLSpan.TableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.span = heartbeatpb.DDLSpan
+	info.syncPointTs = syncPointTs
+	info.syncPointInterval = 10 * time.Second
+	changefeedStatus := broker.getOrSetChangefeedStatus(info.GetChangefeedID())
+	disp := newDispatcherStat(syncPointTs-1, info, nil, 0, changefeedStatus)
+	require.True(t, disp.needSyncPointSequences())
+	followingSyncPoint := disp.getFollowingSyncPoint()
+
+	// The events after the sync point are held until the sequence values are loaded.
+	require.Equal(t, syncPointTs, broker.capBySyncPointSequences(disp, followingSyncPoint+1))
+	task := <-broker.syncPointSequenceCh
+	require.Equal(t, syncPointTs, task.syncPointTs)
+	// The values are being loaded, no more task is sent.
+	require.Equal(t, syncPointTs, broker.capBySyncPointSequences(disp, followingSyncPoint+1))
+	require.Len(t, broker.syncPointSequenceCh, 0)
+
+	// The loading fails, the events are still held and the values are loaded again.
+	ss.getSequenceValues = func(snapTs uint64) ([]event.SequenceValue, error) {
+		return nil, errors.New("read snapshot meta failed")
+	}
+	broker.doLoadSyncPointSequences(task)
+	require.Nil(t, disp.syncPointSequences.Load())
+	// The sync point is not emitted without the values, the event after it is held.
+	require.False(t, broker.emitSyncPointEventIfNeeded(syncPointTs+1, disp, node.ID(info.GetServerID())))
+	require.Len(t, broker.getMessageCh(disp.workerIndex), 0)
+	require.Equal(t, syncPointTs, disp.nextSyncPoint)
+	task = <-broker.syncPointSequenceCh
+	require.Equal(t, syncPointTs, broker.capBySyncPointSequences(disp, followingSyncPoint+1))
+
+	sequences := []event.SequenceValue{{SchemaName: "test", SequenceName: "s", Value: 10}}
+	ss.getSequenceValues = func(snapTs uint64) ([]event.SequenceValue, error) {
+		require.Equal(t, syncPointTs, snapTs)
+		return sequences, nil
+	}
+	broker.doLoadSyncPointSequences(task)
+	// Only one sync point is sent at a time.
+	require.Equal(t, followingSyncPoint, broker.capBySyncPointSequences(disp, followingSyncPoint+1))
+	require.Equal(t, syncPointTs+1, broker.capBySyncPointSequences(disp, syncPointTs+1))
+
+	// The sync point event carries the loaded values.
+	require.True(t, broker.emitSyncPointEventIfNeeded(syncPointTs+1, disp, node.ID(info.GetServerID())))
+	msg := <-broker.getMessageCh(disp.workerIndex)
+	syncPointEvent, ok := msg.e.(*event.SyncPointEvent)
+	require.True(t, ok)
+	require.Equal(t, syncPointTs, syncPointEvent.CommitTs)
+	require.Equal(t, sequences, syncPointEvent.Sequences)
+	require.Equal(t, followingSyncPoint, disp.nextSyncPoint)
+}
@@ -20,10 +20,11 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/heartbeatpb"
-	"github.com/pingcap/ticdc/logservice/eventstore"
-	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"go.uber.org/zap"
@@ -32,7 +33,8 @@ import (
 type DispatcherInfo interface {
 	// GetID returns the ID of the dispatcher.
 	GetID() common.DispatcherID
-	// GetClusterID returns the ID of the TiDB cluster the acceptor wants to accept events from.
+	// GetClusterID returns the ID of the TiDB cluster the acceptor wants to accept events from,
+	// 0 means the default upstream of the server.
 	GetClusterID() uint64
 	GetTopic() string
 	GetServerID() string
@@ -41,6 +43,8 @@ type DispatcherInfo interface {
 	GetActionType() eventpb.ActionType
 	GetChangefeedID() common.ChangeFeedID
 	GetFilter() filter.Filter
+	// GetColumnProjection returns the columns needed by the dispatcher, nil means all the columns.
+	GetColumnProjection() *columnselector.Projection
 
 	// sync point related
 	SyncPointEnabled() bool
@@ -48,31 +52,46 @@ type DispatcherInfo interface {
 	GetSyncPointInterval() time.Duration
 
 	IsOnlyReuse() bool
+	// GetPriority returns the priority class of the changefeed the dispatcher belongs to.
+	GetPriority() config.ChangefeedPriority
+	// NeedInitialSnapshot returns true if the dispatcher needs the rows of the table
+	// at the start ts before the incremental events.
+	NeedInitialSnapshot() bool
+	// GetTargetTs returns the target ts of the changefeed, 0 means no target ts.
+	GetTargetTs() uint64
+	// GetReplicationDelay returns the duration the events are delayed before they are sent,
+	// 0 means the events are sent as soon as possible.
+	GetReplicationDelay() time.Duration
 }
 
 // EventService accepts the requests of pulling events.
 // The EventService is a singleton in the system.
 type eventService struct {
-	mc          messaging.MessageCenter
-	eventStore  eventstore.EventStore
-	schemaStore schemastore.SchemaStore
+	mc messaging.MessageCenter
+	// logServices provides the event store, schema store and snapshot reader of each upstream.
+	logServices *upstreamlog.Manager
 	// clusterID -> eventBroker
 	brokers map[uint64]*eventBroker
+	// pendingDispatchers are the dispatchers waiting for the log service of their upstream to be ready.
+	pendingDispatchers map[common.DispatcherID]*pendingDispatcher
+	// readyDispatchers receives the pending dispatchers whose log service is ready.
+	readyDispatchers chan common.DispatcherID
 
 	// TODO: use a better way to cache the acceptorInfos
 	dispatcherInfo chan DispatcherInfo
 	tz             *time.Location
 }
 
-func New(eventStore eventstore.EventStore, schemaStore schemastore.SchemaStore) common.SubModule {
+func New(logServices *upstreamlog.Manager) common.SubModule {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
 	es := &eventService{
-		mc:             mc,
-		eventStore:     eventStore,
-		schemaStore:    schemaStore,
-		brokers:        make(map[uint64]*eventBroker),
-		dispatcherInfo: make(chan DispatcherInfo, basicChannelSize*16),
-		tz:             time.Local, // FIXME use the timezone from the config
+		mc:                 mc,
+		logServices:        logServices,
+		brokers:            make(map[uint64]*eventBroker),
+		pendingDispatchers: make(map[common.DispatcherID]*pendingDispatcher),
+		readyDispatchers:   make(chan common.DispatcherID, basicChannelSize),
+		dispatcherInfo:     make(chan DispatcherInfo, basicChannelSize*16),
+		tz:                 time.Local, // FIXME use the timezone from the config
 	}
 	es.mc.RegisterHandler(messaging.EventServiceTopic, es.handleMessage)
 	return es
@@ -110,6 +129,12 @@ func (s *eventService) Run(ctx context.Context) error {
 			default:
 				log.Panic("invalid action type", zap.Any("info", info))
 			}
+		case id := <-s.readyDispatchers:
+			// the dispatcher may be removed while waiting
+			if pending, ok := s.pendingDispatchers[id]; ok {
+				s.removePendingDispatcher(id)
+				s.registerDispatcher(ctx, pending.info)
+			}
 		}
 	}
 }
@@ -124,7 +149,7 @@ func (s *eventService) Close(_ context.Context) error {
 }
 
 func (s *eventService) handleMessage(ctx context.Context, msg *messaging.TargetMessage) error {
-	infos := msgToDispatcherInfo(msg)
+	infos, err := msgToDispatcherInfo(msg)
 	for _, info := range infos {
 		select {
 		case <-ctx.Done():
@@ -132,22 +157,87 @@ func (s *eventService) handleMessage(ctx context.Context, msg *messaging.TargetM
 		case s.dispatcherInfo <- info:
 		}
 	}
-	return nil
+	return err
 }
 
 func (s *eventService) registerDispatcher(ctx context.Context, info DispatcherInfo) {
-	clusterID := info.GetClusterID()
+	logService, ok := s.logServices.Get(info.GetClusterID())
+	if !ok {
+		s.waitLogService(ctx, info)
+		return
+	}
+	// the brokers are keyed by the resolved upstream ID,
+	// so the default upstream shares one broker for 0 and its real ID.
+	clusterID := logService.UpstreamID
 	c, ok := s.brokers[clusterID]
 	if !ok {
-		c = newEventBroker(ctx, clusterID, s.eventStore, s.schemaStore, s.mc, s.tz)
+		c = newEventBroker(ctx, clusterID, logService.EventStore, logService.SchemaStore,
+			logService.SnapshotReader, logService.PDClock, s.mc, s.tz)
 		s.brokers[clusterID] = c
 	}
 	c.addDispatcher(info)
 }
 
+// pendingDispatcher is a dispatcher waiting for the log service of its upstream.
+type pendingDispatcher struct {
+	// info is the latest register request of the dispatcher.
+	info DispatcherInfo
+	// ctx is done when the dispatcher is not pending any more, to stop the waiting.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// waitLogService waits for the log service of the dispatcher's upstream in background,
+// the dispatcher is registered after the log service is ready.
+func (s *eventService) waitLogService(ctx context.Context, info DispatcherInfo) {
+	if pending, ok := s.pendingDispatchers[info.GetID()]; ok {
+		pending.info = info
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.pendingDispatchers[info.GetID()] = &pendingDispatcher{info: info, ctx: ctx, cancel: cancel}
+	log.Info("log service of the upstream is not ready, register the dispatcher later",
+		zap.Stringer("dispatcher", info.GetID()),
+		zap.Uint64("upstreamID", info.GetClusterID()))
+	go func() {
+		_, err := s.logServices.Acquire(ctx, info.GetClusterID(), info.GetChangefeedID().Namespace())
+		if err != nil {
+			// the dispatcher is removed if the context is done.
+			if ctx.Err() == nil {
+				log.Warn("wait log service of the upstream failed",
+					zap.Stringer("dispatcher", info.GetID()),
+					zap.Uint64("upstreamID", info.GetClusterID()),
+					zap.Error(err))
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+		case s.readyDispatchers <- info.GetID():
+		}
+	}()
+}
+
+// removePendingDispatcher stops waiting for the log service of the dispatcher.
+func (s *eventService) removePendingDispatcher(id common.DispatcherID) {
+	if pending, ok := s.pendingDispatchers[id]; ok {
+		pending.cancel()
+		delete(s.pendingDispatchers, id)
+	}
+}
+
+func (s *eventService) getBroker(dispatcherInfo DispatcherInfo) (*eventBroker, bool) {
+	logService, ok := s.logServices.Get(dispatcherInfo.GetClusterID())
+	if !ok {
+		return nil, false
+	}
+	c, ok := s.brokers[logService.UpstreamID]
+	return c, ok
+}
+
 func (s *eventService) deregisterDispatcher(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	s.removePendingDispatcher(dispatcherInfo.GetID())
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
@@ -155,8 +245,7 @@ func (s *eventService) deregisterDispatcher(dispatcherInfo DispatcherInfo) {
 }
 
 func (s *eventService) pauseDispatcher(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
@@ -164,8 +253,7 @@ func (s *eventService) pauseDispatcher(dispatcherInfo DispatcherInfo) {
 }
 
 func (s *eventService) resumeDispatcher(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
@@ -173,8 +261,7 @@ func (s *eventService) resumeDispatcher(dispatcherInfo DispatcherInfo) {
 }
 
 func (s *eventService) resetDispatcher(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
@@ -182,8 +269,7 @@ func (s *eventService) resetDispatcher(dispatcherInfo DispatcherInfo) {
 }
 
 func (s *eventService) pauseChangefeed(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
@@ -191,22 +277,34 @@ func (s *eventService) pauseChangefeed(dispatcherInfo DispatcherInfo) {
 }
 
 func (s *eventService) resumeChangefeed(dispatcherInfo DispatcherInfo) {
-	clusterID := dispatcherInfo.GetClusterID()
-	c, ok := s.brokers[clusterID]
+	c, ok := s.getBroker(dispatcherInfo)
 	if !ok {
 		return
 	}
 	c.resumeChangefeed(dispatcherInfo)
 }
 
-func msgToDispatcherInfo(msg *messaging.TargetMessage) []DispatcherInfo {
+// msgToDispatcherInfo converts the message to the dispatcher infos, the invalid
+// requests are dropped and the first error is returned.
+func msgToDispatcherInfo(msg *messaging.TargetMessage) ([]DispatcherInfo, error) {
 	res := make([]DispatcherInfo, 0, len(msg.Message))
+	var firstErr error
 	for _, m := range msg.Message {
 		info, ok := m.(*messaging.RegisterDispatcherRequest)
 		if !ok {
 			log.Panic("invalid dispatcher info", zap.Any("info", m))
 		}
+		if err := info.InitColumnProjection(); err != nil {
+			log.Warn("invalid column projection, ignore the dispatcher request",
+				zap.Stringer("dispatcher", info.GetID()),
+				zap.Any("columnProjection", info.ColumnProjection),
+				zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
 		res = append(res, info)
 	}
-	return res
+	return res, firstErr
 }
@@ -14,6 +14,7 @@
 package eventservice
 
 import (
+	"bytes"
 	"context"
 	"math"
 	"sort"
@@ -26,7 +27,9 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/eventstore"
 	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
@@ -50,7 +53,15 @@ func initEventService(
 	appcontext.SetService(appcontext.MessageCenter, mc)
 	appcontext.SetService(appcontext.EventStore, mockStore)
 	appcontext.SetService(appcontext.SchemaStore, mockSchemaStore)
-	es := New(mockStore, mockSchemaStore)
+	logServices := upstreamlog.NewManager(ctx, t.TempDir(), nil)
+	logServices.SetDefault(&upstreamlog.Service{
+		UpstreamID:     1,
+		PDClock:        mockPDClock,
+		SchemaStore:    mockSchemaStore,
+		EventStore:     mockStore,
+		SnapshotReader: newMockSnapshotReader(),
+	})
+	es := New(logServices)
 	esImpl := es.(*eventService)
 	go func() {
 		err := esImpl.Run(ctx)
@@ -96,7 +107,9 @@ func TestEventServiceBasic(t *testing.T) {
 	// add events to eventStore
 	resolvedTs := kvEvents[len(kvEvents)-1].CRTs + 1
 	sourceSpanStat.update(resolvedTs, kvEvents...)
-	schemastore := esImpl.schemaStore.(*mockSchemaStore)
+	logService, ok := esImpl.logServices.Get(dispatcherInfo.GetClusterID())
+	require.True(t, ok)
+	schemastore := logService.SchemaStore.(*mockSchemaStore)
 	schemastore.AppendDDLEvent(dispatcherInfo.span.TableID, ddlEvent)
 	// receive events from msg center
 	msgCnt := 0
@@ -146,6 +159,59 @@ func TestEventServiceBasic(t *testing.T) {
 	}
 }
 
+func TestRegisterDispatcherWithUnknownUpstream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockStore := newMockEventStore(100)
+	mc := &mockMessageCenter{
+		messageCh: make(chan *messaging.TargetMessage, 100),
+	}
+	esImpl := initEventService(ctx, t, mc, mockStore)
+	esImpl.Close(ctx)
+
+	dispatcherInfo := newMockDispatcherInfo(t, common.NewDispatcherID(), 1, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	dispatcherInfo.clusterID = 2
+	// the log service of the upstream is not ready, so the dispatcher is pending
+	esImpl.registerDispatcher(ctx, dispatcherInfo)
+	require.Empty(t, esImpl.brokers)
+	require.Len(t, esImpl.pendingDispatchers, 1)
+	pending := esImpl.pendingDispatchers[dispatcherInfo.GetID()]
+	require.NoError(t, pending.ctx.Err())
+
+	// the pending dispatcher is dropped and stops waiting after it is removed
+	esImpl.deregisterDispatcher(dispatcherInfo)
+	require.Empty(t, esImpl.pendingDispatchers)
+	require.ErrorIs(t, pending.ctx.Err(), context.Canceled)
+}
+
+func TestMsgToDispatcherInfoColumnProjection(t *testing.T) {
+	newRequest := func(matcher string) *messaging.RegisterDispatcherRequest {
+		return &messaging.RegisterDispatcherRequest{
+			RegisterDispatcherRequest: &eventpb.RegisterDispatcherRequest{
+				DispatcherId: common.NewDispatcherID().ToPB(),
+				ColumnProjection: &eventpb.ColumnProjection{
+					ColumnSelectors: []*eventpb.ColumnSelector{
+						{Matcher: []string{matcher}, Columns: []string{"a"}},
+					},
+				},
+			},
+		}
+	}
+	valid, invalid := newRequest("test.t"), newRequest("test.[")
+	msg := messaging.NewSingleTargetMessage("server1", messaging.EventServiceTopic, valid)
+	msg.Message = append(msg.Message, invalid)
+
+	// the invalid request is rejected when it is received, and the projection
+	// of the valid request is built only once.
+	infos, err := msgToDispatcherInfo(msg)
+	require.Error(t, err)
+	require.Len(t, infos, 1)
+	projection := infos[0].GetColumnProjection()
+	require.NotNil(t, projection)
+	require.Same(t, projection, infos[0].GetColumnProjection())
+}
+
 var _ messaging.MessageCenter = &mockMessageCenter{}
 
 // mockMessageCenter is a mock implementation of the MessageCenter interface
@@ -331,6 +397,9 @@ type mockSchemaStore struct {
 
 	resolvedTs     uint64
 	maxDDLCommitTs uint64
+
+	// getSequenceValues returns the sequence values at snapTs if it's not nil.
+	getSequenceValues func(snapTs uint64) ([]commonEvent.SequenceValue, error)
 }
 
 func newMockSchemaStore() *mockSchemaStore {
@@ -417,6 +486,61 @@ func (m *mockSchemaStore) FetchTableTriggerDDLEvents(tableFilter filter.Filter,
 	return nil, 0, nil
 }
 
+func (m *mockSchemaStore) GetSequenceValues(snapTs uint64, tableFilter filter.Filter) ([]commonEvent.SequenceValue, error) {
+	if m.getSequenceValues != nil {
+		return m.getSequenceValues(snapTs)
+	}
+	return nil, nil
+}
+
+// mockSnapshotReader returns the rows of the tables kept in memory.
+type mockSnapshotReader struct {
+	rows map[common.TableID][]*common.RawKVEntry
+	// batchSize caps the number of rows returned by a scan if it's not 0.
+	batchSize int
+}
+
+func newMockSnapshotReader() *mockSnapshotReader {
+	return &mockSnapshotReader{
+		rows: make(map[common.TableID][]*common.RawKVEntry),
+	}
+}
+
+func (m *mockSnapshotReader) Scan(
+	ctx context.Context, span *heartbeatpb.TableSpan, ts uint64, startKey []byte, limit int,
+) ([]*common.RawKVEntry, []byte, error) {
+	if m.batchSize != 0 && m.batchSize < limit {
+		limit = m.batchSize
+	}
+	rows := m.rows[span.TableID]
+	begin := 0
+	if startKey != nil {
+		begin = sort.Search(len(rows), func(i int) bool {
+			return bytes.Compare(rows[i].Key, startKey) >= 0
+		})
+	}
+	end := begin + limit
+	if end >= len(rows) {
+		end = len(rows)
+	}
+	res := make([]*common.RawKVEntry, 0, end-begin)
+	for _, row := range rows[begin:end] {
+		res = append(res, &common.RawKVEntry{
+			OpType:   common.OpTypePut,
+			StartTs:  ts,
+			CRTs:     ts,
+			KeyLen:   row.KeyLen,
+			ValueLen: row.ValueLen,
+			Key:      row.Key,
+			Value:    row.Value,
+		})
+	}
+	if end == len(rows) {
+		return res, nil, nil
+	}
+	return res, rows[end].Key, nil
+}
+
 type mockSpanStats struct {
 	mu                 sync.RWMutex
 	startTs            uint64
@@ -469,6 +593,17 @@ type mockDispatcherInfo struct {
 	startTs    uint64
 	actionType eventpb.ActionType
 	filter     filter.Filter
+	projection *columnselector.Projection
+	priority   config.ChangefeedPriority
+	// initialSnapshot is true if the dispatcher needs the initial snapshot.
+	initialSnapshot bool
+	// targetTs is the target ts of the changefeed, 0 means no target ts.
+	targetTs uint64
+	// replicationDelay is the replication delay of the changefeed.
+	replicationDelay time.Duration
+	// syncPointTs and syncPointInterval are the sync point settings, the sync point is disabled if the interval is 0.
+	syncPointTs       uint64
+	syncPointInterval time.Duration
 }
 
 func newMockDispatcherInfo(t *testing.T, dispatcherID common.DispatcherID, tableID int64, actionType eventpb.ActionType) *mockDispatcherInfo {
@@ -530,25 +665,45 @@ func (m *mockDispatcherInfo) GetFilterConfig() *tconfig.FilterConfig {
 }
 
 func (m *mockDispatcherInfo) SyncPointEnabled() bool {
-	return false
+	return m.syncPointInterval != 0
 }
 
 func (m *mockDispatcherInfo) GetSyncPointTs() uint64 {
-	return 0
+	return m.syncPointTs
 }
 
 func (m *mockDispatcherInfo) GetSyncPointInterval() time.Duration {
-	return 0
+	return m.syncPointInterval
 }
 
 func (m *mockDispatcherInfo) GetFilter() filter.Filter {
 	return m.filter
 }
 
+func (m *mockDispatcherInfo) GetColumnProjection() *columnselector.Projection {
+	return m.projection
+}
+
 func (m *mockDispatcherInfo) IsOnlyReuse() bool {
 	return false
 }
 
+func (m *mockDispatcherInfo) GetPriority() config.ChangefeedPriority {
+	return m.priority
+}
+
+func (m *mockDispatcherInfo) NeedInitialSnapshot() bool {
+	return m.initialSnapshot
+}
+
+func (m *mockDispatcherInfo) GetTargetTs() uint64 {
+	return m.targetTs
+}
+
+func (m *mockDispatcherInfo) GetReplicationDelay() time.Duration {
+	return m.replicationDelay
+}
+
 func genEvents(helper *pevent.EventTestHelper, t *testing.T, ddl string, dmls ...string) (pevent.DDLEvent, []*common.RawKVEntry) {
 	job := helper.DDL2Job(ddl)
 	schema := job.SchemaName
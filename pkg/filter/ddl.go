@@ -14,6 +14,7 @@
 package filter
 
 import (
+	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
 )
@@ -26,6 +27,7 @@ var ddlWhiteListMap = map[timodel.ActionType]bf.EventType{
 	timodel.ActionCreateSchema:                  bf.CreateDatabase,
 	timodel.ActionDropSchema:                    bf.DropDatabase,
 	timodel.ActionModifySchemaCharsetAndCollate: bf.ModifySchemaCharsetAndCollate,
+	timodel.ActionRecoverSchema:                 bf.CreateDatabase,
 
 	// table related DDLs
 	timodel.ActionCreateTable:                  bf.CreateTable,
@@ -42,6 +44,12 @@ var ddlWhiteListMap = map[timodel.ActionType]bf.EventType{
 	timodel.ActionCreateView: bf.CreateView,
 	timodel.ActionDropView:   bf.DropView,
 
+	// sequence related DDLs, binlog filter has no event type for sequences,
+	// so they are treated as the table DDLs.
+	timodel.ActionCreateSequence: bf.CreateTable,
+	timodel.ActionAlterSequence:  bf.AlterTable,
+	timodel.ActionDropSequence:   bf.DropTable,
+
 	// partition related DDLs
 	timodel.ActionAddTablePartition:      bf.AddTablePartition,
 	timodel.ActionDropTablePartition:     bf.DropTablePartition,
@@ -80,6 +88,41 @@ var ddlWhiteListMap = map[timodel.ActionType]bf.EventType{
 	timodel.ActionDropColumns: bf.DropColumn,
 }
 
+// optionalDDLClasses maps the TiDB specific DDLs to their classes,
+// these DDLs are only replicated when their classes are listed in the filter config.
+var optionalDDLClasses = map[timodel.ActionType]string{
+	timodel.ActionAddCheckConstraint:   config.DDLClassCheckConstraint,
+	timodel.ActionDropCheckConstraint:  config.DDLClassCheckConstraint,
+	timodel.ActionAlterCheckConstraint: config.DDLClassCheckConstraint,
+
+	timodel.ActionAlterTableAttributes:          config.DDLClassTableAttributes,
+	timodel.ActionAlterTablePartitionAttributes: config.DDLClassTableAttributes,
+
+	timodel.ActionCreatePlacementPolicy:        config.DDLClassPlacementPolicy,
+	timodel.ActionAlterPlacementPolicy:         config.DDLClassPlacementPolicy,
+	timodel.ActionDropPlacementPolicy:          config.DDLClassPlacementPolicy,
+	timodel.ActionModifySchemaDefaultPlacement: config.DDLClassPlacementPolicy,
+	timodel.ActionAlterTablePlacement:          config.DDLClassPlacementPolicy,
+	timodel.ActionAlterTablePartitionPlacement: config.DDLClassPlacementPolicy,
+}
+
+// GetOptionalDDLClass returns the class of the DDL if it is only replicated on demand.
+func GetOptionalDDLClass(action timodel.ActionType) (string, bool) {
+	class, ok := optionalDDLClasses[action]
+	return class, ok
+}
+
+// IsPlacementPolicyDDL returns true if the DDL creates, alters or drops a placement policy,
+// which doesn't belong to any schema.
+func IsPlacementPolicyDDL(action timodel.ActionType) bool {
+	switch action {
+	case timodel.ActionCreatePlacementPolicy, timodel.ActionAlterPlacementPolicy, timodel.ActionDropPlacementPolicy:
+		return true
+	default:
+		return false
+	}
+}
+
 // singleTableDDLs should only affect one table.
 var singleTableDDLs = map[timodel.ActionType]struct{}{
 	// table related DDLs
@@ -115,20 +158,24 @@ var singleTableDDLs = map[timodel.ActionType]struct{}{
 	timodel.ActionDropColumns:       {},
 	timodel.ActionMultiSchemaChange: {},
 
+	// optional DDLs, see optionalDDLClasses
+	timodel.ActionAddCheckConstraint:            {},
+	timodel.ActionDropCheckConstraint:           {},
+	timodel.ActionAlterCheckConstraint:          {},
+	timodel.ActionAlterTableAttributes:          {},
+	timodel.ActionAlterTablePartitionAttributes: {},
+	timodel.ActionAlterTablePlacement:           {},
+	timodel.ActionAlterTablePartitionPlacement:  {},
+
 	// Not supported yet
 	// timodel.ActionShardRowID,
 	// timodel.ActionAddForeignKey, timodel.ActionDropForeignKey,
 	// timodel.ActionLockTable, timodel.ActionUnlockTable,
 	// timodel.ActionSetTiFlashReplica,
 	// timodel.ActionModifyTableAutoIdCache, timodel.ActionRebaseAutoRandomBase,
-	// timodel.ActionAddCheckConstraint, timodel.ActionDropCheckConstraint, timodel.ActionAlterCheckConstraint,
 	// timodel.ActionDropIndexes,
-	// timodel.ActionAlterTableAttributes,
 	// timodel.ActionAlterCacheTable, timodel.ActionAlterNoCacheTable,
 	// timodel.ActionRepairTable,
-	// timodel.ActionCreatePlacementPolicy, timodel.ActionAlterPlacementPolicy,
-	// timodel.ActionDropPlacementPolicy,
-	// timodel.ActionRecoverSchema,
 }
 
 // multiTableDDLs affect multiple tables.
@@ -153,6 +200,14 @@ var globalTableDDLs = map[timodel.ActionType]struct{}{
 	timodel.ActionDropTable:                     {},
 	timodel.ActionCreateTables:                  {},
 	timodel.ActionRecoverTable:                  {},
+	timodel.ActionRecoverSchema:                 {},
+	timodel.ActionCreateSequence:                {},
+	timodel.ActionAlterSequence:                 {},
+	timodel.ActionDropSequence:                  {},
+	timodel.ActionModifySchemaDefaultPlacement:  {},
+	timodel.ActionCreatePlacementPolicy:         {},
+	timodel.ActionAlterPlacementPolicy:          {},
+	timodel.ActionDropPlacementPolicy:           {},
 }
 
 func ShouldBlock(action timodel.ActionType) bool {
@@ -164,7 +219,7 @@ func ShouldBlock(action timodel.ActionType) bool {
 	}
 	switch action {
 	case timodel.ActionCreateSchema, timodel.ActionCreateTables,
-		timodel.ActionCreateTable, timodel.ActionRecoverTable:
+		timodel.ActionCreateTable, timodel.ActionRecoverTable, timodel.ActionRecoverSchema:
 		// not block since there are no affected dispatchers.
 		return false
 	default:
@@ -16,21 +16,50 @@ package filter
 import (
 	"testing"
 
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/stretchr/testify/require"
 )
 
 func TestSingleTableDDL(t *testing.T) {
-	for d := range singleTableDDLs {
+	isKnownDDL := func(d timodel.ActionType) bool {
 		_, ok := ddlWhiteListMap[d]
-		require.True(t, ok, "DDL %s is not in the white list", d)
+		_, optional := optionalDDLClasses[d]
+		return ok || optional
+	}
+	for d := range singleTableDDLs {
+		require.True(t, isKnownDDL(d), "DDL %s is not in the white list", d)
 	}
 	for d := range multiTableDDLs {
-		_, ok := ddlWhiteListMap[d]
-		require.True(t, ok, "DDL %s is not in the white list", d)
+		require.True(t, isKnownDDL(d), "DDL %s is not in the white list", d)
 	}
 	for d := range globalTableDDLs {
-		_, ok := ddlWhiteListMap[d]
-		require.True(t, ok, "DDL %s is in the white list", d)
+		require.True(t, isKnownDDL(d), "DDL %s is in the white list", d)
 	}
-	require.Equal(t, len(singleTableDDLs)+len(multiTableDDLs)+len(globalTableDDLs), len(ddlWhiteListMap))
+	require.Equal(t, len(singleTableDDLs)+len(multiTableDDLs)+len(globalTableDDLs), len(ddlWhiteListMap)+len(optionalDDLClasses))
+}
+
+func TestForwardDDLClasses(t *testing.T) {
+	cfg := config.NewDefaultFilterConfig()
+	f, err := NewFilter(cfg, "", false, false)
+	require.NoError(t, err)
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionAddCheckConstraint, "test", "t1", nil))
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionCreatePlacementPolicy, "", "", nil))
+
+	cfg.Rules = []string{"test.*"}
+	cfg.ForwardDDLClasses = []string{config.DDLClassCheckConstraint, config.DDLClassPlacementPolicy}
+	f, err = NewFilter(cfg, "", false, false)
+	require.NoError(t, err)
+	require.False(t, f.ShouldDiscardDDL(timodel.ActionAddCheckConstraint, "test", "t1", nil))
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionAddCheckConstraint, "test2", "t1", nil))
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionAlterTableAttributes, "test", "t1", nil))
+	// placement policies can't be filtered by name
+	require.False(t, f.ShouldDiscardDDL(timodel.ActionCreatePlacementPolicy, "", "", nil))
+	require.False(t, f.ShouldDiscardDDL(timodel.ActionModifySchemaDefaultPlacement, "test", "", nil))
+	require.True(t, f.ShouldDiscardDDL(timodel.ActionModifySchemaDefaultPlacement, "test2", "", nil))
+
+	cfg.ForwardDDLClasses = []string{"foreign-key"}
+	_, err = NewFilter(cfg, "", false, false)
+	require.True(t, cerror.ErrInvalidForwardDDLClass.Equal(err))
 }
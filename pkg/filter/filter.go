@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/ticdc/eventpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
@@ -33,6 +34,12 @@ const (
 	SyncPointTable = "syncpoint_v1"
 	// DDLTsTable is the table name use to write ddl commitTs for each table when downstream is mysql-class
 	DDLTsTable = "ddl_ts_v1"
+	// DeadLetterQueueTable is the table name use to write the rows which can't be applied to the downstream.
+	DeadLetterQueueTable = "dead_letter_queue_v1"
+	// ConsumerProgressTable is the table name use to write the offsets and watermarks of the consumers.
+	ConsumerProgressTable = "consumer_progress_v1"
+	// StorageConsumerProgressTable is the table name use to write the applied files of the storage consumers.
+	StorageConsumerProgressTable = "storage_consumer_progress_v1"
 
 	// TiCDCSystemSchema is the schema only use by TiCDC.
 	TiCDCSystemSchema = "tidb_cdc"
@@ -71,6 +78,8 @@ type filter struct {
 	// ignoreTxnStartTs is used to filter out dml/ddl event by its starsTs.
 	ignoreTxnStartTs []uint64
 	forceReplicate   bool
+	// forwardDDLClasses is the classes of the optional DDLs which should be replicated.
+	forwardDDLClasses map[string]struct{}
 }
 
 // NewFilter creates a filter.
@@ -92,24 +101,28 @@ func NewFilter(cfg *config.FilterConfig, tz string, caseSensitive bool, forceRep
 	if err != nil {
 		return nil, err
 	}
+	forwardDDLClasses, err := verifyForwardDDLClasses(cfg.ForwardDDLClasses)
+	if err != nil {
+		return nil, err
+	}
 	return &filter{
-		tableFilter:      f,
-		dmlExprFilter:    dmlExprFilter,
-		sqlEventFilter:   sqlEventFilter,
-		ignoreTxnStartTs: cfg.IgnoreTxnStartTs,
-		forceReplicate:   forceReplicate,
+		tableFilter:       f,
+		dmlExprFilter:     dmlExprFilter,
+		sqlEventFilter:    sqlEventFilter,
+		ignoreTxnStartTs:  cfg.IgnoreTxnStartTs,
+		forceReplicate:    forceReplicate,
+		forwardDDLClasses: forwardDDLClasses,
 	}, nil
 }
 
 // IsEligible returns whether the table is a eligible table.
 // A table is eligible if it has a primary key or unique key on not null columns.
-// Or when enable forReplicate or the table is a view.
+// Or when enable forReplicate or the table is a view or a sequence.
 // TODO: Add some tests for this function.
 func (f *filter) IsEligible(tableInfo *timodel.TableInfo) bool {
-	// Sequence is not supported yet, TiCDC needs to filter all sequence tables.
-	// See https://github.com/pingcap/tiflow/issues/4559
+	// Sequences have no rows, their values are synced by the table trigger dispatcher.
 	if tableInfo.IsSequence() {
-		return false
+		return true
 	}
 	if f.forceReplicate {
 		return true
@@ -197,13 +210,17 @@ func (f *filter) ShouldIgnoreDMLEvent(
 // 1. By schema name.
 // 2. By table name.
 func (f *filter) ShouldDiscardDDL(ddlType timodel.ActionType, schema, table string, tableInfo *timodel.TableInfo) bool {
-	if !isAllowedDDL(ddlType) {
+	if !f.isAllowedDDL(ddlType) {
 		return true
 	}
 
 	if IsSchemaDDL(ddlType) {
 		return f.ShouldIgnoreSchema(schema)
 	}
+	// placement policies are global objects, they can't be filtered by name.
+	if IsPlacementPolicyDDL(ddlType) {
+		return false
+	}
 	return f.ShouldIgnoreTable(schema, table, tableInfo)
 }
 
@@ -261,16 +278,37 @@ func (f *filter) shouldIgnoreStartTs(ts uint64) bool {
 	return false
 }
 
-func isAllowedDDL(actionType timodel.ActionType) bool {
-	_, ok := ddlWhiteListMap[actionType]
-	return ok
+func (f *filter) isAllowedDDL(actionType timodel.ActionType) bool {
+	if _, ok := ddlWhiteListMap[actionType]; ok {
+		return true
+	}
+	if class, ok := optionalDDLClasses[actionType]; ok {
+		_, ok = f.forwardDDLClasses[class]
+		return ok
+	}
+	return false
+}
+
+// verifyForwardDDLClasses checks the classes of the optional DDLs and converts them to a set.
+func verifyForwardDDLClasses(classes []string) (map[string]struct{}, error) {
+	result := make(map[string]struct{}, len(classes))
+	for _, class := range classes {
+		switch class {
+		case config.DDLClassCheckConstraint, config.DDLClassTableAttributes, config.DDLClassPlacementPolicy:
+			result[class] = struct{}{}
+		default:
+			return nil, cerror.ErrInvalidForwardDDLClass.GenWithStackByArgs(class)
+		}
+	}
+	return result, nil
 }
 
 // IsSchemaDDL returns true if the action type is a schema DDL.
 func IsSchemaDDL(actionType timodel.ActionType) bool {
 	switch actionType {
 	case timodel.ActionCreateSchema, timodel.ActionDropSchema,
-		timodel.ActionModifySchemaCharsetAndCollate:
+		timodel.ActionModifySchemaCharsetAndCollate, timodel.ActionRecoverSchema,
+		timodel.ActionModifySchemaDefaultPlacement:
 		return true
 	default:
 		return false
@@ -310,8 +348,9 @@ func (s *SharedFilterStorage) GetOrSetFilter(
 	}
 	// convert eventpb.FilterConfig to config.FilterConfig
 	filterCfg := &config.FilterConfig{
-		Rules:            cfg.FilterConfig.Rules,
-		IgnoreTxnStartTs: cfg.FilterConfig.IgnoreTxnStartTs,
+		Rules:             cfg.FilterConfig.Rules,
+		IgnoreTxnStartTs:  cfg.FilterConfig.IgnoreTxnStartTs,
+		ForwardDDLClasses: cfg.FilterConfig.ForwardDdlClasses,
 	}
 	for _, rule := range cfg.FilterConfig.EventFilters {
 		f := &config.EventFilterRule{
@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
+)
+
+// supportedCompressions are the compression algorithms the message center can decompress,
+// they are sent to the remote server in the handshake message.
+var supportedCompressions = []string{config.MessageCompressionSnappy, config.MessageCompressionZstd}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+func initZstd() {
+	zstdOnce.Do(func() {
+		// The encoder and decoder are safe for concurrent use by EncodeAll and DecodeAll.
+		zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+}
+
+// negotiateCompression returns the compression to use for the remote server,
+// empty means the messages are not compressed.
+func negotiateCompression(preferred string, accepted []string) string {
+	if preferred == "" || preferred == config.MessageCompressionNone {
+		return ""
+	}
+	if !slices.Contains(supportedCompressions, preferred) || !slices.Contains(accepted, preferred) {
+		return ""
+	}
+	return preferred
+}
+
+func compress(compression string, data []byte) []byte {
+	switch compression {
+	case config.MessageCompressionSnappy:
+		return snappy.Encode(nil, data)
+	case config.MessageCompressionZstd:
+		initZstd()
+		return zstdEncoder.EncodeAll(data, nil)
+	default:
+		return data
+	}
+}
+
+func decompress(compression string, data []byte) ([]byte, error) {
+	switch compression {
+	case "":
+		return data, nil
+	case config.MessageCompressionSnappy:
+		return snappy.Decode(nil, data)
+	case config.MessageCompressionZstd:
+		initZstd()
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, errors.Errorf("unsupported message compression %s", compression)
+	}
+}
+
+func payloadSize(msg *proto.Message) int {
+	size := 0
+	for _, payload := range msg.Payload {
+		size += len(payload)
+	}
+	return size
+}
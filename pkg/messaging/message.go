@@ -23,7 +23,9 @@ import (
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/logservice/logservicepb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/node"
 	"go.uber.org/zap"
@@ -82,6 +84,8 @@ const (
 	TypeMaintainerCloseResponse
 
 	TypeMessageHandShake
+
+	TypeThroughputQuota
 )
 
 func (t IOType) String() string {
@@ -144,6 +148,8 @@ func (t IOType) String() string {
 		return "MessageHandShake"
 	case TypeCheckpointTsMessage:
 		return "CheckpointTsMessage"
+	case TypeThroughputQuota:
+		return "ThroughputQuota"
 	default:
 	}
 	return "Unknown"
@@ -151,6 +157,9 @@ func (t IOType) String() string {
 
 type RegisterDispatcherRequest struct {
 	*eventpb.RegisterDispatcherRequest
+
+	// projection is built from the column projection config once the request is received.
+	projection *columnselector.Projection
 }
 
 func (r RegisterDispatcherRequest) Marshal() ([]byte, error) {
@@ -166,7 +175,7 @@ func (r RegisterDispatcherRequest) GetID() common.DispatcherID {
 }
 
 func (r RegisterDispatcherRequest) GetClusterID() uint64 {
-	return 0
+	return r.UpstreamId
 }
 
 func (r RegisterDispatcherRequest) GetTopic() string {
@@ -200,6 +209,22 @@ func (r RegisterDispatcherRequest) GetFilter() filter.Filter {
 	return filter
 }
 
+// InitColumnProjection validates the column projection config and builds the projection,
+// it should be called once when the request is received, before GetColumnProjection.
+func (r *RegisterDispatcherRequest) InitColumnProjection() error {
+	projection, err := columnselector.NewProjection(r.RegisterDispatcherRequest.ColumnProjection)
+	if err != nil {
+		return err
+	}
+	r.projection = projection
+	return nil
+}
+
+// GetColumnProjection returns the projection built by InitColumnProjection.
+func (r *RegisterDispatcherRequest) GetColumnProjection() *columnselector.Projection {
+	return r.projection
+}
+
 func (r RegisterDispatcherRequest) SyncPointEnabled() bool {
 	return r.EnableSyncPoint
 }
@@ -216,6 +241,18 @@ func (r RegisterDispatcherRequest) IsOnlyReuse() bool {
 	return r.OnlyReuse
 }
 
+func (r RegisterDispatcherRequest) GetPriority() config.ChangefeedPriority {
+	return config.ChangefeedPriority(r.Priority)
+}
+
+func (r RegisterDispatcherRequest) NeedInitialSnapshot() bool {
+	return r.InitialSnapshot
+}
+
+func (r RegisterDispatcherRequest) GetReplicationDelay() time.Duration {
+	return time.Duration(r.ReplicationDelay) * time.Millisecond
+}
+
 type IOTypeT interface {
 	Unmarshal(data []byte) error
 	Marshal() (data []byte, err error)
@@ -280,6 +317,8 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
+	case TypeThroughputQuota:
+		m = &heartbeatpb.ThroughputQuota{}
 	default:
 		log.Panic("Unimplemented IOType", zap.Stringer("Type", ioType))
 	}
@@ -367,6 +406,8 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT, Group ...
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *heartbeatpb.ThroughputQuota:
+		ioType = TypeThroughputQuota
 	default:
 		panic("unknown io type")
 	}
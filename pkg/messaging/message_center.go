@@ -425,8 +425,8 @@ func (s *grpcServer) handleConnect(msg *proto.Message, stream grpcSender, isEven
 		zap.Bool("isEvent", isEvent))
 
 	if isEvent {
-		return remoteTarget.runEventSendStream(stream)
+		return remoteTarget.runEventSendStream(stream, msg)
 	} else {
-		return remoteTarget.runCommandSendStream(stream)
+		return remoteTarget.runCommandSendStream(stream, msg)
 	}
 }
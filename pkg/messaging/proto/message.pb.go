@@ -81,6 +81,16 @@ type Message struct {
 	Topic string `protobuf:"bytes,6,opt,name=topic,proto3" json:"topic,omitempty"`
 	// TODO, change to real types
 	Payload [][]byte `protobuf:"bytes,7,rep,name=payload,proto3" json:"payload,omitempty"`
+	// compression is the algorithm used to compress the payload, empty means the payload is not compressed.
+	Compression string `protobuf:"bytes,8,opt,name=compression,proto3" json:"compression,omitempty"`
+	// accepted_compressions is only set in the handshake message,
+	// it is the compression algorithms that the sender of the handshake can decompress.
+	AcceptedCompressions []string `protobuf:"bytes,9,rep,name=accepted_compressions,json=acceptedCompressions,proto3" json:"accepted_compressions,omitempty"`
+	// accept_batch is only set in the handshake message,
+	// it indicates whether the sender of the handshake can receive the batched messages.
+	AcceptBatch bool `protobuf:"varint,10,opt,name=accept_batch,json=acceptBatch,proto3" json:"accept_batch,omitempty"`
+	// batch is the messages sent together, the other fields are not set when it is not empty.
+	Batch []*Message `protobuf:"bytes,11,rep,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (x *Message) Reset() {
@@ -164,6 +174,34 @@ func (x *Message) GetPayload() [][]byte {
 	return nil
 }
 
+func (x *Message) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *Message) GetAcceptedCompressions() []string {
+	if x != nil {
+		return x.AcceptedCompressions
+	}
+	return nil
+}
+
+func (x *Message) GetAcceptBatch() bool {
+	if x != nil {
+		return x.AcceptBatch
+	}
+	return false
+}
+
+func (x *Message) GetBatch() []*Message {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 type MessageSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -219,7 +257,7 @@ var file_pkg_messaging_proto_message_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x26, 0x0a, 0x0a, 0x43, 0x61,
 	0x6c, 0x6c, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
 	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
+	0x73, 0x73, 0x22, 0xbf, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
 	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
 	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
 	0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
@@ -229,7 +267,17 @@ var file_pkg_messaging_proto_message_proto_rawDesc = []byte{
 	0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x06, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
 	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
-	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x2f, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x15, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x43,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x24,
+	0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x22, 0x2f, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53,
 	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x62,
 	0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x74,
 	0x42, 0x79, 0x74, 0x65, 0x73, 0x32, 0x71, 0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
@@ -263,15 +311,16 @@ var file_pkg_messaging_proto_message_proto_goTypes = []interface{}{
 	(*MessageSummary)(nil), // 2: proto.MessageSummary
 }
 var file_pkg_messaging_proto_message_proto_depIdxs = []int32{
-	1, // 0: proto.MessageCenter.sendEvents:input_type -> proto.Message
-	1, // 1: proto.MessageCenter.sendCommands:input_type -> proto.Message
-	1, // 2: proto.MessageCenter.sendEvents:output_type -> proto.Message
-	1, // 3: proto.MessageCenter.sendCommands:output_type -> proto.Message
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: proto.Message.batch:type_name -> proto.Message
+	1, // 1: proto.MessageCenter.sendEvents:input_type -> proto.Message
+	1, // 2: proto.MessageCenter.sendCommands:input_type -> proto.Message
+	1, // 3: proto.MessageCenter.sendEvents:output_type -> proto.Message
+	1, // 4: proto.MessageCenter.sendCommands:output_type -> proto.Message
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_pkg_messaging_proto_message_proto_init() }
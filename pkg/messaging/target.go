@@ -56,6 +56,7 @@ type remoteMessageTarget struct {
 	targetId    node.ID
 	targetAddr  string
 	security    *security.Credential
+	cfg         *config.MessageCenterConfig
 
 	// senderMu is used to protect the eventSender and commandSender.
 	// It is used to ensure that there is only one eventStream and commandStream for the target.
@@ -166,6 +167,7 @@ func newRemoteMessageTarget(
 		targetAddr:         addr,
 		targetId:           targetId,
 		security:           security,
+		cfg:                cfg,
 		eventSender:        &sendStreamWrapper{ready: atomic.Bool{}},
 		commandSender:      &sendStreamWrapper{ready: atomic.Bool{}},
 		ctx:                ctx,
@@ -268,6 +270,10 @@ func (s *remoteMessageTarget) connect() {
 		To:    string(s.targetId),
 		Epoch: uint64(s.messageCenterEpoch),
 		Type:  int32(TypeMessageHandShake),
+		// The remote server sends messages to us by the streams,
+		// tell it how we can receive the messages.
+		AcceptedCompressions: supportedCompressions,
+		AcceptBatch:          true,
 	}
 
 	eventStream, err := client.SendEvents(s.ctx, handshake)
@@ -324,7 +330,28 @@ LOOP:
 	s.connect()
 }
 
-func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender) error {
+// sendOptions is how the messages are sent to the remote target by a stream,
+// it's negotiated by the handshake message from the remote target.
+type sendOptions struct {
+	// compression is the algorithm to compress the log service events, empty means no compression.
+	compression string
+	// batch is true if the remote target can receive batched messages.
+	batch bool
+}
+
+func (s *remoteMessageTarget) negotiate(handshake *proto.Message, isEvent bool) sendOptions {
+	opts := sendOptions{batch: handshake.GetAcceptBatch()}
+	// Only the event stream carries the data of the tables, so the commands are never compressed.
+	if isEvent {
+		opts.compression = negotiateCompression(s.cfg.Compression, handshake.GetAcceptedCompressions())
+	}
+	log.Info("Negotiated send options with remote target",
+		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId),
+		zap.Bool("isEvent", isEvent), zap.String("compression", opts.compression), zap.Bool("batch", opts.batch))
+	return opts
+}
+
+func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender, handshake *proto.Message) error {
 	s.senderMu.Lock()
 	if s.eventSender.stream != nil {
 		s.senderMu.Unlock()
@@ -334,14 +361,14 @@ func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender) error {
 	s.eventSender.ready.Store(true)
 	s.senderMu.Unlock()
 
-	err := s.runSendMessages(s.ctx, s.eventSender.stream, s.sendEventCh)
+	err := s.runSendMessages(s.ctx, s.eventSender.stream, s.sendEventCh, s.negotiate(handshake, true), msgTypeEvent)
 	log.Info("Event send stream closed",
 		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
 	s.eventSender.ready.Store(false)
 	return err
 }
 
-func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender) error {
+func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender, handshake *proto.Message) error {
 	s.senderMu.Lock()
 	if s.commandSender.stream != nil {
 		s.senderMu.Unlock()
@@ -351,19 +378,31 @@ func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender) err
 	s.commandSender.ready.Store(true)
 	s.senderMu.Unlock()
 
-	err := s.runSendMessages(s.ctx, s.commandSender.stream, s.sendCmdCh)
+	err := s.runSendMessages(s.ctx, s.commandSender.stream, s.sendCmdCh, s.negotiate(handshake, false), msgTypeCommand)
 	log.Info("Command send stream closed",
 		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
 	s.commandSender.ready.Store(false)
 	return err
 }
 
-func (s *remoteMessageTarget) runSendMessages(sendCtx context.Context, stream grpcSender, sendChan chan *proto.Message) error {
+func (s *remoteMessageTarget) runSendMessages(
+	sendCtx context.Context, stream grpcSender, sendChan chan *proto.Message, opts sendOptions, msgType string,
+) error {
+	batchSize := metrics.MessagingBatchSize.WithLabelValues(s.targetAddr, msgType)
 	for {
 		select {
 		case <-sendCtx.Done():
 			return sendCtx.Err()
 		case message := <-sendChan:
+			s.compressMessage(message, opts.compression)
+			if opts.batch {
+				message = s.batchMessages(message, sendChan, opts.compression)
+			}
+			if n := len(message.Batch); n > 0 {
+				batchSize.Observe(float64(n))
+			} else {
+				batchSize.Observe(1)
+			}
 			if err := stream.Send(message); err != nil {
 				log.Error("Error when sending message to remote",
 					zap.Error(err),
@@ -376,6 +415,50 @@ func (s *remoteMessageTarget) runSendMessages(sendCtx context.Context, stream gr
 	}
 }
 
+// batchMessages sends the messages already waiting in the channel together with the first one.
+// It never waits for more messages, so the batch only grows when the messages are produced
+// faster than they are sent, and the latency of a single message is not affected.
+func (s *remoteMessageTarget) batchMessages(first *proto.Message, sendChan chan *proto.Message, compression string) *proto.Message {
+	batch := []*proto.Message{first}
+	size := payloadSize(first)
+LOOP:
+	for len(batch) < s.cfg.MaxBatchCount && size < s.cfg.MaxBatchBytes {
+		select {
+		case message := <-sendChan:
+			s.compressMessage(message, compression)
+			batch = append(batch, message)
+			size += payloadSize(message)
+		default:
+			break LOOP
+		}
+	}
+	if len(batch) == 1 {
+		return first
+	}
+	return &proto.Message{Batch: batch}
+}
+
+// compressMessage compresses the payload of the log service events in place,
+// the small messages are not compressed because the ratio is poor.
+func (s *remoteMessageTarget) compressMessage(message *proto.Message, compression string) {
+	if compression == "" || !IOType(message.Type).IsLogServiceEvent() {
+		return
+	}
+	original := payloadSize(message)
+	if original < s.cfg.CompressionThreshold {
+		return
+	}
+	compressed := 0
+	for i, payload := range message.Payload {
+		message.Payload[i] = compress(compression, payload)
+		compressed += len(message.Payload[i])
+	}
+	message.Compression = compression
+	metrics.MessagingCompressionRatio.WithLabelValues(s.targetAddr, compression).Observe(float64(original) / float64(max(compressed, 1)))
+	metrics.MessagingCompressedBytes.WithLabelValues(s.targetAddr, "original").Add(float64(original))
+	metrics.MessagingCompressedBytes.WithLabelValues(s.targetAddr, "compressed").Add(float64(compressed))
+}
+
 func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh chan *TargetMessage) {
 	s.wg.Add(1)
 	go func() {
@@ -393,33 +476,49 @@ func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh
 				s.collectErr(err)
 				return
 			}
-			mt := IOType(message.Type)
-			if mt == TypeMessageHandShake {
-				log.Info("Received handshake message", zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId))
-				continue
+			messages := []*proto.Message{message}
+			if len(message.Batch) > 0 {
+				messages = message.Batch
 			}
-			targetMsg := &TargetMessage{
-				From:     node.ID(message.From),
-				To:       node.ID(message.To),
-				Topic:    message.Topic,
-				Epoch:    message.Epoch,
-				Sequence: message.Seqnum,
-				Type:     mt,
-			}
-			for _, payload := range message.Payload {
-				msg, err := decodeIOType(mt, payload)
-				if err != nil {
-					// TODO: handle this error properly.
-					err := AppError{Type: ErrorTypeInvalidMessage, Reason: errors.Trace(err).Error()}
-					log.Panic("Failed to decode message", zap.Error(err))
+			for _, msg := range messages {
+				if IOType(msg.Type) == TypeMessageHandShake {
+					log.Info("Received handshake message", zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId))
+					continue
 				}
-				targetMsg.Message = append(targetMsg.Message, msg)
+				receiveCh <- newTargetMessageFromProto(msg)
 			}
-			receiveCh <- targetMsg
 		}
 	}()
 }
 
+func newTargetMessageFromProto(message *proto.Message) *TargetMessage {
+	mt := IOType(message.Type)
+	targetMsg := &TargetMessage{
+		From:     node.ID(message.From),
+		To:       node.ID(message.To),
+		Topic:    message.Topic,
+		Epoch:    message.Epoch,
+		Sequence: message.Seqnum,
+		Type:     mt,
+	}
+	for _, payload := range message.Payload {
+		payload, err := decompress(message.Compression, payload)
+		if err != nil {
+			// TODO: handle this error properly.
+			err := AppError{Type: ErrorTypeInvalidMessage, Reason: errors.Trace(err).Error()}
+			log.Panic("Failed to decompress message", zap.Error(err))
+		}
+		msg, err := decodeIOType(mt, payload)
+		if err != nil {
+			// TODO: handle this error properly.
+			err := AppError{Type: ErrorTypeInvalidMessage, Reason: errors.Trace(err).Error()}
+			log.Panic("Failed to decode message", zap.Error(err))
+		}
+		targetMsg.Message = append(targetMsg.Message, msg)
+	}
+	return targetMsg
+}
+
 func (s *remoteMessageTarget) newMessage(msg ...*TargetMessage) *proto.Message {
 	msgBytes := make([][]byte, 0, len(msg))
 	for _, tm := range msg {
@@ -15,10 +15,16 @@ package messaging
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -51,3 +57,112 @@ func TestRemoteTargetNewMessage(t *testing.T) {
 	require.Equal(t, TypeMessageHandShake, IOType(msg2.Type))
 	require.Equal(t, rt.messageCenterEpoch, uint64(msg2.Epoch))
 }
+
+type mockGrpcSender struct {
+	mu   sync.Mutex
+	sent []*proto.Message
+}
+
+func (s *mockGrpcSender) Send(msg *proto.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *mockGrpcSender) getSent() []*proto.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
+
+func newBatchResolvedEventForTest(n int) *commonEvent.BatchResolvedEvent {
+	batch := &commonEvent.BatchResolvedEvent{}
+	for i := 0; i < n; i++ {
+		batch.Events = append(batch.Events, commonEvent.NewResolvedEvent(common.Ts(100), common.NewDispatcherID()))
+	}
+	return batch
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	// The old servers don't send the accepted compressions in the handshake.
+	require.Equal(t, "", negotiateCompression(config.MessageCompressionSnappy, nil))
+	require.Equal(t, "", negotiateCompression(config.MessageCompressionNone, supportedCompressions))
+	require.Equal(t, "", negotiateCompression(config.MessageCompressionZstd, []string{config.MessageCompressionSnappy}))
+	require.Equal(t, config.MessageCompressionZstd, negotiateCompression(config.MessageCompressionZstd, supportedCompressions))
+}
+
+func TestRemoteTargetSendCompressedAndBatchedMessages(t *testing.T) {
+	rt := newRemoteMessageTargetForTest()
+	defer rt.close()
+	rt.cfg.Compression = config.MessageCompressionZstd
+	rt.cfg.CompressionThreshold = 256
+
+	events := []*commonEvent.BatchResolvedEvent{newBatchResolvedEventForTest(100), newBatchResolvedEventForTest(1)}
+	for _, event := range events {
+		rt.sendEventCh <- rt.newMessage(NewSingleTargetMessage(rt.targetId, EventCollectorTopic, event))
+	}
+	// The messages other than the log service events are not compressed.
+	heartbeat := &heartbeatpb.HeartBeatRequest{Watermark: &heartbeatpb.Watermark{CheckpointTs: 1, ResolvedTs: 2}}
+	rt.sendEventCh <- rt.newMessage(NewSingleTargetMessage(rt.targetId, MaintainerManagerTopic, heartbeat))
+
+	handshake := &proto.Message{AcceptedCompressions: supportedCompressions, AcceptBatch: true}
+	sender := &mockGrpcSender{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rt.runSendMessages(ctx, sender, rt.sendEventCh, rt.negotiate(handshake, true), msgTypeEvent)
+	}()
+	require.Eventually(t, func() bool {
+		return len(sender.getSent()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// The queued messages are sent together, and only the large log service event is compressed.
+	batch := sender.getSent()[0].Batch
+	require.Len(t, batch, 3)
+	require.Equal(t, config.MessageCompressionZstd, batch[0].Compression)
+	require.Equal(t, "", batch[1].Compression)
+	require.Equal(t, "", batch[2].Compression)
+
+	for i, event := range events {
+		msg := newTargetMessageFromProto(batch[i])
+		require.Equal(t, TypeBatchResolvedTs, msg.Type)
+		require.Equal(t, event, msg.Message[0])
+	}
+	msg := newTargetMessageFromProto(batch[2])
+	require.Equal(t, TypeHeartBeatRequest, msg.Type)
+	require.Equal(t, heartbeat.Watermark.ResolvedTs, msg.Message[0].(*heartbeatpb.HeartBeatRequest).Watermark.ResolvedTs)
+}
+
+func TestRemoteTargetSendToOldServer(t *testing.T) {
+	rt := newRemoteMessageTargetForTest()
+	defer rt.close()
+	rt.cfg.CompressionThreshold = 0
+
+	event := newBatchResolvedEventForTest(10)
+	rt.sendEventCh <- rt.newMessage(NewSingleTargetMessage(rt.targetId, EventCollectorTopic, event))
+	rt.sendEventCh <- rt.newMessage(NewSingleTargetMessage(rt.targetId, EventCollectorTopic, event))
+
+	// The handshake from an old server has no capabilities, so the messages are sent as before.
+	sender := &mockGrpcSender{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rt.runSendMessages(ctx, sender, rt.sendEventCh, rt.negotiate(&proto.Message{}, true), msgTypeEvent)
+	}()
+	require.Eventually(t, func() bool {
+		return len(sender.getSent()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	for _, sent := range sender.getSent() {
+		require.Empty(t, sent.Batch)
+		require.Equal(t, "", sent.Compression)
+		require.Equal(t, event, newTargetMessageFromProto(sent).Message[0])
+	}
+}
@@ -39,7 +39,7 @@ var (
 			Namespace: "ticdc",
 			Subsystem: "owner",
 			Name:      "checkpoint_ts_lag",
-			Help:      "checkpoint ts lag of changefeeds in seconds",
+			Help:      "checkpoint ts lag of changefeeds in seconds, the intentional replication delay is excluded",
 		}, []string{"namespace", "changefeed"})
 
 	CurrentPDTsGauge = prometheus.NewGaugeVec(
@@ -62,7 +62,14 @@ var (
 			Namespace: "ticdc",
 			Subsystem: "owner",
 			Name:      "resolved_ts_lag",
-			Help:      "resolved ts lag of changefeeds in seconds",
+			Help:      "resolved ts lag of changefeeds in seconds, the intentional replication delay is excluded",
+		}, []string{"namespace", "changefeed"})
+	ChangefeedReplicationDelayGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "replication_delay",
+			Help:      "The intentional replication delay of changefeeds in seconds",
 		}, []string{"namespace", "changefeed"})
 
 	CoordinatorCounter = prometheus.NewCounter(
@@ -114,6 +121,7 @@ func InitChangefeedMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(ChangefeedCheckpointTsLagGauge)
 	registry.MustRegister(ChangefeedResolvedTsGauge)
 	registry.MustRegister(ChangefeedResolvedTsLagGauge)
+	registry.MustRegister(ChangefeedReplicationDelayGauge)
 	registry.MustRegister(CurrentPDTsGauge)
 	registry.MustRegister(CoordinatorCounter)
 	registry.MustRegister(MaintainerGauge)
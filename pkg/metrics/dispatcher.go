@@ -112,6 +112,13 @@ var (
 			Buckets:   LagBucket(),
 		}, []string{"type"})
 
+	DispatcherThrottledDuration = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ticdc",
+		Subsystem: "dispatcher",
+		Name:      "throttled_duration_seconds",
+		Help:      "The total duration the events are throttled by the throughput limits before written to the sink",
+	}, []string{"namespace", "changefeed"})
+
 	EventCollectorHandleEventDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "ticdc",
@@ -135,4 +142,5 @@ func InitDispatcherMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventCollectorRegisteredDispatcherCount)
 	registry.MustRegister(EventCollectorReceivedEventLagDuration)
 	registry.MustRegister(EventCollectorHandleEventDuration)
+	registry.MustRegister(DispatcherThrottledDuration)
 }
@@ -34,6 +34,20 @@ var (
 			Subsystem: "dynamic_stream",
 			Name:      "pending_queue_len",
 		}, []string{"component"})
+	DynamicStreamStreamPendingQueueLen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dynamic_stream",
+			Name:      "stream_pending_queue_len",
+			Help:      "The number of pending events of each stream",
+		}, []string{"component", "stream"})
+	DynamicStreamStreamPendingSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "dynamic_stream",
+			Name:      "stream_pending_size",
+			Help:      "The total size(bytes) of pending events of each stream",
+		}, []string{"component", "stream"})
 	DynamicStreamAddPathNum = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -61,6 +75,8 @@ func InitDynamicStreamMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(DynamicStreamMemoryUsage)
 	registry.MustRegister(DynamicStreamEventChanSize)
 	registry.MustRegister(DynamicStreamPendingQueueLen)
+	registry.MustRegister(DynamicStreamStreamPendingQueueLen)
+	registry.MustRegister(DynamicStreamStreamPendingSize)
 	registry.MustRegister(DynamicStreamAddPathNum)
 	registry.MustRegister(DynamicStreamRemovePathNum)
 	registry.MustRegister(DynamicStreamArrangeStreamNum)
@@ -64,6 +64,32 @@ var (
 			Name:      "receive_channel_length",
 			Help:      "The length of the receive channel in a message center",
 		}, []string{"type"}) // type: event, command
+
+	MessagingCompressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "messaging",
+			Name:      "compression_ratio",
+			Help:      "The ratio of the original size to the compressed size of the messages sent by a message center",
+			Buckets:   prometheus.LinearBuckets(1, 1, 20),
+		}, []string{"target", "compression"}) // target: its addr, compression: snappy, zstd
+
+	MessagingCompressedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "messaging",
+			Name:      "compressed_bytes",
+			Help:      "The size of the payloads before and after compression of the messages sent by a message center",
+		}, []string{"target", "stage"}) // target: its addr, stage: original, compressed
+
+	MessagingBatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "messaging",
+			Name:      "batch_size",
+			Help:      "The number of messages sent together in one grpc message by a message center",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+		}, []string{"target", "type"}) // target: its addr, type: event, command
 )
 
 // InitMetrics registers all metrics used in owner
@@ -74,4 +100,7 @@ func InitMessagingMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(MessagingErrorCounter)
 	registry.MustRegister(MessagingStreamGauge)
 	registry.Register(MessagingReceiveChannelLength)
+	registry.MustRegister(MessagingCompressionRatio)
+	registry.MustRegister(MessagingCompressedBytes)
+	registry.MustRegister(MessagingBatchSize)
 }
@@ -88,6 +88,42 @@ var (
 			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20), // 1ms~524s
 		}, []string{"namespace", "changefeed"})
 
+	// ConflictDetectorSlotCount records the slot count of the conflict detector.
+	ConflictDetectorSlotCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_conflict_detector_slot_count",
+			Help:      "The slot count of the conflict detector.",
+		}, []string{"namespace", "changefeed"})
+
+	// ActiveWorkerCount records the number of txn workers that are dispatched txns.
+	ActiveWorkerCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "txn_active_worker_count",
+			Help:      "The number of active txn workers.",
+		}, []string{"namespace", "changefeed"})
+
+	// DeadLetterQueueEntryCount records the count of transactions written to the dead letter queue.
+	DeadLetterQueueEntryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "dead_letter_queue_entry_count",
+			Help:      "Total count of transactions written to the dead letter queue.",
+		}, []string{"namespace", "changefeed"})
+
+	// DeadLetterQueueRowCount records the count of rows written to the dead letter queue.
+	DeadLetterQueueRowCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "dead_letter_queue_row_count",
+			Help:      "Total count of rows written to the dead letter queue.",
+		}, []string{"namespace", "changefeed"})
+
 	// QueueDuration = ConflictDetectDuration + (queue time in txn workers).
 	QueueDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -211,6 +247,10 @@ func InitSinkMetrics(registry *prometheus.Registry) {
 
 	// txn sink metrics
 	registry.MustRegister(ConflictDetectDuration)
+	registry.MustRegister(ConflictDetectorSlotCount)
+	registry.MustRegister(ActiveWorkerCount)
+	registry.MustRegister(DeadLetterQueueEntryCount)
+	registry.MustRegister(DeadLetterQueueRowCount)
 	registry.MustRegister(QueueDuration)
 	registry.MustRegister(WorkerFlushDuration)
 	registry.MustRegister(WorkerTotalDuration)
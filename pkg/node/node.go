@@ -51,6 +51,10 @@ type Info struct {
 
 	// Epoch represents how many times the node has been restarted.
 	Epoch uint64 `json:"epoch"`
+
+	// Labels are the user defined labels of the node, e.g. `zone=az1`,
+	// they are used to constrain the placement of changefeeds.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func NewInfo(addr string, deployPath string) *Info {
@@ -65,8 +69,25 @@ func NewInfo(addr string, deployPath string) *Info {
 }
 
 func (c *Info) String() string {
-	return fmt.Sprintf("ID: %s, AdvertiseAddr: %s, Version: %s, GitHash: %s, DeployPath: %s, StartTimestamp: %d, Epoch: %d",
-		c.ID, c.AdvertiseAddr, c.Version, c.GitHash, c.DeployPath, c.StartTimestamp, c.Epoch)
+	return fmt.Sprintf("ID: %s, AdvertiseAddr: %s, Version: %s, GitHash: %s, DeployPath: %s, StartTimestamp: %d, Epoch: %d, Labels: %v",
+		c.ID, c.AdvertiseAddr, c.Version, c.GitHash, c.DeployPath, c.StartTimestamp, c.Epoch, c.Labels)
+}
+
+// GetLabel returns the value of the label key, and whether the label is set.
+func (c *Info) GetLabel(key string) (string, bool) {
+	value, ok := c.Labels[key]
+	return value, ok
+}
+
+// MatchLabels returns true if the node has all the labels in the selector.
+// An empty selector matches all nodes.
+func (c *Info) MatchLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := c.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
 }
 
 // Marshal using json.Marshal.
@@ -49,6 +49,8 @@ type balanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R re
 	forceBalance bool
 
 	newMoveOperator func(r R, source, target node.ID) operator.Operator[T, S]
+	// placement returns the placement constraints of r, nil means no constraint
+	placement func(r R) *Placement
 }
 
 func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
@@ -56,6 +58,7 @@ func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R
 	oc operator.Controller[T, S], db replica.ScheduleGroup[T, R],
 	nodeManager *watcher.NodeManager, balanceInterval time.Duration,
 	newMoveOperator func(R, node.ID, node.ID) operator.Operator[T, S],
+	placement func(R) *Placement,
 ) *balanceScheduler[T, S, R] {
 	return &balanceScheduler[T, S, R]{
 		id:                   id,
@@ -67,6 +70,7 @@ func NewBalanceScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R
 		checkBalanceInterval: balanceInterval,
 		lastRebalanceTime:    time.Now(),
 		newMoveOperator:      newMoveOperator,
+		placement:            placement,
 	}
 }
 
@@ -86,7 +90,14 @@ func (s *balanceScheduler[T, S, R]) Execute() time.Time {
 	}
 
 	nodes := s.nodeManager.GetAliveNodes()
-	moved := s.schedulerGroup(nodes)
+	moved := 0
+	if s.placement != nil {
+		// fix the replications violating their placement before balancing
+		moved = s.checkPlacement(nodes)
+	}
+	if moved == 0 {
+		moved = s.schedulerGroup(nodes)
+	}
 	if moved == 0 {
 		// all groups are balanced, safe to do the global balance
 		moved = s.schedulerGlobal(nodes)
@@ -98,6 +109,9 @@ func (s *balanceScheduler[T, S, R]) Execute() time.Time {
 }
 
 func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info) int {
+	if s.placement != nil {
+		return s.schedulerGroupWithPlacement(nodes)
+	}
 	availableSize, totalMoved := s.batchSize, 0
 	for _, group := range s.db.GetGroups() {
 		// fast path, check the balance status
@@ -117,9 +131,122 @@ func (s *balanceScheduler[T, S, R]) schedulerGroup(nodes map[node.ID]*node.Info)
 	return totalMoved
 }
 
+// checkPlacement moves the replications which are running on the nodes not satisfying
+// their placement, it happens when the placement of a changefeed is updated.
+func (s *balanceScheduler[T, S, R]) checkPlacement(nodes map[node.ID]*node.Info) int {
+	moved := 0
+	for _, group := range s.db.GetGroups() {
+		nodeTasks := s.db.GetTaskSizePerNodeByGroup(group)
+		for _, replication := range s.db.GetReplicatingByGroup(group) {
+			source, ok := nodes[replication.GetNodeID()]
+			placement := s.placement(replication)
+			if !ok || placement.Match(source) {
+				continue
+			}
+			target, ok := placement.PickNode(nodes, nodeTasks)
+			if !ok {
+				continue
+			}
+			if s.doMove(replication, target) {
+				nodeTasks[source.ID]--
+				nodeTasks[target]++
+				moved++
+			}
+			if moved >= s.batchSize {
+				return moved
+			}
+		}
+	}
+	if moved > 0 {
+		log.Info("scheduler: move replications violating placement",
+			zap.String("id", s.id), zap.Int("moved", moved))
+	}
+	return moved
+}
+
+// schedulerGroupWithPlacement balances the replications sharing the same placement
+// among the nodes satisfying it.
+func (s *balanceScheduler[T, S, R]) schedulerGroupWithPlacement(nodes map[node.ID]*node.Info) int {
+	availableSize, totalMoved := s.batchSize, 0
+	for _, group := range s.db.GetGroups() {
+		for _, p := range s.partitionByPlacement(s.db.GetReplicatingByGroup(group), nodes) {
+			var moveSize int
+			if p.placement != nil && p.placement.SpreadByLabel != "" {
+				moveSize = SpreadBalance(availableSize, s.random, p.placement, p.nodes, p.replications, s.doMove)
+			} else {
+				nodeTasks := make(map[node.ID]int, len(p.nodes))
+				for _, replication := range p.replications {
+					nodeTasks[replication.GetNodeID()]++
+				}
+				if CheckBalanceStatus(nodeTasks, p.nodes) <= 0 {
+					continue
+				}
+				moveSize = Balance(availableSize, s.random, p.nodes, p.replications, s.doMove)
+			}
+			totalMoved += moveSize
+			if totalMoved >= s.batchSize {
+				return totalMoved
+			}
+			availableSize -= moveSize
+		}
+	}
+	return totalMoved
+}
+
+type placementPartition[T replica.ReplicationID, R replica.Replication[T]] struct {
+	placement    *Placement
+	nodes        map[node.ID]*node.Info
+	replications []R
+}
+
+// partitionByPlacement groups the replications by their placement, the replications
+// running on the nodes not satisfying their placement are excluded.
+func (s *balanceScheduler[T, S, R]) partitionByPlacement(
+	replications []R, nodes map[node.ID]*node.Info,
+) map[string]*placementPartition[T, R] {
+	partitions := make(map[string]*placementPartition[T, R])
+	for _, replication := range replications {
+		placement := s.placement(replication)
+		p, ok := partitions[placement.Key()]
+		if !ok {
+			p = &placementPartition[T, R]{placement: placement, nodes: placement.FilterNodes(nodes)}
+			partitions[placement.Key()] = p
+		}
+		if _, ok := p.nodes[replication.GetNodeID()]; ok {
+			p.replications = append(p.replications, replication)
+		}
+	}
+	for key, p := range partitions {
+		if len(p.replications) == 0 || len(p.nodes) == 0 {
+			delete(partitions, key)
+		}
+	}
+	return partitions
+}
+
 // TODO: refactor and simplify the implementation and limit max group size
 func (s *balanceScheduler[T, S, R]) schedulerGlobal(nodes map[node.ID]*node.Info) int {
 	var zero R
+	if s.placement != nil {
+		// the global balance only works when all replications share the same placement
+		var placement *Placement
+		for i, replication := range s.db.GetReplicating() {
+			if i == 0 {
+				placement = s.placement(replication)
+			} else if s.placement(replication).Key() != placement.Key() {
+				return 0
+			}
+		}
+		if placement != nil && placement.SpreadByLabel != "" {
+			// the global balance only counts the tasks per node, it may break the spread
+			// across the label values, which is kept by the group balance.
+			return 0
+		}
+		nodes = placement.FilterNodes(nodes)
+		if len(nodes) == 0 {
+			return 0
+		}
+	}
 	// fast path, check the balance status
 	moveSize := CheckBalanceStatus(s.db.GetTaskSizePerNode(), nodes)
 	if moveSize <= 0 {
@@ -310,3 +437,131 @@ func Balance[T replica.ReplicationID, R replica.Replication[T]](
 		zap.Int("victims", totalMoveSize))
 	return movedSize
 }
+
+// SpreadBalance balances the running tasks of a placement with SpreadByLabel, it balances
+// the tasks across the values of the label first, and then across the nodes with the same
+// label value, so the balance between the nodes never breaks the spread across the values.
+func SpreadBalance[T replica.ReplicationID, R replica.Replication[T]](
+	batchSize int, random *rand.Rand,
+	placement *Placement,
+	activeNodes map[node.ID]*node.Info,
+	replicating []R, move func(R, node.ID) bool,
+) (movedSize int) {
+	domainNodes := make(map[string]map[node.ID]*node.Info)
+	for id, n := range activeNodes {
+		domain := placement.domain(n)
+		if _, ok := domainNodes[domain]; !ok {
+			domainNodes[domain] = make(map[node.ID]*node.Info)
+		}
+		domainNodes[domain][id] = n
+	}
+	nodeTasks := make(map[node.ID][]R, len(activeNodes))
+	for _, task := range replicating {
+		nodeTasks[task.GetNodeID()] = append(nodeTasks[task.GetNodeID()], task)
+	}
+
+	movedSize = balanceDomains(batchSize, domainNodes, nodeTasks, move)
+	if movedSize > 0 {
+		// balance the nodes in the next round, after the moved tasks are running
+		return movedSize
+	}
+	for _, nodes := range domainNodes {
+		var tasks []R
+		taskSize := make(map[node.ID]int, len(nodes))
+		for id := range nodes {
+			tasks = append(tasks, nodeTasks[id]...)
+			taskSize[id] = len(nodeTasks[id])
+		}
+		if len(tasks) == 0 || CheckBalanceStatus(taskSize, nodes) <= 0 {
+			continue
+		}
+		movedSize += Balance(batchSize-movedSize, random, nodes, tasks, move)
+		if movedSize >= batchSize {
+			break
+		}
+	}
+	return movedSize
+}
+
+// balanceDomains moves the tasks from the most loaded label value to the least loaded one,
+// until the task size of every label value reaches the lower limit.
+// The task is moved from the most loaded node of the source value to the least loaded
+// node of the target value.
+func balanceDomains[T replica.ReplicationID, R replica.Replication[T]](
+	batchSize int,
+	domainNodes map[string]map[node.ID]*node.Info,
+	nodeTasks map[node.ID][]R, move func(R, node.ID) bool,
+) (movedSize int) {
+	if len(domainNodes) <= 1 {
+		return 0
+	}
+	domainLoad := make(map[string]int, len(domainNodes))
+	totalSize := 0
+	for domain, nodes := range domainNodes {
+		for id := range nodes {
+			domainLoad[domain] += len(nodeTasks[id])
+			totalSize += len(nodeTasks[id])
+		}
+	}
+	lowerLimitPerDomain := totalSize / len(domainNodes)
+
+	for movedSize < batchSize {
+		var minDomain, maxDomain string
+		first := true
+		for domain, load := range domainLoad {
+			if first {
+				minDomain, maxDomain, first = domain, domain, false
+				continue
+			}
+			if load < domainLoad[minDomain] || (load == domainLoad[minDomain] && domain < minDomain) {
+				minDomain = domain
+			}
+			if load > domainLoad[maxDomain] || (load == domainLoad[maxDomain] && domain < maxDomain) {
+				maxDomain = domain
+			}
+		}
+		if domainLoad[minDomain] >= lowerLimitPerDomain {
+			break
+		}
+		source := pickNodeByTaskSize(domainNodes[maxDomain], nodeTasks, false)
+		target := pickNodeByTaskSize(domainNodes[minDomain], nodeTasks, true)
+		tasks := nodeTasks[source]
+		task := tasks[len(tasks)-1]
+		if !move(task, target) {
+			break
+		}
+		nodeTasks[source] = tasks[:len(tasks)-1]
+		nodeTasks[target] = append(nodeTasks[target], task)
+		domainLoad[maxDomain]--
+		domainLoad[minDomain]++
+		movedSize++
+	}
+	if movedSize > 0 {
+		log.Info("scheduler: balance across label values done",
+			zap.Int("movedSize", movedSize),
+			zap.Int("domains", len(domainNodes)))
+	}
+	return movedSize
+}
+
+// pickNodeByTaskSize returns the least loaded node if least is true,
+// otherwise the most loaded node, ties are broken by the node ID.
+func pickNodeByTaskSize[R any](nodes map[node.ID]*node.Info, nodeTasks map[node.ID][]R, least bool) node.ID {
+	var target node.ID
+	first := true
+	for id := range nodes {
+		if first {
+			target, first = id, false
+			continue
+		}
+		size, targetSize := len(nodeTasks[id]), len(nodeTasks[target])
+		if size == targetSize {
+			if id < target {
+				target = id
+			}
+		} else if (size < targetSize) == least {
+			target = id
+		}
+	}
+	return target
+}
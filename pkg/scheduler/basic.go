@@ -37,6 +37,8 @@ type basicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R repl
 
 	absent         []R                                               // buffer for the absent spans
 	newAddOperator func(r R, target node.ID) operator.Operator[T, S] // scheduler r to target node
+	// placement returns the placement constraints of r, nil means no constraint
+	placement func(r R) *Placement
 }
 
 func NewBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R replica.Replication[T]](
@@ -45,6 +47,7 @@ func NewBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R r
 	db replica.ScheduleGroup[T, R],
 	nodeManager *watcher.NodeManager,
 	newAddOperator func(R, node.ID) operator.Operator[T, S],
+	placement func(R) *Placement,
 ) *basicScheduler[T, S, R] {
 	return &basicScheduler[T, S, R]{
 		id:                 id,
@@ -54,6 +57,7 @@ func NewBasicScheduler[T replica.ReplicationID, S replica.ReplicationStatus, R r
 		nodeManager:        nodeManager,
 		absent:             make([]R, 0, batchSize),
 		newAddOperator:     newAddOperator,
+		placement:          placement,
 	}
 }
 
@@ -81,6 +85,16 @@ func (s *basicScheduler[T, S, R]) Execute() time.Time {
 func (s *basicScheduler[T, S, R]) schedule(id replica.GroupID, availableSize int) (scheduled int) {
 	absent := s.db.GetAbsentByGroup(id, availableSize)
 	nodeSize := s.db.GetTaskSizePerNodeByGroup(id)
+	addOperator := func(replication R, id node.ID) bool {
+		op := s.newAddOperator(replication, id)
+		return s.operatorController.AddOperator(op)
+	}
+	if s.placement != nil {
+		PlacementSchedule(availableSize, absent, s.nodeManager.GetAliveNodes(), nodeSize, s.placement, addOperator)
+		scheduled = len(absent)
+		s.absent = absent[:0]
+		return
+	}
 	// add the absent node to the node size map
 	for id := range s.nodeManager.GetAliveNodes() {
 		if _, ok := nodeSize[id]; !ok {
@@ -88,10 +102,7 @@ func (s *basicScheduler[T, S, R]) schedule(id replica.GroupID, availableSize int
 		}
 	}
 	// what happens if the some node removed when scheduling?
-	BasicSchedule(availableSize, absent, nodeSize, func(replication R, id node.ID) bool {
-		op := s.newAddOperator(replication, id)
-		return s.operatorController.AddOperator(op)
-	})
+	BasicSchedule(availableSize, absent, nodeSize, addOperator)
 	scheduled = len(absent)
 	s.absent = absent[:0]
 	return
@@ -0,0 +1,177 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"go.uber.org/zap"
+)
+
+// Placement is the placement constraints of the replications, it's built from
+// the node labels related settings of a changefeed.
+// A nil Placement means the replications can be placed on any node.
+type Placement struct {
+	// NodeSelector only allows the nodes which have all the labels.
+	NodeSelector map[string]string
+	// SpreadByLabel spreads the replications evenly across the distinct values
+	// of the node label, the nodes without the label are treated as one domain.
+	SpreadByLabel string
+
+	key string
+}
+
+// NewPlacement creates the placement from the changefeed scheduler config,
+// it returns nil if the config does not contain any placement constraint.
+func NewPlacement(cfg *config.ChangefeedSchedulerConfig) *Placement {
+	if cfg == nil || (len(cfg.NodeSelector) == 0 && cfg.SpreadByLabel == "") {
+		return nil
+	}
+	p := &Placement{
+		NodeSelector:  cfg.NodeSelector,
+		SpreadByLabel: cfg.SpreadByLabel,
+	}
+
+	labels := make([]string, 0, len(p.NodeSelector))
+	for key, value := range p.NodeSelector {
+		labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(labels)
+	p.key = fmt.Sprintf("selector:%s,spread:%s", strings.Join(labels, ","), p.SpreadByLabel)
+	return p
+}
+
+// Key returns a string which is identical for the placements with the same constraints.
+func (p *Placement) Key() string {
+	if p == nil {
+		return ""
+	}
+	return p.key
+}
+
+// String implements fmt.Stringer interface.
+func (p *Placement) String() string {
+	return p.Key()
+}
+
+// Match returns true if the replication can be placed on the node.
+func (p *Placement) Match(n *node.Info) bool {
+	if p == nil {
+		return true
+	}
+	return n != nil && n.MatchLabels(p.NodeSelector)
+}
+
+// FilterNodes returns the nodes which the replications can be placed on.
+func (p *Placement) FilterNodes(nodes map[node.ID]*node.Info) map[node.ID]*node.Info {
+	if p == nil || len(p.NodeSelector) == 0 {
+		return nodes
+	}
+	res := make(map[node.ID]*node.Info, len(nodes))
+	for id, n := range nodes {
+		if p.Match(n) {
+			res[id] = n
+		}
+	}
+	return res
+}
+
+// PickNode picks the node to place a new replication on, it prefers the least
+// loaded spread domain, and then the least loaded node in that domain.
+// The nodeTasks is the task size of each node, it returns false if there is
+// no eligible node.
+func (p *Placement) PickNode(nodes map[node.ID]*node.Info, nodeTasks map[node.ID]int) (node.ID, bool) {
+	var (
+		target     node.ID
+		found      bool
+		domainLoad map[string]int
+	)
+	if p != nil && p.SpreadByLabel != "" {
+		domainLoad = make(map[string]int)
+		for id, n := range nodes {
+			if p.Match(n) {
+				domainLoad[p.domain(n)] += nodeTasks[id]
+			}
+		}
+	}
+	for id, n := range nodes {
+		if !p.Match(n) {
+			continue
+		}
+		if !found || p.less(n, id, nodes[target], target, nodeTasks, domainLoad) {
+			target, found = id, true
+		}
+	}
+	return target, found
+}
+
+// less returns true if node a is a better target than node b.
+func (p *Placement) less(
+	a *node.Info, aID node.ID, b *node.Info, bID node.ID,
+	nodeTasks map[node.ID]int, domainLoad map[string]int,
+) bool {
+	if domainLoad != nil {
+		da, db := p.domain(a), p.domain(b)
+		if domainLoad[da] != domainLoad[db] {
+			return domainLoad[da] < domainLoad[db]
+		}
+	}
+	if nodeTasks[aID] != nodeTasks[bID] {
+		return nodeTasks[aID] < nodeTasks[bID]
+	}
+	// keep the result stable
+	return aID < bID
+}
+
+func (p *Placement) domain(n *node.Info) string {
+	value, _ := n.GetLabel(p.SpreadByLabel)
+	return value
+}
+
+// PlacementSchedule schedules the absent tasks to the nodes which satisfy their placement,
+// the tasks without eligible nodes are left absent.
+func PlacementSchedule[T replica.ReplicationID, R replica.Replication[T]](
+	availableSize int,
+	absent []R,
+	nodes map[node.ID]*node.Info,
+	nodeTasks map[node.ID]int,
+	placement func(R) *Placement,
+	schedule func(R, node.ID) bool,
+) {
+	taskSize, unschedulable := 0, 0
+	for _, r := range absent {
+		target, ok := placement(r).PickNode(nodes, nodeTasks)
+		if !ok {
+			unschedulable++
+			continue
+		}
+		if schedule(r, target) {
+			nodeTasks[target]++
+			taskSize++
+		}
+		if taskSize >= availableSize {
+			break
+		}
+	}
+	if unschedulable > 0 {
+		log.Warn("scheduler: no node satisfies the placement, skip",
+			zap.Int("unschedulable", unschedulable))
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/pkg/scheduler/replica"
+	"github.com/stretchr/testify/require"
+)
+
+type testReplicationID string
+
+func (id testReplicationID) String() string {
+	return string(id)
+}
+
+type testReplication struct {
+	id     testReplicationID
+	nodeID node.ID
+}
+
+func (r *testReplication) GetID() testReplicationID    { return r.id }
+func (r *testReplication) GetGroupID() replica.GroupID { return replica.DefaultGroupID }
+func (r *testReplication) GetNodeID() node.ID          { return r.nodeID }
+func (r *testReplication) SetNodeID(id node.ID)        { r.nodeID = id }
+func (r *testReplication) ShouldRun() bool             { return true }
+
+func newLabeledNodes() map[node.ID]*node.Info {
+	return map[node.ID]*node.Info{
+		"node1": {ID: "node1", Labels: map[string]string{"zone": "az1", "role": "mq"}},
+		"node2": {ID: "node2", Labels: map[string]string{"zone": "az1", "role": "mysql"}},
+		"node3": {ID: "node3", Labels: map[string]string{"zone": "az2", "role": "mq"}},
+		"node4": {ID: "node4"},
+	}
+}
+
+func TestNewPlacement(t *testing.T) {
+	require.Nil(t, NewPlacement(nil))
+	require.Nil(t, NewPlacement(&config.ChangefeedSchedulerConfig{}))
+
+	p1 := NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector: map[string]string{"zone": "az1", "role": "mq"},
+	})
+	p2 := NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector: map[string]string{"role": "mq", "zone": "az1"},
+	})
+	require.Equal(t, p1.Key(), p2.Key())
+	p3 := NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector:  map[string]string{"role": "mq", "zone": "az1"},
+		SpreadByLabel: "zone",
+	})
+	require.NotEqual(t, p1.Key(), p3.Key())
+
+	var nilPlacement *Placement
+	require.Equal(t, "", nilPlacement.Key())
+	require.True(t, nilPlacement.Match(&node.Info{ID: "node1"}))
+}
+
+func TestPlacementFilterNodes(t *testing.T) {
+	nodes := newLabeledNodes()
+
+	var nilPlacement *Placement
+	require.Len(t, nilPlacement.FilterNodes(nodes), 4)
+
+	p := NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector: map[string]string{"role": "mq"},
+	})
+	filtered := p.FilterNodes(nodes)
+	require.Len(t, filtered, 2)
+	require.Contains(t, filtered, node.ID("node1"))
+	require.Contains(t, filtered, node.ID("node3"))
+
+	p = NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector: map[string]string{"role": "tiflash"},
+	})
+	require.Len(t, p.FilterNodes(nodes), 0)
+	_, ok := p.PickNode(nodes, map[node.ID]int{})
+	require.False(t, ok)
+}
+
+func TestPlacementPickNode(t *testing.T) {
+	nodes := newLabeledNodes()
+
+	// pick the least loaded node without any constraint
+	var nilPlacement *Placement
+	target, ok := nilPlacement.PickNode(nodes, map[node.ID]int{"node1": 3, "node2": 1, "node3": 2, "node4": 1})
+	require.True(t, ok)
+	require.Equal(t, node.ID("node2"), target)
+
+	// only the nodes with role=mq are eligible
+	p := NewPlacement(&config.ChangefeedSchedulerConfig{
+		NodeSelector: map[string]string{"role": "mq"},
+	})
+	target, ok = p.PickNode(nodes, map[node.ID]int{"node1": 3, "node2": 0, "node3": 2})
+	require.True(t, ok)
+	require.Equal(t, node.ID("node3"), target)
+
+	// az1 has 2 tasks in total, az2 has 3 tasks, the node without zone label has 5 tasks
+	p = NewPlacement(&config.ChangefeedSchedulerConfig{SpreadByLabel: "zone"})
+	nodeTasks := map[node.ID]int{"node1": 2, "node2": 0, "node3": 3, "node4": 5}
+	target, ok = p.PickNode(nodes, nodeTasks)
+	require.True(t, ok)
+	require.Equal(t, node.ID("node2"), target)
+
+	// spread the tasks across zones one by one
+	nodeTasks = map[node.ID]int{}
+	zones := map[string]int{}
+	for i := 0; i < 9; i++ {
+		target, ok = p.PickNode(nodes, nodeTasks)
+		require.True(t, ok)
+		nodeTasks[target]++
+		zone, _ := nodes[target].GetLabel("zone")
+		zones[zone]++
+	}
+	require.Equal(t, map[string]int{"az1": 3, "az2": 3, "": 3}, zones)
+	// the tasks are also balanced between the nodes in the same zone
+	require.InDelta(t, nodeTasks["node1"], nodeTasks["node2"], 1)
+}
+
+func TestPlacementSpreadBalance(t *testing.T) {
+	nodes := map[node.ID]*node.Info{
+		"node1": {ID: "node1", Labels: map[string]string{"zone": "az1"}},
+		"node2": {ID: "node2", Labels: map[string]string{"zone": "az1"}},
+		"node3": {ID: "node3", Labels: map[string]string{"zone": "az2"}},
+	}
+	p := NewPlacement(&config.ChangefeedSchedulerConfig{SpreadByLabel: "zone"})
+	placement := func(*testReplication) *Placement { return p }
+	move := func(r *testReplication, target node.ID) bool {
+		r.SetNodeID(target)
+		return true
+	}
+	zoneTasks := func(replications []*testReplication) map[string]int {
+		res := make(map[string]int)
+		for _, r := range replications {
+			zone, _ := nodes[r.GetNodeID()].GetLabel("zone")
+			res[zone]++
+		}
+		return res
+	}
+	random := rand.New(rand.NewSource(1))
+
+	replications := make([]*testReplication, 0, 12)
+	for i := 0; i < 12; i++ {
+		replications = append(replications, &testReplication{id: testReplicationID(fmt.Sprintf("t%d", i))})
+	}
+	PlacementSchedule(len(replications), replications, nodes, map[node.ID]int{}, placement, move)
+	require.Equal(t, map[string]int{"az1": 6, "az2": 6}, zoneTasks(replications))
+
+	// the placed tasks are balanced, the balance must not move them towards 4/4/4 per node
+	require.Equal(t, 0, SpreadBalance(10, random, p, nodes, replications, move))
+	require.Equal(t, map[string]int{"az1": 6, "az2": 6}, zoneTasks(replications))
+
+	// all tasks are moved to node1, they are spread across the zones first
+	for _, r := range replications {
+		r.SetNodeID("node1")
+	}
+	require.Equal(t, 6, SpreadBalance(10, random, p, nodes, replications, move))
+	require.Equal(t, map[string]int{"az1": 6, "az2": 6}, zoneTasks(replications))
+	// and then balanced between the nodes in the same zone
+	require.Equal(t, 3, SpreadBalance(10, random, p, nodes, replications, move))
+	nodeTasks := make(map[node.ID]int)
+	for _, r := range replications {
+		nodeTasks[r.GetNodeID()]++
+	}
+	require.Equal(t, map[node.ID]int{"node1": 3, "node2": 3, "node3": 6}, nodeTasks)
+	require.Equal(t, 0, SpreadBalance(10, random, p, nodes, replications, move))
+}
@@ -28,7 +28,6 @@ import (
 
 	"github.com/pingcap/log"
 	commonType "github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -147,14 +146,15 @@ type FilePathGenerator struct {
 	versionMap map[VersionedTableName]uint64
 }
 
-// NewFilePathGenerator creates a FilePathGenerator.
+// NewFilePathGenerator creates a FilePathGenerator, pdClock is the clock of the
+// upstream of the changefeed, which is used to generate the date of the paths.
 func NewFilePathGenerator(
 	changefeedID commonType.ChangeFeedID,
 	config *Config,
 	storage storage.ExternalStorage,
 	extension string,
+	pdClock pdutil.Clock,
 ) *FilePathGenerator {
-	pdClock := appcontext.GetService[pdutil.Clock](appcontext.DefaultPDClock)
 	if pdClock == nil {
 		pdClock = pdutil.NewMonotonicClock(clock.New())
 		log.Warn("pd clock is not set in storage sink, use local clock instead",
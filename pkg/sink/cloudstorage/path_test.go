@@ -25,7 +25,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
 	commonType "github.com/pingcap/ticdc/pkg/common"
-	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -53,9 +52,7 @@ func testFilePathGenerator(ctx context.Context, t *testing.T, dir string) *FileP
 	err = cfg.Apply(ctx, sinkURI, replicaConfig.Sink)
 	require.NoError(t, err)
 
-	mockPDClock := pdutil.NewClock4Test()
-	appcontext.SetService(appcontext.DefaultPDClock, mockPDClock)
-	f := NewFilePathGenerator(commonType.ChangeFeedID{}, cfg, storage, ".json")
+	f := NewFilePathGenerator(commonType.ChangeFeedID{}, cfg, storage, ".json", pdutil.NewClock4Test())
 	return f
 }
 
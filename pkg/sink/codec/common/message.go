@@ -16,6 +16,8 @@ package common
 import (
 	"encoding/binary"
 	"encoding/json"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
 )
 
 // MaxRecordOverhead is used to calculate message size by sarama kafka client.
@@ -48,6 +50,10 @@ type Message struct {
 
 	// PartitionKey for pulsar, route messages to one or different partitions
 	PartitionKey *string
+
+	// TableSpan is the span of the dispatcher which the message belongs to,
+	// the kafka transactional producer sends the messages of a span in its own transactions.
+	TableSpan *heartbeatpb.TableSpan
 }
 
 // Length returns the expected size of the Kafka message
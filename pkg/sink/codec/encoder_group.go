@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	commonType "github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -227,6 +228,15 @@ func newFuture(key model.TopicPartitionKey,
 	}
 }
 
+// TableSpan returns the table span of the first event in the future,
+// it's the span of all the events if they are grouped by the table span.
+func (p *future) TableSpan() *heartbeatpb.TableSpan {
+	if len(p.events) == 0 {
+		return nil
+	}
+	return p.events[0].TableSpan
+}
+
 // Ready waits until the response is ready, should be called before consuming the future.
 func (p *future) Ready(ctx context.Context) error {
 	select {
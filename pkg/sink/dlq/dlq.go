@@ -0,0 +1,227 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DownstreamTarget means the dead letters are written to a table in the downstream.
+const DownstreamTarget = "downstream"
+
+// Row is a row change in the dead letter entry, the column values are formatted as strings.
+type Row struct {
+	Type       string             `json:"type"`
+	PreColumns map[string]*string `json:"pre_columns,omitempty"`
+	Columns    map[string]*string `json:"columns,omitempty"`
+}
+
+// Entry is a transaction which can't be applied to the downstream.
+type Entry struct {
+	Changefeed string    `json:"changefeed"`
+	Schema     string    `json:"schema"`
+	Table      string    `json:"table"`
+	StartTs    uint64    `json:"start_ts"`
+	CommitTs   uint64    `json:"commit_ts"`
+	Rows       []Row     `json:"rows"`
+	SQLs       []string  `json:"sqls"`
+	Args       [][]any   `json:"args"`
+	Error      string    `json:"error"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewEntry builds the dead letter entry for the event, sqls and args are the statements
+// failed to execute, err is the error returned by the downstream.
+func NewEntry(
+	changefeedID common.ChangeFeedID, event *commonEvent.DMLEvent,
+	sqls []string, args [][]any, err error,
+) (*Entry, error) {
+	tableInfo := event.TableInfo
+	entry := &Entry{
+		Changefeed: changefeedID.String(),
+		Schema:     tableInfo.GetSchemaName(),
+		Table:      tableInfo.GetTableName(),
+		StartTs:    event.StartTs,
+		CommitTs:   event.CommitTs,
+		SQLs:       sqls,
+		Args:       args,
+		Error:      err.Error(),
+		CreatedAt:  time.Now(),
+	}
+
+	columns := tableInfo.GetColumns()
+	formatRow := func(row *chunk.Row) (map[string]*string, error) {
+		values := make(map[string]*string, len(columns))
+		for i, col := range columns {
+			if col == nil || tableInfo.GetColumnFlags()[col.ID].IsGeneratedColumn() {
+				continue
+			}
+			value, err := common.FormatColVal(row, col, i)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if value == nil {
+				values[col.Name.O] = nil
+				continue
+			}
+			s := strings.Clone(model.ColumnValueString(value))
+			values[col.Name.O] = &s
+		}
+		return values, nil
+	}
+
+	defer event.FinishGetRow()
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		r := Row{Type: strings.ToLower(commonEvent.RowTypeToString(row.RowType))}
+		var err error
+		if !row.PreRow.IsEmpty() {
+			if r.PreColumns, err = formatRow(&row.PreRow); err != nil {
+				return nil, err
+			}
+		}
+		if !row.Row.IsEmpty() {
+			if r.Columns, err = formatRow(&row.Row); err != nil {
+				return nil, err
+			}
+		}
+		entry.Rows = append(entry.Rows, r)
+	}
+	return entry, nil
+}
+
+// backend is the target which the dead letter entries are written to.
+type backend interface {
+	write(ctx context.Context, entry *Entry, data []byte) error
+	list(ctx context.Context, limit int) ([]*Entry, error)
+	close()
+}
+
+// Queue is the dead letter queue of a changefeed, the transactions which can't be applied
+// to the downstream are written to it instead of failing the changefeed.
+type Queue struct {
+	changefeedID common.ChangeFeedID
+	target       string
+	backend      backend
+
+	metricEntryCount prometheus.Counter
+	metricRowCount   prometheus.Counter
+}
+
+// New creates the dead letter queue by the target, which can be DownstreamTarget or an
+// external storage uri. db is the downstream database, it's only used by DownstreamTarget.
+// It returns nil if the target is empty.
+func New(
+	ctx context.Context, changefeedID common.ChangeFeedID, target string, db *sql.DB,
+) (*Queue, error) {
+	if target == "" {
+		return nil, nil
+	}
+
+	b, err := newBackend(ctx, changefeedID, target, db)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("dead letter queue enabled",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("target", util.MaskSensitiveDataInURI(target)))
+
+	return &Queue{
+		changefeedID:     changefeedID,
+		target:           target,
+		backend:          b,
+		metricEntryCount: metrics.DeadLetterQueueEntryCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
+		metricRowCount:   metrics.DeadLetterQueueRowCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
+	}, nil
+}
+
+// List returns at most limit entries in the dead letter queue of the changefeed, ordered by
+// the commit ts. It's used to inspect the dead letter queue without a running sink.
+func List(
+	ctx context.Context, changefeedID common.ChangeFeedID, target string, db *sql.DB, limit int,
+) ([]*Entry, error) {
+	b, err := newBackend(ctx, changefeedID, target, db)
+	if err != nil {
+		return nil, err
+	}
+	defer b.close()
+	return b.list(ctx, limit)
+}
+
+func newBackend(
+	ctx context.Context, changefeedID common.ChangeFeedID, target string, db *sql.DB,
+) (backend, error) {
+	if target == DownstreamTarget {
+		if db == nil {
+			return nil, errors.New("downstream database is required by the dead letter queue")
+		}
+		return newTableBackend(changefeedID, db), nil
+	}
+	b, err := newStorageBackend(ctx, changefeedID, target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return b, nil
+}
+
+// Write writes the entry to the dead letter queue.
+func (q *Queue) Write(ctx context.Context, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = q.backend.write(ctx, entry, data); err != nil {
+		return errors.Trace(err)
+	}
+	q.metricEntryCount.Inc()
+	q.metricRowCount.Add(float64(len(entry.Rows)))
+	log.Warn("write transaction to the dead letter queue",
+		zap.String("namespace", q.changefeedID.Namespace()),
+		zap.String("changefeed", q.changefeedID.Name()),
+		zap.String("schema", entry.Schema),
+		zap.String("table", entry.Table),
+		zap.Uint64("commitTs", entry.CommitTs),
+		zap.Int("rows", len(entry.Rows)),
+		zap.String("error", entry.Error))
+	return nil
+}
+
+// Close closes the dead letter queue and cleans up the metrics.
+func (q *Queue) Close() {
+	if q == nil {
+		return
+	}
+	q.backend.close()
+	metrics.DeadLetterQueueEntryCount.DeleteLabelValues(q.changefeedID.Namespace(), q.changefeedID.Name())
+	metrics.DeadLetterQueueRowCount.DeleteLabelValues(q.changefeedID.Namespace(), q.changefeedID.Name())
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEntry(t *testing.T) *Entry {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32), age int);")
+	require.NotNil(t, job)
+
+	event := helper.DML2Event("test", "t", "insert into t values (1, 'test', null)", "insert into t values (2, 'test2', 20)")
+	event.StartTs = 1
+	event.CommitTs = 2
+
+	changefeedID := common.NewChangefeedID4Test("test", "dlq")
+	entry, err := NewEntry(changefeedID, event,
+		[]string{"INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)"},
+		[][]any{{1, "test", nil, 2, "test2", 20}}, errors.New("data too long"))
+	require.NoError(t, err)
+	// the rows of the event can be read again.
+	_, ok := event.GetNextRow()
+	require.True(t, ok)
+	return entry
+}
+
+func TestNewEntry(t *testing.T) {
+	entry := newTestEntry(t)
+	require.Equal(t, "test", entry.Schema)
+	require.Equal(t, "t", entry.Table)
+	require.Equal(t, uint64(1), entry.StartTs)
+	require.Equal(t, uint64(2), entry.CommitTs)
+	require.Equal(t, "data too long", entry.Error)
+	require.Len(t, entry.Rows, 2)
+	require.Equal(t, "insert", entry.Rows[0].Type)
+	require.Nil(t, entry.Rows[0].PreColumns)
+	require.Equal(t, "1", *entry.Rows[0].Columns["id"])
+	require.Equal(t, "test", *entry.Rows[0].Columns["name"])
+	require.Nil(t, entry.Rows[0].Columns["age"])
+	require.Equal(t, "20", *entry.Rows[1].Columns["age"])
+}
+
+func TestStorageQueue(t *testing.T) {
+	ctx := context.Background()
+	changefeedID := common.NewChangefeedID4Test("test", "dlq")
+	target := "file://" + t.TempDir()
+
+	// the queue is disabled if the target is empty.
+	queue, err := New(ctx, changefeedID, "", nil)
+	require.NoError(t, err)
+	require.Nil(t, queue)
+	queue.Close()
+
+	queue, err = New(ctx, changefeedID, target, nil)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	entries, err := List(ctx, changefeedID, target, nil, 0)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	for _, commitTs := range []uint64{12, 3, 100} {
+		entry := newTestEntry(t)
+		entry.CommitTs = commitTs
+		require.NoError(t, queue.Write(ctx, entry))
+	}
+
+	entries, err = List(ctx, changefeedID, target, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(3), entries[0].CommitTs)
+	require.Equal(t, uint64(12), entries[1].CommitTs)
+	require.Equal(t, uint64(100), entries[2].CommitTs)
+	require.Len(t, entries[0].Rows, 2)
+
+	entries, err = List(ctx, changefeedID, target, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// the entries of other changefeeds are not listed.
+	entries, err = List(ctx, common.NewChangefeedID4Test("test", "other"), target, nil, 0)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestDownstreamQueue(t *testing.T) {
+	ctx := context.Background()
+	changefeedID := common.NewChangefeedID4Test("test", "dlq")
+
+	_, err := New(ctx, changefeedID, DownstreamTarget, nil)
+	require.Error(t, err)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	queue, err := New(ctx, changefeedID, DownstreamTarget, db)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	entry := newTestEntry(t)
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `tidb_cdc`").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS `tidb_cdc`.`dead_letter_queue_v1`").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `tidb_cdc`.`dead_letter_queue_v1`").
+		WithArgs(sqlmock.AnyArg(), changefeedID.String(), "test", "t", uint64(2), "data too long", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	require.NoError(t, queue.Write(ctx, entry))
+
+	// the table is only created once.
+	mock.ExpectExec("INSERT INTO `tidb_cdc`.`dead_letter_queue_v1`").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	require.NoError(t, queue.Write(ctx, entry))
+
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT entry FROM `tidb_cdc`.`dead_letter_queue_v1`").
+		WithArgs(sqlmock.AnyArg(), changefeedID.String(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"entry"}).AddRow(string(data)))
+	entries, err := List(ctx, changefeedID, DownstreamTarget, db, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entry.Rows, entries[0].Rows)
+	require.Equal(t, entry.SQLs, entries[0].SQLs)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tiflow/pkg/util"
+)
+
+const defaultStorageTimeout = 5 * time.Minute
+
+// storageBackend writes each dead letter entry as a json file to the external storage,
+// the files are placed in the directory of the changefeed, and named by the commit ts.
+type storageBackend struct {
+	storage storage.ExternalStorage
+	dir     string
+}
+
+func newStorageBackend(ctx context.Context, changefeedID common.ChangeFeedID, uri string) (*storageBackend, error) {
+	externalStorage, err := util.GetExternalStorageWithTimeout(ctx, uri, defaultStorageTimeout)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storageBackend{
+		storage: externalStorage,
+		dir:     path.Join(changefeedID.Namespace(), changefeedID.Name()),
+	}, nil
+}
+
+func (s *storageBackend) write(ctx context.Context, entry *Entry, data []byte) error {
+	// The commit ts is padded to make the files sorted by the commit ts.
+	name := path.Join(s.dir, fmt.Sprintf("%020d-%s.json", entry.CommitTs, uuid.NewString()))
+	return errors.Trace(s.storage.WriteFile(ctx, name, data))
+}
+
+func (s *storageBackend) list(ctx context.Context, limit int) ([]*Entry, error) {
+	var names []string
+	err := s.storage.WalkDir(ctx, &storage.WalkOption{SubDir: s.dir}, func(name string, _ int64) error {
+		if strings.HasSuffix(name, ".json") {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(names)
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+
+	entries := make([]*Entry, 0, len(names))
+	for _, name := range names {
+		data, err := s.storage.ReadFile(ctx, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entry := new(Entry)
+		if err = json.Unmarshal(data, entry); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *storageBackend) close() {
+	s.storage.Close()
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// tableBackend writes the dead letter entries to a table in the downstream.
+type tableBackend struct {
+	changefeedID common.ChangeFeedID
+	db           *sql.DB
+
+	mu          sync.Mutex
+	initialized bool
+}
+
+func newTableBackend(changefeedID common.ChangeFeedID, db *sql.DB) *tableBackend {
+	return &tableBackend{
+		changefeedID: changefeedID,
+		db:           db,
+	}
+}
+
+func (t *tableBackend) quotedTable() string {
+	return common.QuoteSchema(filter.TiCDCSystemSchema, filter.DeadLetterQueueTable)
+}
+
+func (t *tableBackend) createTable(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.initialized {
+		return nil
+	}
+	_, err := t.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+common.QuoteName(filter.TiCDCSystemSchema))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create dead letter queue database"))
+	}
+	query := `CREATE TABLE IF NOT EXISTS %s
+	(
+		id bigint NOT NULL AUTO_INCREMENT,
+		ticdc_cluster_id varchar(255),
+		changefeed varchar(255),
+		schema_name varchar(255),
+		table_name varchar(255),
+		commit_ts bigint unsigned,
+		error text,
+		entry longtext,
+		created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		INDEX (changefeed, commit_ts),
+		PRIMARY KEY (id)
+	);`
+	_, err = t.db.ExecContext(ctx, fmt.Sprintf(query, t.quotedTable()))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to create dead letter queue table"))
+	}
+	t.initialized = true
+	return nil
+}
+
+func (t *tableBackend) write(ctx context.Context, entry *Entry, data []byte) error {
+	if err := t.createTable(ctx); err != nil {
+		return err
+	}
+	query := "INSERT INTO " + t.quotedTable() +
+		" (ticdc_cluster_id, changefeed, schema_name, table_name, commit_ts, error, entry) VALUES (?,?,?,?,?,?,?)"
+	_, err := t.db.ExecContext(ctx, query,
+		config.GetGlobalServerConfig().ClusterID, t.changefeedID.String(),
+		entry.Schema, entry.Table, entry.CommitTs, entry.Error, string(data))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to write dead letter queue table"))
+	}
+	return nil
+}
+
+func (t *tableBackend) list(ctx context.Context, limit int) ([]*Entry, error) {
+	query := "SELECT entry FROM " + t.quotedTable() +
+		" WHERE ticdc_cluster_id = ? AND changefeed = ? ORDER BY commit_ts, id"
+	args := []any{config.GetGlobalServerConfig().ClusterID, t.changefeedID.String()}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError); ok && mysqlErr.Number == mysql.ErrNoSuchTable {
+			// nothing has been written to the dead letter queue yet.
+			return nil, nil
+		}
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		var data string
+		if err = rows.Scan(&data); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		entry := new(Entry)
+		if err = json.Unmarshal([]byte(data), entry); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, errors.Trace(rows.Err())
+}
+
+func (t *tableBackend) close() {}
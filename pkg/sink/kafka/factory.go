@@ -34,6 +34,9 @@ type Factory interface {
 	SyncProducer() (SyncProducer, error)
 	// AsyncProducer creates an async producer to writer message to kafka
 	AsyncProducer(ctx context.Context) (AsyncProducer, error)
+	// TransactionalProducer creates a transactional producer to write message to kafka,
+	// transactionalID must be unique among all the producers.
+	TransactionalProducer(ctx context.Context, transactionalID string) (TransactionalProducer, error)
 	// MetricsCollector returns the kafka metrics collector
 	MetricsCollector(adminClient ClusterAdminClient) MetricsCollector
 }
@@ -68,6 +68,25 @@ func (f *MockFactory) AsyncProducer(ctx context.Context) (AsyncProducer, error)
 	}, nil
 }
 
+// TransactionalProducer creates a transactional producer
+func (f *MockFactory) TransactionalProducer(
+	_ context.Context, transactionalID string,
+) (TransactionalProducer, error) {
+	config, err := newSaramaTransactionalConfig(f.config, transactionalID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	asyncProducer := mocks.NewAsyncProducer(f.ErrorReporter, config)
+	return &MockSaramaTransactionalProducer{
+		saramaTransactionalProducer: &saramaTransactionalProducer{
+			changefeedID:    f.changefeedID,
+			transactionalID: transactionalID,
+			producer:        asyncProducer,
+		},
+		AsyncProducer: asyncProducer,
+	}, nil
+}
+
 // MetricsCollector returns the metric collector
 func (f *MockFactory) MetricsCollector(_ ClusterAdminClient) MetricsCollector {
 	return &mockMetricsCollector{}
@@ -177,6 +196,17 @@ func (p *MockSaramaAsyncProducer) Close() {
 	p.closed = true
 }
 
+// MockSaramaTransactionalProducer is a mock implementation of TransactionalProducer interface.
+type MockSaramaTransactionalProducer struct {
+	*saramaTransactionalProducer
+	AsyncProducer *mocks.AsyncProducer
+}
+
+// Close implement the TransactionalProducer interface.
+func (p *MockSaramaTransactionalProducer) Close() {
+	_ = p.AsyncProducer.Close()
+}
+
 type mockMetricsCollector struct{}
 
 // Run implements the MetricsCollector interface.
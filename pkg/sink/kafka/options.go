@@ -138,6 +138,7 @@ type urlConfig struct {
 	Cert                         *string `form:"cert"`
 	Key                          *string `form:"key"`
 	InsecureSkipVerify           *bool   `form:"insecure-skip-verify"`
+	EnableTransaction            *bool   `form:"enable-transaction"`
 }
 
 // Options stores user specified configurations
@@ -170,6 +171,10 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// EnableTransaction indicates whether to send the messages in kafka transactions,
+	// so the `read_committed` consumers can see each message exactly once.
+	EnableTransaction bool
 }
 
 // NewOptions returns a default Kafka configuration
@@ -308,6 +313,14 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if urlParameter.EnableTransaction != nil {
+		o.EnableTransaction = *urlParameter.EnableTransaction
+	}
+	if o.EnableTransaction && o.RequiredAcks != WaitForAll {
+		return cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"required-acks must be %d when the transaction is enabled", WaitForAll)
+	}
+
 	err = o.applySASL(urlParameter, sinkConfig)
 	if err != nil {
 		return err
@@ -355,6 +368,7 @@ func mergeConfig(
 		dest.Cert = fileConifg.Cert
 		dest.Key = fileConifg.Key
 		dest.InsecureSkipVerify = fileConifg.InsecureSkipVerify
+		dest.EnableTransaction = fileConifg.EnableTransaction
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, err
@@ -123,6 +123,14 @@ func (f *saramaFactory) AsyncProducer(_ context.Context) (AsyncProducer, error)
 	}, nil
 }
 
+// TransactionalProducer returns a transactional producer,
+// it should be the caller's responsibility to close the producer
+func (f *saramaFactory) TransactionalProducer(
+	_ context.Context, transactionalID string,
+) (TransactionalProducer, error) {
+	return newSaramaTransactionalProducer(f.config, f.endpoints, f.changefeedID, transactionalID)
+}
+
 func (f *saramaFactory) MetricsCollector(
 	adminClient ClusterAdminClient,
 ) MetricsCollector {
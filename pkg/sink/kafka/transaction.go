@@ -0,0 +1,228 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// TransactionalProducer is the kafka producer which sends messages in transactions,
+// the messages sent in a transaction are only visible to the `read_committed` consumers
+// after the transaction is committed.
+// It's not thread safe, the caller should make sure that only one goroutine uses it.
+type TransactionalProducer interface {
+	// BeginTxn starts a new transaction.
+	BeginTxn() error
+	// AsyncSend sends a message in the current transaction.
+	AsyncSend(ctx context.Context, topic string, partition int32, message *common.Message) error
+	// CommitTxn waits for all the messages in the current transaction to be
+	// acknowledged, and then commits the transaction.
+	CommitTxn(ctx context.Context) error
+	// AbortTxn aborts the current transaction.
+	AbortTxn() error
+	// Close shuts down the producer, the ongoing transaction is aborted by the broker.
+	Close()
+}
+
+// NewTransactionalID generates the `transactional.id` of the producer which sends
+// the messages of the given table span.
+// The id only depends on the changefeed and the span, so it's stable when the
+// dispatcher of the span restarts or moves to another capture, and the broker can
+// fence the producer of the previous owner and abort its ongoing transaction.
+func NewTransactionalID(changefeedID commonType.ChangeFeedID, span *heartbeatpb.TableSpan) string {
+	id := fmt.Sprintf("TiCDC_txn_%s_%s_%d_%x_%x",
+		changefeedID.Namespace(), changefeedID.Name(), span.TableID, span.StartKey, span.EndKey)
+	return commonInvalidChar.ReplaceAllString(id, "_")
+}
+
+// NewSaramaTransactionalProducer creates a transactional producer with sarama implementation.
+func NewSaramaTransactionalProducer(
+	ctx context.Context, o *Options, changefeedID commonType.ChangeFeedID, transactionalID string,
+) (TransactionalProducer, error) {
+	config, err := NewSaramaConfig(ctx, o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newSaramaTransactionalProducer(config, o.BrokerEndpoints, changefeedID, transactionalID)
+}
+
+func newSaramaTransactionalProducer(
+	config *sarama.Config, endpoints []string,
+	changefeedID commonType.ChangeFeedID, transactionalID string,
+) (*saramaTransactionalProducer, error) {
+	txnConfig, err := newSaramaTransactionalConfig(config, transactionalID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := sarama.NewClient(endpoints, txnConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Trace(err)
+	}
+	log.Info("kafka transactional producer created",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("transactionalID", transactionalID))
+	return &saramaTransactionalProducer{
+		changefeedID:    changefeedID,
+		transactionalID: transactionalID,
+		client:          client,
+		producer:        p,
+	}, nil
+}
+
+// newSaramaTransactionalConfig returns a copy of the config which enables the transaction.
+func newSaramaTransactionalConfig(config *sarama.Config, transactionalID string) (*sarama.Config, error) {
+	if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"kafka transaction requires kafka version 0.11.0 or later, but got %s", config.Version)
+	}
+	txnConfig := *config
+	// The transactional producer must be idempotent, the sequence numbers of
+	// the idempotent producer also guarantee the order of the retried messages.
+	txnConfig.Producer.Idempotent = true
+	txnConfig.Producer.RequiredAcks = sarama.WaitForAll
+	txnConfig.Producer.Retry.Max = 3
+	txnConfig.Producer.Transaction.ID = transactionalID
+	txnConfig.Producer.Return.Successes = true
+	txnConfig.Producer.Return.Errors = true
+	txnConfig.Net.MaxOpenRequests = 1
+	if err := txnConfig.Validate(); err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+	return &txnConfig, nil
+}
+
+type saramaTransactionalProducer struct {
+	changefeedID    commonType.ChangeFeedID
+	transactionalID string
+	client          sarama.Client
+	producer        sarama.AsyncProducer
+	// inflight is the number of messages sent in the current transaction,
+	// which are not acknowledged yet.
+	inflight int
+}
+
+func (p *saramaTransactionalProducer) BeginTxn() error {
+	if err := p.producer.BeginTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	p.inflight = 0
+	return nil
+}
+
+func (p *saramaTransactionalProducer) AsyncSend(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	msg := &sarama.ProducerMessage{
+		Topic:     topic,
+		Partition: partition,
+		Key:       sarama.ByteEncoder(message.Key),
+		Value:     sarama.ByteEncoder(message.Value),
+	}
+	for {
+		// The acknowledgements are drained when sending, otherwise the producer
+		// may be blocked if the successes channel is full.
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case p.producer.Input() <- msg:
+			p.inflight++
+			return nil
+		case <-p.producer.Successes():
+			p.inflight--
+		case err := <-p.producer.Errors():
+			return p.handleError(err)
+		}
+	}
+}
+
+func (p *saramaTransactionalProducer) CommitTxn(ctx context.Context) error {
+	for p.inflight > 0 {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-p.producer.Successes():
+			p.inflight--
+		case err := <-p.producer.Errors():
+			return p.handleError(err)
+		}
+	}
+	if err := p.producer.CommitTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	return nil
+}
+
+func (p *saramaTransactionalProducer) AbortTxn() error {
+	if err := p.producer.AbortTxn(); err != nil {
+		return cerror.WrapError(cerror.ErrKafkaTransaction, err)
+	}
+	return nil
+}
+
+func (p *saramaTransactionalProducer) handleError(err *sarama.ProducerError) error {
+	// See: https://go.dev/doc/faq#nil_error
+	if err == nil {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	return cerror.WrapError(cerror.ErrKafkaAsyncSendMessage, err)
+}
+
+func (p *saramaTransactionalProducer) Close() {
+	// Close asynchronously for the same reason as saramaAsyncProducer,
+	// the messages of the ongoing transaction are invisible to the
+	// `read_committed` consumers, so it's safe to drop them.
+	go func() {
+		start := time.Now()
+		if err := p.client.Close(); err != nil {
+			log.Warn("Close kafka transactional producer client error",
+				zap.String("namespace", p.changefeedID.Namespace()),
+				zap.String("changefeed", p.changefeedID.Name()),
+				zap.String("transactionalID", p.transactionalID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		}
+		start = time.Now()
+		if err := p.producer.Close(); err != nil {
+			log.Warn("Close kafka transactional producer error",
+				zap.String("namespace", p.changefeedID.Namespace()),
+				zap.String("changefeed", p.changefeedID.Name()),
+				zap.String("transactionalID", p.transactionalID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		} else {
+			log.Info("Close kafka transactional producer success",
+				zap.String("namespace", p.changefeedID.Namespace()),
+				zap.String("changefeed", p.changefeedID.Name()),
+				zap.String("transactionalID", p.transactionalID),
+				zap.Duration("duration", time.Since(start)))
+		}
+	}()
+}
@@ -261,6 +261,15 @@ func (f *factory) AsyncProducer(
 	return aw, nil
 }
 
+// TransactionalProducer creates a transactional producer to write message to kafka.
+// kafka-go can't produce the record batches with the producer id and epoch,
+// so the transactional producer is implemented by sarama.
+func (f *factory) TransactionalProducer(
+	ctx context.Context, transactionalID string,
+) (pkafka.TransactionalProducer, error) {
+	return pkafka.NewSaramaTransactionalProducer(ctx, f.options, f.changefeedID, transactionalID)
+}
+
 // MetricsCollector returns the kafka metrics collector
 func (f *factory) MetricsCollector(
 	_ pkafka.ClusterAdminClient,
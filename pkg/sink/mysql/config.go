@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tiflow/pkg/security"
@@ -55,6 +56,9 @@ const (
 	defaultMaxMultiUpdateRowSize = 1024
 	// The upper limit of max worker counts.
 	maxWorkerCount = 1024
+	// defaultMaxWorkerCountFactor is used to calculate the default max worker
+	// count when adaptive concurrency is enabled, it's a multiple of worker-count.
+	defaultMaxWorkerCountFactor = 4
 	// The upper limit of max txn rows.
 	maxMaxTxnRow = 2048
 	// The upper limit of max multi update rows in a single SQL.
@@ -78,6 +82,8 @@ const (
 	defaultBatchDMLEnable  = true
 	defaultMultiStmtEnable = true
 
+	defaultEnableAdaptiveConcurrency = false
+
 	// defaultcachePrepStmts is the default value of cachePrepStmts
 	defaultCachePrepStmts = true
 
@@ -88,8 +94,10 @@ const (
 )
 
 type MysqlConfig struct {
-	sinkURI                *url.URL
-	WorkerCount            int
+	sinkURI     *url.URL
+	WorkerCount int
+	// MaxWorkerCount is the upper limit of workers when adaptive concurrency is enabled.
+	MaxWorkerCount         int
 	MaxTxnRow              int
 	MaxMultiUpdateRowCount int
 	MaxMultiUpdateRowSize  int
@@ -115,6 +123,19 @@ type MysqlConfig struct {
 	MultiStmtEnable bool
 	CachePrepStmts  bool
 
+	// DeadLetterQueueTarget is the target of the dead letter queue, empty means disabled.
+	DeadLetterQueueTarget string
+	// DeadLetterQueue is used to write the transactions which can't be applied to the downstream.
+	DeadLetterQueue *dlq.Queue
+
+	// Router routes the tables to other schemas and tables in the downstream,
+	// nil means the tables are not routed.
+	Router *TableRouter
+
+	// EnableAdaptiveConcurrency enables adjusting the number of active workers and
+	// conflict detector slots at runtime, WorkerCount is the initial number of workers.
+	EnableAdaptiveConcurrency bool
+
 	// sync point
 	SyncPointRetention time.Duration
 
@@ -135,21 +156,22 @@ type MysqlConfig struct {
 // NewConfig returns the default mysql backend config.
 func NewMysqlConfig() *MysqlConfig {
 	return &MysqlConfig{
-		WorkerCount:            DefaultWorkerCount,
-		MaxTxnRow:              DefaultMaxTxnRow,
-		MaxMultiUpdateRowCount: defaultMaxMultiUpdateRowCount,
-		MaxMultiUpdateRowSize:  defaultMaxMultiUpdateRowSize,
-		tidbTxnMode:            defaultTiDBTxnMode,
-		ReadTimeout:            defaultReadTimeout,
-		WriteTimeout:           defaultWriteTimeout,
-		DialTimeout:            defaultDialTimeout,
-		SafeMode:               defaultSafeMode,
-		BatchDMLEnable:         defaultBatchDMLEnable,
-		MultiStmtEnable:        defaultMultiStmtEnable,
-		CachePrepStmts:         defaultCachePrepStmts,
-		SourceID:               config.DefaultTiDBSourceID,
-		DMLMaxRetry:            8,
-		HasVectorType:          defaultHasVectorType,
+		WorkerCount:               DefaultWorkerCount,
+		MaxTxnRow:                 DefaultMaxTxnRow,
+		MaxMultiUpdateRowCount:    defaultMaxMultiUpdateRowCount,
+		MaxMultiUpdateRowSize:     defaultMaxMultiUpdateRowSize,
+		tidbTxnMode:               defaultTiDBTxnMode,
+		ReadTimeout:               defaultReadTimeout,
+		WriteTimeout:              defaultWriteTimeout,
+		DialTimeout:               defaultDialTimeout,
+		SafeMode:                  defaultSafeMode,
+		BatchDMLEnable:            defaultBatchDMLEnable,
+		MultiStmtEnable:           defaultMultiStmtEnable,
+		CachePrepStmts:            defaultCachePrepStmts,
+		EnableAdaptiveConcurrency: defaultEnableAdaptiveConcurrency,
+		SourceID:                  config.DefaultTiDBSourceID,
+		DMLMaxRetry:               8,
+		HasVectorType:             defaultHasVectorType,
 	}
 }
 
@@ -207,14 +229,35 @@ func (c *MysqlConfig) Apply(
 	if err = getMultiStmtEnable(query, &c.MultiStmtEnable); err != nil {
 		return err
 	}
+	if err = getEnableAdaptiveConcurrency(query, &c.EnableAdaptiveConcurrency); err != nil {
+		return err
+	}
+	if err = getMaxWorkerCount(query, c.WorkerCount, &c.MaxWorkerCount); err != nil {
+		return err
+	}
 
 	// c.EnableOldValue = config.EnableOldValue
 	c.ForceReplicate = config.ForceReplicate
 	c.SourceID = config.SinkConfig.TiDBSourceID
+	if config.SinkConfig.MySQLConfig != nil {
+		c.DeadLetterQueueTarget = util.GetOrZero(config.SinkConfig.MySQLConfig.DeadLetterQueue)
+	}
+	c.Router, err = NewTableRouter(config.CaseSensitive, config.SinkConfig.RouteRules)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// GetMaxWorkerCount returns the max number of workers that can write to the downstream concurrently.
+func (c *MysqlConfig) GetMaxWorkerCount() int {
+	if c.EnableAdaptiveConcurrency && c.MaxWorkerCount > c.WorkerCount {
+		return c.MaxWorkerCount
+	}
+	return c.WorkerCount
+}
+
 func NewMySQLConfig(changefeedID common.ChangeFeedID, sinkURI *url.URL, config *config.ChangefeedConfig) (*MysqlConfig, error) {
 	cfg := NewMysqlConfig()
 	err := cfg.Apply(sinkURI, changefeedID, config)
@@ -262,8 +305,8 @@ func NewMysqlConfigAndDB(ctx context.Context, changefeedID common.ChangeFeedID,
 	// This issue is less likely to occur when the connection pool is larger,
 	// as there are more connections available for use.
 	// Adding an extra connection to the connection pool solves the connection exhaustion issue.
-	db.SetMaxIdleConns(cfg.WorkerCount + 1)
-	db.SetMaxOpenConns(cfg.WorkerCount + 1)
+	db.SetMaxIdleConns(cfg.GetMaxWorkerCount() + 1)
+	db.SetMaxOpenConns(cfg.GetMaxWorkerCount() + 1)
 
 	// Inherit the default value of the prepared statement cache from the SinkURI Options
 	cachePrepStmts := cfg.CachePrepStmts
@@ -279,10 +322,10 @@ func NewMysqlConfigAndDB(ctx context.Context, changefeedID common.ChangeFeedID,
 		}
 		// if maxPreparedStmtCount == 0,
 		// it means that the prepared statement cache is disabled on serverside.
-		// if maxPreparedStmtCount/(cfg.WorkerCount+1) == 0, for each single connection,
+		// if maxPreparedStmtCount/(cfg.GetMaxWorkerCount()+1) == 0, for each single connection,
 		// it means that the prepared statement cache is disabled on clientsize.
 		// Because each connection can not hold at lease one prepared statement.
-		if maxPreparedStmtCount == 0 || maxPreparedStmtCount/(cfg.WorkerCount+1) == 0 {
+		if maxPreparedStmtCount == 0 || maxPreparedStmtCount/(cfg.GetMaxWorkerCount()+1) == 0 {
 			cachePrepStmts = false
 		}
 	}
@@ -351,6 +394,31 @@ func getWorkerCount(values url.Values, workerCount *int) error {
 	return nil
 }
 
+func getMaxWorkerCount(values url.Values, workerCount int, maxCount *int) error {
+	s := values.Get("max-worker-count")
+	if len(s) == 0 {
+		*maxCount = min(workerCount*defaultMaxWorkerCountFactor, maxWorkerCount)
+		return nil
+	}
+
+	c, err := strconv.Atoi(s)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if c < workerCount {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-worker-count %d, which must not be less than worker-count %d", c, workerCount))
+	}
+	if c > maxWorkerCount {
+		log.Warn("max-worker-count too large",
+			zap.Int("original", c), zap.Int("override", maxWorkerCount))
+		c = maxWorkerCount
+	}
+
+	*maxCount = c
+	return nil
+}
+
 func getMaxTxnRow(values url.Values, maxTxnRow *int) error {
 	s := values.Get("max-txn-row")
 	if len(s) == 0 {
@@ -569,3 +637,15 @@ func getMultiStmtEnable(values url.Values, multiStmtEnable *bool) error {
 	}
 	return nil
 }
+
+func getEnableAdaptiveConcurrency(values url.Values, enable *bool) error {
+	s := values.Get("enable-adaptive-concurrency")
+	if len(s) > 0 {
+		enableAdaptive, err := strconv.ParseBool(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		*enable = enableAdaptive
+	}
+	return nil
+}
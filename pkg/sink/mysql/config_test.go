@@ -195,11 +195,13 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	expected.SafeMode = false
 	expected.Timezone = `"UTC"`
 	expected.tidbTxnMode = "pessimistic"
+	expected.EnableAdaptiveConcurrency = true
+	expected.MaxWorkerCount = 128
 	// expected.EnableOldValue = true
 	uriStr := "mysql://127.0.0.1:3306/?time-zone=UTC&worker-count=64&max-txn-row=20" +
 		"&max-multi-update-row=80&max-multi-update-row-size=512" +
 		"&batch-replace-enable=true&batch-replace-size=50&safe-mode=false" +
-		"&tidb-txn-mode=pessimistic"
+		"&tidb-txn-mode=pessimistic&enable-adaptive-concurrency=true&max-worker-count=128"
 	uri, err := url.Parse(uriStr)
 	require.Nil(t, err)
 	cfg := NewMysqlConfig()
@@ -283,6 +285,9 @@ func TestParseSinkURIBadQueryString(t *testing.T) {
 		"mysql://127.0.0.1:3306/?max-txn-row=-1",
 		"mysql://127.0.0.1:3306/?max-txn-row=0",
 		"mysql://127.0.0.1:3306/?ssl-ca=only-ca-exists",
+		"mysql://127.0.0.1:3306/?enable-adaptive-concurrency=not-bool",
+		"mysql://127.0.0.1:3306/?max-worker-count=not-number",
+		"mysql://127.0.0.1:3306/?worker-count=16&max-worker-count=8",
 		// "mysql://127.0.0.1:3306/?batch-replace-enable=not-bool",
 		// "mysql://127.0.0.1:3306/?batch-replace-enable=true&batch-replace-size=not-number",
 		"mysql://127.0.0.1:3306/?safe-mode=not-bool",
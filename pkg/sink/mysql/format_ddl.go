@@ -15,11 +15,13 @@ package mysql
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/format"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"go.uber.org/zap"
 )
@@ -62,3 +64,103 @@ func formatQuery(sql string) string {
 	}
 	return buf.String()
 }
+
+// routeVisitor rewrites the schema and table names in the ddl to the downstream ones.
+type routeVisitor struct {
+	router *TableRouter
+	// defaultSchema is used to route the tables which are not qualified by a schema.
+	defaultSchema string
+	routed        bool
+	// skipped is true if the ddl drops the data merged from more than one upstream
+	// schema or table, it must be skipped since the other upstream ones are not dropped.
+	skipped bool
+}
+
+func (v *routeVisitor) Enter(n ast.Node) (node ast.Node, skipChildren bool) {
+	switch t := n.(type) {
+	case *ast.TableName:
+		// qualify all the tables, so the ddl doesn't depend on the current schema.
+		if t.Schema.O == "" {
+			t.Schema = pmodel.NewCIStr(v.defaultSchema)
+		}
+		targetSchema, targetTable := v.router.Route(t.Schema.O, t.Name.O)
+		if targetSchema != t.Schema.O || targetTable != t.Name.O {
+			t.Schema = pmodel.NewCIStr(targetSchema)
+			t.Name = pmodel.NewCIStr(targetTable)
+			v.routed = true
+		}
+	case *ast.CreateDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+	case *ast.DropDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+		if v.router.IsMergedSchema(t.Name.O) {
+			v.skipped = true
+		}
+	case *ast.DropTableStmt:
+		for _, table := range t.Tables {
+			v.checkMergedTable(table)
+		}
+	case *ast.TruncateTableStmt:
+		v.checkMergedTable(t.Table)
+	case *ast.AlterDatabaseStmt:
+		t.Name = v.routeSchema(t.Name)
+	}
+	return n, false
+}
+
+func (v *routeVisitor) Leave(n ast.Node) (node ast.Node, ok bool) {
+	return n, true
+}
+
+// checkMergedTable marks the ddl skipped if the table is merged in the downstream,
+// it's called before the table is routed.
+func (v *routeVisitor) checkMergedTable(table *ast.TableName) {
+	schema := table.Schema.O
+	if schema == "" {
+		schema = v.defaultSchema
+	}
+	if v.router.IsMergedTable(schema, table.Name.O) {
+		v.skipped = true
+	}
+}
+
+func (v *routeVisitor) routeSchema(schema pmodel.CIStr) pmodel.CIStr {
+	if target := v.router.RouteSchema(schema.O); target != schema.O {
+		v.routed = true
+		return pmodel.NewCIStr(target)
+	}
+	return schema
+}
+
+// routeQuery rewrites the schema and table names in the ddl query by the router,
+// it returns the query itself if no table is routed. All the tables in the routed
+// query are qualified by the schema, so it can be executed without switching schema.
+// It returns an empty query if the ddl drops or truncates a merged schema or table.
+func routeQuery(sql string, defaultSchema string, router *TableRouter) (string, error) {
+	p := parser.New()
+	stmts, _, err := p.Parse(sql, "", "")
+	if err != nil {
+		return "", err
+	}
+	visitor := &routeVisitor{router: router, defaultSchema: defaultSchema}
+	for _, stmt := range stmts {
+		stmt.Accept(visitor)
+	}
+	if visitor.skipped {
+		return "", nil
+	}
+	if !visitor.routed {
+		return sql, nil
+	}
+
+	queries := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		buf := new(bytes.Buffer)
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, buf)
+		if err = stmt.Restore(restoreCtx); err != nil {
+			return "", err
+		}
+		queries = append(queries, buf.String())
+	}
+	return strings.Join(queries, ";"), nil
+}
@@ -359,7 +359,9 @@ func needSwitchDB(event *commonEvent.DDLEvent) bool {
 	if len(event.GetDDLSchemaName()) == 0 {
 		return false
 	}
-	if event.GetDDLType() == timodel.ActionCreateSchema || event.GetDDLType() == timodel.ActionDropSchema {
+	// the schema doesn't exist before `CREATE DATABASE` and `FLASHBACK DATABASE` are executed.
+	switch event.GetDDLType() {
+	case timodel.ActionCreateSchema, timodel.ActionDropSchema, timodel.ActionRecoverSchema:
 		return false
 	}
 	return true
@@ -425,6 +427,24 @@ func isRetryableDMLError(err error) bool {
 	return true
 }
 
+// isDeadLetterError returns true if the error is caused by the data of the transaction,
+// such transactions can't be applied to the downstream however many times we retry.
+func isDeadLetterError(err error) bool {
+	errCode, ok := getSQLErrCode(err)
+	if !ok {
+		return false
+	}
+	switch errCode {
+	case mysql.ErrBadNull, mysql.ErrWrongValueCountOnRow, mysql.ErrWarnDataOutOfRange,
+		mysql.ErrTruncatedWrongValue, mysql.ErrInvalidCharacterString, mysql.ErrNoDefaultForField,
+		mysql.ErrDivisionByZero, mysql.ErrTruncatedWrongValueForField, mysql.ErrDataTooLong,
+		mysql.ErrRowIsReferenced2, mysql.ErrNoReferencedRow2, mysql.ErrSignalException,
+		mysql.ErrDataOutOfRange, mysql.ErrInvalidJSONText:
+		return true
+	}
+	return false
+}
+
 func getSQLErrCode(err error) (errors.ErrCode, bool) {
 	mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError)
 	if !ok {
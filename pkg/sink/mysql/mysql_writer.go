@@ -130,9 +130,16 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 			w.FlushDDLTsPre(event)
 		}
 
-		err := w.execDDLWithMaxRetries(event)
-		if err != nil {
-			return errors.Trace(err)
+		// the ddls which can't be executed by other downstreams are split into the ddls they support.
+		events := []*commonEvent.DDLEvent{event}
+		if !w.cfg.IsTiDB && len(event.NonTiDBQueries) != 0 {
+			events = event.GetEvents()
+		}
+		for _, e := range events {
+			err := w.execDDLWithMaxRetries(e)
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		// We need to record ddl' ts after each ddl for each table in the downstream when sink is mysql-compatible.
@@ -142,7 +149,7 @@ func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
 		// We make Flush ddl ts before callback(), in order to make sure the ddl ts is flushed
 		// before new checkpointTs will report to maintainer. Therefore, when the table checkpointTs is forward,
 		// we can ensure the ddl and ddl ts are both flushed downstream successfully.
-		err = w.FlushDDLTs(event)
+		err := w.FlushDDLTs(event)
 		if err != nil {
 			return err
 		}
@@ -174,6 +181,11 @@ func (w *MysqlWriter) FlushSyncPointEvent(event *commonEvent.SyncPointEvent) err
 		// if downstream is tidb, we write ddl ts before ddl first, and update the ddl ts item after ddl executed,
 		// to ensure the atomic with ddl writing when server is restarted.
 		w.FlushDDLTsPre(event)
+		// sequences only exist in tidb, sync their values before writing the sync point,
+		// so the downstream sequences are ahead of the upstream ones at the sync point.
+		if err := w.syncSequenceValues(event); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	err := w.SendSyncPointEvent(event)
@@ -212,7 +224,14 @@ func (w *MysqlWriter) Flush(events []*commonEvent.DMLEvent) error {
 
 	if !w.cfg.DryRun {
 		if err = w.execDMLWithMaxRetries(dmls); err != nil {
-			return errors.Trace(err)
+			if w.cfg.DeadLetterQueue == nil || !isDeadLetterError(err) {
+				return errors.Trace(err)
+			}
+			// apply the events one by one, so only the transactions which can't be
+			// applied are written to the dead letter queue.
+			if err = w.flushEventsOneByOne(events); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	} else {
 		w.tryDryRunBlock()
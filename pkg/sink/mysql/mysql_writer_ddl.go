@@ -102,7 +102,8 @@ func needTimeoutCheck(ddlType timodel.ActionType) bool {
 	case timodel.ActionAddTablePartition, timodel.ActionExchangeTablePartition, timodel.ActionReorganizePartition:
 		return false
 	// reorg related
-	case timodel.ActionAddPrimaryKey, timodel.ActionAddIndex, timodel.ActionModifyColumn:
+	case timodel.ActionAddPrimaryKey, timodel.ActionAddIndex, timodel.ActionModifyColumn,
+		timodel.ActionAddCheckConstraint, timodel.ActionAlterCheckConstraint:
 		return false
 	// following ddls can be fast when the downstream is TiDB, we must
 	// still take them into consideration to ensure compatibility with all
@@ -155,6 +156,26 @@ func (w *MysqlWriter) execDDL(event *commonEvent.DDLEvent) error {
 		}
 	}
 
+	// Route the tables to the downstream tables, the event is kept unchanged
+	// since the ddl may be executed again if it fails.
+	query := event.GetDDLQuery()
+	if w.cfg.Router != nil {
+		routedQuery, err := routeQuery(query, event.GetDDLSchemaName(), w.cfg.Router)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if routedQuery == "" {
+			log.Warn("skip the ddl which drops the data merged from other upstream schemas or tables",
+				zap.String("query", query))
+			return nil
+		}
+		if routedQuery != query {
+			log.Info("route ddl query", zap.String("routedQuery", routedQuery), zap.String("query", query))
+			query = routedQuery
+			shouldSwitchDB = false
+		}
+	}
+
 	tx, err := w.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -180,7 +201,6 @@ func (w *MysqlWriter) execDDL(event *commonEvent.DDLEvent) error {
 		return err
 	}
 
-	query := event.GetDDLQuery()
 	_, err = tx.ExecContext(ctx, query)
 	if err != nil {
 		log.Error("Fail to ExecContext", zap.Any("err", err))
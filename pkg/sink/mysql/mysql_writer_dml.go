@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
 	"github.com/pingcap/ticdc/pkg/sink/sqlmodel"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -39,11 +41,11 @@ import (
 
 // for the events, we try to batch the events of the same table into single update / insert / delete query,
 // to enhance the performance of the sink.
-// While we only support to batch the events with pks, and all the events inSafeMode or all not in inSafeMode.
+// While we only support to batch the events with pks or not null uks, and all the events inSafeMode or all not in inSafeMode.
 // the process is as follows:
 //  1. we group the events by dispatcherID, and hold the order for the events of the same dispatcher
 //  2. For each group,
-//     if the table does't have a handle key or have virtual column, we just generate the sqls for each event row.(TODO: support the case without pk but have uk)
+//     if the table does't have a handle key(pk or not null uk) or have virtual column, we just generate the sqls for each event row.
 //     Otherwise,
 //     if there is only one rows of the whole group, we generate the sqls for the row.
 //     Otherwise, we batch all the event rows for the same dispatcherID to a single delete / update/ insert query(in order)
@@ -139,7 +141,7 @@ func (w *MysqlWriter) generateBatchSQL(events []*commonEvent.DMLEvent) ([]string
 
 func (w *MysqlWriter) generateBatchSQLInSafeMode(events []*commonEvent.DMLEvent) ([]string, [][]interface{}, error) {
 	inSafeMode := true
-	tableInfo := events[0].TableInfo
+	tableInfo := w.cfg.Router.RouteTableInfo(events[0].TableInfo)
 	type RowChangeWithKeys struct {
 		RowChange  *commonEvent.RowChange
 		RowKeys    []byte
@@ -294,10 +296,12 @@ func (w *MysqlWriter) generateBatchSQLInSafeMode(events []*commonEvent.DMLEvent)
 
 func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEvent) ([]string, [][]interface{}, error) {
 	inSafeMode := false
-	tableInfo := events[0].TableInfo
+	tableInfo := w.cfg.Router.RouteTableInfo(events[0].TableInfo)
 	// step 1. divide update row to delete row and insert row, and set into map based on the key hash
 	rowsMap := make(map[uint64][]*commonEvent.RowChange)
 	hashToKeyMap := make(map[uint64][]byte)
+	// hashes keeps the key hashes in the order they first appear, so the rows are generated in a stable order.
+	hashes := make([]uint64, 0)
 
 	// TODO: extract a function here to clean code
 	for _, event := range events {
@@ -317,6 +321,7 @@ func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEven
 					}
 					if _, ok := hashToKeyMap[hashValue]; !ok {
 						hashToKeyMap[hashValue] = keyValue
+						hashes = append(hashes, hashValue)
 					} else {
 						if !compareKeys(hashToKeyMap[hashValue], keyValue) {
 							log.Warn("the key hash is equal, but the keys is not the same; so we don't use batch generate sql, but use the normal generated sql instead")
@@ -336,6 +341,7 @@ func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEven
 					}
 					if _, ok := hashToKeyMap[hashValue]; !ok {
 						hashToKeyMap[hashValue] = keyValue
+						hashes = append(hashes, hashValue)
 					} else {
 						if !compareKeys(hashToKeyMap[hashValue], keyValue) {
 							log.Warn("the key hash is equal, but the keys is not the same; so we don't use batch generate sql, but use the normal generated sql instead")
@@ -354,6 +360,7 @@ func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEven
 				}
 				if _, ok := hashToKeyMap[hashValue]; !ok {
 					hashToKeyMap[hashValue] = keyValue
+					hashes = append(hashes, hashValue)
 				} else {
 					if !compareKeys(hashToKeyMap[hashValue], keyValue) {
 						log.Warn("the key hash is equal, but the keys is not the same; so we don't use batch generate sql, but use the normal generated sql instead")
@@ -370,6 +377,7 @@ func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEven
 				}
 				if _, ok := hashToKeyMap[hashValue]; !ok {
 					hashToKeyMap[hashValue] = keyValue
+					hashes = append(hashes, hashValue)
 				} else {
 					if !compareKeys(hashToKeyMap[hashValue], keyValue) {
 						log.Warn("the key hash is equal, but the keys is not the same; so we don't use batch generate sql, but use the normal generated sql instead")
@@ -385,7 +393,8 @@ func (w *MysqlWriter) generateBatchSQLInUnsafeMode(events []*commonEvent.DMLEven
 
 	// step 2. compare the rows in the same key hash, to generate the final rows
 	rowsList := make([]*commonEvent.RowChange, 0, len(rowsMap))
-	for _, rowChanges := range rowsMap {
+	for _, hashValue := range hashes {
+		rowChanges := rowsMap[hashValue]
 		if len(rowChanges) == 0 {
 			continue
 		}
@@ -447,6 +456,7 @@ func (w *MysqlWriter) generateNormalSQL(event *commonEvent.DMLEvent) ([]string,
 		zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
 		zap.Bool("safeMode", w.cfg.SafeMode))
 
+	tableInfo := w.cfg.Router.RouteTableInfo(event.TableInfo)
 	var query string
 	var args []interface{}
 	for {
@@ -459,9 +469,9 @@ func (w *MysqlWriter) generateNormalSQL(event *commonEvent.DMLEvent) ([]string,
 		switch row.RowType {
 		case commonEvent.RowTypeUpdate:
 			if inSafeMode {
-				query, args, err = buildUpdate(event.TableInfo, row, w.cfg.ForceReplicate)
+				query, args, err = buildUpdate(tableInfo, row, w.cfg.ForceReplicate)
 			} else {
-				query, args, err = buildDelete(event.TableInfo, row, w.cfg.ForceReplicate)
+				query, args, err = buildDelete(tableInfo, row, w.cfg.ForceReplicate)
 				if err != nil {
 					return queryList, argsList, errors.Trace(err)
 				}
@@ -469,12 +479,12 @@ func (w *MysqlWriter) generateNormalSQL(event *commonEvent.DMLEvent) ([]string,
 					queryList = append(queryList, query)
 					argsList = append(argsList, args)
 				}
-				query, args, err = buildInsert(event.TableInfo, row, inSafeMode)
+				query, args, err = buildInsert(tableInfo, row, inSafeMode)
 			}
 		case commonEvent.RowTypeDelete:
-			query, args, err = buildDelete(event.TableInfo, row, w.cfg.ForceReplicate)
+			query, args, err = buildDelete(tableInfo, row, w.cfg.ForceReplicate)
 		case commonEvent.RowTypeInsert:
-			query, args, err = buildInsert(event.TableInfo, row, inSafeMode)
+			query, args, err = buildInsert(tableInfo, row, inSafeMode)
 		}
 
 		if err != nil {
@@ -489,6 +499,40 @@ func (w *MysqlWriter) generateNormalSQL(event *commonEvent.DMLEvent) ([]string,
 	return queryList, argsList, nil
 }
 
+// flushEventsOneByOne executes the events in separate transactions, the event
+// which fails with a dead letter error is written to the dead letter queue.
+func (w *MysqlWriter) flushEventsOneByOne(events []*commonEvent.DMLEvent) error {
+	for _, event := range events {
+		// reset the event, the rows are consumed when the batch is prepared.
+		event.FinishGetRow()
+		dmls, err := w.prepareDMLs([]*commonEvent.DMLEvent{event})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if dmls.rowCount != 0 {
+			err = w.execDMLWithMaxRetries(dmls)
+			if err != nil && isDeadLetterError(err) {
+				event.FinishGetRow()
+				err = w.writeDeadLetter(event, dmls, err)
+			}
+		}
+		dmlsPool.Put(dmls)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (w *MysqlWriter) writeDeadLetter(event *commonEvent.DMLEvent, dmls *preparedDMLs, execErr error) error {
+	// dmls is reused after returned to the pool, so copy the sqls and args.
+	entry, err := dlq.NewEntry(w.ChangefeedID, event, slices.Clone(dmls.sqls), slices.Clone(dmls.values), execErr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return w.cfg.DeadLetterQueue.Write(w.ctx, entry)
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		return cerror.ErrUnexpected.FastGenByArgs(fmt.Sprintf("unexpected number of sqls and values, sqls is %s, values is %s", dmls.sqls, dmls.values))
@@ -501,6 +545,15 @@ func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	writeTimeout, _ := time.ParseDuration(w.cfg.WriteTimeout)
 	writeTimeout += networkDriftDuration
 
+	isRetryable := isRetryableDMLError
+	if w.cfg.DeadLetterQueue != nil {
+		// the transactions meet dead letter errors are written to the dead letter queue,
+		// retrying them is useless.
+		isRetryable = func(err error) bool {
+			return isRetryableDMLError(err) && !isDeadLetterError(err)
+		}
+	}
+
 	tryExec := func() (int, int64, error) {
 		tx, err := w.db.BeginTx(w.ctx, nil)
 		if err != nil {
@@ -567,7 +620,7 @@ func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	}, retry.WithBackoffBaseDelay(pmysql.BackoffBaseDelay.Milliseconds()),
 		retry.WithBackoffMaxDelay(pmysql.BackoffMaxDelay.Milliseconds()),
 		retry.WithMaxTries(w.cfg.DMLMaxRetry),
-		retry.WithIsRetryableErr(isRetryableDMLError))
+		retry.WithIsRetryableErr(isRetryable))
 }
 
 func (w *MysqlWriter) sequenceExecute(
@@ -22,10 +22,12 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"go.uber.org/zap"
 )
 
@@ -136,3 +138,24 @@ func (w *MysqlWriter) SendSyncPointEvent(event *commonEvent.SyncPointEvent) erro
 	err = tx.Commit()
 	return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, "failed to write syncpoint table; Commit Fail;"))
 }
+
+// syncSequenceValues sets the values of the sequences in downstream to the upstream values at the sync point,
+// so the downstream sequences are always ahead of the replicated ids generated by them.
+// SETVAL is ignored by TiDB if the value is smaller than the current value of the sequence.
+func (w *MysqlWriter) syncSequenceValues(event *commonEvent.SyncPointEvent) error {
+	for _, seq := range event.Sequences {
+		query := fmt.Sprintf("SELECT SETVAL(%s, %d)", common.QuoteSchema(seq.SchemaName, seq.SequenceName), seq.Value)
+		_, err := w.db.ExecContext(w.ctx, query)
+		if err != nil {
+			// The sequence may be dropped in downstream manually or filtered by the event filter.
+			if errCode, ok := getSQLErrCode(err); ok && (errCode == mysql.ErrNoSuchTable || errCode == mysql.ErrBadDB) {
+				log.Warn("sequence not found in downstream, skip syncing its value",
+					zap.String("changefeed", w.ChangefeedID.String()),
+					zap.String("query", query), zap.Error(err))
+				continue
+			}
+			return cerror.WrapError(cerror.ErrMySQLTxnError, errors.WithMessage(err, fmt.Sprintf("failed to sync sequence value; Query is %s", query)))
+		}
+	}
+	return nil
+}
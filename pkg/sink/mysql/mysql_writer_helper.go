@@ -32,14 +32,14 @@ func compareKeys(firstKey, secondKey []byte) bool {
 }
 
 func genKeyAndHash(row *chunk.Row, tableInfo *common.TableInfo) (uint64, []byte, error) {
-	idxCol := tableInfo.GetPKIndexOffset()
-	// log.Info("genKeyAndHash", zap.Any("idxCol", idxCol), zap.Any("iIdx", iIdx))
+	// the handle key is the primary key or the best NOT NULL unique key of the table
+	idxCol := tableInfo.GetHandleKeyIndexOffset()
 	key, err := genKeyList(row, idxCol, tableInfo)
 	if err != nil {
 		return 0, nil, errors.Trace(err)
 	}
 	if len(key) == 0 {
-		log.Panic("the table has no primary key", zap.Any("tableinfo", tableInfo))
+		log.Panic("the table has no handle key", zap.Any("tableinfo", tableInfo))
 	}
 
 	hasher := fnv.New32a()
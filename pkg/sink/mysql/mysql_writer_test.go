@@ -20,13 +20,18 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/dlq"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/stretchr/testify/require"
 )
@@ -145,6 +150,127 @@ func TestMysqlWriter_FlushMultiDML(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMysqlWriter_FlushMultiDMLWithNotNullUniqueKey(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int not null, name varchar(32), unique key uk_id(id));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.CommitTs = 2
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+	require.True(t, dmlEvent.TableInfo.HasHandleKey())
+	require.Equal(t, []int{0}, dmlEvent.TableInfo.GetHandleKeyIndexOffset())
+
+	// the rows are batched by the not null unique key
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+
+	// safe mode
+	dmlEvent = helper.DML2Event("test", "t", "insert into t values (3, 'test3')", "insert into t values (4, 'test4');")
+	dmlEvent.CommitTs = 3
+	dmlEvent.ReplicatingTs = 4
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("REPLACE INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
+		WithArgs(3, "test3", 4, "test4").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err)
+}
+
+func TestMysqlWriter_FlushDMLToDeadLetterQueue(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	target := "file://" + t.TempDir()
+	queue, err := dlq.New(context.Background(), writer.ChangefeedID, target, nil)
+	require.NoError(t, err)
+	defer queue.Close()
+	writer.cfg.DeadLetterQueue = queue
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.DispatcherID = common.NewDispatcherID()
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (2, 'test2');")
+	dmlEvent2.CommitTs = 3
+	dmlEvent2.DispatcherID = dmlEvent.DispatcherID
+
+	dataErr := &dmysql.MySQLError{Number: mysql.ErrDataTooLong, Message: "Data too long for column 'name'"}
+	// the batch fails with a data error, it's not retried.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnError(dataErr)
+	mock.ExpectRollback()
+	// the events are applied one by one, only the failed one is written to the dead letter queue.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(2, "test2").
+		WillReturnError(dataErr)
+	mock.ExpectRollback()
+
+	flushed := 0
+	for _, event := range []*commonEvent.DMLEvent{dmlEvent, dmlEvent2} {
+		event.AddPostFlushFunc(func() { flushed++ })
+	}
+	err = writer.Flush([]*commonEvent.DMLEvent{dmlEvent, dmlEvent2})
+	require.NoError(t, err)
+	require.Equal(t, 2, flushed)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	entries, err := dlq.List(context.Background(), writer.ChangefeedID, target, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(3), entries[0].CommitTs)
+	require.Len(t, entries[0].Rows, 1)
+	require.Equal(t, "2", *entries[0].Rows[0].Columns["id"])
+	require.Equal(t, []string{"INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)"}, entries[0].SQLs)
+	require.Contains(t, entries[0].Error, "Data too long")
+
+	// the other errors are returned directly.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnError(&dmysql.MySQLError{Number: mysql.ErrNoSuchTable})
+	mock.ExpectRollback()
+	dmlEvent.FinishGetRow()
+	err = writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 // Test flush ddl event
 // Ensure the ddl query will be write to the databases
 // and the ddl_ts_v1 table will be updated with the ddl_ts and table_id
@@ -236,6 +362,152 @@ func TestMysqlWriter_FlushDDLEvent(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMysqlWriter_FlushRoutedEvents(t *testing.T) {
+	writer, db, mock := newTestMysqlWriter(t)
+	defer db.Close()
+
+	var err error
+	writer.cfg.Router, err = NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"test.t"}, SchemaRule: "merged", TableRule: "{table}_all"},
+	})
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.CommitTs = 2
+	dmlEvent.ReplicatingTs = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `merged`.`t_all` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = writer.Flush([]*commonEvent.DMLEvent{dmlEvent})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	job = helper.DDL2Job("alter table t add column age int;")
+	require.NotNil(t, job)
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 3,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{1},
+		},
+	}
+
+	// the routed ddl is executed without switching schema
+	mock.ExpectBegin()
+	mock.ExpectExec("ALTER TABLE `merged`.`t_all` ADD COLUMN `age` INT").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = writer.execDDL(ddlEvent)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	// the query of the event is kept unchanged
+	require.Equal(t, "alter table t add column age int;", ddlEvent.Query)
+}
+
+func TestMysqlWriter_FlushRecoverSchema(t *testing.T) {
+	newRecoverSchemaEvent := func() *commonEvent.DDLEvent {
+		return &commonEvent.DDLEvent{
+			Type:       byte(timodel.ActionRecoverSchema),
+			Query:      "FLASHBACK DATABASE `test`",
+			SchemaName: "test",
+			FinishedTs: 1,
+			BlockedTables: &commonEvent.InfluencedTables{
+				InfluenceType: commonEvent.InfluenceTypeNormal,
+				TableIDs:      []int64{0},
+			},
+			NeedAddedTables: []commonEvent.Table{{TableID: 1, SchemaID: 1}},
+			MultipleTableInfos: []*common.TableInfo{
+				common.WrapTableInfo(1, "test", &timodel.TableInfo{ID: 1, Name: pmodel.NewCIStr("t")}),
+			},
+			NonTiDBQueries: []string{
+				"CREATE DATABASE IF NOT EXISTS `test`",
+				"CREATE TABLE `t` (`id` int PRIMARY KEY)",
+			},
+		}
+	}
+	expectCreateDDLTsTable := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`CREATE TABLE IF NOT EXISTS ddl_ts_v1
+		(
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			ddl_ts varchar(18),
+			table_id bigint(21),
+			finished bool,
+			related_table_id bigint(21),
+			is_syncpoint bool,
+			created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			INDEX (ticdc_cluster_id, changefeed, table_id),
+			PRIMARY KEY (ticdc_cluster_id, changefeed, table_id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+	}
+
+	// the downstream is not tidb, create the schema and the tables instead
+	{
+		writer, db, mock := newTestMysqlWriter(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `test`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("CREATE TABLE `t` (`id` int PRIMARY KEY)").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		expectCreateDDLTsTable(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id, related_table_id, finished, is_syncpoint) VALUES ('default', 'test/test', '1', 0, 1, 1, 0), ('default', 'test/test', '1', 1, 1, 1, 0) ON DUPLICATE KEY UPDATE finished=VALUES(finished), related_table_id=VALUES(related_table_id), ddl_ts=VALUES(ddl_ts), created_at=NOW(), is_syncpoint=VALUES(is_syncpoint);").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := writer.FlushDDLEvent(newRecoverSchemaEvent())
+		require.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}
+
+	// the downstream is tidb, execute the original query
+	{
+		writer, db, mock := newTestMysqlWriterForTiDB(t)
+		defer db.Close()
+
+		expectCreateDDLTsTable(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id, related_table_id, finished, is_syncpoint) VALUES ('default', 'test/test', '1', 0, 1, 0, 0), ('default', 'test/test', '1', 1, 1, 0, 0) ON DUPLICATE KEY UPDATE finished=VALUES(finished), related_table_id=VALUES(related_table_id), ddl_ts=VALUES(ddl_ts), created_at=NOW(), is_syncpoint=VALUES(is_syncpoint);").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("FLASHBACK DATABASE `test`").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id, related_table_id, finished, is_syncpoint) VALUES ('default', 'test/test', '1', 0, 1, 1, 0), ('default', 'test/test', '1', 1, 1, 1, 0) ON DUPLICATE KEY UPDATE finished=VALUES(finished), related_table_id=VALUES(related_table_id), ddl_ts=VALUES(ddl_ts), created_at=NOW(), is_syncpoint=VALUES(is_syncpoint);").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := writer.FlushDDLEvent(newRecoverSchemaEvent())
+		require.NoError(t, err)
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}
+}
+
 func TestMysqlWriter_Flush_EmptyEvents(t *testing.T) {
 	writer, db, mock := newTestMysqlWriter(t)
 	defer db.Close()
@@ -323,6 +595,33 @@ func TestMysqlWriter_FlushSyncPointEvent(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMysqlWriter_SyncSequenceValues(t *testing.T) {
+	writer, db, mock := newTestMysqlWriterForTiDB(t)
+	defer db.Close()
+
+	syncPointEvent := &commonEvent.SyncPointEvent{
+		CommitTs: 1,
+		Sequences: []commonEvent.SequenceValue{
+			{SchemaName: "test", SequenceName: "seq1", Value: 1000},
+			{SchemaName: "test", SequenceName: "seq2", Value: -10},
+		},
+	}
+	mock.ExpectExec("SELECT SETVAL(`test`.`seq1`, 1000)").WillReturnResult(sqlmock.NewResult(1, 1))
+	// the missing sequence in downstream is skipped
+	mock.ExpectExec("SELECT SETVAL(`test`.`seq2`, -10)").WillReturnError(&dmysql.MySQLError{
+		Number:  mysql.ErrNoSuchTable,
+		Message: "Table 'test.seq2' doesn't exist",
+	})
+	require.NoError(t, writer.syncSequenceValues(syncPointEvent))
+
+	mock.ExpectExec("SELECT SETVAL(`test`.`seq1`, 1000)").WillReturnError(&dmysql.MySQLError{
+		Number:  mysql.ErrAccessDenied,
+		Message: "access denied",
+	})
+	require.Error(t, writer.syncSequenceValues(syncPointEvent))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMysqlWriter_RemoveDDLTsTable(t *testing.T) {
 	writer, db, mock := newTestMysqlWriter(t)
 	defer db.Close()
@@ -0,0 +1,222 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+type routeRule struct {
+	filter     filter.Filter
+	schemaRule string
+	tableRule  string
+	// coversSchemas is true if the rule matches all the tables of the schemas it matches,
+	// only such rules route the schema-level DDLs.
+	coversSchemas bool
+	// mergesTables is true if the rule may route more than one table to the same downstream table.
+	mergesTables bool
+}
+
+// routedTableInfo is the table info routed from the source table info.
+type routedTableInfo struct {
+	source *common.TableInfo
+	target *common.TableInfo
+}
+
+// TableRouter routes the upstream tables to the downstream tables by the route rules,
+// the first matched rule is used.
+type TableRouter struct {
+	rules         []routeRule
+	caseSensitive bool
+	// mergedSchemas are the downstream schemas which more than one upstream schema is routed to.
+	mergedSchemas map[string]struct{}
+	// tableInfos caches the routed table info of each table, tableID -> routedTableInfo
+	tableInfos sync.Map
+}
+
+// NewTableRouter creates a TableRouter, it returns nil if there is no route rule.
+func NewTableRouter(caseSensitive bool, rules []*config.RouteRule) (*TableRouter, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	r := &TableRouter{
+		rules:         make([]routeRule, 0, len(rules)),
+		caseSensitive: caseSensitive,
+		mergedSchemas: make(map[string]struct{}),
+	}
+	// the upstream schemas and tables routed to each downstream schema and table by literal names,
+	// an empty source means the schemas are matched by wildcards.
+	schemaSources := make(map[string]map[string]struct{})
+	tableSources := make(map[string]int)
+	for _, rule := range rules {
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err, rule.Matcher)
+		}
+		if !caseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		r.rules = append(r.rules, routeRule{
+			filter:        f,
+			schemaRule:    rule.SchemaRule,
+			tableRule:     rule.TableRule,
+			coversSchemas: rule.CoversSchemas(),
+			mergesTables:  mergesTables(rule),
+		})
+
+		if rule.SchemaRule == "" || strings.Contains(rule.SchemaRule, config.RouteSchemaPlaceholder) {
+			continue
+		}
+		targetSchema := r.normalize(rule.SchemaRule)
+		if schemaSources[targetSchema] == nil {
+			schemaSources[targetSchema] = make(map[string]struct{})
+		}
+		if schemas, ok := rule.SourceSchemas(); ok {
+			for _, schema := range schemas {
+				schemaSources[targetSchema][r.normalize(schema)] = struct{}{}
+			}
+		} else {
+			schemaSources[targetSchema][""] = struct{}{}
+		}
+		if _, _, ok := rule.SourceTable(); ok && rule.TableRule != "" &&
+			!strings.Contains(rule.TableRule, config.RouteTablePlaceholder) {
+			tableSources[targetSchema+"."+r.normalize(rule.TableRule)]++
+		}
+	}
+	for schema, sources := range schemaSources {
+		if _, ok := sources[""]; ok || len(sources) > 1 {
+			r.mergedSchemas[schema] = struct{}{}
+		}
+	}
+	// The tables routed to the same downstream table by different rules are merged too.
+	for i, rule := range rules {
+		if _, _, ok := rule.SourceTable(); !ok || rule.SchemaRule == "" || rule.TableRule == "" {
+			continue
+		}
+		if tableSources[r.normalize(rule.SchemaRule)+"."+r.normalize(rule.TableRule)] > 1 {
+			r.rules[i].mergesTables = true
+		}
+	}
+	return r, nil
+}
+
+// mergesTables returns true if the rule may route more than one table to the same downstream table.
+func mergesTables(rule *config.RouteRule) bool {
+	if !rule.MergesTables() {
+		return false
+	}
+	// a single table is renamed to the table
+	_, _, ok := rule.SourceTable()
+	return !ok
+}
+
+func (r *TableRouter) normalize(name string) string {
+	if r.caseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// Route returns the downstream schema and table of the upstream table.
+func (r *TableRouter) Route(schema, table string) (string, string) {
+	if r == nil {
+		return schema, table
+	}
+	for _, rule := range r.rules {
+		if rule.filter.MatchTable(schema, table) {
+			return expandRouteRule(rule.schemaRule, schema, table, schema),
+				expandRouteRule(rule.tableRule, schema, table, table)
+		}
+	}
+	return schema, table
+}
+
+// RouteSchema returns the downstream schema of the upstream schema, only the rules
+// which match all the tables of the schema are used, since the schema is shared by
+// the tables which are not routed otherwise.
+func (r *TableRouter) RouteSchema(schema string) string {
+	if r == nil {
+		return schema
+	}
+	for _, rule := range r.rules {
+		if rule.coversSchemas && rule.filter.MatchSchema(schema) {
+			return expandRouteRule(rule.schemaRule, schema, "", schema)
+		}
+	}
+	return schema
+}
+
+// IsMergedSchema returns true if more than one upstream schema is routed to the downstream schema.
+func (r *TableRouter) IsMergedSchema(targetSchema string) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.mergedSchemas[r.normalize(targetSchema)]
+	return ok
+}
+
+// IsMergedTable returns true if the upstream table is routed to a downstream table
+// which other upstream tables may be routed to.
+func (r *TableRouter) IsMergedTable(schema, table string) bool {
+	if r == nil {
+		return false
+	}
+	for _, rule := range r.rules {
+		if !rule.filter.MatchTable(schema, table) {
+			continue
+		}
+		if rule.mergesTables {
+			return true
+		}
+		// the tables of the same name in the merged schemas are merged
+		// if the table name doesn't contain the upstream schema.
+		targetSchema := expandRouteRule(rule.schemaRule, schema, table, schema)
+		return r.IsMergedSchema(targetSchema) &&
+			!strings.Contains(rule.tableRule, config.RouteSchemaPlaceholder)
+	}
+	return false
+}
+
+// RouteTableInfo returns the table info named by the downstream table,
+// it returns the table info itself if the table is not routed.
+func (r *TableRouter) RouteTableInfo(tableInfo *common.TableInfo) *common.TableInfo {
+	if r == nil {
+		return tableInfo
+	}
+	tableID := tableInfo.TableName.TableID
+	if v, ok := r.tableInfos.Load(tableID); ok && v.(routedTableInfo).source == tableInfo {
+		return v.(routedTableInfo).target
+	}
+	target := tableInfo
+	schema, table := r.Route(tableInfo.GetSchemaName(), tableInfo.GetTableName())
+	if schema != tableInfo.GetSchemaName() || table != tableInfo.GetTableName() {
+		target = tableInfo.Rename(schema, table)
+	}
+	r.tableInfos.Store(tableID, routedTableInfo{source: tableInfo, target: target})
+	return target
+}
+
+func expandRouteRule(rule, schema, table, origin string) string {
+	if rule == "" {
+		return origin
+	}
+	rule = strings.ReplaceAll(rule, config.RouteSchemaPlaceholder, schema)
+	return strings.ReplaceAll(rule, config.RouteTablePlaceholder, table)
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"net/url"
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableRouter(t *testing.T) {
+	router, err := NewTableRouter(false, nil)
+	require.NoError(t, err)
+	require.Nil(t, router)
+	schema, table := router.Route("test", "t")
+	require.Equal(t, "test", schema)
+	require.Equal(t, "t", table)
+
+	router, err = NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"shard_*.orders"}, SchemaRule: "merged", TableRule: "orders"},
+		{Matcher: []string{"test.*"}, SchemaRule: "{schema}_bak", TableRule: "{schema}_{table}"},
+		{Matcher: []string{"keep.*"}},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		schema, table             string
+		targetSchema, targetTable string
+	}{
+		{"shard_01", "orders", "merged", "orders"},
+		{"SHARD_02", "Orders", "merged", "orders"},
+		{"shard_01", "users", "shard_01", "users"},
+		{"test", "t", "test_bak", "test_t"},
+		{"keep", "t", "keep", "t"},
+		{"other", "t", "other", "t"},
+	}
+	for _, c := range cases {
+		schema, table = router.Route(c.schema, c.table)
+		require.Equal(t, c.targetSchema, schema)
+		require.Equal(t, c.targetTable, table)
+	}
+
+	// The schema is not routed by the rule which only matches some tables of it.
+	require.Equal(t, "shard_01", router.RouteSchema("shard_01"))
+	require.Equal(t, "test_bak", router.RouteSchema("test"))
+	require.Equal(t, "other", router.RouteSchema("other"))
+}
+
+func TestTableRouterMerged(t *testing.T) {
+	router, err := NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"shard_*.orders"}, SchemaRule: "merged", TableRule: "orders"},
+		{Matcher: []string{"db_*.*"}, SchemaRule: "db"},
+		{Matcher: []string{"app_*.*"}, SchemaRule: "app", TableRule: "{schema}_{table}"},
+		{Matcher: []string{"test.t1"}, SchemaRule: "test_bak", TableRule: "t"},
+		{Matcher: []string{"test.t2"}, SchemaRule: "test_bak", TableRule: "t2_bak"},
+		{Matcher: []string{"test.t3"}, SchemaRule: "TEST_BAK", TableRule: "T2_BAK"},
+		{Matcher: []string{"single.*"}, SchemaRule: "single_bak"},
+	})
+	require.NoError(t, err)
+
+	require.True(t, router.IsMergedSchema("merged"))
+	require.True(t, router.IsMergedSchema("db"))
+	require.True(t, router.IsMergedSchema("app"))
+	require.False(t, router.IsMergedSchema("test_bak"))
+	require.False(t, router.IsMergedSchema("single_bak"))
+	require.False(t, router.IsMergedSchema("other"))
+
+	cases := []struct {
+		schema, table string
+		merged        bool
+	}{
+		{"shard_01", "orders", true},
+		// the tables of the same name in the merged schemas are merged
+		{"db_01", "t", true},
+		// the table name contains the schema, so the tables are not merged
+		{"app_01", "t", false},
+		// a single table is renamed
+		{"test", "t1", false},
+		// the tables routed to the same table by different rules are merged
+		{"test", "t2", true},
+		{"test", "t3", true},
+		{"single", "t", false},
+		{"other", "t", false},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.merged, router.IsMergedTable(c.schema, c.table), "%s.%s", c.schema, c.table)
+	}
+}
+
+func TestValidateRouteRules(t *testing.T) {
+	sinkURI, err := url.Parse("mysql://127.0.0.1:3306")
+	require.NoError(t, err)
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Sink.RouteRules = []*config.RouteRule{
+		{Matcher: []string{"shard_*.orders"}, SchemaRule: "merged", TableRule: "orders"},
+		{Matcher: []string{"db_*.*", "!db_tmp.*"}, SchemaRule: "db"},
+	}
+	require.NoError(t, cfg.ValidateAndAdjust(sinkURI))
+
+	// The rule matching both whole schemas and tables is rejected, since the schema-level
+	// DDLs of the tables' schemas can't be routed.
+	cfg = config.GetDefaultReplicaConfig()
+	cfg.Sink.RouteRules = []*config.RouteRule{
+		{Matcher: []string{"db_*.*", "other.t"}, SchemaRule: "db"},
+	}
+	require.ErrorIs(t, cfg.ValidateAndAdjust(sinkURI), cerror.ErrSinkInvalidConfig)
+}
+
+func TestTableRouterRouteTableInfo(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+	tableInfo := helper.GetTableInfo(job)
+
+	router, err := NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"test.t"}, SchemaRule: "target", TableRule: "t_{table}"},
+	})
+	require.NoError(t, err)
+
+	routed := router.RouteTableInfo(tableInfo)
+	require.Equal(t, "target", routed.GetSchemaName())
+	require.Equal(t, "t_t", routed.GetTableName())
+	require.Equal(t, tableInfo.TableName.TableID, routed.TableName.TableID)
+	require.Equal(t, "`target`.`t_t`", routed.TableName.QuoteString())
+	require.Equal(t, len(tableInfo.GetColumns()), len(routed.GetColumns()))
+	// the routed table info is cached
+	require.Same(t, routed, router.RouteTableInfo(tableInfo))
+
+	router, err = NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"other.*"}, SchemaRule: "target"},
+	})
+	require.NoError(t, err)
+	require.Same(t, tableInfo, router.RouteTableInfo(tableInfo))
+}
+
+func TestRouteQuery(t *testing.T) {
+	router, err := NewTableRouter(false, []*config.RouteRule{
+		{Matcher: []string{"shard_*.orders"}, SchemaRule: "merged", TableRule: "orders"},
+		{Matcher: []string{"test.*"}, SchemaRule: "{schema}_bak"},
+		{Matcher: []string{"db_*.*"}, SchemaRule: "db"},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		schema   string
+		query    string
+		expected string
+	}{
+		{
+			schema:   "shard_01",
+			query:    "create table orders (id int primary key)",
+			expected: "CREATE TABLE `merged`.`orders` (`id` INT PRIMARY KEY)",
+		},
+		{
+			schema:   "shard_01",
+			query:    "alter table shard_01.orders add column c int",
+			expected: "ALTER TABLE `merged`.`orders` ADD COLUMN `c` INT",
+		},
+		{
+			schema:   "test",
+			query:    "rename table t1 to t2, other.t3 to t4",
+			expected: "RENAME TABLE `test_bak`.`t1` TO `test_bak`.`t2`, `other`.`t3` TO `test_bak`.`t4`",
+		},
+		{
+			schema:   "test",
+			query:    "create database test",
+			expected: "CREATE DATABASE `test_bak`",
+		},
+		{
+			// the schema is not routed by the rule which only matches some tables of it
+			schema:   "shard_01",
+			query:    "create database shard_01",
+			expected: "create database shard_01",
+		},
+		{
+			schema:   "shard_01",
+			query:    "drop database shard_01",
+			expected: "drop database shard_01",
+		},
+		{
+			// the ddls dropping the merged schema or table are skipped
+			schema:   "db_01",
+			query:    "drop database db_01",
+			expected: "",
+		},
+		{
+			schema:   "db_01",
+			query:    "create database db_01",
+			expected: "CREATE DATABASE `db`",
+		},
+		{
+			schema:   "shard_01",
+			query:    "drop table orders",
+			expected: "",
+		},
+		{
+			schema:   "test",
+			query:    "truncate table shard_02.orders",
+			expected: "",
+		},
+		{
+			schema:   "db_01",
+			query:    "drop table t1, t2",
+			expected: "",
+		},
+		{
+			// the not merged tables are dropped
+			schema:   "test",
+			query:    "drop table t1",
+			expected: "DROP TABLE `test_bak`.`t1`",
+		},
+		{
+			schema:   "shard_01",
+			query:    "drop table users",
+			expected: "drop table users",
+		},
+		{
+			// the query is kept if no table is routed
+			schema:   "other",
+			query:    "create table t (id int primary key)",
+			expected: "create table t (id int primary key)",
+		},
+	}
+	for _, c := range cases {
+		query, err := routeQuery(c.query, c.schema, router)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, query)
+	}
+
+	_, err = routeQuery("create tabel t", "test", router)
+	require.Error(t, err)
+}
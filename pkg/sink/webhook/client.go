@@ -0,0 +1,187 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+const (
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeBinary = "application/octet-stream"
+
+	// maxErrorBodyLength is the max length of the response body kept in the error.
+	maxErrorBodyLength = 512
+)
+
+// Client posts the encoded messages to the webhook endpoint.
+// Each call of Send is one request, it's retried with backoff if the request
+// failed by network errors, 5xx or 429 responses.
+//
+// The messages of the JSON based protocols are written into the request body as
+// newline delimited JSON, one message value per line. The messages of the other
+// protocols are written as a sequence of length prefixed key and value, the length
+// is an 8 bytes big endian integer.
+type Client struct {
+	changefeedID commonType.ChangeFeedID
+	config       *Config
+	contentType  string
+	client       *http.Client
+}
+
+// NewClient creates a new webhook client.
+func NewClient(
+	changefeedID commonType.ChangeFeedID,
+	cfg *Config,
+	protocol config.Protocol,
+	encodingFormat common.EncodingFormatType,
+) *Client {
+	contentType := contentTypeBinary
+	if isJSONProtocol(protocol, encodingFormat) {
+		contentType = contentTypeNDJSON
+	}
+	return &Client{
+		changefeedID: changefeedID,
+		config:       cfg,
+		contentType:  contentType,
+		client:       &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func isJSONProtocol(protocol config.Protocol, encodingFormat common.EncodingFormatType) bool {
+	switch protocol {
+	case config.ProtocolCanalJSON, config.ProtocolDebezium, config.ProtocolMaxwell:
+		return true
+	case config.ProtocolSimple:
+		return encodingFormat == common.EncodingFormatJSON
+	default:
+	}
+	return false
+}
+
+// Send posts the messages to the endpoint in one request.
+func (c *Client) Send(ctx context.Context, messages ...*common.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	body := c.buildBody(messages)
+	err := retry.Do(ctx, func() error {
+		err := c.post(ctx, body)
+		if err != nil {
+			log.Warn("webhook sink send request failed",
+				zap.String("namespace", c.changefeedID.Namespace()),
+				zap.String("changefeed", c.changefeedID.Name()),
+				zap.Int("messages", len(messages)),
+				zap.Error(err))
+		}
+		return err
+	}, retry.WithBackoffBaseDelay(c.config.RetryBackoffBaseInMs),
+		retry.WithBackoffMaxDelay(c.config.RetryBackoffMaxInMs),
+		retry.WithMaxTries(uint64(c.config.MaxRetries)+1),
+		retry.WithIsRetryableErr(isRetryableError))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrWebhookSendMessage, err)
+	}
+	return nil
+}
+
+func (c *Client) buildBody(messages []*common.Message) []byte {
+	var buf bytes.Buffer
+	if c.contentType == contentTypeNDJSON {
+		for _, message := range messages {
+			buf.Write(bytes.TrimRight(message.Value, "\r\n"))
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes()
+	}
+
+	var length [8]byte
+	for _, message := range messages {
+		binary.BigEndian.PutUint64(length[:], uint64(len(message.Key)))
+		buf.Write(length[:])
+		buf.Write(message.Key)
+		binary.BigEndian.PutUint64(length[:], uint64(len(message.Value)))
+		buf.Write(length[:])
+		buf.Write(message.Value)
+	}
+	return buf.Bytes()
+}
+
+func (c *Client) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", c.contentType)
+	for key, value := range c.config.Headers {
+		req.Header.Set(key, value)
+	}
+	if c.config.BasicAuthUser != "" {
+		req.SetBasicAuth(c.config.BasicAuthUser, c.config.BasicAuthPassword)
+	} else if c.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		// Drain the body to reuse the connection.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	return &statusError{code: resp.StatusCode, body: string(respBody)}
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.client.CloseIdleConnections()
+}
+
+// statusError is returned when the endpoint responds with a non 2xx status code.
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook responds with status code %d: %s", e.code, e.body)
+}
+
+func isRetryableError(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *statusError:
+		return cause.code >= http.StatusInternalServerError ||
+			cause.code == http.StatusTooManyRequests
+	default:
+		return cause != context.Canceled && cause != context.DeadlineExceeded
+	}
+}
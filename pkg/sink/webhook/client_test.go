@@ -0,0 +1,145 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func newClientForTest(
+	t *testing.T, rawURL string, protocol config.Protocol,
+) *Client {
+	sinkURI, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	cfg := NewConfig()
+	require.NoError(t, cfg.Apply(sinkURI, config.GetDefaultReplicaConfig().Sink))
+	return NewClient(commonType.NewChangefeedID4Test("test", "test"), cfg, protocol, common.EncodingFormatJSON)
+}
+
+func TestClientSendNDJSON(t *testing.T) {
+	var (
+		body        []byte
+		contentType string
+		user        string
+		header      string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+		user, _, _ = r.BasicAuth()
+		header = r.Header.Get("X-Source")
+	}))
+	defer server.Close()
+
+	sinkURI, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	sinkURI.User = url.UserPassword("user", "password")
+	sinkURI.Path = "/events"
+	cfg := NewConfig()
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.WebhookConfig = &config.WebhookConfig{Headers: map[string]string{"X-Source": "ticdc"}}
+	require.NoError(t, cfg.Apply(sinkURI, sinkConfig))
+	client := NewClient(commonType.NewChangefeedID4Test("test", "test"), cfg, config.ProtocolCanalJSON, common.EncodingFormatJSON)
+	defer client.Close()
+
+	err = client.Send(context.Background(),
+		&common.Message{Value: []byte(`{"id":1}`)},
+		&common.Message{Value: []byte("{\"id\":2}\r\n")})
+	require.NoError(t, err)
+	require.Equal(t, "{\"id\":1}\n{\"id\":2}\n", string(body))
+	require.Equal(t, contentTypeNDJSON, contentType)
+	require.Equal(t, "user", user)
+	require.Equal(t, "ticdc", header)
+}
+
+func TestClientSendBinary(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		require.Equal(t, contentTypeBinary, r.Header.Get("Content-Type"))
+	}))
+	defer server.Close()
+
+	client := newClientForTest(t, server.URL, config.ProtocolOpen)
+	defer client.Close()
+	err := client.Send(context.Background(), &common.Message{Key: []byte("key"), Value: []byte("value")})
+	require.NoError(t, err)
+
+	require.Len(t, body, 8+3+8+5)
+	require.Equal(t, uint64(3), binary.BigEndian.Uint64(body[:8]))
+	require.Equal(t, "key", string(body[8:11]))
+	require.Equal(t, uint64(5), binary.BigEndian.Uint64(body[11:19]))
+	require.Equal(t, "value", string(body[19:]))
+}
+
+func TestClientRetry(t *testing.T) {
+	requests := atomic.NewInt64(0)
+	statusCodes := []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCodes[requests.Inc()-1])
+	}))
+	defer server.Close()
+
+	client := newClientForTest(t, server.URL+"?retry-backoff-base-in-ms=1&retry-backoff-max-in-ms=10", config.ProtocolCanalJSON)
+	defer client.Close()
+	err := client.Send(context.Background(), &common.Message{Value: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), requests.Load())
+}
+
+func TestClientNonRetryableError(t *testing.T) {
+	requests := atomic.NewInt64(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad request"))
+	}))
+	defer server.Close()
+
+	client := newClientForTest(t, server.URL+"?retry-backoff-base-in-ms=1&retry-backoff-max-in-ms=10", config.ProtocolCanalJSON)
+	defer client.Close()
+	err := client.Send(context.Background(), &common.Message{Value: []byte(`{}`)})
+	require.ErrorIs(t, err, cerror.ErrWebhookSendMessage)
+	require.Contains(t, err.Error(), "bad request")
+	require.Equal(t, int64(1), requests.Load())
+}
+
+func TestClientReachMaxRetries(t *testing.T) {
+	requests := atomic.NewInt64(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Inc()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newClientForTest(t,
+		server.URL+"?max-retries=2&retry-backoff-base-in-ms=1&retry-backoff-max-in-ms=10", config.ProtocolCanalJSON)
+	defer client.Close()
+	err := client.Send(context.Background(), &common.Message{Value: []byte(`{}`)})
+	require.ErrorIs(t, err, cerror.ErrWebhookSendMessage)
+	require.Equal(t, int64(3), requests.Load())
+}
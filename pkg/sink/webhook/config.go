@@ -0,0 +1,204 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/imdario/mergo"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/util"
+)
+
+const (
+	// Scheme indicates the scheme is webhook over http.
+	Scheme = "http"
+	// SSLScheme indicates the scheme is webhook over https.
+	SSLScheme = "https"
+
+	// defaultMaxBatchSize is the default value of max-batch-size.
+	defaultMaxBatchSize = 256
+	// the upper limit of max-batch-size.
+	maxMaxBatchSize = 4096
+	// defaultMaxRetries is the default value of max-retries.
+	defaultMaxRetries = 10
+	// defaultTimeout is the default timeout of each request.
+	defaultTimeout = 10 * time.Second
+	// defaultRetryBackoffBaseInMs is the default base delay of the retry backoff.
+	defaultRetryBackoffBaseInMs = 100
+	// defaultRetryBackoffMaxInMs is the default max delay of the retry backoff.
+	defaultRetryBackoffMaxInMs = 10 * 1000
+)
+
+// IsWebhookScheme returns true if the scheme belong to webhook scheme.
+func IsWebhookScheme(scheme string) bool {
+	return scheme == Scheme || scheme == SSLScheme
+}
+
+type urlConfig struct {
+	MaxBatchSize         *int    `form:"max-batch-size"`
+	MaxRetries           *int    `form:"max-retries"`
+	Timeout              *string `form:"timeout"`
+	RetryBackoffBaseInMs *int64  `form:"retry-backoff-base-in-ms"`
+	RetryBackoffMaxInMs  *int64  `form:"retry-backoff-max-in-ms"`
+	BasicAuthUser        *string `form:"basic-auth-user"`
+	BasicAuthPassword    *string `form:"basic-auth-password"`
+	BearerToken          *string `form:"bearer-token"`
+}
+
+// Config is the configuration for webhook sink.
+type Config struct {
+	// Endpoint is the URL which the messages are posted to,
+	// it's the sink URI without the query parameters and user info.
+	Endpoint string
+	Headers  map[string]string
+
+	BasicAuthUser     string
+	BasicAuthPassword string
+	BearerToken       string
+
+	MaxBatchSize         int
+	MaxRetries           int
+	Timeout              time.Duration
+	RetryBackoffBaseInMs int64
+	RetryBackoffMaxInMs  int64
+}
+
+// NewConfig returns the default webhook sink config.
+func NewConfig() *Config {
+	return &Config{
+		Headers:              make(map[string]string),
+		MaxBatchSize:         defaultMaxBatchSize,
+		MaxRetries:           defaultMaxRetries,
+		Timeout:              defaultTimeout,
+		RetryBackoffBaseInMs: defaultRetryBackoffBaseInMs,
+		RetryBackoffMaxInMs:  defaultRetryBackoffMaxInMs,
+	}
+}
+
+// Apply applies the sink URI parameters and the sink config to the config.
+func (c *Config) Apply(sinkURI *url.URL, sinkConfig *config.SinkConfig) (err error) {
+	if sinkURI == nil {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"failed to open webhook sink, empty SinkURI")
+	}
+	scheme := strings.ToLower(sinkURI.Scheme)
+	if !IsWebhookScheme(scheme) {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"can't create webhook sink with unsupported scheme: %s", scheme)
+	}
+	if sinkURI.Host == "" {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"failed to open webhook sink, host is empty")
+	}
+
+	req := &http.Request{URL: sinkURI}
+	urlParameter := &urlConfig{}
+	if err = binding.Query.Bind(req, urlParameter); err != nil {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	if urlParameter, err = mergeConfig(sinkConfig, urlParameter); err != nil {
+		return err
+	}
+
+	endpoint := *sinkURI
+	endpoint.RawQuery = ""
+	endpoint.User = nil
+	c.Endpoint = endpoint.String()
+	if sinkURI.User != nil {
+		c.BasicAuthUser = sinkURI.User.Username()
+		c.BasicAuthPassword, _ = sinkURI.User.Password()
+	}
+	if sinkConfig != nil && sinkConfig.WebhookConfig != nil {
+		for key, value := range sinkConfig.WebhookConfig.Headers {
+			c.Headers[key] = value
+		}
+	}
+
+	if urlParameter.BasicAuthUser != nil {
+		c.BasicAuthUser = *urlParameter.BasicAuthUser
+	}
+	if urlParameter.BasicAuthPassword != nil {
+		c.BasicAuthPassword = *urlParameter.BasicAuthPassword
+	}
+	c.BearerToken = util.GetOrZero(urlParameter.BearerToken)
+	if c.BearerToken != "" && c.BasicAuthUser != "" {
+		return cerror.ErrWebhookInvalidConfig.GenWithStack(
+			"basic-auth-user and bearer-token cannot be set at the same time")
+	}
+
+	if urlParameter.MaxBatchSize != nil {
+		c.MaxBatchSize = *urlParameter.MaxBatchSize
+		if c.MaxBatchSize <= 0 || c.MaxBatchSize > maxMaxBatchSize {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+				fmt.Errorf("invalid max-batch-size %d, it must be in [1, %d]", c.MaxBatchSize, maxMaxBatchSize))
+		}
+	}
+	if urlParameter.MaxRetries != nil {
+		c.MaxRetries = *urlParameter.MaxRetries
+		if c.MaxRetries < 0 {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+				fmt.Errorf("invalid max-retries %d, it must not be negative", c.MaxRetries))
+		}
+	}
+	if urlParameter.Timeout != nil && len(*urlParameter.Timeout) != 0 {
+		c.Timeout, err = time.ParseDuration(*urlParameter.Timeout)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+		}
+		if c.Timeout <= 0 {
+			return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+				fmt.Errorf("invalid timeout %s, it must be greater than 0", c.Timeout))
+		}
+	}
+	if urlParameter.RetryBackoffBaseInMs != nil {
+		c.RetryBackoffBaseInMs = *urlParameter.RetryBackoffBaseInMs
+	}
+	if urlParameter.RetryBackoffMaxInMs != nil {
+		c.RetryBackoffMaxInMs = *urlParameter.RetryBackoffMaxInMs
+	}
+	if c.RetryBackoffBaseInMs <= 0 || c.RetryBackoffMaxInMs < c.RetryBackoffBaseInMs {
+		return cerror.WrapError(cerror.ErrWebhookInvalidConfig,
+			fmt.Errorf("invalid retry backoff [%d, %d], the base must be greater than 0 and not greater than the max",
+				c.RetryBackoffBaseInMs, c.RetryBackoffMaxInMs))
+	}
+	return nil
+}
+
+func mergeConfig(
+	sinkConfig *config.SinkConfig,
+	urlParameters *urlConfig,
+) (*urlConfig, error) {
+	dest := &urlConfig{}
+	if sinkConfig != nil && sinkConfig.WebhookConfig != nil {
+		dest.MaxBatchSize = sinkConfig.WebhookConfig.MaxBatchSize
+		dest.MaxRetries = sinkConfig.WebhookConfig.MaxRetries
+		dest.Timeout = sinkConfig.WebhookConfig.Timeout
+		dest.RetryBackoffBaseInMs = sinkConfig.WebhookConfig.RetryBackoffBaseInMs
+		dest.RetryBackoffMaxInMs = sinkConfig.WebhookConfig.RetryBackoffMaxInMs
+		dest.BasicAuthUser = sinkConfig.WebhookConfig.BasicAuthUser
+		dest.BasicAuthPassword = sinkConfig.WebhookConfig.BasicAuthPassword
+		dest.BearerToken = sinkConfig.WebhookConfig.BearerToken
+	}
+	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
+		return nil, cerror.WrapError(cerror.ErrWebhookInvalidConfig, err)
+	}
+	return dest, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/pingcap/log"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/tiflow/pkg/security"
 	pd "github.com/tikv/pd/client"
 	clientV3 "go.etcd.io/etcd/client/v3"
@@ -39,18 +40,21 @@ type Manager struct {
 	mu sync.Mutex
 
 	defaultUpstream *Upstream
+	// etcdClient is used to load the info of the upstreams not added yet.
+	etcdClient etcd.CDCEtcdClient
 
 	initUpstreamFunc func(context.Context, *Upstream) error
 }
 
 // NewManager creates a new Manager.
 // ctx will be used to initialize upstream spawned by this Manager.
-func NewManager(ctx context.Context) *Manager {
+func NewManager(ctx context.Context, etcdClient etcd.CDCEtcdClient) *Manager {
 	ctx, cancel := context.WithCancel(ctx)
 	return &Manager{
 		ups:              new(sync.Map),
 		ctx:              ctx,
 		cancel:           cancel,
+		etcdClient:       etcdClient,
 		initUpstreamFunc: initUpstream,
 	}
 }
@@ -112,6 +116,7 @@ func (m *Manager) add(upstreamID uint64,
 		}
 	}
 	up := newUpstream(pdEndpoints, securityConf)
+	up.ID = upstreamID
 	m.ups.Store(upstreamID, up)
 	go func() {
 		err := m.initUpstreamFunc(m.ctx, up)
@@ -154,6 +159,39 @@ func (m *Manager) Get(upstreamID uint64) (*Upstream, bool) {
 	return up, true
 }
 
+// GetOrLoad gets a upstream by upstreamID, if the upstream is not added yet,
+// its info is loaded from etcd and the upstream is initialized in background.
+// So the returned upstream may be not ready, check IsNormal before using it.
+func (m *Manager) GetOrLoad(ctx context.Context, upstreamID uint64, namespace string) (*Upstream, error) {
+	if up, ok := m.Get(upstreamID); ok {
+		if up.Error() == nil {
+			return up, nil
+		}
+		// the upstream failed to initialize, close it and load it again. It may be
+		// reloaded by another caller already, only the caller removes it closes it.
+		m.mu.Lock()
+		removed := m.ups.CompareAndDelete(upstreamID, up)
+		m.mu.Unlock()
+		if removed {
+			log.Warn("upstream initialize failed, reload it",
+				zap.Uint64("id", upstreamID), zap.Error(up.Error()))
+			up.Close()
+		}
+	}
+	info, err := m.etcdClient.GetUpstreamInfo(ctx, upstreamID, namespace)
+	if err != nil {
+		return nil, cerror.Trace(err)
+	}
+	return m.AddUpstream(&UpstreamInfo{
+		ID:            info.ID,
+		PDEndpoints:   info.PDEndpoints,
+		KeyPath:       info.KeyPath,
+		CertPath:      info.CertPath,
+		CAPath:        info.CAPath,
+		CertAllowedCN: info.CertAllowedCN,
+	}), nil
+}
+
 // Close closes all upstreams.
 // Please make sure it will only be called once when capture exits.
 func (m *Manager) Close() {
@@ -0,0 +1,52 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net/url"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// sensitiveSinkURIParameters are the query parameters of the sink URI which carry credentials.
+var sensitiveSinkURIParameters = []string{
+	"sasl-password",
+	// the credentials of the webhook sink.
+	"basic-auth-user",
+	"basic-auth-password",
+	"bearer-token",
+}
+
+// MaskSinkURI returns a sink URI whose password and credential parameters are masked.
+func MaskSinkURI(uri string) (string, error) {
+	uriParsed, err := url.Parse(uri)
+	if err != nil {
+		log.Error("failed to parse sink URI", zap.Error(err))
+		return "", errors.Trace(err)
+	}
+	queries := uriParsed.Query()
+	masked := false
+	for _, key := range sensitiveSinkURIParameters {
+		if queries.Has(key) {
+			queries.Set(key, "xxxxx")
+			masked = true
+		}
+	}
+	if masked {
+		uriParsed.RawQuery = queries.Encode()
+	}
+	return uriParsed.Redacted(), nil
+}
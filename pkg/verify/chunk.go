@@ -0,0 +1,169 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+type tableName struct {
+	schema string
+	table  string
+}
+
+type tableInfo struct {
+	tableName
+	columns   []string
+	pkColumns []string
+}
+
+func tableExists(ctx context.Context, conn *sql.Conn, table tableName) (bool, error) {
+	var count int
+	err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.tables "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", table.schema, table.table).Scan(&count)
+	if err != nil {
+		return false, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return count > 0, nil
+}
+
+func getTableInfo(ctx context.Context, conn *sql.Conn, table tableName) (*tableInfo, error) {
+	columns, err := queryColumnNames(ctx, conn, "SELECT COLUMN_NAME FROM information_schema.columns "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", table.schema, table.table)
+	if err != nil {
+		return nil, err
+	}
+	pkColumns, err := queryColumnNames(ctx, conn, "SELECT COLUMN_NAME FROM information_schema.key_column_usage "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY' ORDER BY ORDINAL_POSITION",
+		table.schema, table.table)
+	if err != nil {
+		return nil, err
+	}
+	return &tableInfo{
+		tableName: table,
+		columns:   columns,
+		pkColumns: pkColumns,
+	}, nil
+}
+
+func queryColumnNames(ctx context.Context, conn *sql.Conn, query string, args ...interface{}) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		names = append(names, name)
+	}
+	return names, cerror.WrapError(cerror.ErrMySQLQueryError, rows.Err())
+}
+
+// nextChunkBound returns the primary key values of the last row of the chunk after lower,
+// it returns nil if the rest rows are not more than chunkSize.
+func nextChunkBound(
+	ctx context.Context, conn *sql.Conn, info *tableInfo, lower []string, chunkSize int,
+) ([]string, error) {
+	where, args := buildRangeCondition(info.pkColumns, lower, nil)
+	pkList := quoteColumns(info.pkColumns)
+	query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT 1 OFFSET %d",
+		pkList, common.QuoteSchema(info.schema, info.table), where, pkList, chunkSize-1)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, rows.Err())
+	}
+	values := make([]sql.RawBytes, len(info.pkColumns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	bound := make([]string, len(values))
+	for i, value := range values {
+		bound[i] = string(value)
+	}
+	return bound, nil
+}
+
+// checksum returns the row count and the checksum of the rows matching the condition.
+func checksum(
+	ctx context.Context, conn *sql.Conn, info *tableInfo, where string, args []interface{},
+) (int64, uint64, error) {
+	isNulls := make([]string, 0, len(info.columns))
+	for _, column := range info.columns {
+		isNulls = append(isNulls, fmt.Sprintf("ISNULL(%s)", common.QuoteName(column)))
+	}
+	// The null flags are appended since CONCAT_WS skips the null values.
+	query := fmt.Sprintf("SELECT COUNT(*), COALESCE(BIT_XOR(CRC32(CONCAT_WS(',', %s, CONCAT(%s)))), 0) FROM %s%s",
+		quoteColumns(info.columns), strings.Join(isNulls, ", "),
+		common.QuoteSchema(info.schema, info.table), where)
+	var (
+		count int64
+		sum   uint64
+	)
+	if err := conn.QueryRowContext(ctx, query, args...).Scan(&count, &sum); err != nil {
+		return 0, 0, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return count, sum, nil
+}
+
+// buildRangeCondition builds the where clause of the range (lower, upper],
+// nil bound means unbounded.
+func buildRangeCondition(columns []string, lower, upper []string) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	if lower != nil {
+		conds = append(conds, fmt.Sprintf("(%s) > %s", quoteColumns(columns), placeholders))
+		for _, value := range lower {
+			args = append(args, value)
+		}
+	}
+	if upper != nil {
+		conds = append(conds, fmt.Sprintf("(%s) <= %s", quoteColumns(columns), placeholders))
+		for _, value := range upper {
+			args = append(args, value)
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func quoteColumns(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quoted = append(quoted, common.QuoteName(column))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"go.uber.org/zap"
+)
+
+// DefaultChunkSize is the default max number of rows in a chunk.
+const DefaultChunkSize = 10000
+
+// Config is the config of the Verifier.
+type Config struct {
+	// ClusterID and ChangefeedID locate the sync point rows in the downstream,
+	// ChangefeedID is in "namespace/name" format.
+	ClusterID    string
+	ChangefeedID string
+	// PrimaryTs is the primary ts of the sync point to verify,
+	// 0 means the latest sync point.
+	PrimaryTs uint64
+	// ChunkSize is the max number of rows in a chunk.
+	ChunkSize int
+	// Filter and CaseSensitive are the same as the changefeed's,
+	// they are used to pick the tables to verify.
+	Filter        *config.FilterConfig
+	CaseSensitive bool
+}
+
+// SyncPoint is a row of the sync point table, it maps the primary ts of
+// the upstream to the secondary ts of the downstream.
+type SyncPoint struct {
+	PrimaryTs   uint64 `json:"primary_ts"`
+	SecondaryTs uint64 `json:"secondary_ts"`
+}
+
+// ChunkMismatch is a chunk of a table whose data is different between
+// the upstream and the downstream.
+type ChunkMismatch struct {
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	// LowerBound and UpperBound are the values of the primary key columns,
+	// the range of the chunk is (LowerBound, UpperBound], nil means unbounded.
+	LowerBound []string `json:"lower_bound"`
+	UpperBound []string `json:"upper_bound"`
+
+	UpstreamCount      int64  `json:"upstream_count"`
+	DownstreamCount    int64  `json:"downstream_count"`
+	UpstreamChecksum   uint64 `json:"upstream_checksum"`
+	DownstreamChecksum uint64 `json:"downstream_checksum"`
+	Reason             string `json:"reason,omitempty"`
+}
+
+// Report is the result of a verification.
+type Report struct {
+	SyncPoint  SyncPoint       `json:"sync_point"`
+	Tables     int             `json:"tables"`
+	Chunks     int             `json:"chunks"`
+	Mismatches []ChunkMismatch `json:"mismatches"`
+}
+
+// Consistent returns true if no mismatch is found.
+func (r *Report) Consistent() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Verifier compares the data of the upstream and the downstream at a sync point.
+// The upstream is read at the primary ts and the downstream is read at the
+// secondary ts, so both of them must support snapshot read by @@tidb_snapshot.
+type Verifier struct {
+	upstream   *sql.DB
+	downstream *sql.DB
+	cfg        *Config
+	filter     filter.Filter
+}
+
+// NewVerifier creates a Verifier.
+func NewVerifier(upstream, downstream *sql.DB, cfg *Config) (*Verifier, error) {
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	filterConfig := cfg.Filter
+	if filterConfig == nil {
+		filterConfig = config.GetDefaultReplicaConfig().Filter
+	}
+	f, err := filter.NewFilter(filterConfig, "", cfg.CaseSensitive, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Verifier{
+		upstream:   upstream,
+		downstream: downstream,
+		cfg:        cfg,
+		filter:     f,
+	}, nil
+}
+
+// Verify compares all the tables picked by the filter, and returns the mismatching chunks.
+func (v *Verifier) Verify(ctx context.Context) (*Report, error) {
+	syncPoint, err := v.getSyncPoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("verify data at sync point",
+		zap.String("changefeed", v.cfg.ChangefeedID),
+		zap.Uint64("primaryTs", syncPoint.PrimaryTs),
+		zap.Uint64("secondaryTs", syncPoint.SecondaryTs))
+
+	upConn, err := newSnapshotConn(ctx, v.upstream, syncPoint.PrimaryTs)
+	if err != nil {
+		return nil, err
+	}
+	defer upConn.Close()
+	downConn, err := newSnapshotConn(ctx, v.downstream, syncPoint.SecondaryTs)
+	if err != nil {
+		return nil, err
+	}
+	defer downConn.Close()
+
+	tables, err := v.listTables(ctx, upConn)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{SyncPoint: *syncPoint}
+	for _, table := range tables {
+		exists, err := tableExists(ctx, downConn, table)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			log.Warn("table is not found in downstream",
+				zap.String("schema", table.schema), zap.String("table", table.table))
+			report.Mismatches = append(report.Mismatches, ChunkMismatch{
+				Schema: table.schema,
+				Table:  table.table,
+				Reason: "table is not found in downstream",
+			})
+			continue
+		}
+		if err := v.verifyTable(ctx, upConn, downConn, table, report); err != nil {
+			return nil, err
+		}
+		report.Tables++
+	}
+	log.Info("verify data finished",
+		zap.String("changefeed", v.cfg.ChangefeedID),
+		zap.Int("tables", report.Tables),
+		zap.Int("chunks", report.Chunks),
+		zap.Int("mismatches", len(report.Mismatches)))
+	return report, nil
+}
+
+func (v *Verifier) getSyncPoint(ctx context.Context) (*SyncPoint, error) {
+	query := fmt.Sprintf("SELECT primary_ts, secondary_ts FROM %s WHERE ticdc_cluster_id = ? AND changefeed = ?",
+		common.QuoteSchema(filter.TiCDCSystemSchema, filter.SyncPointTable))
+	args := []interface{}{v.cfg.ClusterID, v.cfg.ChangefeedID}
+	if v.cfg.PrimaryTs != 0 {
+		query += " AND primary_ts = ?"
+		args = append(args, strconv.FormatUint(v.cfg.PrimaryTs, 10))
+	}
+	query += " ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT 1"
+
+	var primaryTs, secondaryTs string
+	err := v.downstream.QueryRowContext(ctx, query, args...).Scan(&primaryTs, &secondaryTs)
+	if err == sql.ErrNoRows {
+		return nil, cerror.ErrSyncPointNotFound.GenWithStackByArgs(v.cfg.ChangefeedID)
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	syncPoint := &SyncPoint{}
+	if syncPoint.PrimaryTs, err = strconv.ParseUint(primaryTs, 10, 64); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if syncPoint.SecondaryTs, err = strconv.ParseUint(secondaryTs, 10, 64); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return syncPoint, nil
+}
+
+// newSnapshotConn returns a connection which reads the snapshot at the ts.
+func newSnapshotConn(ctx context.Context, db *sql.DB, ts uint64) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("SET @@tidb_snapshot = '%d'", ts))
+	if err != nil {
+		conn.Close()
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return conn, nil
+}
+
+func (v *Verifier) listTables(ctx context.Context, conn *sql.Conn) ([]tableName, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT TABLE_SCHEMA, TABLE_NAME FROM information_schema.tables "+
+		"WHERE TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_SCHEMA, TABLE_NAME")
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+
+	var tables []tableName
+	for rows.Next() {
+		var table tableName
+		if err := rows.Scan(&table.schema, &table.table); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		if v.filter.ShouldIgnoreTable(table.schema, table.table, nil) {
+			continue
+		}
+		tables = append(tables, table)
+	}
+	return tables, cerror.WrapError(cerror.ErrMySQLQueryError, rows.Err())
+}
+
+// verifyTable splits the table into chunks by the primary key, and compares
+// the checksum of each chunk. The chunk bounds are decided by the upstream.
+func (v *Verifier) verifyTable(
+	ctx context.Context, upConn, downConn *sql.Conn, table tableName, report *Report,
+) error {
+	info, err := getTableInfo(ctx, upConn, table)
+	if err != nil {
+		return err
+	}
+	var lower []string
+	for {
+		var upper []string
+		// the table without primary key is compared as a whole
+		if len(info.pkColumns) != 0 {
+			upper, err = nextChunkBound(ctx, upConn, info, lower, v.cfg.ChunkSize)
+			if err != nil {
+				return err
+			}
+		}
+		where, args := buildRangeCondition(info.pkColumns, lower, upper)
+		upCount, upChecksum, err := checksum(ctx, upConn, info, where, args)
+		if err != nil {
+			return err
+		}
+		downCount, downChecksum, err := checksum(ctx, downConn, info, where, args)
+		if err != nil {
+			return err
+		}
+		report.Chunks++
+		if upCount != downCount || upChecksum != downChecksum {
+			log.Warn("chunk mismatch",
+				zap.String("schema", table.schema), zap.String("table", table.table),
+				zap.Strings("lowerBound", lower), zap.Strings("upperBound", upper),
+				zap.Int64("upstreamCount", upCount), zap.Int64("downstreamCount", downCount))
+			report.Mismatches = append(report.Mismatches, ChunkMismatch{
+				Schema:             table.schema,
+				Table:              table.table,
+				LowerBound:         lower,
+				UpperBound:         upper,
+				UpstreamCount:      upCount,
+				DownstreamCount:    downCount,
+				UpstreamChecksum:   upChecksum,
+				DownstreamChecksum: downChecksum,
+			})
+		}
+		if upper == nil {
+			return nil
+		}
+		lower = upper
+	}
+}
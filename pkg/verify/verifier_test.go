@@ -0,0 +1,144 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRangeCondition(t *testing.T) {
+	where, args := buildRangeCondition([]string{"a"}, nil, nil)
+	require.Empty(t, where)
+	require.Empty(t, args)
+
+	where, args = buildRangeCondition([]string{"a", "b"}, []string{"1", "x"}, nil)
+	require.Equal(t, " WHERE (`a`, `b`) > (?, ?)", where)
+	require.Equal(t, []interface{}{"1", "x"}, args)
+
+	where, args = buildRangeCondition([]string{"a"}, []string{"1"}, []string{"5"})
+	require.Equal(t, " WHERE (`a`) > (?) AND (`a`) <= (?)", where)
+	require.Equal(t, []interface{}{"1", "5"}, args)
+}
+
+func TestVerify(t *testing.T) {
+	upstream, upMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer upstream.Close()
+	downstream, downMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer downstream.Close()
+
+	downMock.ExpectQuery(regexp.QuoteMeta("SELECT primary_ts, secondary_ts FROM `tidb_cdc`.`syncpoint_v1`")).
+		WithArgs("default", "default/test").
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}).AddRow("100", "200"))
+	upMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '100'")).WillReturnResult(sqlmock.NewResult(0, 0))
+	downMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '200'")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	upMock.ExpectQuery(regexp.QuoteMeta("SELECT TABLE_SCHEMA, TABLE_NAME FROM information_schema.tables")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME"}).
+			AddRow("test", "t").
+			AddRow("test", "t2").
+			AddRow("ignored", "t"))
+
+	// table test.t is split into 2 chunks, the second one is mismatched.
+	downMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM information_schema.tables")).
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	upMock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.columns")).
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("v"))
+	upMock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.key_column_usage")).
+		WithArgs("test", "t").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id"))
+
+	upMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` ORDER BY `id` LIMIT 1 OFFSET 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+	checksumQuery := regexp.QuoteMeta("SELECT COUNT(*), COALESCE(BIT_XOR(CRC32(CONCAT_WS(',', `id`, `v`, " +
+		"CONCAT(ISNULL(`id`), ISNULL(`v`))))), 0) FROM `test`.`t`")
+	upMock.ExpectQuery(checksumQuery + " WHERE \\(`id`\\) <= \\(\\?\\)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 100))
+	downMock.ExpectQuery(checksumQuery + " WHERE \\(`id`\\) <= \\(\\?\\)").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 100))
+
+	upMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` WHERE (`id`) > (?) ORDER BY `id` LIMIT 1 OFFSET 1")).
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	upMock.ExpectQuery(checksumQuery + " WHERE \\(`id`\\) > \\(\\?\\)$").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(1, 7))
+	downMock.ExpectQuery(checksumQuery + " WHERE \\(`id`\\) > \\(\\?\\)$").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(1, 8))
+
+	// table test.t2 is not found in downstream.
+	downMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM information_schema.tables")).
+		WithArgs("test", "t2").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	verifier, err := NewVerifier(upstream, downstream, &Config{
+		ClusterID:    "default",
+		ChangefeedID: "default/test",
+		ChunkSize:    2,
+		Filter:       &config.FilterConfig{Rules: []string{"test.*"}},
+	})
+	require.NoError(t, err)
+	report, err := verifier.Verify(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, SyncPoint{PrimaryTs: 100, SecondaryTs: 200}, report.SyncPoint)
+	require.Equal(t, 1, report.Tables)
+	require.Equal(t, 2, report.Chunks)
+	require.False(t, report.Consistent())
+	require.Equal(t, []ChunkMismatch{
+		{
+			Schema:             "test",
+			Table:              "t",
+			LowerBound:         []string{"2"},
+			UpstreamCount:      1,
+			DownstreamCount:    1,
+			UpstreamChecksum:   7,
+			DownstreamChecksum: 8,
+		},
+		{
+			Schema: "test",
+			Table:  "t2",
+			Reason: "table is not found in downstream",
+		},
+	}, report.Mismatches)
+	require.NoError(t, upMock.ExpectationsWereMet())
+	require.NoError(t, downMock.ExpectationsWereMet())
+}
+
+func TestSyncPointNotFound(t *testing.T) {
+	downstream, downMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer downstream.Close()
+
+	downMock.ExpectQuery(regexp.QuoteMeta("SELECT primary_ts, secondary_ts FROM `tidb_cdc`.`syncpoint_v1`")).
+		WithArgs("default", "default/test", "100").
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}))
+
+	verifier, err := NewVerifier(nil, downstream, &Config{
+		ClusterID:    "default",
+		ChangefeedID: "default/test",
+		PrimaryTs:    100,
+	})
+	require.NoError(t, err)
+	_, err = verifier.Verify(context.Background())
+	require.ErrorContains(t, err, "sync point of changefeed 'default/test' is not found")
+}
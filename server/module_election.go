@@ -135,6 +135,7 @@ func (e *elector) campaignCoordinator(ctx context.Context) error {
 			e.svr.info,
 			e.svr.pdClient,
 			e.svr.PDClock,
+			e.svr.upstreamManager,
 			changefeed.NewEtcdBackend(e.svr.EtcdClient),
 			e.svr.EtcdClient.GetGCServiceID(),
 			coordinatorVersion,
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/logservice/schemastore"
 	"github.com/pingcap/ticdc/logservice/txnutil"
+	"github.com/pingcap/ticdc/logservice/upstreamlog"
 	"github.com/pingcap/ticdc/maintainer"
 	"github.com/pingcap/ticdc/pkg/common"
 	appctx "github.com/pingcap/ticdc/pkg/common/context"
@@ -38,6 +39,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	tiserver "github.com/pingcap/ticdc/pkg/server"
+	"github.com/pingcap/ticdc/pkg/upstream"
 	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -79,6 +81,10 @@ type server struct {
 	RegionCache *tikv.RegionCache
 	PDClock     pdutil.Clock
 
+	// upstreamManager holds the upstreams other than the default one,
+	// which is served by the pdClient and KVStorage above.
+	upstreamManager *upstream.Manager
+
 	tcpServer tcpserver.TCPServer
 
 	// preServices is the preServices will be start before the server is running
@@ -144,7 +150,22 @@ func (c *server) initialize(ctx context.Context) error {
 	)
 	schemaStore := schemastore.New(ctx, conf.DataDir, subscriptionClient, c.pdClient, c.PDClock, c.KVStorage)
 	eventStore := eventstore.New(ctx, conf.DataDir, subscriptionClient, c.PDClock)
-	eventService := eventservice.New(eventStore, schemaStore)
+
+	// The log service of the default upstream is run as sub modules of the server,
+	// the log services of other upstreams are created on demand by the upstream log manager.
+	c.upstreamManager = upstream.NewManager(ctx, c.EtcdClient)
+	logServices := upstreamlog.NewManager(ctx, conf.DataDir, c.upstreamManager)
+	logServices.SetDefault(&upstreamlog.Service{
+		UpstreamID:         c.pdClient.GetClusterID(ctx),
+		PDClock:            c.PDClock,
+		PDAPIClient:        c.pdAPIClient,
+		RegionCache:        c.RegionCache,
+		SubscriptionClient: subscriptionClient,
+		SchemaStore:        schemaStore,
+		EventStore:         eventStore,
+		SnapshotReader:     logpuller.NewSnapshotReader(c.KVStorage),
+	})
+	eventService := eventservice.New(logServices)
 	c.subModules = []common.SubModule{
 		nodeManager,
 		subscriptionClient,
@@ -156,6 +177,7 @@ func (c *server) initialize(ctx context.Context) error {
 			c.pdAPIClient, c.PDClock, c.RegionCache),
 		eventStore,
 		eventService,
+		logServices,
 	}
 	// register it into global var
 	for _, subModule := range c.subModules {
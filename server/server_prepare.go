@@ -30,7 +30,6 @@ import (
 	"github.com/pingcap/ticdc/pkg/pdutil"
 	"github.com/pingcap/tidb/pkg/util/gctuner"
 	"github.com/pingcap/tiflow/cdc/kv"
-	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/fsutil"
 	"github.com/tikv/client-go/v2/tikv"
 	pd "github.com/tikv/pd/client"
@@ -143,6 +142,7 @@ func (c *server) prepare(ctx context.Context) error {
 	}
 	// TODO: Get id from disk after restart.
 	c.info = node.NewInfo(conf.AdvertiseAddr, deployPath)
+	c.info.Labels = conf.Labels
 	c.session = session
 	return nil
 }
@@ -199,15 +199,7 @@ func (c *server) setUpDir() {
 
 // registerNodeToEtcd the server by put the server's information in etcd
 func (c *server) registerNodeToEtcd(ctx context.Context) error {
-	cInfo := &model.CaptureInfo{
-		ID:             model.CaptureID(c.info.ID),
-		AdvertiseAddr:  c.info.AdvertiseAddr,
-		Version:        c.info.Version,
-		GitHash:        c.info.GitHash,
-		DeployPath:     c.info.DeployPath,
-		StartTimestamp: c.info.StartTimestamp,
-	}
-	err := c.EtcdClient.PutCaptureInfo(ctx, cInfo, c.session.Lease())
+	err := c.EtcdClient.PutCaptureInfo(ctx, c.info, c.session.Lease())
 	if err != nil {
 		return errors.WrapError(errors.ErrCaptureRegister, err)
 	}
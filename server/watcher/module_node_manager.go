@@ -29,7 +29,14 @@ import (
 	"go.uber.org/zap"
 )
 
-const NodeManagerName = "node-manager"
+const (
+	NodeManagerName = "node-manager"
+
+	getNodeInfoTimeout     = 5 * time.Second
+	nodeInfoChanSize       = 128
+	minLoadNodeInfoBackoff = time.Second
+	maxLoadNodeInfoBackoff = 30 * time.Second
+)
 
 type (
 	NodeChangeHandler  func(map[node.ID]*node.Info)
@@ -44,6 +51,15 @@ type NodeManager struct {
 	coordinatorID atomic.Value
 	nodes         atomic.Pointer[map[node.ID]*node.Info]
 
+	// loadedNodes caches the full node info read from etcd in background,
+	// the Tick never reads etcd for the node info directly.
+	loadedNodes  sync.Map // node.ID -> *node.Info
+	loadingNodes sync.Map // node.ID -> struct{}
+	// loadBackoffs records the backoff before loading the node info again,
+	// for the nodes whose info failed to load.
+	loadBackoffs sync.Map // node.ID -> time.Duration
+	nodeInfoCh   chan *model.CaptureInfo
+
 	nodeChangeHandlers struct {
 		sync.RWMutex
 		m map[node.ID]NodeChangeHandler
@@ -62,6 +78,7 @@ func NewNodeManager(
 	m := &NodeManager{
 		session:    session,
 		etcdClient: etcdClient,
+		nodeInfoCh: make(chan *model.CaptureInfo, nodeInfoChanSize),
 		nodeChangeHandlers: struct {
 			sync.RWMutex
 			m map[node.ID]NodeChangeHandler
@@ -108,14 +125,31 @@ func (c *NodeManager) Tick(
 	for _, info := range oldMap {
 		if _, exist := state.Captures[model.CaptureID(info.ID)]; !exist {
 			changed = true
+			c.loadedNodes.Delete(info.ID)
+			c.loadBackoffs.Delete(info.ID)
 		}
 	}
 
 	for _, capture := range state.Captures {
-		if _, exist := oldMap[node.ID(capture.ID)]; !exist {
-			changed = true
+		id := node.ID(capture.ID)
+		oldInfo, exist := oldMap[id]
+		if loaded, ok := c.loadedNodes.Load(id); ok {
+			// the node info is immutable during the lifetime of a capture,
+			// it only changes once when the full node info is loaded.
+			info := loaded.(*node.Info)
+			if oldInfo != info {
+				changed = true
+			}
+			allNodes[id] = info
+			continue
+		}
+		c.loadNodeInfo(capture)
+		if exist {
+			allNodes[id] = oldInfo
+			continue
 		}
-		allNodes[node.ID(capture.ID)] = node.CaptureInfoToNodeInfo(capture)
+		changed = true
+		allNodes[id] = node.CaptureInfoToNodeInfo(capture)
 	}
 	c.nodes.Store(&allNodes)
 
@@ -141,6 +175,65 @@ func (c *NodeManager) Tick(
 	return state, nil
 }
 
+// loadNodeInfo asks the background goroutine to load the full node info of a capture,
+// it never blocks the Tick. If the request is dropped, it is sent again in the next Tick.
+func (c *NodeManager) loadNodeInfo(capture *model.CaptureInfo) {
+	if _, loading := c.loadingNodes.LoadOrStore(node.ID(capture.ID), struct{}{}); loading {
+		return
+	}
+	select {
+	case c.nodeInfoCh <- capture:
+	default:
+		c.loadingNodes.Delete(node.ID(capture.ID))
+	}
+}
+
+// runNodeInfoLoader loads the node info of the new captures, the labels of the node are
+// not recognized by model.CaptureInfo, so read the full node info from etcd.
+// If the node info fails to load, the node is not marked as loaded, and it's loaded
+// again by the Tick after a backoff.
+func (c *NodeManager) runNodeInfoLoader(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case capture := <-c.nodeInfoCh:
+			id := node.ID(capture.ID)
+			info, err := c.getNodeInfo(ctx, capture)
+			if err != nil {
+				backoff := c.nextLoadBackoff(id)
+				log.Warn("get node info failed, retry later",
+					zap.String("captureID", capture.ID),
+					zap.Duration("backoff", backoff), zap.Error(err))
+				time.AfterFunc(backoff, func() {
+					c.loadingNodes.Delete(id)
+				})
+				continue
+			}
+			c.loadBackoffs.Delete(id)
+			c.loadedNodes.Store(id, info)
+			c.loadingNodes.Delete(id)
+		}
+	}
+}
+
+func (c *NodeManager) getNodeInfo(ctx context.Context, capture *model.CaptureInfo) (*node.Info, error) {
+	ctx, cancel := context.WithTimeout(ctx, getNodeInfoTimeout)
+	defer cancel()
+	return c.etcdClient.GetNodeInfo(ctx, capture.ID)
+}
+
+// nextLoadBackoff returns the backoff before loading the node info again,
+// it's doubled on every failure of the node.
+func (c *NodeManager) nextLoadBackoff(id node.ID) time.Duration {
+	backoff := minLoadNodeInfoBackoff
+	if last, ok := c.loadBackoffs.Load(id); ok {
+		backoff = min(last.(time.Duration)*2, maxLoadNodeInfoBackoff)
+	}
+	c.loadBackoffs.Store(id, backoff)
+	return backoff
+}
+
 // GetAliveNodes get all alive captures, the caller mustn't modify the returned map
 func (c *NodeManager) GetAliveNodes() map[node.ID]*node.Info {
 	return *c.nodes.Load()
@@ -151,6 +244,10 @@ func (c *NodeManager) GetNodeInfo(id node.ID) *node.Info {
 }
 
 func (c *NodeManager) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go c.runNodeInfoLoader(ctx)
+
 	cfg := config.GetGlobalServerConfig()
 	watcher := NewEtcdWatcher(c.etcdClient,
 		c.session,
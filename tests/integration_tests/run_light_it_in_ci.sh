@@ -29,7 +29,7 @@ group_num=${group#G}
 
 mysql_groups=(
 	# G00
-	'charset_gbk changefeed_finish sql_mode changefeed_reconstruct'
+	'charset_gbk changefeed_finish changefeed_target_ts sql_mode changefeed_reconstruct'
 	# G01
 	'common_1 foreign_key generate_column many_pk_or_uk drop_many_tables'
 	# G02
@@ -22,6 +22,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type intEvent int
@@ -227,3 +228,58 @@ func BenchmarkDSGoroutine1000000x20x50(b *testing.B) {
 		}
 	}
 }
+
+// prepareSkewedDynamicStream hashes all the paths to the first stream, the paths
+// are balanced between the streams only if the scheduler is enabled.
+func prepareSkewedDynamicStream(pathCount int, eventCount int, times int, schedulerInterval time.Duration) (DynamicStream[int, int, intEvent, D, *intEventHandler], *atomic.Int64, *sync.WaitGroup) {
+	wg := &sync.WaitGroup{}
+	wg.Add(eventCount * pathCount)
+	inc := &atomic.Int64{}
+
+	handler := &intEventHandler{
+		inc:   inc,
+		times: times,
+		wg:    wg,
+	}
+
+	option := NewOption()
+	option.StreamCount = 4
+	option.SchedulerInterval = schedulerInterval
+	ds := NewParallelDynamicStream(func(p int) uint64 { return 0 }, handler, option)
+	ds.Start()
+
+	for i := 0; i < pathCount; i++ {
+		ds.AddPath(i, D{})
+	}
+
+	return ds, inc, wg
+}
+
+func benchmarkSkewedDynamicStream(b *testing.B, schedulerInterval time.Duration) {
+	const (
+		pathCount  = 64
+		eventCount = 2000
+		times      = 500
+	)
+	for k := 0; k < b.N; k++ {
+		ds, inc, wg := prepareSkewedDynamicStream(pathCount, eventCount, times, schedulerInterval)
+
+		b.ResetTimer()
+		inc.Store(0)
+		runDynamicStream(ds, pathCount, eventCount)
+		wg.Wait()
+
+		if inc.Load() != int64(pathCount*eventCount*times) {
+			panic(fmt.Sprintf("total: %d, expected: %d", inc.Load(), pathCount*eventCount*times))
+		}
+		ds.Close()
+	}
+}
+
+func BenchmarkDSSkewedNoBalance64x2000x500(b *testing.B) {
+	benchmarkSkewedDynamicStream(b, 0)
+}
+
+func BenchmarkDSSkewedBalance64x2000x500(b *testing.B) {
+	benchmarkSkewedDynamicStream(b, 10*time.Millisecond)
+}
@@ -34,6 +34,7 @@ type eventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	// Signal queue is used to decide which path's events should be popped.
 	signalQueue        *deque.Deque[eventSignal[A, P, T, D, H]]
 	totalPendingLength *atomic.Int64 // The total signal count in the queue.
+	totalPendingSize   *atomic.Int64 // The total size(bytes) of the pending events of the paths in the queue.
 }
 
 func newEventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](option Option, handler H) eventQueue[A, P, T, D, H] {
@@ -43,6 +44,7 @@ func newEventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](optio
 		eventBlockAlloc:    deque.NewBlockAllocator[eventWrap[A, P, T, D, H]](32, 1024),
 		signalQueue:        deque.NewDeque(1024, deque.NewBlockAllocator[eventSignal[A, P, T, D, H]](1024, 32)),
 		totalPendingLength: &atomic.Int64{},
+		totalPendingSize:   &atomic.Int64{},
 	}
 
 	return eq
@@ -50,6 +52,52 @@ func newEventQueue[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](optio
 
 func (q *eventQueue[A, P, T, D, H]) initPath(path *pathInfo[A, P, T, D, H]) {
 	path.pendingQueue.SetBlockAllocator(q.eventBlockAlloc)
+	path.owner.Store(q)
+}
+
+// detachPath detaches the path which is migrated to another stream.
+// The pending events are moved to a new pending queue without the block allocator,
+// since the allocator can only be used by this queue. The signals of the path
+// left in the signal queue are ignored after the path is detached.
+func (q *eventQueue[A, P, T, D, H]) detachPath(path *pathInfo[A, P, T, D, H]) {
+	pendingQueue := deque.NewDeque[eventWrap[A, P, T, D, H]](BlockLenInPendingQueue)
+	for {
+		e, ok := path.pendingQueue.PopFront()
+		if !ok {
+			break
+		}
+		pendingQueue.PushBack(e)
+	}
+	path.pendingQueue = pendingQueue
+	path.owner.Store(nil)
+	q.totalPendingSize.Add(-path.pendingSize.Load())
+}
+
+// attachPath attaches the path which is migrated from another stream,
+// its pending events are handled in the same order.
+func (q *eventQueue[A, P, T, D, H]) attachPath(path *pathInfo[A, P, T, D, H]) {
+	path.pendingQueue.SetBlockAllocator(q.eventBlockAlloc)
+	path.owner.Store(q)
+	q.totalPendingSize.Add(path.pendingSize.Load())
+	// The blocking path is added to the signal queue when it is waked.
+	if !path.blocking {
+		q.wakePath(path)
+	}
+}
+
+// removePath releases the pending events of the removed path, and removes it from the area.
+// It is called by the handle goroutine, so the pending size is not updated concurrently.
+func (q *eventQueue[A, P, T, D, H]) removePath(path *pathInfo[A, P, T, D, H]) {
+	if path.areaMemStat != nil {
+		path.areaMemStat.memControl.removePathFromArea(path)
+	}
+	for {
+		if _, ok := path.pendingQueue.PopFront(); !ok {
+			break
+		}
+	}
+	q.totalPendingSize.Add(-path.pendingSize.Load())
+	path.updatePendingSize(-path.pendingSize.Load())
 }
 
 func (q *eventQueue[A, P, T, D, H]) appendEvent(event eventWrap[A, P, T, D, H]) {
@@ -65,9 +113,11 @@ func (q *eventQueue[A, P, T, D, H]) appendEvent(event eventWrap[A, P, T, D, H])
 		q.totalPendingLength.Add(1)
 	}
 
+	pendingSize := path.pendingSize.Load()
 	if path.appendEvent(event, q.handler) {
 		addSignal()
 	}
+	q.totalPendingSize.Add(path.pendingSize.Load() - pendingSize)
 }
 
 func (q *eventQueue[A, P, T, D, H]) blockPath(path *pathInfo[A, P, T, D, H]) {
@@ -87,7 +137,9 @@ func (q *eventQueue[A, P, T, D, H]) popEvents(buf []T) ([]T, *pathInfo[A, P, T,
 	// Append the event to the buffer
 	appendToBuf := func(event *eventWrap[A, P, T, D, H], path *pathInfo[A, P, T, D, H]) {
 		buf = append(buf, event.event)
-		path.popEvent()
+		if e, ok := path.popEvent(); ok {
+			q.totalPendingSize.Add(-int64(e.eventSize))
+		}
 	}
 
 	for {
@@ -98,19 +150,19 @@ func (q *eventQueue[A, P, T, D, H]) popEvents(buf []T) ([]T, *pathInfo[A, P, T,
 		}
 
 		path := signal.pathInfo
-		pendingQueue := path.pendingQueue
 
 		if signal.eventCount == 0 {
 			log.Panic("signal event count is zero")
 		}
-		if path.blocking || path.removed.Load() {
-			// The path is blocking or removed, we should ignore the signal completely.
+		if path.owner.Load() != q || path.blocking || path.removed.Load() {
+			// The path is migrated, blocking or removed, we should ignore the signal completely.
 			// Since when it is waked, a signal event will be added to the queue.
 			q.totalPendingLength.Add(-int64(signal.eventCount))
 			q.signalQueue.PopFront()
 			continue
 		}
 
+		pendingQueue := path.pendingQueue
 		batchSize := min(signal.eventCount, q.option.BatchCount)
 
 		firstEvent, ok := pendingQueue.FrontRef()
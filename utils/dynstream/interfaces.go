@@ -191,13 +191,17 @@ const (
 	DefaultReportInterval    = 10 * time.Second
 	DefaultMaxPendingSize    = uint64(1024 * 1024 * 1024) // 1 GB
 	DefaultFeedbackInterval  = 1000 * time.Millisecond
+
+	memoryControlCheckInterval = 100 * time.Millisecond
 )
 
 type Option struct {
 	InputChanSize int // The buffer size of the input channel. By default 0, means 1024.
 
-	SchedulerInterval time.Duration // The interval of the scheduler. The scheduler is used to balance the paths between streams.
-	ReportInterval    time.Duration // The interval of reporting the status of stream, the status is used by the scheduler.
+	SchedulerInterval time.Duration // The interval of the scheduler. The scheduler is used to balance the paths between streams. By default 0, means no balance. DefaultSchedulerInterval is recommended for the streams with hot paths.
+	// Deprecated: the streams don't report their status anymore, the scheduler reads the busy time
+	// of the streams and the paths directly every SchedulerInterval. It's ignored.
+	ReportInterval time.Duration
 
 	StreamCount int // The count of streams. I.e. the count of goroutines to handle events. By default 0, means runtime.NumCPU().
 	BatchCount  int // The batch count of handling events. <= 1 means no batch. By default 1.
@@ -212,11 +216,10 @@ type Option struct {
 
 func NewOption() Option {
 	return Option{
-		SchedulerInterval: DefaultSchedulerInterval,
-		ReportInterval:    DefaultReportInterval,
-		StreamCount:       0,
-		BatchCount:        1,
-		UseBuffer:         false,
+		ReportInterval: DefaultReportInterval,
+		StreamCount:    0,
+		BatchCount:     1,
+		UseBuffer:      false,
 	}
 }
 
@@ -320,6 +323,18 @@ type Metrics[A Area] struct {
 	PendingQueueLen int
 	AddPath         int
 	RemovePath      int
+	// MovePath is the number of paths moved between streams by the scheduler.
+	MovePath int
+
+	// Streams is the metrics of each stream, the streams are labeled by the id.
+	Streams []StreamMetric
 
 	MemoryControl MemoryMetric[A]
 }
+
+type StreamMetric struct {
+	ID              int
+	PendingQueueLen int
+	// The total size(bytes) of the pending events in the stream.
+	PendingSize int64
+}
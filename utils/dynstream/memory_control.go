@@ -43,6 +43,8 @@ type areaMemStat[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct
 	settings     atomic.Pointer[AreaSettings]
 	feedbackChan chan<- Feedback[A, P, D]
 
+	// The paths of the area, it is protected by the mutex of memControl.
+	paths                map[P]*pathInfo[A, P, T, D, H]
 	pathCount            atomic.Int64
 	totalPendingSize     atomic.Int64
 	paused               atomic.Bool
@@ -60,6 +62,7 @@ func newAreaMemStat[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](
 		area:                 area,
 		memControl:           memoryControl,
 		feedbackChan:         feedbackChan,
+		paths:                make(map[P]*pathInfo[A, P, T, D, H]),
 		lastSendFeedbackTime: atomic.Value{},
 	}
 	res.lastSendFeedbackTime.Store(time.Unix(0, 0))
@@ -85,6 +88,8 @@ func (as *areaMemStat[A, P, T, D, H]) appendEvent(
 		if ok && back.eventType.Property == PeriodicSignal {
 			// If the last event is a periodic signal, we only need to keep the latest one.
 			// And we don't need to add a new signal.
+			// Keep the size of the replaced event, which is already counted in the pending size.
+			event.eventSize = back.eventSize
 			*back = event
 			return false
 		}
@@ -147,6 +152,7 @@ func (as *areaMemStat[A, P, T, D, H]) updatePathPauseState(path *pathInfo[A, P,
 			FeedbackType: feedbackType,
 		}
 		path.paused.Store(pause)
+		as.memControl.markPathPaused(path, pause)
 
 		log.Info("send path feedback", zap.Any("area", as.area),
 			zap.Any("path", path.path), zap.Stringer("feedbackType", feedbackType),
@@ -210,6 +216,7 @@ func (as *areaMemStat[A, P, T, D, H]) updateAreaPauseState(path *pathInfo[A, P,
 			FeedbackType: feedbackType,
 		}
 		as.paused.Store(pause)
+		as.memControl.markAreaPaused(as, pause)
 
 		log.Info("send area feedback",
 			zap.Any("area", as.area),
@@ -251,17 +258,53 @@ func (as *areaMemStat[A, P, T, D, H]) decPendingSize(path *pathInfo[A, P, T, D,
 }
 
 // A memControl is used to control the memory usage of the dynamic stream.
-// It is a global level struct, not stream level.
+// It is shared by all the streams of a parallel dynamic stream, so the pending size
+// of an area is accounted across the streams its paths are dispatched to.
 type memControl[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	// Since this struct is global level, different streams may access it concurrently.
 	mutex sync.Mutex
 
 	areaStatMap map[A]*areaMemStat[A, P, T, D, H]
+
+	// pausedMutex protects the paused areas and paths, which are the only ones
+	// need to be rechecked periodically. It is separated from the mutex to avoid
+	// contending with the pushes.
+	pausedMutex sync.Mutex
+	pausedAreas map[*areaMemStat[A, P, T, D, H]]struct{}
+	pausedPaths map[*pathInfo[A, P, T, D, H]]struct{}
 }
 
 func newMemControl[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]]() *memControl[A, P, T, D, H] {
 	return &memControl[A, P, T, D, H]{
 		areaStatMap: make(map[A]*areaMemStat[A, P, T, D, H]),
+		pausedAreas: make(map[*areaMemStat[A, P, T, D, H]]struct{}),
+		pausedPaths: make(map[*pathInfo[A, P, T, D, H]]struct{}),
+	}
+}
+
+func (m *memControl[A, P, T, D, H]) markAreaPaused(area *areaMemStat[A, P, T, D, H], paused bool) {
+	if m == nil {
+		return
+	}
+	m.pausedMutex.Lock()
+	defer m.pausedMutex.Unlock()
+	if paused {
+		m.pausedAreas[area] = struct{}{}
+	} else {
+		delete(m.pausedAreas, area)
+	}
+}
+
+func (m *memControl[A, P, T, D, H]) markPathPaused(path *pathInfo[A, P, T, D, H], paused bool) {
+	if m == nil {
+		return
+	}
+	m.pausedMutex.Lock()
+	defer m.pausedMutex.Unlock()
+	if paused {
+		m.pausedPaths[path] = struct{}{}
+	} else {
+		delete(m.pausedPaths, path)
 	}
 }
 
@@ -286,12 +329,14 @@ func (m *memControl[A, P, T, D, H]) addPathToArea(path *pathInfo[A, P, T, D, H],
 	}
 
 	path.areaMemStat = area
+	area.paths[path.path] = path
 	area.pathCount.Add(1)
 	// Update the settings
+	settings.fix()
 	area.settings.Store(&settings)
 }
 
-// This method is called after the path is removed.
+// This method is called by the handle goroutine of the path's stream after the path is removed.
 func (m *memControl[A, P, T, D, H]) removePathFromArea(path *pathInfo[A, P, T, D, H]) {
 	area := path.areaMemStat
 	area.decPendingSize(path, int64(path.pendingSize.Load()))
@@ -299,13 +344,59 @@ func (m *memControl[A, P, T, D, H]) removePathFromArea(path *pathInfo[A, P, T, D
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	if area.paths[path.path] == path {
+		delete(area.paths, path.path)
+	}
 	area.pathCount.Add(-1)
-	if area.pathCount.Load() == 0 {
+	areaRemoved := area.pathCount.Load() == 0
+	if areaRemoved {
 		delete(m.areaStatMap, area.area)
 	}
+
+	m.pausedMutex.Lock()
+	defer m.pausedMutex.Unlock()
+	delete(m.pausedPaths, path)
+	if areaRemoved {
+		delete(m.pausedAreas, area)
+	}
+}
+
+// checkPauseState rechecks the paused areas and paths.
+// The resume feedback is skipped if it is too close to the last feedback, and there may be
+// no more events to trigger the check again, e.g. the upstream stops sending events after
+// the area is paused. So it is called periodically to make sure the resume feedback is sent.
+// Only the paused areas and paths are rechecked, the others are checked when events are pushed.
+func (m *memControl[A, P, T, D, H]) checkPauseState() {
+	m.pausedMutex.Lock()
+	pausedAreas := make([]*areaMemStat[A, P, T, D, H], 0, len(m.pausedAreas))
+	for area := range m.pausedAreas {
+		pausedAreas = append(pausedAreas, area)
+	}
+	pausedPaths := make([]*pathInfo[A, P, T, D, H], 0, len(m.pausedPaths))
+	for path := range m.pausedPaths {
+		pausedPaths = append(pausedPaths, path)
+	}
+	m.pausedMutex.Unlock()
+
+	// Send the feedbacks without holding the mutex, since the feedback channel may be full.
+	for _, area := range pausedAreas {
+		// The area feedback carries a path of the area, pick any of them.
+		m.mutex.Lock()
+		var path *pathInfo[A, P, T, D, H]
+		for _, p := range area.paths {
+			path = p
+			break
+		}
+		m.mutex.Unlock()
+		if path != nil {
+			area.updateAreaPauseState(path)
+		}
+	}
+	for _, path := range pausedPaths {
+		path.areaMemStat.updatePathPauseState(path)
+	}
 }
 
-// FIXME/TODO: We use global metric here, which is not good for multiple streams.
 func (m *memControl[A, P, T, D, H]) getMetrics() MemoryMetric[A] {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
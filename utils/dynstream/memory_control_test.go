@@ -15,6 +15,8 @@ package dynstream
 
 import (
 	"fmt"
+	"hash/fnv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -406,6 +408,39 @@ func TestUpdatePathPauseState(t *testing.T) {
 	}
 }
 
+func TestCheckPauseStateOnlyPausedPaths(t *testing.T) {
+	mc, path := setupTestComponents()
+	settings := AreaSettings{
+		maxPendingSize:   100,
+		feedbackInterval: time.Millisecond * 100,
+	}
+	feedbackChan := make(chan Feedback[int, string, any], 10)
+	mc.addPathToArea(path, settings, feedbackChan)
+	areaMemStat := path.areaMemStat
+
+	// the path is paused, it is tracked to be rechecked
+	path.pendingSize.Store(int64(60))
+	areaMemStat.updatePathPauseState(path)
+	require.True(t, path.paused.Load())
+	require.Equal(t, PausePath, (<-feedbackChan).FeedbackType)
+	require.Len(t, mc.pausedPaths, 1)
+
+	// the resume feedback is sent by the periodical check without new events
+	path.pendingSize.Store(int64(0))
+	time.Sleep(settings.feedbackInterval)
+	mc.checkPauseState()
+	require.False(t, path.paused.Load())
+	require.Equal(t, ResumePath, (<-feedbackChan).FeedbackType)
+	require.Empty(t, mc.pausedPaths)
+
+	// the removed path is not tracked anymore
+	path.pendingSize.Store(int64(60))
+	areaMemStat.updatePathPauseState(path)
+	require.Equal(t, PausePath, (<-feedbackChan).FeedbackType)
+	mc.removePathFromArea(path)
+	require.Empty(t, mc.pausedPaths)
+}
+
 func TestShouldPausePathV2(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -678,3 +713,161 @@ func TestCalculateThresholds(t *testing.T) {
 		})
 	}
 }
+
+type memControlTestEvent struct {
+	path string
+	size int
+}
+
+// memControlTestHandler blocks the path after handling an event if block is true.
+type memControlTestHandler struct {
+	block   atomic.Bool
+	handled atomic.Int64
+}
+
+func (h *memControlTestHandler) Path(event *memControlTestEvent) string { return event.path }
+func (h *memControlTestHandler) Handle(dest any, events ...*memControlTestEvent) (await bool) {
+	h.handled.Add(int64(len(events)))
+	return h.block.Load()
+}
+func (h *memControlTestHandler) GetSize(event *memControlTestEvent) int            { return event.size }
+func (h *memControlTestHandler) GetArea(path string, dest any) int                 { return 0 }
+func (h *memControlTestHandler) GetTimestamp(event *memControlTestEvent) Timestamp { return 0 }
+func (h *memControlTestHandler) GetType(event *memControlTestEvent) EventType {
+	return DefaultEventType
+}
+func (h *memControlTestHandler) IsPaused(event *memControlTestEvent) bool { return false }
+func (h *memControlTestHandler) OnDrop(event *memControlTestEvent)        {}
+
+func newMemControlTestStream(handler *memControlTestHandler) *parallelDynamicStream[int, string, *memControlTestEvent, any, *memControlTestHandler] {
+	option := NewOption()
+	option.StreamCount = 4
+	option.EnableMemoryControl = true
+	hasher := func(path string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(path))
+		return h.Sum64()
+	}
+	return newParallelDynamicStream[int, string, *memControlTestEvent, any, *memControlTestHandler](hasher, handler, option)
+}
+
+func waitFeedback(t *testing.T, ch <-chan Feedback[int, string, any], feedbackType FeedbackType) {
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case fb := <-ch:
+			if fb.FeedbackType == feedbackType {
+				return
+			}
+		case <-timer.C:
+			require.FailNow(t, "wait feedback timeout", feedbackType.String())
+		}
+	}
+}
+
+func TestParallelDynamicStreamPauseResumeArea(t *testing.T) {
+	handler := &memControlTestHandler{}
+	handler.block.Store(true)
+	ds := newMemControlTestStream(handler)
+	ds.Start()
+	defer ds.Close()
+
+	const (
+		pathCount    = 8
+		eventPerPath = 3
+		eventSize    = 1000
+	)
+	// The pending events of each path are 2 * size, the area is paused when
+	// the pending size of all paths reaches 80% of the max pending size.
+	size := eventSize + ds.eventExtraSize
+	maxPendingSize := uint64(pathCount * 2 * size)
+	// Use a long feedback interval, so the resume feedback can only be sent by the periodic check.
+	settings := AreaSettings{maxPendingSize: maxPendingSize, feedbackInterval: time.Second, algorithm: MemoryControlAlgorithmV1}
+	paths := make([]string, 0, pathCount)
+	for i := 0; i < pathCount; i++ {
+		path := fmt.Sprintf("path-%d", i)
+		paths = append(paths, path)
+		require.NoError(t, ds.AddPath(path, nil, settings))
+	}
+	for i := 0; i < eventPerPath; i++ {
+		for _, path := range paths {
+			ds.Push(path, &memControlTestEvent{path: path, size: eventSize})
+		}
+	}
+
+	// The first event of each path is handled and the path is blocked,
+	// so the pending events of all streams are accounted in the same area.
+	waitFeedback(t, ds.Feedback(), PauseArea)
+	require.Eventually(t, func() bool {
+		return handler.handled.Load() == pathCount
+	}, 5*time.Second, 10*time.Millisecond)
+	metrics := ds.GetMetrics()
+	require.Len(t, metrics.Streams, 4)
+	require.Len(t, metrics.MemoryControl.AreaMemoryMetrics, 1)
+	totalPendingSize := int64(0)
+	for _, stream := range metrics.Streams {
+		totalPendingSize += stream.PendingSize
+	}
+	require.Equal(t, int64(pathCount*(eventPerPath-1)*size), totalPendingSize)
+	require.Equal(t, totalPendingSize, metrics.MemoryControl.AreaMemoryMetrics[0].MemoryUsage())
+
+	// Unblock the paths, all the pending events are handled and the area is resumed.
+	handler.block.Store(false)
+	for _, path := range paths {
+		ds.Wake(path)
+	}
+	waitFeedback(t, ds.Feedback(), ResumeArea)
+	require.Equal(t, int64(pathCount*eventPerPath), handler.handled.Load())
+	metrics = ds.GetMetrics()
+	for _, stream := range metrics.Streams {
+		require.Equal(t, int64(0), stream.PendingSize)
+	}
+	require.Equal(t, int64(0), metrics.MemoryControl.AreaMemoryMetrics[0].MemoryUsage())
+}
+
+func TestParallelDynamicStreamRemovePathReleaseMemory(t *testing.T) {
+	handler := &memControlTestHandler{}
+	handler.block.Store(true)
+	ds := newMemControlTestStream(handler)
+	ds.Start()
+	defer ds.Close()
+
+	settings := AreaSettings{maxPendingSize: 1024 * 1024, feedbackInterval: time.Second, algorithm: MemoryControlAlgorithmV1}
+	paths := []string{"path-0", "path-1", "path-2", "path-3"}
+	for _, path := range paths {
+		require.NoError(t, ds.AddPath(path, nil, settings))
+		for i := 0; i < 10; i++ {
+			ds.Push(path, &memControlTestEvent{path: path, size: 100})
+		}
+	}
+	require.Eventually(t, func() bool {
+		metrics := ds.GetMetrics()
+		return len(metrics.MemoryControl.AreaMemoryMetrics) == 1 &&
+			metrics.MemoryControl.AreaMemoryMetrics[0].MemoryUsage() == int64(len(paths)*9*(100+ds.eventExtraSize))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Remove the first path, its pending events are released.
+	require.NoError(t, ds.RemovePath(paths[0]))
+	require.Eventually(t, func() bool {
+		metrics := ds.GetMetrics()
+		return metrics.MemoryControl.AreaMemoryMetrics[0].MemoryUsage() == int64((len(paths)-1)*9*(100+ds.eventExtraSize))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Remove all the paths, the area is removed and the streams have no pending events.
+	for _, path := range paths[1:] {
+		require.NoError(t, ds.RemovePath(path))
+	}
+	require.Eventually(t, func() bool {
+		metrics := ds.GetMetrics()
+		if len(metrics.MemoryControl.AreaMemoryMetrics) != 0 {
+			return false
+		}
+		for _, stream := range metrics.Streams {
+			if stream.PendingSize != 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -32,16 +32,23 @@ type parallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D
 	pathHasher PathHasher[P]
 	streams    []*stream[A, P, T, D, H]
 	pathMap    map[P]*pathInfo[A, P, T, D, H]
+	option     Option
 
 	eventExtraSize int
-	memControl     *memControl[A, P, T, D, H] // TODO: implement memory control
+	// memControl is shared by all the streams, it is nil if the memory control is disabled.
+	memControl *memControl[A, P, T, D, H]
 
 	mutex sync.RWMutex
 
 	feedbackChan chan Feedback[A, P, D]
 
+	isClosed atomic.Bool
+	closeCh  chan struct{}
+	wg       sync.WaitGroup
+
 	_statAddPathCount    atomic.Int64
 	_statRemovePathCount atomic.Int64
+	_statMovePathCount   atomic.Int64
 }
 
 func newParallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](hasher PathHasher[P], handler H, option Option) *parallelDynamicStream[A, P, T, D, H] {
@@ -60,7 +67,9 @@ func newParallelDynamicStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T
 		handler:        handler,
 		pathHasher:     hasher,
 		pathMap:        make(map[P]*pathInfo[A, P, T, D, H]),
+		option:         option,
 		eventExtraSize: eventExtraSize,
+		closeCh:        make(chan struct{}),
 	}
 	if option.EnableMemoryControl {
 		log.Info("Dynamic stream enable memory control")
@@ -77,14 +86,42 @@ func (s *parallelDynamicStream[A, P, T, D, H]) Start() {
 	for _, ds := range s.streams {
 		ds.start()
 	}
+	if s.memControl != nil {
+		s.wg.Add(1)
+		go s.memControlLoop()
+	}
+	if len(s.streams) > 1 && s.option.SchedulerInterval > 0 {
+		s.wg.Add(1)
+		go s.scheduleLoop()
+	}
 }
 
 func (s *parallelDynamicStream[A, P, T, D, H]) Close() {
+	if s.isClosed.CompareAndSwap(false, true) {
+		close(s.closeCh)
+	}
+	s.wg.Wait()
 	for _, ds := range s.streams {
 		ds.close()
 	}
 }
 
+// memControlLoop periodically rechecks the paused areas and paths,
+// to make sure the resume feedbacks are not lost.
+func (s *parallelDynamicStream[A, P, T, D, H]) memControlLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(memoryControlCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			s.memControl.checkPauseState()
+		}
+	}
+}
+
 func (s *parallelDynamicStream[A, P, T, D, H]) Push(path P, e T) {
 	var pi *pathInfo[A, P, T, D, H]
 	var ok bool
@@ -159,10 +196,9 @@ func (s *parallelDynamicStream[A, P, T, D, H]) RemovePath(path P) error {
 
 	pi.removed.Store(true)
 
-	if s.memControl != nil {
-		s.memControl.removePathFromArea(pi)
-	}
-	pi.stream.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi}
+	// The pending events of the path are released by the handle goroutine of the stream,
+	// to avoid updating the pending size concurrently.
+	pi.stream.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi, removePath: true}
 	delete(s.pathMap, path)
 
 	s._statRemovePathCount.Add(1)
@@ -180,9 +216,15 @@ func (s *parallelDynamicStream[A, P, T, D, H]) GetMetrics() Metrics[A] {
 	for _, ds := range s.streams {
 		size := ds.getPendingSize()
 		metrics.PendingQueueLen += size
+		metrics.Streams = append(metrics.Streams, StreamMetric{
+			ID:              ds.id,
+			PendingQueueLen: size,
+			PendingSize:     ds.getPendingBytes(),
+		})
 	}
 	metrics.AddPath = int(s._statAddPathCount.Load())
 	metrics.RemovePath = int(s._statRemovePathCount.Load())
+	metrics.MovePath = int(s._statMovePathCount.Load())
 
 	if s.memControl != nil {
 		metrics.MemoryControl = s.memControl.getMetrics()
@@ -0,0 +1,173 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynstream
+
+import (
+	"time"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// The max number of paths moved in one arrangement, to avoid moving too many paths at once.
+	maxMovePathsPerArrange = 16
+	// The streams which are busy less than this ratio of the scheduler interval are not balanced.
+	minBusyRatioToArrange = 0.1
+	// The streams are balanced only if the busy time gap between the busiest stream and
+	// the idlest stream is larger than this ratio of the busy time of the busiest stream.
+	minImbalanceRatioToArrange = 0.2
+)
+
+// pathLoad is the load of a path in the last scheduler interval.
+type pathLoad[P Path] struct {
+	path     P
+	stream   int
+	busyTime int64
+}
+
+// pathMove is a decision to move a path from a stream to another.
+type pathMove[P Path] struct {
+	path P
+	from int
+	to   int
+}
+
+// planPathMoves decides which paths should be moved, to balance the busy time of the streams.
+// The busy time of the streams and the paths are in the last scheduler interval. It moves the
+// busiest path of the busiest stream, which doesn't make the idlest stream busier than the
+// busiest stream, to the idlest stream repeatedly.
+func planPathMoves[P Path](streamBusyTime []int64, paths []pathLoad[P], minBusyTime int64) []pathMove[P] {
+	var moves []pathMove[P]
+	for len(moves) < maxMovePathsPerArrange {
+		busiest, idlest := 0, 0
+		for i, busyTime := range streamBusyTime {
+			if busyTime > streamBusyTime[busiest] {
+				busiest = i
+			}
+			if busyTime < streamBusyTime[idlest] {
+				idlest = i
+			}
+		}
+		gap := streamBusyTime[busiest] - streamBusyTime[idlest]
+		if streamBusyTime[busiest] < minBusyTime ||
+			float64(gap) <= float64(streamBusyTime[busiest])*minImbalanceRatioToArrange {
+			break
+		}
+
+		target := -1
+		for i, p := range paths {
+			if p.stream != busiest || p.busyTime <= 0 || p.busyTime >= gap {
+				continue
+			}
+			if target < 0 || p.busyTime > paths[target].busyTime {
+				target = i
+			}
+		}
+		if target < 0 {
+			// All the load of the busiest stream comes from the paths which can't be split.
+			break
+		}
+
+		p := &paths[target]
+		moves = append(moves, pathMove[P]{path: p.path, from: busiest, to: idlest})
+		streamBusyTime[busiest] -= p.busyTime
+		streamBusyTime[idlest] += p.busyTime
+		p.stream = idlest
+	}
+	return moves
+}
+
+// scheduleLoop balances the paths between the streams periodically, based on the busy time
+// of the streams and the paths reported by the handle goroutines.
+func (s *parallelDynamicStream[A, P, T, D, H]) scheduleLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.option.SchedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			s.arrangeStreams()
+		}
+	}
+}
+
+func (s *parallelDynamicStream[A, P, T, D, H]) arrangeStreams() {
+	streamBusyTime := make([]int64, len(s.streams))
+	for i, ds := range s.streams {
+		busyTime := ds.busyTime.Load()
+		streamBusyTime[i] = busyTime - ds.lastBusyTime
+		ds.lastBusyTime = busyTime
+	}
+
+	var paths []pathLoad[P]
+	s.mutex.RLock()
+	for _, pi := range s.pathMap {
+		busyTime := pi.busyTime.Load()
+		if delta := busyTime - pi.lastBusyTime; delta > 0 {
+			paths = append(paths, pathLoad[P]{path: pi.path, stream: pi.stream.id, busyTime: delta})
+		}
+		pi.lastBusyTime = busyTime
+	}
+	s.mutex.RUnlock()
+
+	minBusyTime := int64(float64(s.option.SchedulerInterval) * minBusyRatioToArrange)
+	moves := planPathMoves(streamBusyTime, paths, minBusyTime)
+	for _, move := range moves {
+		if !s.movePath(move.path, s.streams[move.to]) {
+			continue
+		}
+		s._statMovePathCount.Add(1)
+		log.Debug("dynamic stream move path",
+			zap.Any("path", move.path), zap.Int("from", move.from), zap.Int("to", move.to))
+	}
+}
+
+// movePath moves the path to the target stream, the events of the path are handled
+// in the same order, and a blocking path is still blocked until it is waked.
+// It returns false if the path doesn't exist or it is already in the target stream.
+// The events are sent to the streams without holding the mutex, since the input
+// channels may be full and the handlers may need the mutex to make progress.
+func (s *parallelDynamicStream[A, P, T, D, H]) movePath(path P, target *stream[A, P, T, D, H]) bool {
+	s.mutex.Lock()
+	pi, ok := s.pathMap[path]
+	if !ok || pi.stream == target || pi.migratingTo.Load() != nil {
+		s.mutex.Unlock()
+		return false
+	}
+	source := pi.stream
+	// The events pushed after the mutex is released are sent to the target stream,
+	// and they are stashed by the target stream until the path is detached from the source stream.
+	pi.migratingTo.Store(target)
+	pi.stream = target
+	s.mutex.Unlock()
+
+	detached := make(chan struct{})
+	source.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi, migrate: migrateOut, migrateDone: detached}
+	select {
+	case <-detached:
+	case <-s.closeCh:
+		return false
+	}
+	attached := make(chan struct{})
+	target.in() <- eventWrap[A, P, T, D, H]{pathInfo: pi, migrate: migrateIn, migrateDone: attached}
+	select {
+	case <-attached:
+	case <-s.closeCh:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,319 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynstream
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanPathMoves(t *testing.T) {
+	// All the paths are in the first stream.
+	streamBusyTime := []int64{100, 0, 0, 0}
+	paths := []pathLoad[string]{
+		{path: "p1", stream: 0, busyTime: 25},
+		{path: "p2", stream: 0, busyTime: 25},
+		{path: "p3", stream: 0, busyTime: 25},
+		{path: "p4", stream: 0, busyTime: 25},
+	}
+	moves := planPathMoves(streamBusyTime, paths, 10)
+	require.Len(t, moves, 3)
+	require.Equal(t, []int64{25, 25, 25, 25}, streamBusyTime)
+	targets := make(map[int]struct{})
+	for _, move := range moves {
+		require.Equal(t, 0, move.from)
+		targets[move.to] = struct{}{}
+	}
+	require.Len(t, targets, 3)
+
+	// The stream is not busy enough.
+	streamBusyTime = []int64{5, 0}
+	paths = []pathLoad[string]{
+		{path: "p1", stream: 0, busyTime: 2},
+		{path: "p2", stream: 0, busyTime: 3},
+	}
+	require.Empty(t, planPathMoves(streamBusyTime, paths, 10))
+
+	// Moving the only hot path doesn't help.
+	streamBusyTime = []int64{100, 0}
+	paths = []pathLoad[string]{{path: "p1", stream: 0, busyTime: 100}}
+	require.Empty(t, planPathMoves(streamBusyTime, paths, 10))
+
+	// The streams are balanced enough.
+	streamBusyTime = []int64{100, 90}
+	paths = []pathLoad[string]{
+		{path: "p1", stream: 0, busyTime: 5},
+		{path: "p2", stream: 1, busyTime: 90},
+	}
+	require.Empty(t, planPathMoves(streamBusyTime, paths, 10))
+}
+
+type orderEvent struct {
+	path  string
+	seq   int
+	await bool
+}
+
+// orderHandler records the handled events, and wakes the path asynchronously
+// if the event needs to await.
+type orderHandler struct {
+	mutex   sync.Mutex
+	handled map[string][]int
+	wg      *sync.WaitGroup
+	wake    func(path string)
+	// onHandle is called before the events are recorded, if it is set.
+	onHandle func(events ...*orderEvent)
+}
+
+func (h *orderHandler) Path(event *orderEvent) string { return event.path }
+func (h *orderHandler) Handle(dest any, events ...*orderEvent) (await bool) {
+	if h.onHandle != nil {
+		h.onHandle(events...)
+	}
+	h.mutex.Lock()
+	for _, e := range events {
+		h.handled[e.path] = append(h.handled[e.path], e.seq)
+		await = await || e.await
+	}
+	h.mutex.Unlock()
+	if await {
+		path := events[0].path
+		go func() {
+			time.Sleep(time.Millisecond)
+			h.wake(path)
+		}()
+	}
+	h.wg.Add(-len(events))
+	return await
+}
+func (h *orderHandler) GetSize(event *orderEvent) int            { return 0 }
+func (h *orderHandler) GetArea(path string, dest any) int        { return 0 }
+func (h *orderHandler) GetTimestamp(event *orderEvent) Timestamp { return 0 }
+func (h *orderHandler) GetType(event *orderEvent) EventType      { return DefaultEventType }
+func (h *orderHandler) IsPaused(event *orderEvent) bool          { return false }
+func (h *orderHandler) OnDrop(event *orderEvent)                 {}
+
+func TestParallelDynamicStreamMovePath(t *testing.T) {
+	const eventCount = 2000
+	wg := &sync.WaitGroup{}
+	wg.Add(eventCount * 2)
+	handler := &orderHandler{handled: make(map[string][]int), wg: wg}
+	option := NewOption()
+	option.StreamCount = 4
+	option.SchedulerInterval = 0
+	ds := newParallelDynamicStream(func(path string) uint64 { return 0 }, handler, option)
+	handler.wake = ds.Wake
+	ds.Start()
+	defer ds.Close()
+
+	paths := []string{"p1", "p2"}
+	for _, path := range paths {
+		require.NoError(t, ds.AddPath(path, nil))
+	}
+
+	pushDone := make(chan struct{})
+	go func() {
+		defer close(pushDone)
+		for i := 0; i < eventCount; i++ {
+			for _, path := range paths {
+				ds.Push(path, &orderEvent{path: path, seq: i, await: i%100 == 0})
+			}
+		}
+	}()
+	// Move the paths between the streams while the events are pushed and handled.
+	moved := 0
+	for i := 0; i < 50; i++ {
+		for _, path := range paths {
+			if ds.movePath(path, ds.streams[(i+1)%len(ds.streams)]) {
+				moved++
+			}
+		}
+	}
+	<-pushDone
+	wg.Wait()
+	require.Equal(t, 100, moved)
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	for _, path := range paths {
+		seqs := handler.handled[path]
+		require.Len(t, seqs, eventCount)
+		for i, seq := range seqs {
+			require.Equal(t, i, seq)
+		}
+	}
+	for _, s := range ds.streams {
+		require.Equal(t, int64(0), s.getPendingBytes())
+	}
+}
+
+func TestParallelDynamicStreamBalancePaths(t *testing.T) {
+	const (
+		pathCount  = 8
+		eventCount = 200
+	)
+	wg := &sync.WaitGroup{}
+	wg.Add(pathCount * eventCount)
+	handler := &orderHandler{handled: make(map[string][]int), wg: wg}
+	option := NewOption()
+	option.StreamCount = 4
+	option.SchedulerInterval = 20 * time.Millisecond
+	// All the paths are hashed to the first stream.
+	ds := newParallelDynamicStream(func(path string) uint64 { return 0 }, handler, option)
+	handler.wake = ds.Wake
+	ds.Start()
+	defer ds.Close()
+
+	paths := make([]string, 0, pathCount)
+	for i := 0; i < pathCount; i++ {
+		path := string(rune('a' + i))
+		paths = append(paths, path)
+		require.NoError(t, ds.AddPath(path, nil))
+	}
+	// The events await except the last one, so the paths are moved while they are blocking.
+	go func() {
+		for i := 0; i < eventCount; i++ {
+			for _, path := range paths {
+				ds.Push(path, &orderEvent{path: path, seq: i, await: i < eventCount-1})
+			}
+		}
+	}()
+	// Make the handler busy in the first stream.
+	ds.streams[0].busyTime.Add(int64(time.Second))
+	for _, path := range paths {
+		ds.mutex.RLock()
+		ds.pathMap[path].busyTime.Add(int64(time.Second) / pathCount)
+		ds.mutex.RUnlock()
+	}
+	require.Eventually(t, func() bool {
+		return ds.GetMetrics().MovePath > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	wg.Wait()
+
+	streams := make(map[int]struct{})
+	ds.mutex.RLock()
+	for _, pi := range ds.pathMap {
+		streams[pi.stream.id] = struct{}{}
+	}
+	ds.mutex.RUnlock()
+	require.Greater(t, len(streams), 1)
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	for _, path := range paths {
+		seqs := handler.handled[path]
+		require.Len(t, seqs, eventCount)
+		for i, seq := range seqs {
+			require.Equal(t, i, seq)
+		}
+	}
+}
+
+func TestParallelDynamicStreamMovePathTargetBusy(t *testing.T) {
+	const eventCount = 16
+	wg := &sync.WaitGroup{}
+	wg.Add(eventCount + 1)
+	handler := &orderHandler{handled: make(map[string][]int), wg: wg}
+	option := NewOption()
+	option.StreamCount = 2
+	option.InputChanSize = 1
+	ds := newParallelDynamicStream(func(path string) uint64 {
+		if path == "p1" {
+			return 0
+		}
+		return 1
+	}, handler, option)
+	handler.wake = ds.Wake
+
+	// The handler of the target stream needs the mutex of the dynamic stream,
+	// and it is blocked until the path is moving.
+	release := make(chan struct{})
+	handler.onHandle = func(events ...*orderEvent) {
+		if events[0].path == "p2" && events[0].seq == 0 {
+			<-release
+			require.NoError(t, ds.AddPath("p3", nil))
+		}
+	}
+	ds.Start()
+	defer ds.Close()
+	require.NoError(t, ds.AddPath("p1", nil))
+	require.NoError(t, ds.AddPath("p2", nil))
+
+	// Fill the input channel of the target stream.
+	go func() {
+		for i := 0; i < eventCount; i++ {
+			ds.Push("p2", &orderEvent{path: "p2", seq: i})
+		}
+	}()
+	ds.Push("p1", &orderEvent{path: "p1", seq: 0})
+
+	moved := make(chan bool)
+	go func() {
+		moved <- ds.movePath("p1", ds.streams[1])
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	select {
+	case ok := <-moved:
+		require.True(t, ok)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "move path is blocked")
+	}
+	wg.Wait()
+}
+
+func TestParallelDynamicStreamStashedEventsUnderMemoryControl(t *testing.T) {
+	const eventCount = 3
+	wg := &sync.WaitGroup{}
+	wg.Add(eventCount)
+	handler := &orderHandler{handled: make(map[string][]int), wg: wg}
+	option := NewOption()
+	option.StreamCount = 2
+	option.EnableMemoryControl = true
+	ds := newParallelDynamicStream(func(path string) uint64 { return 0 }, handler, option)
+	handler.wake = ds.Wake
+	ds.Start()
+	defer ds.Close()
+	require.NoError(t, ds.AddPath("p1", nil))
+
+	// Start to move the path, the events are stashed by the target stream
+	// until the path is detached from the source stream.
+	ds.mutex.Lock()
+	pi := ds.pathMap["p1"]
+	source, target := pi.stream, ds.streams[1]
+	pi.migratingTo.Store(target)
+	pi.stream = target
+	ds.mutex.Unlock()
+	for i := 0; i < eventCount; i++ {
+		ds.Push("p1", &orderEvent{path: "p1", seq: i})
+	}
+	area := pi.areaMemStat
+	require.Eventually(t, func() bool {
+		return area.totalPendingSize.Load() == int64(eventCount*ds.eventExtraSize)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	detached := make(chan struct{})
+	source.in() <- eventWrap[int, string, *orderEvent, any, *orderHandler]{pathInfo: pi, migrate: migrateOut, migrateDone: detached}
+	<-detached
+	attached := make(chan struct{})
+	target.in() <- eventWrap[int, string, *orderEvent, any, *orderHandler]{pathInfo: pi, migrate: migrateIn, migrateDone: attached}
+	<-attached
+	wg.Wait()
+	require.Eventually(t, func() bool {
+		return area.totalPendingSize.Load() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -44,6 +44,14 @@ type stream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 
 	// The queue to store the pending events of this stream.
 	eventQueue eventQueue[A, P, T, D, H]
+	// The events of the paths which are migrating to this stream.
+	// They are handled after the paths are attached.
+	migratingPaths map[*pathInfo[A, P, T, D, H]][]eventWrap[A, P, T, D, H]
+
+	// The total time(ns) spent on handling events, it is used to balance the paths between streams.
+	busyTime atomic.Int64
+	// The busyTime at the last arrangement, only accessed by the scheduler.
+	lastBusyTime int64
 
 	option Option
 
@@ -60,11 +68,12 @@ func newStream[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](
 	option Option,
 ) *stream[A, P, T, D, H] {
 	s := &stream[A, P, T, D, H]{
-		id:         id,
-		handler:    handler,
-		eventQueue: newEventQueue(option, handler),
-		option:     option,
-		startTime:  time.Now(),
+		id:             id,
+		handler:        handler,
+		eventQueue:     newEventQueue(option, handler),
+		migratingPaths: make(map[*pathInfo[A, P, T, D, H]][]eventWrap[A, P, T, D, H]),
+		option:         option,
+		startTime:      time.Now(),
 	}
 
 	if option.UseBuffer {
@@ -90,6 +99,10 @@ func (s *stream[A, P, T, D, H]) getPendingSize() int {
 	}
 }
 
+func (s *stream[A, P, T, D, H]) getPendingBytes() int64 {
+	return s.eventQueue.totalPendingSize.Load()
+}
+
 func (s *stream[A, P, T, D, H]) in() chan eventWrap[A, P, T, D, H] {
 	if s.option.UseBuffer {
 		return s.inChan
@@ -174,12 +187,36 @@ func (s *stream[A, P, T, D, H]) receiver() {
 // handleLoop is the main loop of the stream.
 // It handles the events.
 func (s *stream[A, P, T, D, H]) handleLoop() {
-	handleEvent := func(e eventWrap[A, P, T, D, H]) {
+	var handleEvent func(e eventWrap[A, P, T, D, H])
+	handleEvent = func(e eventWrap[A, P, T, D, H]) {
+		if e.migrate != migrateIn && e.pathInfo.migratingTo.Load() == s {
+			// The path is not attached yet, keep the events in order.
+			s.migratingPaths[e.pathInfo] = append(s.migratingPaths[e.pathInfo], e)
+			e.pathInfo.updateStashedSize(int64(e.eventSize))
+			return
+		}
 		switch {
+		case e.migrate == migrateOut:
+			s.eventQueue.detachPath(e.pathInfo)
+			close(e.migrateDone)
+		case e.migrate == migrateIn:
+			stashed := s.migratingPaths[e.pathInfo]
+			delete(s.migratingPaths, e.pathInfo)
+			for _, se := range stashed {
+				e.pathInfo.updateStashedSize(-int64(se.eventSize))
+			}
+			e.pathInfo.migratingTo.Store(nil)
+			s.eventQueue.attachPath(e.pathInfo)
+			for _, se := range stashed {
+				handleEvent(se)
+			}
+			close(e.migrateDone)
 		case e.wake:
 			s.eventQueue.wakePath(e.pathInfo)
 		case e.newPath:
 			s.eventQueue.initPath(e.pathInfo)
+		case e.removePath:
+			s.eventQueue.removePath(e.pathInfo)
 		case e.pathInfo.removed.Load():
 			// The path is removed, so we don't need to handle its events.
 			return
@@ -249,7 +286,11 @@ Loop:
 					eventQueueEmpty = true
 					continue Loop
 				}
+				start := time.Now()
 				path.blocking = s.handler.Handle(path.dest, eventBuf...)
+				busyTime := int64(time.Since(start))
+				s.busyTime.Add(busyTime)
+				path.busyTime.Add(busyTime)
 				if path.blocking {
 					s.eventQueue.blockPath(path)
 				}
@@ -272,8 +313,14 @@ type pathInfo[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	path P
 	dest D
 
-	// The current stream this path belongs to.
+	// The current stream this path belongs to. It is protected by the mutex of parallelDynamicStream,
+	// and could be changed when the path is migrated to another stream.
 	stream *stream[A, P, T, D, H]
+	// The event queue which handles the events of this path now, nil if the path is migrating.
+	owner atomic.Pointer[eventQueue[A, P, T, D, H]]
+	// The stream the path is migrating to, nil if the path is not migrating.
+	// The events received by that stream are stashed until the path is attached.
+	migratingTo atomic.Pointer[stream[A, P, T, D, H]]
 	// This field is used to mark the path as removed, so that the handle goroutine can ignore it.
 	// Note that we should not need to use a atomic.Bool here, because this field is set by the RemovePaths method,
 	// and we use sync.WaitGroup to wait for finish. So if RemovePaths is called in the handle goroutine, it should be
@@ -291,6 +338,10 @@ type pathInfo[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	pendingSize          atomic.Int64 // The total size(bytes) of pending events in the pendingQueue of the path.
 	paused               atomic.Bool  // The path is paused to send events.
 	lastSendFeedbackTime atomic.Value
+
+	// Fields used by the scheduler.
+	busyTime     atomic.Int64 // The total time(ns) spent on handling the events of the path.
+	lastBusyTime int64        // The busyTime at the last arrangement, only accessed by the scheduler.
 }
 
 func newPathInfo[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]](area A, path P, dest D) *pathInfo[A, P, T, D, H] {
@@ -316,22 +367,20 @@ func (pi *pathInfo[A, P, T, D, H]) appendEvent(event eventWrap[A, P, T, D, H], h
 		return pi.areaMemStat.appendEvent(pi, event, handler)
 	}
 
-	if event.eventType.Property != PeriodicSignal {
-		pi.pendingQueue.PushBack(event)
-		pi.updatePendingSize(int64(event.eventSize))
-		return true
-	}
-
-	back, ok := pi.pendingQueue.BackRef()
-	if ok && back.eventType.Property == PeriodicSignal {
-		// If the last event is a periodic signal, we only need to keep the latest one.
-		// And we don't need to add a new signal.
-		*back = event
-		return false
-	} else {
-		pi.pendingQueue.PushBack(event)
-		return true
+	if event.eventType.Property == PeriodicSignal {
+		back, ok := pi.pendingQueue.BackRef()
+		if ok && back.eventType.Property == PeriodicSignal {
+			// If the last event is a periodic signal, we only need to keep the latest one.
+			// And we don't need to add a new signal.
+			// Keep the size of the replaced event, which is already counted in the pending size.
+			event.eventSize = back.eventSize
+			*back = event
+			return false
+		}
 	}
+	pi.pendingQueue.PushBack(event)
+	pi.updatePendingSize(int64(event.eventSize))
+	return true
 }
 
 func (pi *pathInfo[A, P, T, D, H]) popEvent() (eventWrap[A, P, T, D, H], bool) {
@@ -347,6 +396,21 @@ func (pi *pathInfo[A, P, T, D, H]) popEvent() (eventWrap[A, P, T, D, H], bool) {
 	return e, true
 }
 
+// updateStashedSize updates the size of the events stashed by the target stream during migration.
+// They are not in the pending queue yet, but they are still counted by the memory control of the area.
+func (pi *pathInfo[A, P, T, D, H]) updateStashedSize(delta int64) {
+	if pi.areaMemStat == nil || delta == 0 {
+		return
+	}
+	if delta < 0 {
+		pi.areaMemStat.decPendingSize(pi, -delta)
+		return
+	}
+	pi.areaMemStat.totalPendingSize.Add(delta)
+	pi.areaMemStat.updatePathPauseState(pi)
+	pi.areaMemStat.updateAreaPauseState(pi)
+}
+
 func (pi *pathInfo[A, P, T, D, H]) updatePendingSize(delta int64) {
 	oldSize := pi.pendingSize.Load()
 	// Check for integer overflow/underflow
@@ -369,9 +433,14 @@ func (pi *pathInfo[A, P, T, D, H]) updatePendingSize(delta int64) {
 // eventWrap contains the event and the path info.
 // It can be a event or a wake signal.
 type eventWrap[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
-	event   T
-	wake    bool
-	newPath bool
+	event      T
+	wake       bool
+	newPath    bool
+	removePath bool
+
+	// Used to migrate the path between streams.
+	migrate     migrateStep
+	migrateDone chan struct{}
 
 	pathInfo *pathInfo[A, P, T, D, H]
 
@@ -382,3 +451,15 @@ type eventWrap[A Area, P Path, T Event, D Dest, H Handler[A, P, T, D]] struct {
 	timestamp Timestamp
 	queueTime time.Time
 }
+
+// migrateStep is the step of migrating a path from a source stream to a target stream.
+type migrateStep int
+
+const (
+	migrateNone migrateStep = iota
+	// migrateOut is sent to the source stream, to detach the path after all the
+	// previous events of the path are appended to its pending queue.
+	migrateOut
+	// migrateIn is sent to the target stream after the path is detached from the source stream.
+	migrateIn
+)